@@ -5,6 +5,9 @@ package v1alpha1
 func (in *BlueprintPhase) DeepCopyInto(out *BlueprintPhase) {
 	*out = *in
 	// TODO: Handle 'Args'
+	if in.ForEach != nil {
+		out.ForEach = in.ForEach.DeepCopy()
+	}
 }
 
 // DeepCopyInto handles the Phase deep copies, copying the receiver, writing into out. in must be non-nil.
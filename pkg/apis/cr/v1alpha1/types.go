@@ -108,8 +108,13 @@ type ActionSpec struct {
 	// Options will be used to specify additional values
 	// to be used in the Blueprint.
 	Options map[string]string `json:"options,omitempty"`
+	// OptionsFrom sets options from the keys of ConfigMaps and Secrets, like
+	// the valueFrom of container environment variables. Options set in
+	// Options take precedence.
+	OptionsFrom map[string]OptionValueSource `json:"optionsFrom,omitempty"`
 	// PreferredVersion will be used to select the preferred version of Kanister functions
-	// to be executed for this action
+	// to be executed for this action. It can be a version or a semver constraint,
+	// e.g. `>=0.1.0 <1.0`, in which case the highest matching version is used.
 	PreferredVersion string `json:"preferredVersion"`
 	// PodLabels will be used to configure the labels of the pods that are created
 	// by Kanister functions run by this ActionSet
@@ -192,6 +197,29 @@ const (
 	StateComplete State = "complete"
 )
 
+// OptionValueSource selects the value of an option from a key of a ConfigMap
+// or a Secret. Exactly one of the refs must be set.
+type OptionValueSource struct {
+	// ConfigMapKeyRef selects a key of a ConfigMap.
+	ConfigMapKeyRef *ObjectKeySelector `json:"configMapKeyRef,omitempty"`
+	// SecretKeyRef selects a key of a Secret. Options set from Secrets are
+	// sensitive and are redacted from logs and events.
+	SecretKeyRef *ObjectKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// ObjectKeySelector selects a key of a ConfigMap or a Secret.
+type ObjectKeySelector struct {
+	// Name of the ConfigMap or Secret.
+	Name string `json:"name"`
+	// Namespace of the ConfigMap or Secret. Defaults to the namespace of the
+	// object of the action.
+	Namespace string `json:"namespace,omitempty"`
+	// Key to select.
+	Key string `json:"key"`
+	// Optional skips the option if the ConfigMap, Secret or key doesn't exist.
+	Optional bool `json:"optional,omitempty"`
+}
+
 // Error represents an error that occurred when executing an actionset.
 type Error struct {
 	// Message is the actual error message that is displayed in case of errors.
@@ -208,6 +236,9 @@ type Phase struct {
 	Output map[string]interface{} `json:"output,omitempty"`
 	// Progress represents the phase execution progress.
 	Progress PhaseProgress `json:"progress,omitempty"`
+	// Version is the version of the Kanister function that was chosen to
+	// execute the Blueprint phase.
+	Version string `json:"version,omitempty"`
 }
 
 // PhaseProgress represents the execution state of the phase.
@@ -305,6 +336,20 @@ type BlueprintPhase struct {
 	ObjectRefs map[string]ObjectReference `json:"objects,omitempty"`
 	// Args represents a map of named arguments that the controller will pass to the Kanister function.
 	Args map[string]interface{} `json:"args"`
+	// ForEach runs the phase once for each item of a list.
+	ForEach *ForEach `json:"forEach,omitempty"`
+}
+
+// ForEach configures a phase to run once for each item of a list. The item
+// and its index are available to the args of the phase as `.Item` and
+// `.Index`.
+type ForEach struct {
+	// Items is a template expression that evaluates to a list, e.g.
+	// `.StatefulSet.Pods`, or a go template that renders a JSON or YAML list.
+	Items string `json:"items"`
+	// Parallelism is the maximum number of items the phase runs for at the
+	// same time. The items are processed one at a time by default.
+	Parallelism int `json:"parallelism,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
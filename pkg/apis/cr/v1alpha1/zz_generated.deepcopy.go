@@ -200,6 +200,13 @@ func (in *ActionSpec) DeepCopyInto(out *ActionSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.OptionsFrom != nil {
+		in, out := &in.OptionsFrom, &out.OptionsFrom
+		*out = make(map[string]OptionValueSource, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	if in.PodLabels != nil {
 		in, out := &in.PodLabels, &out.PodLabels
 		*out = make(map[string]string, len(*in))
@@ -462,6 +469,22 @@ func (in *Error) DeepCopy() *Error {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForEach) DeepCopyInto(out *ForEach) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForEach.
+func (in *ForEach) DeepCopy() *ForEach {
+	if in == nil {
+		return nil
+	}
+	out := new(ForEach)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JSONMap.
 func (in JSONMap) DeepCopy() JSONMap {
 	if in == nil {
@@ -585,6 +608,48 @@ func (in *Phase) DeepCopy() *Phase {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectKeySelector) DeepCopyInto(out *ObjectKeySelector) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectKeySelector.
+func (in *ObjectKeySelector) DeepCopy() *ObjectKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OptionValueSource) DeepCopyInto(out *OptionValueSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(ObjectKeySelector)
+		**out = **in
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(ObjectKeySelector)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OptionValueSource.
+func (in *OptionValueSource) DeepCopy() *OptionValueSource {
+	if in == nil {
+		return nil
+	}
+	out := new(OptionValueSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PhaseProgress) DeepCopyInto(out *PhaseProgress) {
 	*out = *in
@@ -34,8 +34,9 @@ const (
 )
 
 // Do takes a blueprint and validates if the function names in phases are correct
-// and all the required arguments for the kanister functions are provided. This doesn't
-// check anything with template params yet.
+// and all the required arguments for the kanister functions are provided. It also
+// checks that the templates in the arguments only reference template params that
+// can be defined when the action runs.
 func Do(bp *crv1alpha1.Blueprint, funcVersion string) error {
 	for name, action := range bp.Actions {
 		// GetPhases also checks if the function names referred in the action are correct
@@ -69,6 +70,14 @@ func Do(bp *crv1alpha1.Blueprint, funcVersion string) error {
 			}
 			utils.PrintStage(fmt.Sprintf("validation of phase %s in action %s", phase.Name(), name), utils.Pass)
 		}
+
+		// validate that the templates in the phases' arguments only
+		// reference params that can be defined
+		if err := kanister.CheckTemplates(*bp, name, param.TemplateParams{}, true); err != nil {
+			utils.PrintStage(fmt.Sprintf("validation of templates in action %s", name), utils.Fail)
+			return errkit.Wrap(err, fmt.Sprintf("%s action %s", BPValidationErr, name))
+		}
+		utils.PrintStage(fmt.Sprintf("validation of templates in action %s", name), utils.Pass)
 	}
 
 	return validatePhaseNames(bp)
@@ -531,6 +531,56 @@ func (v *ValidateBlueprint) TestValidatePhaseNames(c *check.C) {
 	}
 }
 
+func (v *ValidateBlueprint) TestValidateTemplates(c *check.C) {
+	for _, tc := range []struct {
+		command     string
+		err         check.Checker
+		errContains string
+	}{
+		{
+			command: "echo {{ .Phases.backup.Output.id }} {{ .Options.anything }} {{ .StatefulSet.Name }}",
+			err:     check.IsNil,
+		},
+		{
+			command:     "echo {{ .Phases.restore.Output.id }}",
+			err:         check.NotNil,
+			errContains: ".Phases.restore.Output.id is not defined",
+		},
+		{
+			command:     "echo {{ .Deploymnt.Name }}",
+			err:         check.NotNil,
+			errContains: ".Deploymnt.Name is not defined, TemplateParams has no field Deploymnt",
+		},
+	} {
+		bp := blueprint()
+		bp.Actions["backup"].Phases = []crv1alpha1.BlueprintPhase{
+			{
+				Name: "backup",
+				Func: "KubeTask",
+				Args: map[string]interface{}{
+					"image":     "",
+					"namespace": "",
+					"command":   []interface{}{"sh", "-c", "echo backup"},
+				},
+			},
+			{
+				Name: "print",
+				Func: "KubeTask",
+				Args: map[string]interface{}{
+					"image":     "",
+					"namespace": "",
+					"command":   []interface{}{"sh", "-c", tc.command},
+				},
+			},
+		}
+		err := Do(bp, kanister.DefaultVersion)
+		if err != nil {
+			c.Assert(strings.Contains(err.Error(), tc.errContains), check.Equals, true)
+		}
+		c.Assert(err, tc.err)
+	}
+}
+
 func blueprint() *crv1alpha1.Blueprint {
 	return &crv1alpha1.Blueprint{
 		Actions: map[string]*crv1alpha1.BlueprintAction{
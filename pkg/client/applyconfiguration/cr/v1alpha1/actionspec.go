@@ -25,18 +25,19 @@ import (
 // ActionSpecApplyConfiguration represents a declarative configuration of the ActionSpec type for use
 // with apply.
 type ActionSpecApplyConfiguration struct {
-	Name             *string                                      `json:"name,omitempty"`
-	Object           *ObjectReferenceApplyConfiguration           `json:"object,omitempty"`
-	Blueprint        *string                                      `json:"blueprint,omitempty"`
-	Artifacts        map[string]ArtifactApplyConfiguration        `json:"artifacts,omitempty"`
-	ConfigMaps       map[string]ObjectReferenceApplyConfiguration `json:"configMaps,omitempty"`
-	Secrets          map[string]ObjectReferenceApplyConfiguration `json:"secrets,omitempty"`
-	Profile          *ObjectReferenceApplyConfiguration           `json:"profile,omitempty"`
-	PodOverride      *crv1alpha1.JSONMap                          `json:"podOverride,omitempty"`
-	Options          map[string]string                            `json:"options,omitempty"`
-	PreferredVersion *string                                      `json:"preferredVersion,omitempty"`
-	PodLabels        map[string]string                            `json:"podLabels,omitempty"`
-	PodAnnotations   map[string]string                            `json:"podAnnotations,omitempty"`
+	Name             *string                                        `json:"name,omitempty"`
+	Object           *ObjectReferenceApplyConfiguration             `json:"object,omitempty"`
+	Blueprint        *string                                        `json:"blueprint,omitempty"`
+	Artifacts        map[string]ArtifactApplyConfiguration          `json:"artifacts,omitempty"`
+	ConfigMaps       map[string]ObjectReferenceApplyConfiguration   `json:"configMaps,omitempty"`
+	Secrets          map[string]ObjectReferenceApplyConfiguration   `json:"secrets,omitempty"`
+	Profile          *ObjectReferenceApplyConfiguration             `json:"profile,omitempty"`
+	PodOverride      *crv1alpha1.JSONMap                            `json:"podOverride,omitempty"`
+	Options          map[string]string                              `json:"options,omitempty"`
+	OptionsFrom      map[string]OptionValueSourceApplyConfiguration `json:"optionsFrom,omitempty"`
+	PreferredVersion *string                                        `json:"preferredVersion,omitempty"`
+	PodLabels        map[string]string                              `json:"podLabels,omitempty"`
+	PodAnnotations   map[string]string                              `json:"podAnnotations,omitempty"`
 }
 
 // ActionSpecApplyConfiguration constructs a declarative configuration of the ActionSpec type for use with
@@ -141,6 +142,20 @@ func (b *ActionSpecApplyConfiguration) WithOptions(entries map[string]string) *A
 	return b
 }
 
+// WithOptionsFrom puts the entries into the OptionsFrom field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the OptionsFrom field,
+// overwriting an existing map entries in OptionsFrom field with the same key.
+func (b *ActionSpecApplyConfiguration) WithOptionsFrom(entries map[string]OptionValueSourceApplyConfiguration) *ActionSpecApplyConfiguration {
+	if b.OptionsFrom == nil && len(entries) > 0 {
+		b.OptionsFrom = make(map[string]OptionValueSourceApplyConfiguration, len(entries))
+	}
+	for k, v := range entries {
+		b.OptionsFrom[k] = v
+	}
+	return b
+}
+
 // WithPreferredVersion sets the PreferredVersion field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the PreferredVersion field is set to the value of the last call.
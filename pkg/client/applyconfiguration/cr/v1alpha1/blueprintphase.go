@@ -25,6 +25,7 @@ type BlueprintPhaseApplyConfiguration struct {
 	Name       *string                                      `json:"name,omitempty"`
 	ObjectRefs map[string]ObjectReferenceApplyConfiguration `json:"objects,omitempty"`
 	Args       map[string]any                               `json:"args,omitempty"`
+	ForEach    *ForEachApplyConfiguration                   `json:"forEach,omitempty"`
 }
 
 // BlueprintPhaseApplyConfiguration constructs a declarative configuration of the BlueprintPhase type for use with
@@ -76,3 +77,11 @@ func (b *BlueprintPhaseApplyConfiguration) WithArgs(entries map[string]any) *Blu
 	}
 	return b
 }
+
+// WithForEach sets the ForEach field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ForEach field is set to the value of the last call.
+func (b *BlueprintPhaseApplyConfiguration) WithForEach(value *ForEachApplyConfiguration) *BlueprintPhaseApplyConfiguration {
+	b.ForEach = value
+	return b
+}
@@ -29,6 +29,7 @@ type PhaseApplyConfiguration struct {
 	State    *crv1alpha1.State                `json:"state,omitempty"`
 	Output   map[string]any                   `json:"output,omitempty"`
 	Progress *PhaseProgressApplyConfiguration `json:"progress,omitempty"`
+	Version  *string                          `json:"version,omitempty"`
 }
 
 // PhaseApplyConfiguration constructs a declarative configuration of the Phase type for use with
@@ -74,3 +75,11 @@ func (b *PhaseApplyConfiguration) WithProgress(value *PhaseProgressApplyConfigur
 	b.Progress = value
 	return b
 }
+
+// WithVersion sets the Version field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Version field is set to the value of the last call.
+func (b *PhaseApplyConfiguration) WithVersion(value string) *PhaseApplyConfiguration {
+	b.Version = &value
+	return b
+}
@@ -56,6 +56,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &crv1alpha1.CredentialApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Error"):
 		return &crv1alpha1.ErrorApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ForEach"):
+		return &crv1alpha1.ForEachApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("KeyPair"):
 		return &crv1alpha1.KeyPairApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("KopiaServerSecret"):
@@ -64,8 +66,12 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &crv1alpha1.KopiaServerSecretRefApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Location"):
 		return &crv1alpha1.LocationApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ObjectKeySelector"):
+		return &crv1alpha1.ObjectKeySelectorApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ObjectReference"):
 		return &crv1alpha1.ObjectReferenceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("OptionValueSource"):
+		return &crv1alpha1.OptionValueSourceApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Phase"):
 		return &crv1alpha1.PhaseApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PhaseProgress"):
@@ -54,6 +54,7 @@ import (
 	"github.com/kanisterio/kanister/pkg/eventer"
 	"github.com/kanisterio/kanister/pkg/field"
 	"github.com/kanisterio/kanister/pkg/log"
+	pkgout "github.com/kanisterio/kanister/pkg/output"
 	"github.com/kanisterio/kanister/pkg/param"
 	"github.com/kanisterio/kanister/pkg/progress"
 	"github.com/kanisterio/kanister/pkg/reconcile"
@@ -454,6 +455,12 @@ func (c *Controller) runAction(ctx context.Context, t *tomb.Tomb, as *crv1alpha1
 		c.incrementActionSetResolutionCounterVec(ActionSetCounterVecLabelResFailure)
 		return err
 	}
+	tp.Action = &param.ActionParams{
+		Name:      action.Name,
+		ActionSet: as.GetName(),
+		Namespace: as.GetNamespace(),
+		Blueprint: bp.GetName(),
+	}
 	phases, err := kanister.GetPhases(*bp, action.Name, action.PreferredVersion, *tp)
 	if err != nil {
 		c.incrementActionSetResolutionCounterVec(ActionSetCounterVecLabelResFailure)
@@ -468,12 +475,21 @@ func (c *Controller) runAction(ctx context.Context, t *tomb.Tomb, as *crv1alpha1
 		return err
 	}
 
+	c.recordPhaseVersions(ctx, as, aIDX, bp, phases, deferPhase)
+
 	ctx = field.Context(ctx, consts.ActionsetNameKey, as.GetName())
+	// Keep the values of the options that were set from Secrets out of the
+	// pod output logged by the phase functions
+	ctx = pkgout.WithRedaction(ctx, func(s string) string { return tp.Redact(s) })
 	t.Go(func() error {
 		var coreErr error
+		var runningPhase string
 		defer func() {
 			var deferErr error
 			if deferPhase != nil {
+				if coreErr != nil {
+					param.UpdateActionFailureParams(ctx, tp, runningPhase, coreErr)
+				}
 				deferErr = param.InitDeferPhaseParams(ctx, c.clientset, tp, deferPhase.Objects())
 				if deferErr == nil {
 					c.updateActionSetRunningPhase(ctx, aIDX, as, deferPhase.Name())
@@ -491,6 +507,7 @@ func (c *Controller) runAction(ctx context.Context, t *tomb.Tomb, as *crv1alpha1
 		}()
 
 		for i, p := range phases {
+			runningPhase = p.Name()
 			ctx = field.Context(ctx, consts.PhaseNameKey, p.Name())
 			c.logAndSuccessEvent(ctx, fmt.Sprintf("Executing phase %s", p.Name()), "Started Phase", as)
 			err = param.InitPhaseParams(ctx, c.clientset, tp, p.Name(), p.Objects())
@@ -510,7 +527,7 @@ func (c *Controller) runAction(ctx context.Context, t *tomb.Tomb, as *crv1alpha1
 				output, err = p.Exec(ctx, *bp, action.Name, *tp)
 				doneProgressTrack()
 			} else {
-				msg = fmt.Sprintf("Failed to init phase params: %#v:", as.Status.Actions[aIDX].Phases[i])
+				msg = tp.Redact(fmt.Sprintf("Failed to init phase params: %#v:", as.Status.Actions[aIDX].Phases[i]))
 			}
 
 			var ewd errorWithDetails
@@ -519,6 +536,7 @@ func (c *Controller) runAction(ctx context.Context, t *tomb.Tomb, as *crv1alpha1
 				data, _ := json.Marshal(details)
 				err = errkit.Wrap(err, string(data))
 			}
+			err = redactError(tp, err)
 
 			var rf func(*crv1alpha1.ActionSet) error
 			if err != nil {
@@ -543,7 +561,7 @@ func (c *Controller) runAction(ctx context.Context, t *tomb.Tomb, as *crv1alpha1
 					}
 					ras.Status.Actions[aIDX].Phases[i].Progress = pp
 					// this updates the phase output in the actionset status
-					ras.Status.Actions[aIDX].Phases[i].Output = output
+					ras.Status.Actions[aIDX].Phases[i].Output = tp.RedactOutput(output)
 					if err := progress.SetActionSetPercentCompleted(ras); err != nil {
 						log.Error().WithError(err)
 					}
@@ -553,7 +571,7 @@ func (c *Controller) runAction(ctx context.Context, t *tomb.Tomb, as *crv1alpha1
 
 			if rErr := reconcile.ActionSet(ctx, c.crClient.CrV1alpha1(), as.Namespace, as.Name, rf); rErr != nil {
 				reason := fmt.Sprintf("ActionSetFailed Action: %s", as.Spec.Actions[aIDX].Name)
-				msg := fmt.Sprintf("Failed to update phase: %#v:", as.Status.Actions[aIDX].Phases[i])
+				msg := tp.Redact(fmt.Sprintf("Failed to update phase: %#v:", as.Status.Actions[aIDX].Phases[i]))
 				c.logAndErrorEvent(ctx, msg, reason, rErr, as, bp)
 				coreErr = rErr
 				return nil
@@ -562,7 +580,7 @@ func (c *Controller) runAction(ctx context.Context, t *tomb.Tomb, as *crv1alpha1
 			if err != nil {
 				reason := fmt.Sprintf("ActionSetFailed Action: %s", as.Spec.Actions[aIDX].Name)
 				if msg == "" {
-					msg = fmt.Sprintf("Failed to execute phase: %#v:", as.Status.Actions[aIDX].Phases[i])
+					msg = tp.Redact(fmt.Sprintf("Failed to execute phase: %#v:", as.Status.Actions[aIDX].Phases[i]))
 				}
 				c.logAndErrorEvent(ctx, msg, reason, err, as, bp)
 				coreErr = err
@@ -576,6 +594,42 @@ func (c *Controller) runAction(ctx context.Context, t *tomb.Tomb, as *crv1alpha1
 	return nil
 }
 
+// recordPhaseVersions records the versions of the functions chosen for the
+// phases in the actionset status and warns about the use of deprecated versions.
+// It doesn't fail if there was a problem updating the actionset. It just logs
+// the failure.
+func (c *Controller) recordPhaseVersions(
+	ctx context.Context,
+	as *crv1alpha1.ActionSet,
+	aIDX int,
+	bp *crv1alpha1.Blueprint,
+	phases []*kanister.Phase,
+	deferPhase *kanister.Phase,
+) {
+	for _, p := range append([]*kanister.Phase{deferPhase}, phases...) {
+		if p == nil || p.Deprecation() == "" {
+			continue
+		}
+		reason := fmt.Sprintf("DeprecatedFunction Action: %s", as.Spec.Actions[aIDX].Name)
+		msg := fmt.Sprintf("Phase %s uses deprecated version %s of its function", p.Name(), p.Version())
+		c.logAndErrorEvent(ctx, msg, reason, errkit.New(p.Deprecation()), as, bp)
+	}
+	err := reconcile.ActionSet(ctx, c.crClient.CrV1alpha1(), as.Namespace, as.Name, func(ras *crv1alpha1.ActionSet) error {
+		for i := range ras.Status.Actions[aIDX].Phases {
+			if i < len(phases) {
+				ras.Status.Actions[aIDX].Phases[i].Version = phases[i].Version()
+			}
+		}
+		if deferPhase != nil {
+			ras.Status.Actions[aIDX].DeferPhase.Version = deferPhase.Version()
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().WithError(err).Print("Failed to update actionset with phase versions")
+	}
+}
+
 // updateActionSetRunningPhase updates the actionset's `status.Progress.RunningPhase` with the phase name
 // that is being run currently. It doesn't fail if there was a problem updating the actionset. It just logs
 // the failure.
@@ -614,6 +668,7 @@ func (c *Controller) executeDeferPhase(ctx context.Context,
 	c.logAndSuccessEvent(ctx, fmt.Sprintf("Executing deferPhase %s", as.Status.Actions[aIDX].DeferPhase.Name), "Started deferPhase", as)
 
 	output, err := deferPhase.Exec(ctx, *bp, actionName, *tp)
+	err = redactError(tp, err)
 	var rf func(*crv1alpha1.ActionSet) error
 	if err != nil {
 		rf = func(as *crv1alpha1.ActionSet) error {
@@ -628,14 +683,14 @@ func (c *Controller) executeDeferPhase(ctx context.Context,
 	} else {
 		rf = func(as *crv1alpha1.ActionSet) error {
 			as.Status.Actions[aIDX].DeferPhase.State = crv1alpha1.StateComplete
-			as.Status.Actions[aIDX].DeferPhase.Output = output
+			as.Status.Actions[aIDX].DeferPhase.Output = tp.RedactOutput(output)
 			return nil
 		}
 	}
 	var msg string
 	if rErr := reconcile.ActionSet(ctx, c.crClient.CrV1alpha1(), actionsetNS, actionsetName, rf); rErr != nil {
 		reason := fmt.Sprintf("ActionSetFailed Action: %s", as.Spec.Actions[aIDX].Name)
-		msg := fmt.Sprintf("Failed to update defer phase: %#v:", as.Status.Actions[aIDX].DeferPhase)
+		msg := tp.Redact(fmt.Sprintf("Failed to update defer phase: %#v:", as.Status.Actions[aIDX].DeferPhase))
 		c.logAndErrorEvent(ctx, msg, reason, rErr, as, bp)
 		return rErr
 	}
@@ -643,7 +698,7 @@ func (c *Controller) executeDeferPhase(ctx context.Context,
 	if err != nil {
 		reason := fmt.Sprintf("ActionSetFailed Action: %s", as.Spec.Actions[aIDX].Name)
 		if msg == "" {
-			msg = fmt.Sprintf("Failed to execute defer phase: %#v:", as.Status.Actions[aIDX].DeferPhase)
+			msg = tp.Redact(fmt.Sprintf("Failed to execute defer phase: %#v:", as.Status.Actions[aIDX].DeferPhase))
 		}
 		c.logAndErrorEvent(ctx, msg, reason, err, as, bp)
 		return err
@@ -751,6 +806,33 @@ func (c *Controller) maybeSetActionSetStateComplete(ctx context.Context,
 	}
 }
 
+// redactError removes the values of the options that were set from Secrets
+// from err, so that it can be logged and recorded in events and the status.
+// The redacted error still wraps err.
+func redactError(tp *param.TemplateParams, err error) error {
+	if err == nil {
+		return nil
+	}
+	if msg := tp.Redact(err.Error()); msg != err.Error() {
+		return &redactedError{msg: msg, cause: err}
+	}
+	return err
+}
+
+// redactedError is an error whose message was redacted.
+type redactedError struct {
+	msg   string
+	cause error
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.cause
+}
+
 func (c *Controller) logAndErrorEvent(ctx context.Context, msg, reason string, err error, objects ...runtime.Object) {
 	log.WithContext(ctx).WithError(err).Print(msg)
 	if len(objects) == 0 {
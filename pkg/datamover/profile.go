@@ -16,16 +16,23 @@ package datamover
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/kanisterio/errkit"
+	"github.com/kopia/kopia/snapshot/policy"
 
 	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
 	"github.com/kanisterio/kanister/pkg/kopia"
 	"github.com/kanisterio/kanister/pkg/kopia/repository"
 	"github.com/kanisterio/kanister/pkg/kopia/snapshot"
+	"github.com/kanisterio/kanister/pkg/output"
 	"github.com/kanisterio/kanister/pkg/param"
 )
 
+// BlockChecksumOutput is the key of the output with the checksum of a block
+// device pushed or pulled using kopia
+const BlockChecksumOutput = "checksum"
+
 // Check that Profile implements DataMover interface
 var _ DataMover = (*Profile)(nil)
 
@@ -82,6 +89,81 @@ func (p *Profile) Delete(ctx context.Context, destinationPath string) error {
 	return locationDelete(ctx, p.profile, destinationPath)
 }
 
+// PushBlock creates a kopia snapshot of the block device and prints it and
+// the checksum of the data as output
+func (p *Profile) PushBlock(ctx context.Context, device, destinationPath string) error {
+	if p.profile.Location.Type != crv1alpha1.LocationTypeKopia {
+		return errkit.New("Block devices can only be pushed to kopia locations")
+	}
+	if err := p.connectToKopiaRepositoryServer(ctx, repository.WriteAccess); err != nil {
+		return err
+	}
+	snapInfo, checksum, err := snapshot.WriteBlock(ctx, device, destinationPath, p.profile.Credential.KopiaServerSecret.Password)
+	if err != nil {
+		return errkit.Wrap(err, "Failed to push block device using kopia")
+	}
+	snapInfoJSON, err := snapshot.MarshalKopiaSnapshot(snapInfo)
+	if err != nil {
+		return err
+	}
+	if err := output.PrintOutput(p.outputName, snapInfoJSON); err != nil {
+		return err
+	}
+	return output.PrintOutput(BlockChecksumOutput, checksum)
+}
+
+// PullBlock writes the kopia snapshot of a block device back to the device
+// and verifies its checksum
+func (p *Profile) PullBlock(ctx context.Context, device, sourcePath, checksum string, skipZeroes bool) error {
+	if p.profile.Location.Type != crv1alpha1.LocationTypeKopia {
+		return errkit.New("Block devices can only be pulled from kopia locations")
+	}
+	kopiaSnap, err := p.unmarshalKopiaSnapshot(ctx)
+	if err != nil {
+		return err
+	}
+	if err := p.connectToKopiaRepositoryServer(ctx, repository.ReadOnlyAccess); err != nil {
+		return err
+	}
+	checksum, err = snapshot.ReadBlock(ctx, kopiaSnap.ID, sourcePath, device, p.profile.Credential.KopiaServerSecret.Password, checksum, skipZeroes)
+	if err != nil {
+		return errkit.Wrap(err, "Failed to pull block device using kopia")
+	}
+	return output.PrintOutput(BlockChecksumOutput, checksum)
+}
+
+// ApplyRetentionPolicy deletes the kopia snapshots that aren't kept by the
+// retention policy and prints their IDs as output
+func (p *Profile) ApplyRetentionPolicy(ctx context.Context, rp *policy.RetentionPolicy, tags []string) error {
+	if p.profile.Location.Type != crv1alpha1.LocationTypeKopia {
+		return errkit.New("Retention policies can only be applied to kopia locations")
+	}
+	if err := p.connectToKopiaRepositoryServer(ctx, repository.WriteAccess); err != nil {
+		return err
+	}
+	removed, err := snapshot.ApplyRetentionPolicy(ctx, p.profile.Credential.KopiaServerSecret.Password, rp, tags)
+	if err != nil {
+		return errkit.Wrap(err, "Failed to apply retention policy using kopia")
+	}
+	removedJSON, err := json.Marshal(removed)
+	if err != nil {
+		return errkit.Wrap(err, "Failed to marshal removed kopia snapshots")
+	}
+	return output.PrintOutput(p.outputName, string(removedJSON))
+}
+
+// CheckRepository checks that the kopia repository server can be reached and
+// the repository can be opened with the credentials of the profile
+func (p *Profile) CheckRepository(ctx context.Context) error {
+	if p.profile.Location.Type != crv1alpha1.LocationTypeKopia {
+		return errkit.New("Only kopia repositories can be checked")
+	}
+	if err := p.connectToKopiaRepositoryServer(ctx, repository.ReadOnlyAccess); err != nil {
+		return err
+	}
+	return snapshot.CheckRepository(ctx, p.profile.Credential.KopiaServerSecret.Password)
+}
+
 func (p *Profile) connectToKopiaRepositoryServer(ctx context.Context, accessMode repository.AccessMode) error {
 	contentCacheSize := kopia.GetDataStoreGeneralContentCacheSize(p.profile.Credential.KopiaServerSecret.ConnectOptions)
 	metadataCacheSize := kopia.GetDataStoreGeneralMetadataCacheSize(p.profile.Credential.KopiaServerSecret.ConnectOptions)
@@ -0,0 +1,156 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kanister
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kanisterio/errkit"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/param"
+)
+
+// ForEachOutputKey is the key of the list of the outputs for each item in the
+// output of a phase that runs for each item of a list.
+const ForEachOutputKey = "items"
+
+// execForEach executes the function of the phase once for each item, with
+// at most forEach.Parallelism executions at the same time. It stops starting
+// new executions after the first failure. The outputs for the items are
+// returned as a list under ForEachOutputKey.
+func (p *Phase) execForEach(ctx context.Context, bp crv1alpha1.Blueprint, action string, tp param.TemplateParams) (map[string]interface{}, error) {
+	items, err := renderForEachItems(p.forEach.Items, tp)
+	if err != nil {
+		return nil, errkit.Wrap(err, fmt.Sprintf("Failed to render forEach items of phase %s", p.name))
+	}
+	parallelism := p.forEach.Parallelism
+	if parallelism <= 0 {
+		parallelism = 1
+	}
+
+	p.itemsTotal.Store(int64(len(items)))
+	p.itemsDone.Store(0)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	outputs := make([]interface{}, len(items))
+	errs := make([]error, len(items))
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i, item := range items {
+		sem <- struct{}{}
+		if ctx.Err() != nil {
+			break
+		}
+		itp := tp
+		itp.Item = item
+		itp.Index = i
+		ip := &Phase{
+			name:        p.name,
+			objects:     p.objects,
+			f:           newFuncInstance(p.f),
+			version:     p.version,
+			deprecation: p.deprecation,
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			out, err := ip.Exec(ctx, bp, action, itp)
+			if err != nil {
+				errs[i] = errkit.Wrap(err, fmt.Sprintf("Failed to execute phase %s for item %d", p.name, i))
+				cancel()
+				return
+			}
+			if out == nil {
+				out = map[string]interface{}{}
+			}
+			outputs[i] = out
+			p.itemsDone.Add(1)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{ForEachOutputKey: outputs}, nil
+}
+
+// forEachProgress returns the progress of a phase that runs for each item as
+// the percentage of the items that completed.
+func (p *Phase) forEachProgress() crv1alpha1.PhaseProgress {
+	percent := 0
+	if total := p.itemsTotal.Load(); total > 0 {
+		percent = int(p.itemsDone.Load() * 100 / total)
+	}
+	now := metav1.NewTime(time.Now())
+	return crv1alpha1.PhaseProgress{
+		ProgressPercent:    strconv.Itoa(percent),
+		LastTransitionTime: &now,
+	}
+}
+
+// newFuncInstance returns a copy of the registered Func for the execution of
+// one item. The registered Funcs are shared, and they keep state like their
+// progress in their fields, so items that run at the same time can't
+// execute the same instance.
+func newFuncInstance(f Func) Func {
+	v := reflect.ValueOf(f)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return f
+	}
+	c := reflect.New(v.Elem().Type())
+	c.Elem().Set(v.Elem())
+	return c.Interface().(Func)
+}
+
+// renderForEachItems renders the items of a forEach. A template expression,
+// e.g. `.StatefulSet.Pods`, is rendered as JSON. A go template has to render
+// a JSON or YAML list.
+func renderForEachItems(items string, tp param.TemplateParams) ([]interface{}, error) {
+	args, err := param.RenderArgs(map[string]interface{}{"items": forEachItemsTemplate(items)}, tp)
+	if err != nil {
+		return nil, err
+	}
+	rendered, _ := args["items"].(string)
+	var list []interface{}
+	if err := yaml.Unmarshal([]byte(rendered), &list); err != nil {
+		return nil, errkit.Wrap(err, fmt.Sprintf("Rendered items {%s} are not a list", rendered))
+	}
+	return list, nil
+}
+
+func forEachItemsTemplate(items string) string {
+	if strings.Contains(items, "{{") {
+		return items
+	}
+	return "{{ toJson " + items + " }}"
+}
@@ -0,0 +1,206 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kanister
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/kanisterio/errkit"
+	"gopkg.in/check.v1"
+
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/param"
+)
+
+type ForEachSuite struct{}
+
+var _ = check.Suite(&ForEachSuite{})
+
+// itemFunc returns its args as output and fails for the pod `fail`.
+type itemFunc struct {
+	testFunc
+}
+
+var (
+	// itemsRunning and itemsMaxRunning count the executions of itemFunc
+	itemsRunning    int32
+	itemsMaxRunning int32
+	// itemInstances records the instances of itemFunc that were executed
+	itemInstances sync.Map
+)
+
+func (*itemFunc) Name() string {
+	return "forEachTestFunc"
+}
+
+func (*itemFunc) Arguments() []string {
+	return []string{"pod", "index"}
+}
+
+func (f *itemFunc) Validate(args map[string]any) error {
+	return nil
+}
+
+func (f *itemFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	itemInstances.Store(f, true)
+	running := atomic.AddInt32(&itemsRunning, 1)
+	defer atomic.AddInt32(&itemsRunning, -1)
+	for {
+		max := atomic.LoadInt32(&itemsMaxRunning)
+		if running <= max || atomic.CompareAndSwapInt32(&itemsMaxRunning, max, running) {
+			break
+		}
+	}
+	if args["pod"] == "fail" {
+		return nil, errkit.New("Pod failed")
+	}
+	return args, nil
+}
+
+func forEachBlueprint(fe *crv1alpha1.ForEach) crv1alpha1.Blueprint {
+	return crv1alpha1.Blueprint{
+		Actions: map[string]*crv1alpha1.BlueprintAction{
+			"backup": {
+				Phases: []crv1alpha1.BlueprintPhase{
+					{
+						Name:    "perPod",
+						Func:    "forEachTestFunc",
+						ForEach: fe,
+						Args: map[string]interface{}{
+							"pod":   "{{ .Item }}",
+							"index": "{{ .Index }}",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *ForEachSuite) SetUpSuite(c *check.C) {
+	c.Assert(Register(&itemFunc{}), check.IsNil)
+}
+
+func (s *ForEachSuite) TestExecForEach(c *check.C) {
+	tp := param.TemplateParams{
+		StatefulSet: &param.StatefulSetParams{Name: "db", Pods: []string{"db-0", "db-1", "db-2"}},
+	}
+	for _, tc := range []struct {
+		forEach *crv1alpha1.ForEach
+		output  []interface{}
+		errChk  check.Checker
+		errMsg  string
+	}{
+		{
+			forEach: &crv1alpha1.ForEach{Items: ".StatefulSet.Pods"},
+			output: []interface{}{
+				map[string]interface{}{"pod": "db-0", "index": "0"},
+				map[string]interface{}{"pod": "db-1", "index": "1"},
+				map[string]interface{}{"pod": "db-2", "index": "2"},
+			},
+			errChk: check.IsNil,
+		},
+		{
+			forEach: &crv1alpha1.ForEach{
+				Items:       "{{ range .StatefulSet.Pods }}\n- {{ . }}-data{{ end }}",
+				Parallelism: 2,
+			},
+			output: []interface{}{
+				map[string]interface{}{"pod": "db-0-data", "index": "0"},
+				map[string]interface{}{"pod": "db-1-data", "index": "1"},
+				map[string]interface{}{"pod": "db-2-data", "index": "2"},
+			},
+			errChk: check.IsNil,
+		},
+		{
+			forEach: &crv1alpha1.ForEach{Items: `{{ list "db-0" "fail" "db-2" | toJson }}`},
+			errChk:  check.NotNil,
+			errMsg:  "Failed to execute phase perPod for item 1.*Pod failed.*",
+		},
+		{
+			forEach: &crv1alpha1.ForEach{Items: ".StatefulSet.Name"},
+			errChk:  check.NotNil,
+			errMsg:  "Failed to render forEach items of phase perPod.*are not a list.*",
+		},
+		{
+			forEach: &crv1alpha1.ForEach{Items: ".StatefulSet.Podz"},
+			errChk:  check.NotNil,
+			errMsg:  "Failed to render forEach items of phase perPod.*",
+		},
+	} {
+		bp := forEachBlueprint(tc.forEach)
+		phases, err := GetPhases(bp, "backup", "", tp)
+		c.Assert(err, check.IsNil)
+		c.Assert(phases[0].ForEach(), check.Equals, true)
+		out, err := phases[0].Exec(context.Background(), bp, "backup", tp)
+		c.Assert(err, tc.errChk)
+		if err != nil {
+			c.Assert(err, check.ErrorMatches, tc.errMsg)
+			continue
+		}
+		c.Assert(out, check.DeepEquals, map[string]interface{}{ForEachOutputKey: tc.output})
+		pp, err := phases[0].Progress()
+		c.Assert(err, check.IsNil)
+		c.Assert(pp.ProgressPercent, check.Equals, "100")
+	}
+}
+
+func (s *ForEachSuite) TestForEachParallelism(c *check.C) {
+	pods := []string{}
+	for i := 0; i < 20; i++ {
+		pods = append(pods, "pod")
+	}
+	tp := param.TemplateParams{StatefulSet: &param.StatefulSetParams{Pods: pods}}
+	f := KanisterFuncForName("forEachTestFunc", DefaultVersion)
+	for _, parallelism := range []int{0, 3} {
+		atomic.StoreInt32(&itemsMaxRunning, 0)
+		itemInstances.Clear()
+		bp := forEachBlueprint(&crv1alpha1.ForEach{Items: ".StatefulSet.Pods", Parallelism: parallelism})
+		phases, err := GetPhases(bp, "backup", "", tp)
+		c.Assert(err, check.IsNil)
+		out, err := phases[0].Exec(context.Background(), bp, "backup", tp)
+		c.Assert(err, check.IsNil)
+		c.Assert(out[ForEachOutputKey], check.HasLen, 20)
+		expected := int32(parallelism)
+		if expected == 0 {
+			expected = 1
+		}
+		c.Assert(atomic.LoadInt32(&itemsMaxRunning) <= expected, check.Equals, true)
+
+		// Every item is executed by its own instance of the function
+		instances := 0
+		itemInstances.Range(func(k, _ any) bool {
+			c.Assert(k, check.Not(check.Equals), f)
+			instances++
+			return true
+		})
+		c.Assert(instances, check.Equals, 20)
+	}
+}
+
+func (s *ForEachSuite) TestForEachValidation(c *check.C) {
+	_, err := GetPhases(forEachBlueprint(&crv1alpha1.ForEach{}), "backup", "", param.TemplateParams{})
+	c.Assert(err, check.ErrorMatches, "Items of forEach of phase {perPod} must be set")
+	_, err = GetPhases(forEachBlueprint(&crv1alpha1.ForEach{Items: ".StatefulSet.Pods", Parallelism: -1}), "backup", "", param.TemplateParams{})
+	c.Assert(err, check.ErrorMatches, "Parallelism of forEach of phase {perPod} must not be negative")
+
+	// The items are checked with the args of the phase
+	err = CheckTemplates(forEachBlueprint(&crv1alpha1.ForEach{Items: ".StatefulSet.Podz"}), "backup", param.TemplateParams{}, true)
+	c.Assert(err, check.ErrorMatches, "(?s).*forEach.items.*StatefulSet.Podz.*")
+	err = CheckTemplates(forEachBlueprint(&crv1alpha1.ForEach{Items: ".StatefulSet.Pods"}), "backup", param.TemplateParams{}, true)
+	c.Assert(err, check.IsNil)
+}
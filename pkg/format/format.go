@@ -85,9 +85,11 @@ func info(podName string, containerName string, l string) {
 	}
 }
 
+// LogWithCtx logs each line of output with the pod and container, after
+// passing it through the redaction function of ctx.
 func LogWithCtx(ctx context.Context, podName string, containerName string, output string) {
 	if output != "" {
-		logs := regex.Split(output, -1)
+		logs := regex.Split(pkgout.RedactWithCtx(ctx, output), -1)
 		for _, l := range logs {
 			infoWithCtx(ctx, podName, containerName, l)
 		}
@@ -0,0 +1,438 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/kanisterio/errkit"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/consts"
+	"github.com/kanisterio/kanister/pkg/ephemeral"
+	"github.com/kanisterio/kanister/pkg/format"
+	"github.com/kanisterio/kanister/pkg/kube"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/progress"
+	"github.com/kanisterio/kanister/pkg/restic"
+	"github.com/kanisterio/kanister/pkg/utils"
+)
+
+const (
+	// ApplyRetentionPolicyFuncName gives the function name
+	ApplyRetentionPolicyFuncName = "ApplyRetentionPolicy"
+	// ApplyRetentionPolicyNamespaceArg provides the namespace to run the pod in
+	ApplyRetentionPolicyNamespaceArg = "namespace"
+	// ApplyRetentionPolicyImageArg provides the image of the pod
+	ApplyRetentionPolicyImageArg = "image"
+	// ApplyRetentionPolicyBackupArtifactPrefixArg provides the path of the restic repository
+	ApplyRetentionPolicyBackupArtifactPrefixArg = "backupArtifactPrefix"
+	// ApplyRetentionPolicyEncryptionKeyArg provides the encryption key of the restic repository
+	ApplyRetentionPolicyEncryptionKeyArg = "encryptionKey"
+	// ApplyRetentionPolicyKeepLastArg provides the number of the latest snapshots to keep
+	ApplyRetentionPolicyKeepLastArg = "keepLast"
+	// ApplyRetentionPolicyKeepHourlyArg provides the number of the latest hourly snapshots to keep
+	ApplyRetentionPolicyKeepHourlyArg = "keepHourly"
+	// ApplyRetentionPolicyKeepDailyArg provides the number of the latest daily snapshots to keep
+	ApplyRetentionPolicyKeepDailyArg = "keepDaily"
+	// ApplyRetentionPolicyKeepWeeklyArg provides the number of the latest weekly snapshots to keep
+	ApplyRetentionPolicyKeepWeeklyArg = "keepWeekly"
+	// ApplyRetentionPolicyKeepMonthlyArg provides the number of the latest monthly snapshots to keep
+	ApplyRetentionPolicyKeepMonthlyArg = "keepMonthly"
+	// ApplyRetentionPolicyKeepYearlyArg provides the number of the latest yearly snapshots to keep
+	ApplyRetentionPolicyKeepYearlyArg = "keepYearly"
+	// ApplyRetentionPolicyTagsArg provides the tags of the snapshots the policy is applied to
+	ApplyRetentionPolicyTagsArg = "tags"
+	// ApplyRetentionPolicyPruneArg provides a way to specify if the data of the removed snapshots should be pruned
+	ApplyRetentionPolicyPruneArg = "prune"
+	// ApplyRetentionPolicyOutputRemovedSnapshots is the key for the output with the IDs of the removed snapshots
+	ApplyRetentionPolicyOutputRemovedSnapshots = "removedSnapshots"
+	// ApplyRetentionPolicyOutputSpaceFreed is the key for the output reporting the space freed
+	ApplyRetentionPolicyOutputSpaceFreed = "spaceFreed"
+
+	applyRetentionPolicyJobPrefix = "apply-retention-policy-"
+)
+
+func init() {
+	_ = kanister.Register(&applyRetentionPolicyFunc{})
+}
+
+var _ kanister.Func = (*applyRetentionPolicyFunc)(nil)
+
+type applyRetentionPolicyFunc struct {
+	progressPercent string
+}
+
+func (*applyRetentionPolicyFunc) Name() string {
+	return ApplyRetentionPolicyFuncName
+}
+
+type retentionPolicyArgs struct {
+	policy               restic.RetentionPolicy
+	tags                 []string
+	prune                bool
+	backupArtifactPrefix string
+	encryptionKey        string
+	insecureTLS          bool
+}
+
+func parseRetentionPolicyArgs(args map[string]interface{}, profile *param.Profile) (retentionPolicyArgs, error) {
+	var rpa retentionPolicyArgs
+	for _, k := range []struct {
+		arg   string
+		value *int
+	}{
+		{ApplyRetentionPolicyKeepLastArg, &rpa.policy.KeepLast},
+		{ApplyRetentionPolicyKeepHourlyArg, &rpa.policy.KeepHourly},
+		{ApplyRetentionPolicyKeepDailyArg, &rpa.policy.KeepDaily},
+		{ApplyRetentionPolicyKeepWeeklyArg, &rpa.policy.KeepWeekly},
+		{ApplyRetentionPolicyKeepMonthlyArg, &rpa.policy.KeepMonthly},
+		{ApplyRetentionPolicyKeepYearlyArg, &rpa.policy.KeepYearly},
+	} {
+		if err := OptArg(args, k.arg, k.value, 0); err != nil {
+			return rpa, err
+		}
+		if *k.value < 0 {
+			return rpa, errkit.New(fmt.Sprintf("%s must not be negative", k.arg))
+		}
+	}
+	if rpa.policy.IsZero() {
+		return rpa, errkit.New("Retention policy must keep at least one snapshot")
+	}
+	if err := OptArg(args, ApplyRetentionPolicyTagsArg, &rpa.tags, nil); err != nil {
+		return rpa, err
+	}
+	if err := OptArg(args, ApplyRetentionPolicyPruneArg, &rpa.prune, false); err != nil {
+		return rpa, err
+	}
+	if err := OptArg(args, ApplyRetentionPolicyBackupArtifactPrefixArg, &rpa.backupArtifactPrefix, ""); err != nil {
+		return rpa, err
+	}
+	if err := OptArg(args, ApplyRetentionPolicyEncryptionKeyArg, &rpa.encryptionKey, restic.GeneratePassword()); err != nil {
+		return rpa, err
+	}
+	if err := OptArg(args, InsecureTLS, &rpa.insecureTLS, false); err != nil {
+		return rpa, err
+	}
+	if profile.Location.Type == crv1alpha1.LocationTypeKopia {
+		if rpa.prune {
+			return rpa, errkit.New("Pruning is not supported for kopia repositories, the space is reclaimed by the maintenance of the kopia repository server")
+		}
+		return rpa, nil
+	}
+	if rpa.backupArtifactPrefix == "" {
+		return rpa, errkit.New(fmt.Sprintf("%s is required for restic repositories", ApplyRetentionPolicyBackupArtifactPrefixArg))
+	}
+	rpa.backupArtifactPrefix = ResolveArtifactPrefix(rpa.backupArtifactPrefix, profile)
+	return rpa, nil
+}
+
+func (a *applyRetentionPolicyFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	// Set progress percent
+	a.progressPercent = progress.StartedPercent
+	defer func() { a.progressPercent = progress.CompletedPercent }()
+
+	var namespace, image string
+	var bpAnnotations, bpLabels map[string]string
+	if err := Arg(args, ApplyRetentionPolicyNamespaceArg, &namespace); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, ApplyRetentionPolicyImageArg, &image, consts.GetKanisterToolsImage()); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, PodAnnotationsArg, &bpAnnotations, nil); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, PodLabelsArg, &bpLabels, nil); err != nil {
+		return nil, err
+	}
+	if err := ValidateProfile(tp.Profile); err != nil {
+		return nil, err
+	}
+	rpa, err := parseRetentionPolicyArgs(args, tp.Profile)
+	if err != nil {
+		return nil, err
+	}
+	podOverride, err := GetPodSpecOverride(tp, args, PodOverrideArg)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := bpAnnotations
+	labels := bpLabels
+	if tp.PodAnnotations != nil {
+		// merge the actionset annotations with blueprint annotations
+		var actionSetAnn ActionSetAnnotations = tp.PodAnnotations
+		annotations = actionSetAnn.MergeBPAnnotations(bpAnnotations)
+	}
+
+	if tp.PodLabels != nil {
+		// merge the actionset labels with blueprint labels
+		var actionSetLabels ActionSetLabels = tp.PodLabels
+		labels = actionSetLabels.MergeBPLabels(bpLabels)
+	}
+
+	cli, err := kube.NewClient()
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to create Kubernetes client")
+	}
+	return applyRetentionPolicy(ctx, cli, tp, namespace, image, rpa, podOverride, annotations, labels)
+}
+
+func applyRetentionPolicy(
+	ctx context.Context,
+	cli kubernetes.Interface,
+	tp param.TemplateParams,
+	namespace,
+	image string,
+	rpa retentionPolicyArgs,
+	podOverride crv1alpha1.JSONMap,
+	annotations,
+	labels map[string]string,
+) (map[string]interface{}, error) {
+	options := &kube.PodOptions{
+		Namespace:    namespace,
+		GenerateName: applyRetentionPolicyJobPrefix,
+		Image:        image,
+		Command:      []string{"sh", "-c", "tail -f /dev/null"},
+		PodOverride:  podOverride,
+		Annotations:  annotations,
+		Labels:       labels,
+	}
+
+	// Apply the registered ephemeral pod changes.
+	if err := ephemeral.PodOptions.Apply(options); err != nil {
+		return nil, errkit.Wrap(err, "Failed to apply ephemeral pod options")
+	}
+
+	pr := kube.NewPodRunner(cli, options)
+	return pr.Run(ctx, func(ctx context.Context, pc kube.PodController) (map[string]interface{}, error) {
+		pod := pc.Pod()
+		if err := pc.WaitForPodReady(ctx); err != nil {
+			return nil, errkit.Wrap(err, "Failed while waiting for Pod to be ready", "pod", pod.Name)
+		}
+		ex, err := pc.GetCommandExecutor()
+		if err != nil {
+			return nil, err
+		}
+		if tp.Profile.Location.Type == crv1alpha1.LocationTypeKopia {
+			return applyKopiaRetentionPolicy(ctx, ex, tp.Profile, rpa, pod.Name, pod.Spec.Containers[0].Name)
+		}
+
+		remover, err := MaybeWriteProfileCredentials(ctx, pc, tp.Profile)
+		if err != nil {
+			return nil, err
+		}
+		// Parent context could already be dead, so removing file within new context
+		defer remover.Remove(context.Background()) //nolint:errcheck
+		return applyResticRetentionPolicy(ctx, ex, tp, rpa, pod)
+	})
+}
+
+func applyResticRetentionPolicy(ctx context.Context, ex kube.PodCommandExecutor, tp param.TemplateParams, rpa retentionPolicyArgs, pod *corev1.Pod) (map[string]interface{}, error) {
+	cmd, err := restic.ForgetCommandByPolicy(tp.Profile, rpa.backupArtifactPrefix, rpa.policy, rpa.tags, rpa.encryptionKey, rpa.insecureTLS)
+	if err != nil {
+		return nil, err
+	}
+	stdout, _, err := ExecAndLog(ctx, ex, cmd, pod)
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to forget data")
+	}
+	removed, err := restic.SnapshotIDsFromForgetLog(stdout)
+	if err != nil {
+		return nil, err
+	}
+	var spaceFreed int64
+	if rpa.prune && len(removed) > 0 {
+		spaceFreedStr, err := pruneData(tp, pod, ex, rpa.encryptionKey, rpa.backupArtifactPrefix, rpa.insecureTLS)
+		if err != nil {
+			return nil, errkit.Wrap(err, "Error executing prune command")
+		}
+		spaceFreed = restic.ParseResticSizeStringBytes(spaceFreedStr)
+	}
+	return map[string]interface{}{
+		ApplyRetentionPolicyOutputRemovedSnapshots: removed,
+		ApplyRetentionPolicyOutputSpaceFreed:       fmt.Sprintf("%d B", spaceFreed),
+	}, nil
+}
+
+func applyKopiaRetentionPolicy(ctx context.Context, ex kube.PodCommandExecutor, profile *param.Profile, rpa retentionPolicyArgs, podName, containerName string) (map[string]interface{}, error) {
+	stdin, err := kopiaProfileStdin(profile)
+	if err != nil {
+		return nil, err
+	}
+	var stdout, stderr bytes.Buffer
+	err = ex.Exec(ctx, kopiaApplyRetentionCommand(rpa.policy, rpa.tags), stdin, &stdout, &stderr)
+	format.LogWithCtx(ctx, podName, containerName, stdout.String())
+	format.LogWithCtx(ctx, podName, containerName, stderr.String())
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to apply retention policy to kopia snapshots")
+	}
+	out, err := parseLogAndCreateOutput(ctx, stdout.String())
+	if err != nil {
+		return nil, err
+	}
+	removedJSON, _ := out[kopiaRemovedSnapshotsKandoOutput].(string)
+	removed := []string{}
+	if err := json.Unmarshal([]byte(removedJSON), &removed); err != nil {
+		return nil, errkit.Wrap(err, "Failed to parse the removed kopia snapshots")
+	}
+	// The data of the removed snapshots is only deleted by the maintenance of
+	// the kopia repository server, so the freed space isn't known.
+	return map[string]interface{}{
+		ApplyRetentionPolicyOutputRemovedSnapshots: removed,
+	}, nil
+}
+
+// kopiaApplyRetentionCommand deletes the kopia snapshots that aren't kept by
+// the policy. The profile is read from stdin.
+func kopiaApplyRetentionCommand(policy restic.RetentionPolicy, tags []string) []string {
+	cmd := []string{
+		"sh", "-c",
+		`kando location apply-retention --profile "$(cat)" --output-name ` + kopiaRemovedSnapshotsKandoOutput + ` "$@"`,
+		"sh",
+	}
+	for _, k := range []struct {
+		flag  string
+		value int
+	}{
+		{"--keep-last", policy.KeepLast},
+		{"--keep-hourly", policy.KeepHourly},
+		{"--keep-daily", policy.KeepDaily},
+		{"--keep-weekly", policy.KeepWeekly},
+		{"--keep-monthly", policy.KeepMonthly},
+		{"--keep-yearly", policy.KeepYearly},
+	} {
+		if k.value > 0 {
+			cmd = append(cmd, k.flag, strconv.Itoa(k.value))
+		}
+	}
+	for _, t := range tags {
+		cmd = append(cmd, "--tag", t)
+	}
+	return cmd
+}
+
+func (*applyRetentionPolicyFunc) RequiredArgs() []string {
+	return []string{ApplyRetentionPolicyNamespaceArg}
+}
+
+func (*applyRetentionPolicyFunc) Arguments() []string {
+	return []string{
+		ApplyRetentionPolicyNamespaceArg,
+		ApplyRetentionPolicyImageArg,
+		ApplyRetentionPolicyBackupArtifactPrefixArg,
+		ApplyRetentionPolicyEncryptionKeyArg,
+		ApplyRetentionPolicyKeepLastArg,
+		ApplyRetentionPolicyKeepHourlyArg,
+		ApplyRetentionPolicyKeepDailyArg,
+		ApplyRetentionPolicyKeepWeeklyArg,
+		ApplyRetentionPolicyKeepMonthlyArg,
+		ApplyRetentionPolicyKeepYearlyArg,
+		ApplyRetentionPolicyTagsArg,
+		ApplyRetentionPolicyPruneArg,
+		InsecureTLS,
+		PodOverrideArg,
+		PodAnnotationsArg,
+		PodLabelsArg,
+	}
+}
+
+func (*applyRetentionPolicyFunc) Schema() kanister.FuncSchema {
+	keepArg := func(name, period string) kanister.ArgSchema {
+		return kanister.ArgSchema{
+			Name:        name,
+			Type:        kanister.ArgTypeInteger,
+			Description: fmt.Sprintf("Number of the latest %ssnapshots to keep", period),
+		}
+	}
+	return kanister.FuncSchema{
+		Name:        ApplyRetentionPolicyFuncName,
+		Description: "Removes the restic or kopia snapshots that are not kept by a retention policy",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        ApplyRetentionPolicyNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace to run the pod that applies the policy in",
+			},
+			{
+				Name:        ApplyRetentionPolicyImageArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Image of the pod that applies the policy, needs to have restic or kando installed",
+			},
+			{
+				Name:        ApplyRetentionPolicyBackupArtifactPrefixArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Path of the restic repository in the object store, required for restic repositories",
+			},
+			encryptionKeyArgSchema,
+			keepArg(ApplyRetentionPolicyKeepLastArg, ""),
+			keepArg(ApplyRetentionPolicyKeepHourlyArg, "hourly "),
+			keepArg(ApplyRetentionPolicyKeepDailyArg, "daily "),
+			keepArg(ApplyRetentionPolicyKeepWeeklyArg, "weekly "),
+			keepArg(ApplyRetentionPolicyKeepMonthlyArg, "monthly "),
+			keepArg(ApplyRetentionPolicyKeepYearlyArg, "yearly "),
+			{
+				Name:        ApplyRetentionPolicyTagsArg,
+				Type:        kanister.ArgTypeList,
+				Description: "Only apply the policy to the snapshots with all of these tags",
+			},
+			{
+				Name:        ApplyRetentionPolicyPruneArg,
+				Type:        kanister.ArgTypeBoolean,
+				Description: "Prune the data of the removed snapshots, only supported for restic repositories",
+				Default:     false,
+			},
+			insecureTLSArgSchema,
+			podOverrideArgSchema,
+			podAnnotationsArgSchema,
+			podLabelsArgSchema,
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: ApplyRetentionPolicyOutputRemovedSnapshots, Type: kanister.ArgTypeList, Description: "IDs of the removed snapshots"},
+			{Name: ApplyRetentionPolicyOutputSpaceFreed, Type: kanister.ArgTypeString, Description: "Space freed by pruning, only set for restic repositories"},
+		},
+	}
+}
+
+func (a *applyRetentionPolicyFunc) Validate(args map[string]any) error {
+	if err := ValidatePodLabelsAndAnnotations(a.Name(), args); err != nil {
+		return err
+	}
+
+	if err := utils.CheckSupportedArgs(a.Arguments(), args); err != nil {
+		return err
+	}
+
+	return utils.CheckRequiredArgs(a.RequiredArgs(), args)
+}
+
+func (a *applyRetentionPolicyFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
+	metav1Time := metav1.NewTime(time.Now())
+	return crv1alpha1.PhaseProgress{
+		ProgressPercent:    a.progressPercent,
+		LastTransitionTime: &metav1Time,
+	}, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"context"
+	"strings"
+
+	"gopkg.in/check.v1"
+
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/kube"
+	"github.com/kanisterio/kanister/pkg/output"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/restic"
+)
+
+type ApplyRetentionPolicySuite struct{}
+
+var _ = check.Suite(&ApplyRetentionPolicySuite{})
+
+func (s *ApplyRetentionPolicySuite) TestParseRetentionPolicyArgs(c *check.C) {
+	resticProfile := &param.Profile{
+		Location: crv1alpha1.Location{Type: crv1alpha1.LocationTypeS3Compliant, Bucket: "bucket"},
+	}
+	for _, tc := range []struct {
+		args    map[string]interface{}
+		profile *param.Profile
+		policy  restic.RetentionPolicy
+		prefix  string
+		errMsg  string
+	}{
+		{
+			args: map[string]interface{}{
+				ApplyRetentionPolicyKeepLastArg:             "3",
+				ApplyRetentionPolicyKeepDailyArg:            7,
+				ApplyRetentionPolicyBackupArtifactPrefixArg: "path/repo",
+			},
+			profile: resticProfile,
+			policy:  restic.RetentionPolicy{KeepLast: 3, KeepDaily: 7},
+			prefix:  "bucket/path/repo",
+		},
+		{
+			args:    map[string]interface{}{ApplyRetentionPolicyKeepWeeklyArg: 4},
+			profile: kopiaTestProfile(),
+			policy:  restic.RetentionPolicy{KeepWeekly: 4},
+		},
+		{
+			args:    map[string]interface{}{ApplyRetentionPolicyBackupArtifactPrefixArg: "path/repo"},
+			profile: resticProfile,
+			errMsg:  "Retention policy must keep at least one snapshot",
+		},
+		{
+			args:    map[string]interface{}{ApplyRetentionPolicyKeepLastArg: -1},
+			profile: resticProfile,
+			errMsg:  "keepLast must not be negative",
+		},
+		{
+			args:    map[string]interface{}{ApplyRetentionPolicyKeepLastArg: 1},
+			profile: resticProfile,
+			errMsg:  "backupArtifactPrefix is required for restic repositories",
+		},
+		{
+			args:    map[string]interface{}{ApplyRetentionPolicyKeepLastArg: 1, ApplyRetentionPolicyPruneArg: true},
+			profile: kopiaTestProfile(),
+			errMsg:  "Pruning is not supported for kopia repositories.*",
+		},
+	} {
+		rpa, err := parseRetentionPolicyArgs(tc.args, tc.profile)
+		if tc.errMsg != "" {
+			c.Assert(err, check.ErrorMatches, tc.errMsg)
+			continue
+		}
+		c.Assert(err, check.IsNil)
+		c.Assert(rpa.policy, check.Equals, tc.policy)
+		c.Assert(rpa.backupArtifactPrefix, check.Equals, tc.prefix)
+	}
+}
+
+func (s *ApplyRetentionPolicySuite) TestKopiaApplyRetentionCommand(c *check.C) {
+	cmd := kopiaApplyRetentionCommand(restic.RetentionPolicy{KeepLast: 2, KeepMonthly: 6}, []string{"app:db"})
+	c.Assert(cmd[3:], check.DeepEquals, []string{"sh", "--keep-last", "2", "--keep-monthly", "6", "--tag", "app:db"})
+}
+
+func (s *ApplyRetentionPolicySuite) TestApplyKopiaRetentionPolicyRedactsLogs(c *check.C) {
+	ex := &kube.FakePodCommandExecutor{
+		ExecStdout: "Applying policy with s3cr3t\n" + output.PhaseOpString + ` {"key":"` + kopiaRemovedSnapshotsKandoOutput + `","value":"[\"s3cr3t\"]"}` + "\n",
+		ExecStderr: "Connecting with s3cr3t",
+	}
+	var logged []string
+	redact := func(line string) string {
+		logged = append(logged, line)
+		return strings.ReplaceAll(line, "s3cr3t", "<****>")
+	}
+	// The controller installs the redaction on the context of the phase
+	ctx := output.WithRedaction(context.Background(), redact)
+	rpa := retentionPolicyArgs{policy: restic.RetentionPolicy{KeepLast: 2}}
+	out, err := applyKopiaRetentionPolicy(ctx, ex, kopiaTestProfile(), rpa, "pod", "container")
+	c.Assert(err, check.IsNil)
+	// The stdout and stderr and the lines parsed for outputs are all logged
+	// through the redaction, but the outputs aren't redacted.
+	c.Assert(logged, check.HasLen, 4)
+	c.Assert(logged[1], check.Equals, "Connecting with s3cr3t")
+	c.Assert(out[ApplyRetentionPolicyOutputRemovedSnapshots], check.DeepEquals, []string{"s3cr3t"})
+}
@@ -17,17 +17,71 @@
 package function
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/kanisterio/errkit"
 	"github.com/mitchellh/mapstructure"
 	"sigs.k8s.io/yaml"
 
+	kanister "github.com/kanisterio/kanister/pkg"
 	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
 	"github.com/kanisterio/kanister/pkg/kube"
 	"github.com/kanisterio/kanister/pkg/param"
 )
 
+// Schemas of the arguments that are shared by multiple functions
+var (
+	podOverrideArgSchema = kanister.ArgSchema{
+		Name:        PodOverrideArg,
+		Type:        kanister.ArgTypeMap,
+		Description: "Pod spec that is merged into the spec of the pod created by the function",
+	}
+	podAnnotationsArgSchema = kanister.ArgSchema{
+		Name:        PodAnnotationsArg,
+		Type:        kanister.ArgTypeMap,
+		Description: "Annotations added to the pod created by the function",
+	}
+	podLabelsArgSchema = kanister.ArgSchema{
+		Name:        PodLabelsArg,
+		Type:        kanister.ArgTypeMap,
+		Description: "Labels added to the pod created by the function",
+	}
+	insecureTLSArgSchema = kanister.ArgSchema{
+		Name:        InsecureTLS,
+		Type:        kanister.ArgTypeBoolean,
+		Description: "Skip the TLS verification of the object store",
+		Default:     false,
+	}
+	encryptionKeyArgSchema = kanister.ArgSchema{
+		Name:        "encryptionKey",
+		Type:        kanister.ArgTypeString,
+		Description: "Encryption key of the restic repository, defaults to a key generated from the profile",
+	}
+	credentialsSourceArgSchema = kanister.ArgSchema{
+		Name:        CredentialsSourceArg,
+		Type:        kanister.ArgTypeString,
+		Description: "Source of the AWS credentials used to access RDS",
+		Default:     string(CredentialSourceProfile),
+		Enum:        []string{string(CredentialSourceProfile), string(CredentialSourceSecret), string(CredentialSourceServiceAccount)},
+	}
+	credentialsSecretArgSchema = kanister.ArgSchema{
+		Name:        CredentialsSecretArg,
+		Type:        kanister.ArgTypeString,
+		Description: "Name of the secret with the AWS credentials, required if credentialsSource is secret",
+	}
+	regionArgSchema = kanister.ArgSchema{
+		Name:        RegionArg,
+		Type:        kanister.ArgTypeString,
+		Description: "AWS region, overrides the region of the profile",
+	}
+	versionOutputSchema = kanister.OutputSchema{
+		Name:        FunctionOutputVersion,
+		Type:        kanister.ArgTypeString,
+		Description: "Version of the function that produced the output",
+	}
+)
+
 // Arg returns the value of the specified argument
 // It will return an error if the argument type does not match the result type
 func Arg(args map[string]interface{}, argName string, result interface{}) error {
@@ -49,6 +103,24 @@ func OptArg(args map[string]interface{}, argName string, result interface{}, def
 	return mapstructure.Decode(defaultValue, result)
 }
 
+// optJSONArg decodes the value of the specified argument through JSON if it
+// exists, e.g. for types with inline fields that mapstructure can't decode.
+// The result is left unchanged if the argument does not exist.
+func optJSONArg(args map[string]interface{}, argName string, result interface{}) error {
+	val, ok := args[argName]
+	if !ok {
+		return nil
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return errkit.Wrap(err, fmt.Sprintf("Failed to decode arg `%s`", argName))
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return errkit.Wrap(err, fmt.Sprintf("Failed to decode arg `%s`", argName))
+	}
+	return nil
+}
+
 // ArgExists checks if the argument exists
 func ArgExists(args map[string]interface{}, argName string) bool {
 	_, ok := args[argName]
@@ -16,6 +16,9 @@ package function
 
 import (
 	"gopkg.in/check.v1"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	"github.com/kanisterio/kanister/pkg/filter"
 )
 
 var _ = check.Suite(&ArgsTestSuite{})
@@ -78,3 +81,85 @@ func (s *ArgsTestSuite) TestGetYamlList(c *check.C) {
 		c.Check(valList, check.DeepEquals, tc.valList, check.Commentf("Test: %s Failed!", tc.name))
 	}
 }
+
+func (s *ArgsTestSuite) TestOptJSONArg(c *check.C) {
+	var m filter.ResourceMatcher
+	err := optJSONArg(map[string]interface{}{}, BackupResourcesIncludeArg, &m)
+	c.Assert(err, check.IsNil)
+	c.Assert(m.Empty(), check.Equals, true)
+
+	err = optJSONArg(map[string]interface{}{
+		BackupResourcesIncludeArg: []interface{}{
+			map[string]interface{}{"group": "apps", "resource": "deployments", "name": "app"},
+		},
+	}, BackupResourcesIncludeArg, &m)
+	c.Assert(err, check.IsNil)
+	c.Assert(m, check.HasLen, 1)
+	c.Assert(m[0].Group, check.Equals, "apps")
+	c.Assert(m[0].Resource, check.Equals, "deployments")
+	c.Assert(m[0].Name, check.Equals, "app")
+
+	err = optJSONArg(map[string]interface{}{BackupResourcesIncludeArg: "deployments"}, BackupResourcesIncludeArg, &m)
+	c.Assert(err, check.ErrorMatches, "Failed to decode arg `includeResources`.*")
+}
+
+func (s *ArgsTestSuite) TestFuncSchemas(c *check.C) {
+	for _, schema := range kanister.RegisteredFuncSchemas() {
+		f := kanister.KanisterFuncForName(schema.Name, schema.Version)
+		if _, ok := f.(kanister.FuncDescriber); !ok {
+			// Funcs registered by other tests
+			continue
+		}
+		c.Assert(schema.Description, check.Not(check.Equals), "", check.Commentf("Function %s", schema.Name))
+		var names, required []string
+		for _, a := range schema.Args {
+			c.Assert(a.Type, check.Not(check.Equals), kanister.ArgType(""), check.Commentf("Arg %s of function %s", a.Name, schema.Name))
+			names = append(names, a.Name)
+			if a.Required {
+				required = append(required, a.Name)
+			}
+		}
+		c.Assert(names, check.DeepEquals, f.Arguments(), check.Commentf("Function %s", schema.Name))
+		if len(f.RequiredArgs()) == 0 {
+			c.Assert(required, check.HasLen, 0, check.Commentf("Function %s", schema.Name))
+			continue
+		}
+		c.Assert(required, check.DeepEquals, f.RequiredArgs(), check.Commentf("Function %s", schema.Name))
+	}
+}
+
+func (s *ArgsTestSuite) TestValidateArgTypes(c *check.C) {
+	schema := kanister.DescribeFunc(&scaleWorkloadFunc{})
+	for _, tc := range []struct {
+		args       map[string]interface{}
+		errChecker check.Checker
+	}{
+		{
+			args:       map[string]interface{}{ScaleWorkloadReplicas: 2, ScaleWorkloadKindArg: "Deployment", ScaleWorkloadWaitArg: false},
+			errChecker: check.IsNil,
+		},
+		{
+			args:       map[string]interface{}{ScaleWorkloadReplicas: "2", ScaleWorkloadWaitArg: "true"},
+			errChecker: check.IsNil,
+		},
+		{
+			args:       map[string]interface{}{ScaleWorkloadReplicas: "{{ .Options.replicas }}", ScaleWorkloadKindArg: "{{ .Options.kind }}"},
+			errChecker: check.IsNil,
+		},
+		{
+			args:       map[string]interface{}{ScaleWorkloadReplicas: "two"},
+			errChecker: check.NotNil,
+		},
+		{
+			args:       map[string]interface{}{ScaleWorkloadReplicas: 1, ScaleWorkloadKindArg: "replicationcontroller"},
+			errChecker: check.NotNil,
+		},
+		{
+			args:       map[string]interface{}{ScaleWorkloadReplicas: 1, ScaleWorkloadNameArg: map[string]interface{}{"name": "app"}},
+			errChecker: check.NotNil,
+		},
+	} {
+		err := schema.ValidateArgs(tc.args)
+		c.Assert(err, tc.errChecker, check.Commentf("Args %v", tc.args))
+	}
+}
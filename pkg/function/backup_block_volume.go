@@ -0,0 +1,289 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/kanisterio/errkit"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/consts"
+	"github.com/kanisterio/kanister/pkg/ephemeral"
+	"github.com/kanisterio/kanister/pkg/format"
+	"github.com/kanisterio/kanister/pkg/kube"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/progress"
+	"github.com/kanisterio/kanister/pkg/utils"
+)
+
+const (
+	// BackupBlockVolumeFuncName gives the name of the function
+	BackupBlockVolumeFuncName = "BackupBlockVolume"
+	// BlockVolumeNamespaceArg provides the namespace of the PVC
+	BlockVolumeNamespaceArg = "namespace"
+	// BlockVolumePVCArg provides the name of the PVC with volumeMode Block
+	BlockVolumePVCArg = "pvc"
+	// BlockVolumeImageArg provides the image of the pod the PVC is attached to
+	BlockVolumeImageArg = "image"
+	// BlockVolumeChecksumOutput is the key used for returning the checksum of the volume data
+	BlockVolumeChecksumOutput = "checksum"
+
+	backupBlockVolumeJobPrefix = "backup-block-volume-"
+	// blockVolumeDevicePath is the path of the device of the PVC in the pod
+	blockVolumeDevicePath = "/dev/kanister-block"
+)
+
+func init() {
+	_ = kanister.Register(&backupBlockVolumeFunc{})
+}
+
+var _ kanister.Func = (*backupBlockVolumeFunc)(nil)
+
+type backupBlockVolumeFunc struct {
+	progressPercent string
+}
+
+func (*backupBlockVolumeFunc) Name() string {
+	return BackupBlockVolumeFuncName
+}
+
+// blockVolumeSnapshotPath returns the path of the kopia streaming file with
+// the data of the PVC. Every PVC is a separate kopia source, so that the
+// snapshots of a PVC are incremental.
+func blockVolumeSnapshotPath(namespace, pvc string) string {
+	return fmt.Sprintf("/kanister-block/%s/%s/device", namespace, pvc)
+}
+
+type blockVolumePodArgs struct {
+	namespace   string
+	pvc         string
+	image       string
+	podOverride crv1alpha1.JSONMap
+	annotations map[string]string
+	labels      map[string]string
+}
+
+func parseBlockVolumePodArgs(tp param.TemplateParams, args map[string]interface{}) (blockVolumePodArgs, error) {
+	var a blockVolumePodArgs
+	var bpAnnotations, bpLabels map[string]string
+	if err := Arg(args, BlockVolumeNamespaceArg, &a.namespace); err != nil {
+		return a, err
+	}
+	if err := Arg(args, BlockVolumePVCArg, &a.pvc); err != nil {
+		return a, err
+	}
+	if err := OptArg(args, BlockVolumeImageArg, &a.image, consts.GetKanisterToolsImage()); err != nil {
+		return a, err
+	}
+	if err := OptArg(args, PodAnnotationsArg, &bpAnnotations, nil); err != nil {
+		return a, err
+	}
+	if err := OptArg(args, PodLabelsArg, &bpLabels, nil); err != nil {
+		return a, err
+	}
+	podOverride, err := GetPodSpecOverride(tp, args, PodOverrideArg)
+	if err != nil {
+		return a, err
+	}
+	a.podOverride = podOverride
+
+	a.annotations = bpAnnotations
+	a.labels = bpLabels
+	if tp.PodAnnotations != nil {
+		// merge the actionset annotations with blueprint annotations
+		var actionSetAnn ActionSetAnnotations = tp.PodAnnotations
+		a.annotations = actionSetAnn.MergeBPAnnotations(bpAnnotations)
+	}
+
+	if tp.PodLabels != nil {
+		// merge the actionset labels with blueprint labels
+		var actionSetLabels ActionSetLabels = tp.PodLabels
+		a.labels = actionSetLabels.MergeBPLabels(bpLabels)
+	}
+	return a, nil
+}
+
+// runBlockVolumePod runs cmd in a pod with the PVC attached as a device at
+// blockVolumeDevicePath and returns its stdout. The profile is passed to cmd
+// on stdin.
+func runBlockVolumePod(ctx context.Context, cli kubernetes.Interface, a blockVolumePodArgs, jobPrefix string, profile *param.Profile, cmd []string) (string, error) {
+	pvc, err := cli.CoreV1().PersistentVolumeClaims(a.namespace).Get(ctx, a.pvc, metav1.GetOptions{})
+	if err != nil {
+		return "", errkit.Wrap(err, "Failed to retrieve PVC.", "namespace", a.namespace, "name", a.pvc)
+	}
+	if pvc.Spec.VolumeMode == nil || *pvc.Spec.VolumeMode != corev1.PersistentVolumeBlock {
+		return "", errkit.New("PVC must have volumeMode Block", "namespace", a.namespace, "name", a.pvc)
+	}
+
+	options := &kube.PodOptions{
+		Namespace:    a.namespace,
+		GenerateName: jobPrefix,
+		Image:        a.image,
+		Command:      []string{"sh", "-c", "tail -f /dev/null"},
+		BlockVolumes: map[string]string{a.pvc: blockVolumeDevicePath},
+		PodOverride:  a.podOverride,
+		Annotations:  a.annotations,
+		Labels:       a.labels,
+	}
+
+	// Apply the registered ephemeral pod changes.
+	if err := ephemeral.PodOptions.Apply(options); err != nil {
+		return "", errkit.Wrap(err, "Failed to apply ephemeral pod options")
+	}
+
+	var stdout bytes.Buffer
+	pr := kube.NewPodRunner(cli, options)
+	_, err = pr.Run(ctx, func(ctx context.Context, pc kube.PodController) (map[string]interface{}, error) {
+		pod := pc.Pod()
+		if err := pc.WaitForPodReady(ctx); err != nil {
+			return nil, errkit.Wrap(err, "Failed while waiting for Pod to be ready", "pod", pod.Name)
+		}
+		ex, err := pc.GetCommandExecutor()
+		if err != nil {
+			return nil, err
+		}
+		stdin, err := kopiaProfileStdin(profile)
+		if err != nil {
+			return nil, err
+		}
+		var stderr bytes.Buffer
+		err = ex.Exec(ctx, cmd, stdin, &stdout, &stderr)
+		format.LogWithCtx(ctx, pod.Name, pod.Spec.Containers[0].Name, stdout.String())
+		format.LogWithCtx(ctx, pod.Name, pod.Spec.Containers[0].Name, stderr.String())
+		return nil, err
+	})
+	return stdout.String(), err
+}
+
+func (b *backupBlockVolumeFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	// Set progress percent
+	b.progressPercent = progress.StartedPercent
+	defer func() { b.progressPercent = progress.CompletedPercent }()
+
+	a, err := parseBlockVolumePodArgs(tp, args)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateKopiaProfile(tp.Profile); err != nil {
+		return nil, err
+	}
+	cli, err := kube.NewClient()
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to create Kubernetes client")
+	}
+	cmd := kopiaPushBlockCommand(blockVolumeSnapshotPath(a.namespace, a.pvc), blockVolumeDevicePath)
+	stdout, err := runBlockVolumePod(ctx, cli, a, backupBlockVolumeJobPrefix, tp.Profile, cmd)
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to back up block volume", "pvc", a.pvc)
+	}
+	snapJSON, snapInfo, err := kopiaSnapshotFromLog(ctx, stdout)
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to parse kopia snapshot from the backup logs")
+	}
+	out, err := parseLogAndCreateOutput(ctx, stdout)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		KopiaSnapshotOutput:       snapJSON,
+		KopiaSnapshotIDOutput:     snapInfo.ID,
+		KopiaSnapshotSizeOutput:   strconv.FormatInt(snapInfo.LogicalSize, 10),
+		BlockVolumeChecksumOutput: out[kopiaChecksumKandoOutput],
+		FunctionOutputVersion:     kanister.DefaultVersion,
+	}, nil
+}
+
+func (*backupBlockVolumeFunc) RequiredArgs() []string {
+	return []string{
+		BlockVolumeNamespaceArg,
+		BlockVolumePVCArg,
+	}
+}
+
+func (*backupBlockVolumeFunc) Arguments() []string {
+	return []string{
+		BlockVolumeNamespaceArg,
+		BlockVolumePVCArg,
+		BlockVolumeImageArg,
+		PodOverrideArg,
+		PodAnnotationsArg,
+		PodLabelsArg,
+	}
+}
+
+func (*backupBlockVolumeFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        BackupBlockVolumeFuncName,
+		Description: "Backs up a PVC with volumeMode Block to a kopia repository server",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        BlockVolumeNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the PVC",
+			},
+			{
+				Name:        BlockVolumePVCArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the PVC with volumeMode Block",
+			},
+			{
+				Name:        BlockVolumeImageArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Image of the pod the PVC is attached to, needs to have kando installed",
+			},
+			podOverrideArgSchema,
+			podAnnotationsArgSchema,
+			podLabelsArgSchema,
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: KopiaSnapshotOutput, Type: kanister.ArgTypeString, Description: "Kopia snapshot to pass to RestoreBlockVolume and DeleteDataUsingKopia"},
+			{Name: KopiaSnapshotIDOutput, Type: kanister.ArgTypeString, Description: "ID of the kopia snapshot"},
+			{Name: KopiaSnapshotSizeOutput, Type: kanister.ArgTypeString, Description: "Size of the volume in bytes"},
+			{Name: BlockVolumeChecksumOutput, Type: kanister.ArgTypeString, Description: "SHA-256 checksum of the volume data"},
+			versionOutputSchema,
+		},
+	}
+}
+
+func (b *backupBlockVolumeFunc) Validate(args map[string]any) error {
+	if err := ValidatePodLabelsAndAnnotations(b.Name(), args); err != nil {
+		return err
+	}
+
+	if err := utils.CheckSupportedArgs(b.Arguments(), args); err != nil {
+		return err
+	}
+
+	return utils.CheckRequiredArgs(b.RequiredArgs(), args)
+}
+
+func (b *backupBlockVolumeFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
+	metav1Time := metav1.NewTime(time.Now())
+	return crv1alpha1.PhaseProgress{
+		ProgressPercent:    b.progressPercent,
+		LastTransitionTime: &metav1Time,
+	}, nil
+}
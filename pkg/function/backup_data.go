@@ -158,6 +158,55 @@ func (*backupDataFunc) Arguments() []string {
 	}
 }
 
+func (*backupDataFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        BackupDataFuncName,
+		Description: "Backs up the data of a volume mounted in a pod to the object store using restic",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        BackupDataNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the pod",
+			},
+			{
+				Name:        BackupDataPodArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the pod the volume is mounted in",
+			},
+			{
+				Name:        BackupDataContainerArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the container the volume is mounted in, the container needs to have restic installed",
+			},
+			{
+				Name:        BackupDataIncludePathArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Path of the data to back up in the container",
+			},
+			{
+				Name:        BackupDataBackupArtifactPrefixArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Path in the object store to store the backup in",
+			},
+			encryptionKeyArgSchema,
+			insecureTLSArgSchema,
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: BackupDataOutputBackupID, Type: kanister.ArgTypeString, Description: "ID of the backup"},
+			{Name: BackupDataOutputBackupTag, Type: kanister.ArgTypeString, Description: "Tag of the backup"},
+			{Name: BackupDataOutputBackupFileCount, Type: kanister.ArgTypeString, Description: "Number of files in the backup"},
+			{Name: BackupDataOutputBackupSize, Type: kanister.ArgTypeString, Description: "Size of the backup"},
+			{Name: BackupDataOutputBackupPhysicalSize, Type: kanister.ArgTypeString, Description: "Size added to the repository by the backup"},
+			versionOutputSchema,
+		},
+	}
+}
+
 func (b *backupDataFunc) Validate(args map[string]any) error {
 	if err := utils.CheckSupportedArgs(b.Arguments(), args); err != nil {
 		return err
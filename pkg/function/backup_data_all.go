@@ -115,23 +115,37 @@ func (b *backupDataAllFunc) Exec(ctx context.Context, tp param.TemplateParams, a
 	if err != nil {
 		return nil, errkit.Wrap(err, "Failed to create Kubernetes client")
 	}
-	var ps []string
-	if pods == "" {
-		switch {
-		case tp.Deployment != nil:
-			ps = tp.Deployment.Pods
-		case tp.StatefulSet != nil:
-			ps = tp.StatefulSet.Pods
-		default:
-			return nil, errkit.New("Failed to get pods")
-		}
-	} else {
-		ps = strings.Fields(pods)
+	ps, err := backupDataAllPods(tp, pods)
+	if err != nil {
+		return nil, err
 	}
 	ctx = field.Context(ctx, consts.ContainerNameKey, container)
 	return backupDataAll(ctx, cli, namespace, ps, container, backupArtifactPrefix, includePath, encryptionKey, insecureTLS, tp)
 }
 
+// backupDataAllPods returns the space separated pods, or the pods of the
+// workload if pods is empty.
+func backupDataAllPods(tp param.TemplateParams, pods string) ([]string, error) {
+	if pods != "" {
+		return strings.Fields(pods), nil
+	}
+	switch {
+	case tp.Deployment != nil:
+		return tp.Deployment.Pods, nil
+	case tp.StatefulSet != nil:
+		return tp.StatefulSet.Pods, nil
+	case tp.DaemonSet != nil:
+		return tp.DaemonSet.Pods, nil
+	case tp.ReplicaSet != nil:
+		return tp.ReplicaSet.Pods, nil
+	case tp.Job != nil:
+		return tp.Job.Pods, nil
+	case tp.CronJob != nil:
+		return tp.CronJob.Pods, nil
+	}
+	return nil, errkit.New("Failed to get pods")
+}
+
 func (*backupDataAllFunc) RequiredArgs() []string {
 	return []string{
 		BackupDataAllNamespaceArg,
@@ -153,6 +167,50 @@ func (*backupDataAllFunc) Arguments() []string {
 	}
 }
 
+func (*backupDataAllFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        BackupDataAllFuncName,
+		Description: "Backs up the data of the volumes mounted in all the pods of a workload to the object store using restic",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        BackupDataAllNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the pods",
+			},
+			{
+				Name:        BackupDataAllContainerArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the container the volumes are mounted in, the container needs to have restic installed",
+			},
+			{
+				Name:        BackupDataAllIncludePathArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Path of the data to back up in the containers",
+			},
+			{
+				Name:        BackupDataAllBackupArtifactPrefixArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Path in the object store to store the backups in",
+			},
+			{
+				Name:        BackupDataAllPodsArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Space separated names of the pods to back up, defaults to the pods of the workload",
+			},
+			encryptionKeyArgSchema,
+			insecureTLSArgSchema,
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: BackupDataAllOutput, Type: kanister.ArgTypeString, Description: "JSON encoded map of the pods to their backup IDs and tags"},
+			versionOutputSchema,
+		},
+	}
+}
+
 func (b *backupDataAllFunc) Validate(args map[string]any) error {
 	if err := utils.CheckSupportedArgs(b.Arguments(), args); err != nil {
 		return err
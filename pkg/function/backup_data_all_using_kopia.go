@@ -0,0 +1,228 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/kanisterio/errkit"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/consts"
+	"github.com/kanisterio/kanister/pkg/field"
+	"github.com/kanisterio/kanister/pkg/kube"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/progress"
+	"github.com/kanisterio/kanister/pkg/utils"
+)
+
+const (
+	// BackupDataAllUsingKopiaFuncName gives the name of the function
+	BackupDataAllUsingKopiaFuncName = "BackupDataAllUsingKopia"
+)
+
+// KopiaBackupInfo is the kopia snapshot of the data of a pod backed up by
+// BackupDataAllUsingKopia
+type KopiaBackupInfo struct {
+	PodName    string
+	Snapshot   string
+	SnapshotID string
+	Size       string
+}
+
+func init() {
+	_ = kanister.Register(&backupDataAllUsingKopiaFunc{})
+}
+
+var _ kanister.Func = (*backupDataAllUsingKopiaFunc)(nil)
+
+type backupDataAllUsingKopiaFunc struct {
+	progressPercent string
+	upload          kopiaUploadProgress
+}
+
+func (*backupDataAllUsingKopiaFunc) Name() string {
+	return BackupDataAllUsingKopiaFuncName
+}
+
+func (b *backupDataAllUsingKopiaFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	// Set progress percent
+	b.progressPercent = progress.StartedPercent
+	defer func() { b.progressPercent = progress.CompletedPercent }()
+
+	var namespace, pods, container, includePath string
+	if err := Arg(args, BackupDataAllNamespaceArg, &namespace); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, BackupDataAllContainerArg, &container); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, BackupDataAllIncludePathArg, &includePath); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, BackupDataAllPodsArg, &pods, ""); err != nil {
+		return nil, err
+	}
+	if err := validateKopiaProfile(tp.Profile); err != nil {
+		return nil, err
+	}
+	ps, err := backupDataAllPods(tp, pods)
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := kube.NewClient()
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to create Kubernetes client")
+	}
+	ctx = field.Context(ctx, consts.ContainerNameKey, container)
+	b.upload.reset(len(ps))
+	return backupDataAllUsingKopia(ctx, cli, namespace, ps, container, includePath, tp.Profile, &b.upload)
+}
+
+// backupDataAllUsingKopia snapshots includePath in the container of each pod
+// in parallel.
+func backupDataAllUsingKopia(
+	ctx context.Context,
+	cli kubernetes.Interface,
+	namespace string,
+	ps []string,
+	container,
+	includePath string,
+	profile *param.Profile,
+	upload *kopiaUploadProgress,
+) (map[string]interface{}, error) {
+	type result struct {
+		info KopiaBackupInfo
+		err  error
+	}
+	results := make(chan result, len(ps))
+	for i, pod := range ps {
+		go func(i int, pod string) {
+			podCtx := field.Context(ctx, consts.PodNameKey, pod)
+			out, err := backupDataUsingKopia(podCtx, cli, namespace, pod, container, includePath, profile, upload.writer(i))
+			if err != nil {
+				results <- result{err: errkit.Wrap(err, "Failed to backup data for pod", "pod", pod)}
+				return
+			}
+			results <- result{info: KopiaBackupInfo{
+				PodName:    pod,
+				Snapshot:   out[KopiaSnapshotOutput].(string),
+				SnapshotID: out[KopiaSnapshotIDOutput].(string),
+				Size:       out[KopiaSnapshotSizeOutput].(string),
+			}}
+		}(i, pod)
+	}
+	infos := make(map[string]KopiaBackupInfo, len(ps))
+	errs := make([]string, 0, len(ps))
+	for range ps {
+		r := <-results
+		if r.err != nil {
+			errs = append(errs, r.err.Error())
+			continue
+		}
+		infos[r.info.PodName] = r.info
+	}
+	if len(errs) != 0 {
+		return nil, errkit.New(strings.Join(errs, "\n"))
+	}
+	infoJSON, err := json.Marshal(infos)
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to encode JSON data")
+	}
+	return map[string]interface{}{
+		BackupDataAllOutput:   string(infoJSON),
+		FunctionOutputVersion: kanister.DefaultVersion,
+	}, nil
+}
+
+func (*backupDataAllUsingKopiaFunc) RequiredArgs() []string {
+	return []string{
+		BackupDataAllNamespaceArg,
+		BackupDataAllContainerArg,
+		BackupDataAllIncludePathArg,
+	}
+}
+
+func (*backupDataAllUsingKopiaFunc) Arguments() []string {
+	return []string{
+		BackupDataAllNamespaceArg,
+		BackupDataAllContainerArg,
+		BackupDataAllIncludePathArg,
+		BackupDataAllPodsArg,
+	}
+}
+
+func (*backupDataAllUsingKopiaFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        BackupDataAllUsingKopiaFuncName,
+		Description: "Backs up the data of the volumes mounted in all the pods of a workload to a kopia repository server",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        BackupDataAllNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the pods",
+			},
+			{
+				Name:        BackupDataAllContainerArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the container the volumes are mounted in, the container needs to have kando installed",
+			},
+			{
+				Name:        BackupDataAllIncludePathArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Path of the data to back up in the containers",
+			},
+			{
+				Name:        BackupDataAllPodsArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Space separated names of the pods to back up, defaults to the pods of the workload",
+			},
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: BackupDataAllOutput, Type: kanister.ArgTypeString, Description: "JSON encoded map of the pods to their kopia snapshots, snapshot IDs and sizes"},
+			versionOutputSchema,
+		},
+	}
+}
+
+func (b *backupDataAllUsingKopiaFunc) Validate(args map[string]any) error {
+	if err := utils.CheckSupportedArgs(b.Arguments(), args); err != nil {
+		return err
+	}
+
+	return utils.CheckRequiredArgs(b.RequiredArgs(), args)
+}
+
+func (b *backupDataAllUsingKopiaFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
+	metav1Time := metav1.NewTime(time.Now())
+	percent := b.progressPercent
+	if percent == progress.StartedPercent {
+		percent = b.upload.percent()
+	}
+	return crv1alpha1.PhaseProgress{
+		ProgressPercent:    percent,
+		LastTransitionTime: &metav1Time,
+	}, nil
+}
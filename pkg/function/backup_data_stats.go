@@ -257,6 +257,54 @@ func (*BackupDataStatsFunc) Arguments() []string {
 	}
 }
 
+func (*BackupDataStatsFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        BackupDataStatsFuncName,
+		Description: "Gets the stats of a restic backup",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        BackupDataStatsNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace to create the pod that gets the stats in",
+			},
+			{
+				Name:        BackupDataStatsImageArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Image of the pod, needs to have restic installed. Defaults to the kanister tools image",
+			},
+			{
+				Name:        BackupDataStatsBackupArtifactPrefixArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Path in the object store the backup is stored in",
+			},
+			{
+				Name:        BackupDataStatsBackupIdentifierArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "ID of the backup",
+			},
+			{
+				Name:        BackupDataStatsMode,
+				Type:        kanister.ArgTypeString,
+				Description: "Mode of the restic stats command",
+				Default:     defaultStatsMode,
+			},
+			encryptionKeyArgSchema,
+			podOverrideArgSchema,
+			podAnnotationsArgSchema,
+			podLabelsArgSchema,
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: BackupDataStatsOutputMode, Type: kanister.ArgTypeString, Description: "Mode of the stats"},
+			{Name: BackupDataStatsOutputFileCount, Type: kanister.ArgTypeString, Description: "Number of files in the backup"},
+			{Name: BackupDataStatsOutputSize, Type: kanister.ArgTypeString, Description: "Size of the backup"},
+			versionOutputSchema,
+		},
+	}
+}
+
 func (b *BackupDataStatsFunc) Validate(args map[string]any) error {
 	if err := ValidatePodLabelsAndAnnotations(b.Name(), args); err != nil {
 		return err
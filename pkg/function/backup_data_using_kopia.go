@@ -0,0 +1,192 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/kanisterio/errkit"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/consts"
+	"github.com/kanisterio/kanister/pkg/field"
+	"github.com/kanisterio/kanister/pkg/format"
+	"github.com/kanisterio/kanister/pkg/kube"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/progress"
+	"github.com/kanisterio/kanister/pkg/utils"
+)
+
+const (
+	// BackupDataUsingKopiaFuncName gives the name of the function
+	BackupDataUsingKopiaFuncName = "BackupDataUsingKopia"
+)
+
+func init() {
+	_ = kanister.Register(&backupDataUsingKopiaFunc{})
+}
+
+var _ kanister.Func = (*backupDataUsingKopiaFunc)(nil)
+
+type backupDataUsingKopiaFunc struct {
+	progressPercent string
+	upload          kopiaUploadProgress
+}
+
+func (*backupDataUsingKopiaFunc) Name() string {
+	return BackupDataUsingKopiaFuncName
+}
+
+func (b *backupDataUsingKopiaFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	// Set progress percent
+	b.progressPercent = progress.StartedPercent
+	defer func() { b.progressPercent = progress.CompletedPercent }()
+	b.upload.reset(1)
+
+	var namespace, pod, container, includePath string
+	if err := Arg(args, BackupDataNamespaceArg, &namespace); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, BackupDataPodArg, &pod); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, BackupDataContainerArg, &container); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, BackupDataIncludePathArg, &includePath); err != nil {
+		return nil, err
+	}
+	if err := validateKopiaProfile(tp.Profile); err != nil {
+		return nil, err
+	}
+
+	cli, err := kube.NewClient()
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to create Kubernetes client")
+	}
+	ctx = field.Context(ctx, consts.PodNameKey, pod)
+	ctx = field.Context(ctx, consts.ContainerNameKey, container)
+	return backupDataUsingKopia(ctx, cli, namespace, pod, container, includePath, tp.Profile, b.upload.writer(0))
+}
+
+// backupDataUsingKopia snapshots includePath in the container. The stdout of
+// kando is also written to progressWriter, to track the upload progress.
+func backupDataUsingKopia(ctx context.Context, cli kubernetes.Interface, namespace, pod, container, includePath string, profile *param.Profile, progressWriter io.Writer) (map[string]interface{}, error) {
+	stdin, err := kopiaProfileStdin(profile)
+	if err != nil {
+		return nil, err
+	}
+	var stdout, stderr bytes.Buffer
+	err = kube.ExecOutput(ctx, cli, namespace, pod, container, kopiaPushCommand(includePath), stdin, io.MultiWriter(&stdout, progressWriter), &stderr)
+	format.LogWithCtx(ctx, pod, container, stdout.String())
+	format.LogWithCtx(ctx, pod, container, stderr.String())
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to create and upload kopia snapshot")
+	}
+	snapJSON, snapInfo, err := kopiaSnapshotFromLog(ctx, stdout.String())
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to parse kopia snapshot from the backup logs")
+	}
+	return map[string]interface{}{
+		KopiaSnapshotOutput:     snapJSON,
+		KopiaSnapshotIDOutput:   snapInfo.ID,
+		KopiaSnapshotSizeOutput: strconv.FormatInt(snapInfo.LogicalSize, 10),
+		FunctionOutputVersion:   kanister.DefaultVersion,
+	}, nil
+}
+
+func (*backupDataUsingKopiaFunc) RequiredArgs() []string {
+	return []string{
+		BackupDataNamespaceArg,
+		BackupDataPodArg,
+		BackupDataContainerArg,
+		BackupDataIncludePathArg,
+	}
+}
+
+func (*backupDataUsingKopiaFunc) Arguments() []string {
+	return []string{
+		BackupDataNamespaceArg,
+		BackupDataPodArg,
+		BackupDataContainerArg,
+		BackupDataIncludePathArg,
+	}
+}
+
+func (*backupDataUsingKopiaFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        BackupDataUsingKopiaFuncName,
+		Description: "Backs up the data of a volume mounted in a pod to a kopia repository server",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        BackupDataNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the pod",
+			},
+			{
+				Name:        BackupDataPodArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the pod the volume is mounted in",
+			},
+			{
+				Name:        BackupDataContainerArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the container the volume is mounted in, the container needs to have kando installed",
+			},
+			{
+				Name:        BackupDataIncludePathArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Path of the data to back up in the container",
+			},
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: KopiaSnapshotOutput, Type: kanister.ArgTypeString, Description: "Kopia snapshot to pass to RestoreDataUsingKopia and DeleteDataUsingKopia"},
+			{Name: KopiaSnapshotIDOutput, Type: kanister.ArgTypeString, Description: "ID of the kopia snapshot"},
+			{Name: KopiaSnapshotSizeOutput, Type: kanister.ArgTypeString, Description: "Size of the backed up data in bytes"},
+			versionOutputSchema,
+		},
+	}
+}
+
+func (b *backupDataUsingKopiaFunc) Validate(args map[string]any) error {
+	if err := utils.CheckSupportedArgs(b.Arguments(), args); err != nil {
+		return err
+	}
+
+	return utils.CheckRequiredArgs(b.RequiredArgs(), args)
+}
+
+func (b *backupDataUsingKopiaFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
+	metav1Time := metav1.NewTime(time.Now())
+	percent := b.progressPercent
+	if percent == progress.StartedPercent {
+		percent = b.upload.percent()
+	}
+	return crv1alpha1.PhaseProgress{
+		ProgressPercent:    percent,
+		LastTransitionTime: &metav1Time,
+	}, nil
+}
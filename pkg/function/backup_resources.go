@@ -0,0 +1,357 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kanisterio/errkit"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/rand"
+	k8sdiscovery "k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/discovery"
+	"github.com/kanisterio/kanister/pkg/field"
+	"github.com/kanisterio/kanister/pkg/filter"
+	"github.com/kanisterio/kanister/pkg/kube"
+	"github.com/kanisterio/kanister/pkg/location"
+	"github.com/kanisterio/kanister/pkg/log"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/progress"
+	"github.com/kanisterio/kanister/pkg/utils"
+)
+
+func init() {
+	_ = kanister.Register(&backupResourcesFunc{})
+}
+
+var (
+	_ kanister.Func = (*backupResourcesFunc)(nil)
+)
+
+const (
+	// BackupResourcesFuncName gives the function name
+	BackupResourcesFuncName = "BackupResources"
+	// BackupResourcesNamespaceArg provides the namespace of the resources
+	BackupResourcesNamespaceArg = "namespace"
+	// BackupResourcesArtifactPrefixArg provides the path to store the archive on the object store
+	BackupResourcesArtifactPrefixArg = "backupArtifactPrefix"
+	// BackupResourcesIncludeArg lists the resources to back up
+	BackupResourcesIncludeArg = "includeResources"
+	// BackupResourcesExcludeArg lists the resources not to back up
+	BackupResourcesExcludeArg = "excludeResources"
+	// BackupResourcesIncludeSecretsArg enables the backup of Secrets
+	BackupResourcesIncludeSecretsArg = "includeSecrets"
+	// BackupResourcesPathOutput is the key used for returning the path of the archive
+	BackupResourcesPathOutput = "path"
+	// BackupResourcesIDOutput is the key used for returning the ID of the backup
+	BackupResourcesIDOutput = "backupID"
+	// BackupResourcesCountOutput is the key used for returning the number of backed up resources
+	BackupResourcesCountOutput = "count"
+)
+
+// defaultBackupResourcesExclude matches the resources that are managed by
+// Kubernetes and can't be restored.
+var defaultBackupResourcesExclude = filter.ResourceTypeMatcher{
+	{Group: filter.K8sCoreGroupExactMatch, Resource: "events"},
+	{Group: "events.k8s.io", Resource: "events"},
+	{Group: filter.K8sCoreGroupExactMatch, Resource: "endpoints"},
+	{Group: "discovery.k8s.io", Resource: "endpointslices"},
+	{Group: "metrics.k8s.io"},
+}
+
+// backupResourcesSecrets matches Secrets, which are only backed up if
+// requested since the archive isn't encrypted.
+var backupResourcesSecrets = filter.ResourceTypeMatcher{
+	{Group: filter.K8sCoreGroupExactMatch, Resource: "secrets"},
+}
+
+// kubeRootCAConfigMap is published to every namespace by Kubernetes.
+const kubeRootCAConfigMap = "kube-root-ca.crt"
+
+// runtimeMetadataFields are set by the API server and are stripped from the
+// backed up manifests.
+var runtimeMetadataFields = []string{
+	"uid",
+	"resourceVersion",
+	"generation",
+	"creationTimestamp",
+	"deletionTimestamp",
+	"deletionGracePeriodSeconds",
+	"managedFields",
+	"selfLink",
+	"ownerReferences",
+}
+
+// pvcBindingAnnotations bind a PVC to its volume and are stripped from the
+// backed up PVCs.
+var pvcBindingAnnotations = []string{
+	"pv.kubernetes.io/bind-completed",
+	"pv.kubernetes.io/bound-by-controller",
+	"volume.beta.kubernetes.io/storage-provisioner",
+	"volume.kubernetes.io/storage-provisioner",
+	"volume.kubernetes.io/selected-node",
+}
+
+type backupResourcesFunc struct {
+	progressPercent string
+}
+
+func (*backupResourcesFunc) Name() string {
+	return BackupResourcesFuncName
+}
+
+func (b *backupResourcesFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	// Set progress percent
+	b.progressPercent = progress.StartedPercent
+	defer func() { b.progressPercent = progress.CompletedPercent }()
+
+	var namespace, backupArtifactPrefix string
+	if err := Arg(args, BackupResourcesNamespaceArg, &namespace); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, BackupResourcesArtifactPrefixArg, &backupArtifactPrefix); err != nil {
+		return nil, err
+	}
+	include, err := resourceMatcherArg(args, BackupResourcesIncludeArg)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := resourceMatcherArg(args, BackupResourcesExcludeArg)
+	if err != nil {
+		return nil, err
+	}
+	var includeSecrets bool
+	if err := OptArg(args, BackupResourcesIncludeSecretsArg, &includeSecrets, false); err != nil {
+		return nil, err
+	}
+	if err := ValidateProfile(tp.Profile); err != nil {
+		return nil, errkit.Wrap(err, "Failed to validate Profile")
+	}
+
+	kubeCli, err := kube.NewClient()
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to create Kubernetes client")
+	}
+	dynCli, err := kube.NewDynamicClient()
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to create dynamic Kubernetes client")
+	}
+	resources, err := collectResources(ctx, kubeCli.Discovery(), dynCli, namespace, include, exclude, includeSecrets)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := writeResourceArchive(&buf, namespace, resources); err != nil {
+		return nil, err
+	}
+	backupID := rand.String(10)
+	path := fmt.Sprintf("%s/%s%s", strings.TrimSuffix(backupArtifactPrefix, "/"), backupID, resourceArchiveExtension)
+	if err := location.Write(ctx, &buf, *tp.Profile, path); err != nil {
+		return nil, errkit.Wrap(err, "Failed to write resource archive", "path", path)
+	}
+	return map[string]interface{}{
+		BackupResourcesPathOutput:  path,
+		BackupResourcesIDOutput:    backupID,
+		BackupResourcesCountOutput: len(resources),
+	}, nil
+}
+
+// resourceMatcherArg decodes a list of resource requirements. The
+// requirements are decoded from JSON since they have inline fields.
+func resourceMatcherArg(args map[string]interface{}, argName string) (filter.ResourceMatcher, error) {
+	var m filter.ResourceMatcher
+	if err := optJSONArg(args, argName, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// collectResources lists the resources of the namespace that match the
+// filters. Resources that are owned by a controller are skipped since
+// they're recreated by their owner, and so are the resources that
+// Kubernetes creates in every namespace. Secrets are skipped unless
+// includeSecrets is set.
+func collectResources(ctx context.Context, discCli k8sdiscovery.DiscoveryInterface, dynCli dynamic.Interface, namespace string, include, exclude filter.ResourceMatcher, includeSecrets bool) ([]archivedResource, error) {
+	typeExclude := filter.JoinResourceTypeMatchers(defaultBackupResourcesExclude, exclude.TypeMatcher(false))
+	if !includeSecrets {
+		typeExclude = filter.JoinResourceTypeMatchers(typeExclude, backupResourcesSecrets)
+	}
+	gvrs, err := discovery.NamespacedGVRsIgnoreGroupErrs(ctx, discCli, typeExclude)
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to discover resource types")
+	}
+	gvrs = filter.GroupVersionResourceList(gvrs).Include(include.TypeMatcher(true)).Exclude(typeExclude)
+	// Discovery doesn't return the resource types in a stable order
+	sort.Slice(gvrs, func(i, j int) bool {
+		return gvrs[i].String() < gvrs[j].String()
+	})
+
+	var resources []archivedResource
+	for _, gvr := range gvrs {
+		list, err := dynCli.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		switch {
+		case apierrors.IsMethodNotSupported(err), apierrors.IsNotFound(err):
+			// Some resource types, e.g. bindings, can't be listed
+			log.Debug().Print("Skipping resource type that can't be listed", field.M{"Resource": gvr.String()})
+			continue
+		case err != nil:
+			return nil, errkit.Wrap(err, "Failed to list resources", "resource", gvr.String(), "namespace", namespace)
+		}
+		for _, item := range list.Items {
+			if metav1.GetControllerOf(&item) != nil || isNamespaceDefaultResource(gvr, item) {
+				continue
+			}
+			if !include.Empty() && !include.Any(item.GetName(), gvr, item.GetLabels()) {
+				continue
+			}
+			if exclude.Any(item.GetName(), gvr, item.GetLabels()) {
+				continue
+			}
+			stripRuntimeFields(gvr, &item)
+			resources = append(resources, archivedResource{GVR: gvr, Object: item})
+		}
+	}
+	return resources, nil
+}
+
+// isNamespaceDefaultResource returns true for the resources that Kubernetes
+// creates in every namespace, i.e. the root CA ConfigMap and the tokens of
+// service accounts.
+func isNamespaceDefaultResource(gvr schema.GroupVersionResource, obj unstructured.Unstructured) bool {
+	if gvr.Group != "" {
+		return false
+	}
+	switch gvr.Resource {
+	case "configmaps":
+		return obj.GetName() == kubeRootCAConfigMap
+	case "secrets":
+		secretType, _, _ := unstructured.NestedString(obj.Object, "type")
+		return secretType == string(corev1.SecretTypeServiceAccountToken)
+	}
+	return false
+}
+
+// stripRuntimeFields removes the fields of the manifest that are set by the
+// API server or controllers and would prevent a restore.
+func stripRuntimeFields(gvr schema.GroupVersionResource, obj *unstructured.Unstructured) {
+	unstructured.RemoveNestedField(obj.Object, "status")
+	for _, f := range runtimeMetadataFields {
+		unstructured.RemoveNestedField(obj.Object, "metadata", f)
+	}
+	if gvr.Group != "" {
+		return
+	}
+	switch gvr.Resource {
+	case "services":
+		// Headless services keep `None`, only allocated IPs are removed
+		if clusterIP, _, _ := unstructured.NestedString(obj.Object, "spec", "clusterIP"); clusterIP != corev1.ClusterIPNone {
+			unstructured.RemoveNestedField(obj.Object, "spec", "clusterIP")
+			unstructured.RemoveNestedField(obj.Object, "spec", "clusterIPs")
+		}
+	case "persistentvolumeclaims":
+		unstructured.RemoveNestedField(obj.Object, "spec", "volumeName")
+		for _, a := range pvcBindingAnnotations {
+			unstructured.RemoveNestedField(obj.Object, "metadata", "annotations", a)
+		}
+	}
+}
+
+func (*backupResourcesFunc) RequiredArgs() []string {
+	return []string{
+		BackupResourcesNamespaceArg,
+		BackupResourcesArtifactPrefixArg,
+	}
+}
+
+func (*backupResourcesFunc) Arguments() []string {
+	return []string{
+		BackupResourcesNamespaceArg,
+		BackupResourcesArtifactPrefixArg,
+		BackupResourcesIncludeArg,
+		BackupResourcesExcludeArg,
+		BackupResourcesIncludeSecretsArg,
+	}
+}
+
+func (*backupResourcesFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        BackupResourcesFuncName,
+		Description: "Backs up the manifests of the resources in a namespace to the object store of the profile",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        BackupResourcesNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the resources",
+			},
+			{
+				Name:        BackupResourcesArtifactPrefixArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Path to store the archive on the object store",
+			},
+			{
+				Name:        BackupResourcesIncludeArg,
+				Type:        kanister.ArgTypeList,
+				Description: "Resources to back up, matched by group, version, resource, name and labels",
+			},
+			{
+				Name:        BackupResourcesExcludeArg,
+				Type:        kanister.ArgTypeList,
+				Description: "Resources not to back up, matched by group, version, resource, name and labels",
+			},
+			{
+				Name:        BackupResourcesIncludeSecretsArg,
+				Type:        kanister.ArgTypeBoolean,
+				Default:     false,
+				Description: "Back up the Secrets of the namespace, except service account tokens",
+			},
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: BackupResourcesPathOutput, Type: kanister.ArgTypeString, Description: "Path of the archive on the object store"},
+			{Name: BackupResourcesIDOutput, Type: kanister.ArgTypeString, Description: "ID of the backup"},
+			{Name: BackupResourcesCountOutput, Type: kanister.ArgTypeInteger, Description: "Number of backed up resources"},
+		},
+	}
+}
+
+func (b *backupResourcesFunc) Validate(args map[string]any) error {
+	if err := utils.CheckSupportedArgs(b.Arguments(), args); err != nil {
+		return err
+	}
+
+	return utils.CheckRequiredArgs(b.RequiredArgs(), args)
+}
+
+func (b *backupResourcesFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
+	metav1Time := metav1.NewTime(time.Now())
+	return crv1alpha1.PhaseProgress{
+		ProgressPercent:    b.progressPercent,
+		LastTransitionTime: &metav1Time,
+	}, nil
+}
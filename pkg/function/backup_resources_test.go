@@ -0,0 +1,322 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+
+	"gopkg.in/check.v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	k8sdiscovery "k8s.io/client-go/discovery"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kanisterio/kanister/pkg/filter"
+)
+
+type BackupResourcesSuite struct{}
+
+var _ = check.Suite(&BackupResourcesSuite{})
+
+const testBackupResourcesNamespace = "test-backup-resources"
+
+var (
+	testDeploymentGVR = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	testReplicaSetGVR = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "replicasets"}
+	testConfigMapGVR  = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	testServiceGVR    = schema.GroupVersionResource{Version: "v1", Resource: "services"}
+	testSecretGVR     = schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+	testEventGVR      = schema.GroupVersionResource{Version: "v1", Resource: "events"}
+)
+
+// preferredFakeDiscovery resolves the preferred resources from the resources
+// of the fake, which returns none itself.
+type preferredFakeDiscovery struct {
+	*fakediscovery.FakeDiscovery
+}
+
+func (d preferredFakeDiscovery) ServerPreferredNamespacedResources() ([]*metav1.APIResourceList, error) {
+	return k8sdiscovery.ServerPreferredNamespacedResources(d.FakeDiscovery)
+}
+
+func newTestUnstructured(apiVersion, kind, name string, labels map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"name":            name,
+			"namespace":       testBackupResourcesNamespace,
+			"uid":             "uid-" + name,
+			"resourceVersion": "42",
+			"managedFields":   []interface{}{map[string]interface{}{"manager": "kubectl"}},
+			"labels":          labels,
+		},
+		"status": map[string]interface{}{"observedGeneration": int64(1)},
+	}}
+}
+
+// newBackupResourcesClients returns clients that serve a deployment with its
+// replicaset, a service, two configmaps, two secrets and an event.
+func newBackupResourcesClients() (k8sdiscovery.DiscoveryInterface, *dynfake.FakeDynamicClient) {
+	kubeCli := fake.NewSimpleClientset()
+	verbs := metav1.Verbs{"get", "list"}
+	kubeCli.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: verbs},
+				{Name: "services", Kind: "Service", Namespaced: true, Verbs: verbs},
+				{Name: "secrets", Kind: "Secret", Namespaced: true, Verbs: verbs},
+				{Name: "events", Kind: "Event", Namespaced: true, Verbs: verbs},
+				{Name: "namespaces", Kind: "Namespace", Namespaced: false, Verbs: verbs},
+			},
+		},
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "deployments", Kind: "Deployment", Namespaced: true, Verbs: verbs},
+				{Name: "replicasets", Kind: "ReplicaSet", Namespaced: true, Verbs: verbs},
+			},
+		},
+	}
+
+	deployment := newTestUnstructured("apps/v1", "Deployment", "app", map[string]interface{}{"app": "db"})
+	replicaSet := newTestUnstructured("apps/v1", "ReplicaSet", "app-1234", map[string]interface{}{"app": "db"})
+	replicaSet.SetOwnerReferences([]metav1.OwnerReference{{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       "app",
+		UID:        "uid-app",
+		Controller: func() *bool { b := true; return &b }(),
+	}})
+	service := newTestUnstructured("v1", "Service", "app", nil)
+	service.Object["spec"] = map[string]interface{}{
+		"clusterIP":  "10.0.0.1",
+		"clusterIPs": []interface{}{"10.0.0.1"},
+		"ports":      []interface{}{map[string]interface{}{"port": int64(5432)}},
+	}
+	secret := newTestUnstructured("v1", "Secret", "credentials", nil)
+	secret.Object["type"] = "Opaque"
+	token := newTestUnstructured("v1", "Secret", "default-token", nil)
+	token.Object["type"] = "kubernetes.io/service-account-token"
+	dynCli := dynfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		testDeploymentGVR: "DeploymentList",
+		testReplicaSetGVR: "ReplicaSetList",
+		testConfigMapGVR:  "ConfigMapList",
+		testServiceGVR:    "ServiceList",
+		testSecretGVR:     "SecretList",
+		testEventGVR:      "EventList",
+	},
+		deployment,
+		replicaSet,
+		service,
+		secret,
+		token,
+		newTestUnstructured("v1", "ConfigMap", "config", map[string]interface{}{"app": "db"}),
+		newTestUnstructured("v1", "ConfigMap", "kube-root-ca.crt", nil),
+		newTestUnstructured("v1", "Event", "app.1234", nil),
+	)
+	return preferredFakeDiscovery{kubeCli.Discovery().(*fakediscovery.FakeDiscovery)}, dynCli
+}
+
+func archivedNames(resources []archivedResource) []string {
+	names := make([]string, 0, len(resources))
+	for _, r := range resources {
+		names = append(names, r.GVR.Resource+"/"+r.Object.GetName())
+	}
+	return names
+}
+
+func (s *BackupResourcesSuite) TestCollectResources(c *check.C) {
+	discCli, dynCli := newBackupResourcesClients()
+	resources, err := collectResources(context.Background(), discCli, dynCli, testBackupResourcesNamespace, nil, nil, false)
+	c.Assert(err, check.IsNil)
+	// Owned replicasets, events, secrets and the root CA are skipped
+	c.Assert(archivedNames(resources), check.DeepEquals, []string{
+		"configmaps/config",
+		"services/app",
+		"deployments/app",
+	})
+
+	for _, r := range resources {
+		c.Assert(r.Object.GetUID(), check.Equals, types.UID(""))
+		c.Assert(r.Object.GetResourceVersion(), check.Equals, "")
+		c.Assert(r.Object.GetManagedFields(), check.HasLen, 0)
+		_, ok := r.Object.Object["status"]
+		c.Assert(ok, check.Equals, false)
+	}
+	spec := resources[1].Object.Object["spec"].(map[string]interface{})
+	c.Assert(spec, check.DeepEquals, map[string]interface{}{
+		"ports": []interface{}{map[string]interface{}{"port": int64(5432)}},
+	})
+}
+
+func (s *BackupResourcesSuite) TestStripRuntimeFields(c *check.C) {
+	for _, tc := range []struct {
+		spec     map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			spec: map[string]interface{}{
+				"clusterIP":  "10.0.0.1",
+				"clusterIPs": []interface{}{"10.0.0.1"},
+				"type":       "ClusterIP",
+			},
+			expected: map[string]interface{}{"type": "ClusterIP"},
+		},
+		{
+			// Headless services keep their cluster IP
+			spec: map[string]interface{}{
+				"clusterIP":  "None",
+				"clusterIPs": []interface{}{"None"},
+				"type":       "ClusterIP",
+			},
+			expected: map[string]interface{}{
+				"clusterIP":  "None",
+				"clusterIPs": []interface{}{"None"},
+				"type":       "ClusterIP",
+			},
+		},
+	} {
+		service := newTestUnstructured("v1", "Service", "app", nil)
+		service.Object["spec"] = tc.spec
+		stripRuntimeFields(testServiceGVR, service)
+		c.Assert(service.Object["spec"], check.DeepEquals, tc.expected)
+		c.Assert(service.GetUID(), check.Equals, types.UID(""))
+	}
+}
+
+func (s *BackupResourcesSuite) TestCollectResourcesIncludeSecrets(c *check.C) {
+	discCli, dynCli := newBackupResourcesClients()
+	resources, err := collectResources(context.Background(), discCli, dynCli, testBackupResourcesNamespace, nil, nil, true)
+	c.Assert(err, check.IsNil)
+	// Service account tokens are skipped
+	c.Assert(archivedNames(resources), check.DeepEquals, []string{
+		"configmaps/config",
+		"secrets/credentials",
+		"services/app",
+		"deployments/app",
+	})
+}
+
+func (s *BackupResourcesSuite) TestCollectResourcesFilters(c *check.C) {
+	discCli, dynCli := newBackupResourcesClients()
+	include, err := resourceMatcherArg(map[string]interface{}{
+		BackupResourcesIncludeArg: []interface{}{
+			map[string]interface{}{"group": "core", "resource": "configmaps"},
+			map[string]interface{}{"matchLabels": map[string]interface{}{"app": "db"}},
+		},
+	}, BackupResourcesIncludeArg)
+	c.Assert(err, check.IsNil)
+	exclude, err := resourceMatcherArg(map[string]interface{}{
+		BackupResourcesExcludeArg: []interface{}{
+			map[string]interface{}{"name": "other"},
+		},
+	}, BackupResourcesExcludeArg)
+	c.Assert(err, check.IsNil)
+
+	resources, err := collectResources(context.Background(), discCli, dynCli, testBackupResourcesNamespace, include, exclude, false)
+	c.Assert(err, check.IsNil)
+	c.Assert(archivedNames(resources), check.DeepEquals, []string{
+		"configmaps/config",
+		"deployments/app",
+	})
+}
+
+func (s *BackupResourcesSuite) TestResourceMatcherArg(c *check.C) {
+	m, err := resourceMatcherArg(map[string]interface{}{}, BackupResourcesIncludeArg)
+	c.Assert(err, check.IsNil)
+	c.Assert(m.Empty(), check.Equals, true)
+
+	m, err = resourceMatcherArg(map[string]interface{}{
+		BackupResourcesIncludeArg: []interface{}{
+			map[string]interface{}{"group": "apps", "resource": "deployments", "name": "app"},
+		},
+	}, BackupResourcesIncludeArg)
+	c.Assert(err, check.IsNil)
+	c.Assert(m, check.HasLen, 1)
+	c.Assert(m[0].Group, check.Equals, "apps")
+	c.Assert(m[0].Resource, check.Equals, "deployments")
+	c.Assert(m[0].Name, check.Equals, "app")
+
+	_, err = resourceMatcherArg(map[string]interface{}{BackupResourcesIncludeArg: "deployments"}, BackupResourcesIncludeArg)
+	c.Assert(err, check.NotNil)
+}
+
+func (s *BackupResourcesSuite) TestWriteResourceArchive(c *check.C) {
+	discCli, dynCli := newBackupResourcesClients()
+	resources, err := collectResources(context.Background(), discCli, dynCli, testBackupResourcesNamespace, nil, filter.ResourceMatcher{{ResourceTypeRequirement: filter.ResourceTypeRequirement{Resource: "services"}}}, false)
+	c.Assert(err, check.IsNil)
+	var buf bytes.Buffer
+	err = writeResourceArchive(&buf, testBackupResourcesNamespace, resources)
+	c.Assert(err, check.IsNil)
+
+	gzr, err := gzip.NewReader(&buf)
+	c.Assert(err, check.IsNil)
+	tr := tar.NewReader(gzr)
+	files := map[string][]byte{}
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		c.Assert(err, check.IsNil)
+		data, err := io.ReadAll(tr)
+		c.Assert(err, check.IsNil)
+		files[hdr.Name] = data
+		names = append(names, hdr.Name)
+	}
+	c.Assert(names, check.DeepEquals, []string{
+		resourceArchiveMetadataFile,
+		"resources/core/v1/configmaps/config.yaml",
+		"resources/apps/v1/deployments/app.yaml",
+	})
+
+	var metadata resourceArchiveMetadata
+	err = json.Unmarshal(files[resourceArchiveMetadataFile], &metadata)
+	c.Assert(err, check.IsNil)
+	c.Assert(metadata.Version, check.Equals, resourceArchiveVersion)
+	c.Assert(metadata.Namespace, check.Equals, testBackupResourcesNamespace)
+	c.Assert(metadata.Resources, check.HasLen, 2)
+	c.Assert(metadata.Resources[1], check.DeepEquals, resourceArchiveEntry{
+		Group:    "apps",
+		Version:  "v1",
+		Resource: "deployments",
+		Name:     "app",
+		Path:     "resources/apps/v1/deployments/app.yaml",
+	})
+
+	var deployment map[string]interface{}
+	err = yaml.Unmarshal(files["resources/apps/v1/deployments/app.yaml"], &deployment)
+	c.Assert(err, check.IsNil)
+	c.Assert(deployment["kind"], check.Equals, "Deployment")
+	c.Assert(deployment["metadata"], check.DeepEquals, map[string]interface{}{
+		"name":      "app",
+		"namespace": testBackupResourcesNamespace,
+		"labels":    map[string]interface{}{"app": "db"},
+	})
+}
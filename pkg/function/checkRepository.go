@@ -233,6 +233,36 @@ func (*CheckRepositoryFunc) Arguments() []string {
 	}
 }
 
+func (*CheckRepositoryFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        CheckRepositoryFuncName,
+		Description: "Checks if a restic repository exists and can be accessed with the encryption key",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        CheckRepositoryImageArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Image of the pod that checks the repository, defaults to the kanister tools image",
+			},
+			{
+				Name:        CheckRepositoryArtifactPrefixArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Path of the repository in the object store",
+			},
+			encryptionKeyArgSchema,
+			insecureTLSArgSchema,
+			podOverrideArgSchema,
+			podAnnotationsArgSchema,
+			podLabelsArgSchema,
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: CheckRepositoryPasswordIncorrect, Type: kanister.ArgTypeString, Description: "\"true\" if the encryption key is incorrect"},
+			{Name: CheckRepositoryRepoDoesNotExist, Type: kanister.ArgTypeString, Description: "\"true\" if the repository does not exist"},
+			versionOutputSchema,
+		},
+	}
+}
+
 func (c *CheckRepositoryFunc) Validate(args map[string]any) error {
 	if err := ValidatePodLabelsAndAnnotations(c.Name(), args); err != nil {
 		return err
@@ -0,0 +1,234 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/kanisterio/errkit"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/consts"
+	"github.com/kanisterio/kanister/pkg/ephemeral"
+	"github.com/kanisterio/kanister/pkg/format"
+	"github.com/kanisterio/kanister/pkg/kube"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/progress"
+	"github.com/kanisterio/kanister/pkg/utils"
+)
+
+const (
+	// CheckRepositoryUsingKopiaFuncName gives the name of the function
+	CheckRepositoryUsingKopiaFuncName  = "CheckRepositoryUsingKopia"
+	checkRepositoryUsingKopiaJobPrefix = "check-repository-kopia-"
+
+	// kopiaAccessDenied is part of the error of the kopia repository server
+	// when the credentials are incorrect
+	kopiaAccessDenied = "access denied"
+	// kopiaServerUnavailable is part of the error of kando when the kopia
+	// repository server can't be reached
+	kopiaServerUnavailable = "Failed connecting to the Kopia API Server"
+)
+
+func init() {
+	_ = kanister.Register(&checkRepositoryUsingKopiaFunc{})
+}
+
+var _ kanister.Func = (*checkRepositoryUsingKopiaFunc)(nil)
+
+type checkRepositoryUsingKopiaFunc struct {
+	progressPercent string
+}
+
+func (*checkRepositoryUsingKopiaFunc) Name() string {
+	return CheckRepositoryUsingKopiaFuncName
+}
+
+func (c *checkRepositoryUsingKopiaFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	// Set progress percent
+	c.progressPercent = progress.StartedPercent
+	defer func() { c.progressPercent = progress.CompletedPercent }()
+
+	var image string
+	var bpAnnotations, bpLabels map[string]string
+	if err := OptArg(args, CheckRepositoryImageArg, &image, consts.GetKanisterToolsImage()); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, PodAnnotationsArg, &bpAnnotations, nil); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, PodLabelsArg, &bpLabels, nil); err != nil {
+		return nil, err
+	}
+	podOverride, err := GetPodSpecOverride(tp, args, PodOverrideArg)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := bpAnnotations
+	labels := bpLabels
+	if tp.PodAnnotations != nil {
+		// merge the actionset annotations with blueprint annotations
+		var actionSetAnn ActionSetAnnotations = tp.PodAnnotations
+		annotations = actionSetAnn.MergeBPAnnotations(bpAnnotations)
+	}
+
+	if tp.PodLabels != nil {
+		// merge the actionset labels with blueprint labels
+		var actionSetLabels ActionSetLabels = tp.PodLabels
+		labels = actionSetLabels.MergeBPLabels(bpLabels)
+	}
+
+	if err = validateKopiaProfile(tp.Profile); err != nil {
+		return nil, err
+	}
+
+	cli, err := kube.NewClient()
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to create Kubernetes client")
+	}
+	return checkRepositoryUsingKopia(ctx, cli, tp.Profile, image, podOverride, annotations, labels)
+}
+
+func checkRepositoryUsingKopia(
+	ctx context.Context,
+	cli kubernetes.Interface,
+	profile *param.Profile,
+	image string,
+	podOverride crv1alpha1.JSONMap,
+	annotations,
+	labels map[string]string,
+) (map[string]interface{}, error) {
+	namespace, err := kube.GetControllerNamespace()
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to get controller namespace")
+	}
+	options := &kube.PodOptions{
+		Namespace:    namespace,
+		GenerateName: checkRepositoryUsingKopiaJobPrefix,
+		Image:        image,
+		Command:      []string{"sh", "-c", "tail -f /dev/null"},
+		PodOverride:  podOverride,
+		Annotations:  annotations,
+		Labels:       labels,
+	}
+
+	// Apply the registered ephemeral pod changes.
+	if err := ephemeral.PodOptions.Apply(options); err != nil {
+		return nil, errkit.Wrap(err, "Failed to apply ephemeral pod options")
+	}
+
+	pr := kube.NewPodRunner(cli, options)
+	return pr.Run(ctx, func(ctx context.Context, pc kube.PodController) (map[string]interface{}, error) {
+		pod := pc.Pod()
+		if err := pc.WaitForPodReady(ctx); err != nil {
+			return nil, errkit.Wrap(err, "Failed while waiting for Pod to be ready", "pod", pod.Name)
+		}
+		ex, err := pc.GetCommandExecutor()
+		if err != nil {
+			return nil, err
+		}
+		stdin, err := kopiaProfileStdin(profile)
+		if err != nil {
+			return nil, err
+		}
+		var stdout, stderr bytes.Buffer
+		err = ex.Exec(ctx, kopiaCheckCommand(), stdin, &stdout, &stderr)
+		format.LogWithCtx(ctx, pod.Name, pod.Spec.Containers[0].Name, stdout.String())
+		format.LogWithCtx(ctx, pod.Name, pod.Spec.Containers[0].Name, stderr.String())
+		return kopiaCheckRepositoryOutputs(err, stderr.String())
+	})
+}
+
+// kopiaCheckRepositoryOutputs returns the outputs of CheckRepository for the
+// result of `kando location check`.
+func kopiaCheckRepositoryOutputs(err error, stderr string) (map[string]interface{}, error) {
+	passwordIncorrect, repoUnavailable := "false", "false"
+	switch {
+	case err == nil:
+	case strings.Contains(stderr, kopiaAccessDenied):
+		passwordIncorrect = "true"
+	case strings.Contains(stderr, kopiaServerUnavailable):
+		repoUnavailable = "true"
+	default:
+		return nil, errkit.Wrap(err, "Failed to check kopia repository")
+	}
+	return map[string]interface{}{
+		CheckRepositoryPasswordIncorrect: passwordIncorrect,
+		CheckRepositoryRepoDoesNotExist:  repoUnavailable,
+		FunctionOutputVersion:            kanister.DefaultVersion,
+	}, nil
+}
+
+func (*checkRepositoryUsingKopiaFunc) RequiredArgs() []string {
+	return []string{}
+}
+
+func (*checkRepositoryUsingKopiaFunc) Arguments() []string {
+	return []string{
+		CheckRepositoryImageArg,
+		PodOverrideArg,
+		PodAnnotationsArg,
+		PodLabelsArg,
+	}
+}
+
+func (*checkRepositoryUsingKopiaFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        CheckRepositoryUsingKopiaFuncName,
+		Description: "Checks if the kopia repository server of the profile can be reached and accessed with its credentials",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        CheckRepositoryImageArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Image of the pod that checks the repository, the image needs to have kando installed",
+			},
+			podOverrideArgSchema,
+			podAnnotationsArgSchema,
+			podLabelsArgSchema,
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: CheckRepositoryPasswordIncorrect, Type: kanister.ArgTypeString, Description: "\"true\" if the credentials of the profile are incorrect"},
+			{Name: CheckRepositoryRepoDoesNotExist, Type: kanister.ArgTypeString, Description: "\"true\" if the kopia repository server can't be reached"},
+			versionOutputSchema,
+		},
+	}
+}
+
+func (c *checkRepositoryUsingKopiaFunc) Validate(args map[string]any) error {
+	if err := ValidatePodLabelsAndAnnotations(c.Name(), args); err != nil {
+		return err
+	}
+
+	if err := utils.CheckSupportedArgs(c.Arguments(), args); err != nil {
+		return err
+	}
+
+	return utils.CheckRequiredArgs(c.RequiredArgs(), args)
+}
+
+func (c *checkRepositoryUsingKopiaFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
+	metav1Time := metav1.NewTime(time.Now())
+	return crv1alpha1.PhaseProgress{
+		ProgressPercent:    c.progressPercent,
+		LastTransitionTime: &metav1Time,
+	}, nil
+}
@@ -0,0 +1,482 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kanisterio/errkit"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/client/clientset/versioned"
+	"github.com/kanisterio/kanister/pkg/consts"
+	"github.com/kanisterio/kanister/pkg/field"
+	"github.com/kanisterio/kanister/pkg/format"
+	"github.com/kanisterio/kanister/pkg/kopia/snapshot"
+	"github.com/kanisterio/kanister/pkg/kube"
+	"github.com/kanisterio/kanister/pkg/location"
+	"github.com/kanisterio/kanister/pkg/log"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/progress"
+	"github.com/kanisterio/kanister/pkg/utils"
+)
+
+const (
+	// CopyArtifactsFuncName gives the name of the function
+	CopyArtifactsFuncName = "CopyArtifacts"
+	// CopyArtifactsSourceProfileArg is the Profile to copy from, as `<namespace>/<name>`
+	CopyArtifactsSourceProfileArg = "sourceProfile"
+	// CopyArtifactsDestinationProfileArg is the Profile to copy to, as `<namespace>/<name>`
+	CopyArtifactsDestinationProfileArg = "destinationProfile"
+	// CopyArtifactsPrefixArg is the prefix of the objects to copy
+	CopyArtifactsPrefixArg = "prefix"
+	// CopyArtifactsDestinationPrefixArg is the prefix of the copied objects
+	CopyArtifactsDestinationPrefixArg = "destinationPrefix"
+	// CopyArtifactsPathArg is the path of the streaming file in the kopia snapshot to copy
+	CopyArtifactsPathArg = "path"
+	// CopyArtifactsNamespaceArg is the namespace of the pods that copy the kopia snapshot
+	CopyArtifactsNamespaceArg = "namespace"
+	// CopyArtifactsImageArg is the image of the pods that copy the kopia snapshot
+	CopyArtifactsImageArg = "image"
+	// CopyArtifactsPrefixOutput is the prefix of the copied objects, with the destination bucket
+	CopyArtifactsPrefixOutput = "prefix"
+	// CopyArtifactsCopiedObjectsOutput is the number of objects that were copied
+	CopyArtifactsCopiedObjectsOutput = "copiedObjects"
+	// CopyArtifactsSkippedObjectsOutput is the number of objects that already had a copy
+	CopyArtifactsSkippedObjectsOutput = "skippedObjects"
+	// CopyArtifactsChecksumOutput is the SHA-256 checksum of the data of the copied kopia snapshot
+	CopyArtifactsChecksumOutput = "checksum"
+
+	copyArtifactsJobPrefix = "copy-artifacts-"
+)
+
+func init() {
+	_ = kanister.Register(&copyArtifactsFunc{})
+}
+
+var _ kanister.Func = (*copyArtifactsFunc)(nil)
+
+type copyArtifactsFunc struct {
+	progressPercent string
+}
+
+func (*copyArtifactsFunc) Name() string {
+	return CopyArtifactsFuncName
+}
+
+type copyArtifactsArgs struct {
+	sourceProfile      string
+	destinationProfile string
+	prefix             string
+	destinationPrefix  string
+	snapshot           string
+	path               string
+	namespace          string
+	image              string
+	podOverride        crv1alpha1.JSONMap
+	annotations        map[string]string
+	labels             map[string]string
+}
+
+func parseCopyArtifactsArgs(tp param.TemplateParams, args map[string]interface{}) (*copyArtifactsArgs, error) {
+	a := &copyArtifactsArgs{}
+	var bpAnnotations, bpLabels map[string]string
+	if err := OptArg(args, CopyArtifactsSourceProfileArg, &a.sourceProfile, ""); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, CopyArtifactsDestinationProfileArg, &a.destinationProfile); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, CopyArtifactsPrefixArg, &a.prefix, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, CopyArtifactsDestinationPrefixArg, &a.destinationPrefix, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, KopiaSnapshotArg, &a.snapshot, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, CopyArtifactsPathArg, &a.path, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, CopyArtifactsNamespaceArg, &a.namespace, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, CopyArtifactsImageArg, &a.image, consts.GetKanisterToolsImage()); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, PodAnnotationsArg, &bpAnnotations, nil); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, PodLabelsArg, &bpLabels, nil); err != nil {
+		return nil, err
+	}
+
+	if (a.prefix != "") == (a.snapshot != "") {
+		return nil, errkit.New(fmt.Sprintf("Require one argument: %s or %s", CopyArtifactsPrefixArg, KopiaSnapshotArg))
+	}
+	if a.snapshot != "" {
+		if err := validateKopiaStreamSnapshot(a.snapshot); err != nil {
+			return nil, err
+		}
+		if a.path == "" || a.namespace == "" {
+			return nil, errkit.New(fmt.Sprintf("Arguments %s and %s are required to copy a kopia snapshot", CopyArtifactsPathArg, CopyArtifactsNamespaceArg))
+		}
+	}
+	var err error
+	if a.podOverride, err = GetPodSpecOverride(tp, args, PodOverrideArg); err != nil {
+		return nil, err
+	}
+	a.annotations = bpAnnotations
+	a.labels = bpLabels
+	if tp.PodAnnotations != nil {
+		// merge the actionset annotations with blueprint annotations
+		var actionSetAnn ActionSetAnnotations = tp.PodAnnotations
+		a.annotations = actionSetAnn.MergeBPAnnotations(bpAnnotations)
+	}
+	if tp.PodLabels != nil {
+		// merge the actionset labels with blueprint labels
+		var actionSetLabels ActionSetLabels = tp.PodLabels
+		a.labels = actionSetLabels.MergeBPLabels(bpLabels)
+	}
+	return a, nil
+}
+
+// validateKopiaStreamSnapshot checks that the snapshot arg is a kopia
+// snapshot of a streaming file. Snapshots of a directory, e.g. the ones of
+// BackupDataUsingKopia, can't be copied.
+func validateKopiaStreamSnapshot(snapJSON string) error {
+	snapInfo, err := snapshot.UnmarshalKopiaSnapshot(snapJSON)
+	if err != nil {
+		return errkit.Wrap(err, "Invalid kopia snapshot", "arg", KopiaSnapshotArg)
+	}
+	if snapInfo.Directory {
+		return errkit.New("Only kopia snapshots of a streaming file can be copied, not snapshots of a directory", "snapshotID", snapInfo.ID)
+	}
+	return nil
+}
+
+// parseProfileRef parses a reference to a Profile as `<namespace>/<name>`
+func parseProfileRef(ref string) (crv1alpha1.ObjectReference, error) {
+	namespace, name, ok := strings.Cut(ref, "/")
+	if !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+		return crv1alpha1.ObjectReference{}, errkit.New("Profile must be referenced as <namespace>/<name>", "profile", ref)
+	}
+	return crv1alpha1.ObjectReference{Namespace: namespace, Name: name}, nil
+}
+
+// copyArtifactsProfiles returns the source Profile, which defaults to the
+// Profile of the ActionSet, and the destination Profile.
+func copyArtifactsProfiles(ctx context.Context, cli kubernetes.Interface, crCli versioned.Interface, tp param.TemplateParams, a *copyArtifactsArgs) (*param.Profile, *param.Profile, error) {
+	src := tp.Profile
+	if a.sourceProfile != "" {
+		ref, err := parseProfileRef(a.sourceProfile)
+		if err != nil {
+			return nil, nil, err
+		}
+		if src, err = param.FetchProfile(ctx, cli, crCli, ref); err != nil {
+			return nil, nil, errkit.Wrap(err, "Failed to fetch source Profile", "profile", a.sourceProfile)
+		}
+	}
+	ref, err := parseProfileRef(a.destinationProfile)
+	if err != nil {
+		return nil, nil, err
+	}
+	dst, err := param.FetchProfile(ctx, cli, crCli, ref)
+	if err != nil {
+		return nil, nil, errkit.Wrap(err, "Failed to fetch destination Profile", "profile", a.destinationProfile)
+	}
+	if err := ValidateProfile(src); err != nil {
+		return nil, nil, errkit.Wrap(err, "Failed to validate source Profile")
+	}
+	if err := ValidateProfile(dst); err != nil {
+		return nil, nil, errkit.Wrap(err, "Failed to validate destination Profile")
+	}
+	return src, dst, nil
+}
+
+// copyObjects copies the objects under the prefix. Like the artifacts of
+// the other functions, the prefix can start with the bucket of the Profile.
+func copyObjects(ctx context.Context, src, dst *param.Profile, a *copyArtifactsArgs) (map[string]interface{}, error) {
+	if src.Location.Type == crv1alpha1.LocationTypeKopia || dst.Location.Type == crv1alpha1.LocationTypeKopia {
+		return nil, errkit.New("Objects can only be copied between object store locations")
+	}
+	srcSuffix := strings.TrimPrefix(a.prefix, src.Location.Bucket)
+	dstSuffix := srcSuffix
+	if a.destinationPrefix != "" {
+		dstSuffix = strings.TrimPrefix(a.destinationPrefix, dst.Location.Bucket)
+	}
+	res, err := location.Copy(ctx, *src, *dst, srcSuffix, dstSuffix)
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to copy objects", "prefix", a.prefix)
+	}
+	log.Print("Copied objects", field.M{"Prefix": a.prefix, "Copied": len(res.Copied), "Skipped": len(res.Skipped)})
+	return map[string]interface{}{
+		CopyArtifactsPrefixOutput:         ResolveArtifactPrefix(strings.TrimPrefix(dstSuffix, "/"), dst),
+		CopyArtifactsCopiedObjectsOutput:  len(res.Copied),
+		CopyArtifactsSkippedObjectsOutput: len(res.Skipped),
+		FunctionOutputVersion:             kanister.DefaultVersion,
+	}, nil
+}
+
+// copyKopiaSnapshot streams the data of the kopia snapshot from the source
+// pod to a new snapshot in the destination pod, and verifies the checksum of
+// the data of the new snapshot. Each repository server is accessed from its
+// own pod because kando connects to one repository server at a time.
+func copyKopiaSnapshot(ctx context.Context, srcEx, dstEx kube.PodCommandExecutor, src, dst *param.Profile, snapJSON, path string) (map[string]interface{}, error) {
+	srcStdin, err := kopiaProfileStdin(src)
+	if err != nil {
+		return nil, err
+	}
+	dstProfile, err := kopiaProfileStdin(dst)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	h := sha256.New()
+	pullErr := make(chan error, 1)
+	go func() {
+		var stderr bytes.Buffer
+		err := srcEx.Exec(ctx, kopiaStreamPullCommand(snapJSON, path), srcStdin, io.MultiWriter(pw, h), &stderr)
+		if err != nil {
+			err = errkit.Wrap(err, "Failed to read kopia snapshot", "stderr", stderr.String())
+		}
+		pw.CloseWithError(err) //nolint:errcheck
+		pullErr <- err
+	}()
+	var stdout, stderr bytes.Buffer
+	stdin := io.MultiReader(dstProfile, strings.NewReader("\n"), pr)
+	err = dstEx.Exec(ctx, kopiaStreamPushCommand(path), stdin, &stdout, &stderr)
+	// Stop the pull if the push failed
+	pr.CloseWithError(errkit.New("Stopped reading kopia snapshot")) //nolint:errcheck
+	perr := <-pullErr
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to write kopia snapshot", "stderr", stderr.String())
+	}
+	if perr != nil {
+		return nil, perr
+	}
+	checksum := hex.EncodeToString(h.Sum(nil))
+	dstSnapJSON, snapInfo, err := kopiaSnapshotFromLog(ctx, stdout.String())
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the copy back to verify it
+	if _, err := dstProfile.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	vh := sha256.New()
+	stderr.Reset()
+	if err := dstEx.Exec(ctx, kopiaStreamPullCommand(dstSnapJSON, path), dstProfile, vh, &stderr); err != nil {
+		return nil, errkit.Wrap(err, "Failed to read copied kopia snapshot", "stderr", stderr.String())
+	}
+	if copyChecksum := hex.EncodeToString(vh.Sum(nil)); copyChecksum != checksum {
+		return nil, errkit.New("Checksum of the copied kopia snapshot doesn't match", "sourceChecksum", checksum, "destinationChecksum", copyChecksum)
+	}
+	return map[string]interface{}{
+		KopiaSnapshotOutput:         dstSnapJSON,
+		KopiaSnapshotIDOutput:       snapInfo.ID,
+		KopiaSnapshotSizeOutput:     strconv.FormatInt(snapInfo.LogicalSize, 10),
+		CopyArtifactsChecksumOutput: checksum,
+		FunctionOutputVersion:       kanister.DefaultVersion,
+	}, nil
+}
+
+// runCopyArtifactsPods runs a pod for each repository server and copies the
+// kopia snapshot between them.
+func runCopyArtifactsPods(ctx context.Context, cli kubernetes.Interface, src, dst *param.Profile, a *copyArtifactsArgs) (map[string]interface{}, error) {
+	if src.Location.Type != crv1alpha1.LocationTypeKopia || dst.Location.Type != crv1alpha1.LocationTypeKopia {
+		return nil, errkit.New("Kopia snapshots can only be copied between kopia locations")
+	}
+	if err := validateKopiaProfile(src); err != nil {
+		return nil, err
+	}
+	if err := validateKopiaProfile(dst); err != nil {
+		return nil, err
+	}
+	runPod := func(ctx context.Context, podFunc func(context.Context, kube.PodController) (map[string]interface{}, error)) (map[string]interface{}, error) {
+		return PrepareAndRunPod(ctx, cli, a.namespace, copyArtifactsJobPrefix, a.image, []string{"sh", "-c", "tail -f /dev/null"}, nil, a.podOverride, a.annotations, a.labels, podFunc)
+	}
+	executor := func(ctx context.Context, pc kube.PodController) (kube.PodCommandExecutor, error) {
+		if err := pc.WaitForPodReady(ctx); err != nil {
+			return nil, errkit.Wrap(err, "Failed while waiting for Pod to be ready", "pod", pc.PodName())
+		}
+		return pc.GetCommandExecutor()
+	}
+	return runPod(ctx, func(ctx context.Context, srcPC kube.PodController) (map[string]interface{}, error) {
+		srcEx, err := executor(ctx, srcPC)
+		if err != nil {
+			return nil, err
+		}
+		return runPod(ctx, func(ctx context.Context, dstPC kube.PodController) (map[string]interface{}, error) {
+			dstEx, err := executor(ctx, dstPC)
+			if err != nil {
+				return nil, err
+			}
+			out, err := copyKopiaSnapshot(ctx, srcEx, dstEx, src, dst, a.snapshot, a.path)
+			if err == nil {
+				format.LogWithCtx(ctx, dstPC.PodName(), dstPC.Pod().Spec.Containers[0].Name, fmt.Sprintf("Copied kopia snapshot to %s", out[KopiaSnapshotIDOutput]))
+			}
+			return out, err
+		})
+	})
+}
+
+func (c *copyArtifactsFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	// Set progress percent
+	c.progressPercent = progress.StartedPercent
+	defer func() { c.progressPercent = progress.CompletedPercent }()
+
+	a, err := parseCopyArtifactsArgs(tp, args)
+	if err != nil {
+		return nil, err
+	}
+	config, err := kube.LoadConfig()
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to load Kubernetes config")
+	}
+	cli, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to create Kubernetes client")
+	}
+	crCli, err := versioned.NewForConfig(config)
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to create Kanister client")
+	}
+	src, dst, err := copyArtifactsProfiles(ctx, cli, crCli, tp, a)
+	if err != nil {
+		return nil, err
+	}
+	if a.snapshot != "" {
+		return runCopyArtifactsPods(ctx, cli, src, dst, a)
+	}
+	return copyObjects(ctx, src, dst, a)
+}
+
+func (*copyArtifactsFunc) RequiredArgs() []string {
+	return []string{CopyArtifactsDestinationProfileArg}
+}
+
+func (*copyArtifactsFunc) Arguments() []string {
+	return []string{
+		CopyArtifactsSourceProfileArg,
+		CopyArtifactsDestinationProfileArg,
+		CopyArtifactsPrefixArg,
+		CopyArtifactsDestinationPrefixArg,
+		KopiaSnapshotArg,
+		CopyArtifactsPathArg,
+		CopyArtifactsNamespaceArg,
+		CopyArtifactsImageArg,
+		PodOverrideArg,
+		PodAnnotationsArg,
+		PodLabelsArg,
+	}
+}
+
+func (*copyArtifactsFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        CopyArtifactsFuncName,
+		Description: "Copies the objects under a prefix, or a kopia snapshot, from a Profile to another and verifies the copies",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        CopyArtifactsSourceProfileArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Profile to copy from as <namespace>/<name>, defaults to the Profile of the ActionSet",
+			},
+			{
+				Name:        CopyArtifactsDestinationProfileArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Profile to copy to as <namespace>/<name>",
+			},
+			{
+				Name:        CopyArtifactsPrefixArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Prefix of the objects to copy, required unless a kopia snapshot is copied",
+			},
+			{
+				Name:        CopyArtifactsDestinationPrefixArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Prefix of the copied objects, defaults to the prefix",
+			},
+			{
+				Name:        KopiaSnapshotArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Kopia snapshot of a stream pushed with kando to copy",
+			},
+			{
+				Name:        CopyArtifactsPathArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Path of the stream in the kopia snapshot, required to copy a kopia snapshot",
+			},
+			{
+				Name:        CopyArtifactsNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Namespace of the pods that copy the kopia snapshot, required to copy a kopia snapshot",
+			},
+			{
+				Name:        CopyArtifactsImageArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Image of the pods that copy the kopia snapshot, needs to have kando installed",
+			},
+			podOverrideArgSchema,
+			podAnnotationsArgSchema,
+			podLabelsArgSchema,
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: CopyArtifactsPrefixOutput, Type: kanister.ArgTypeString, Description: "Prefix of the copied objects with the bucket of the destination Profile"},
+			{Name: CopyArtifactsCopiedObjectsOutput, Type: kanister.ArgTypeInteger, Description: "Number of objects that were copied"},
+			{Name: CopyArtifactsSkippedObjectsOutput, Type: kanister.ArgTypeInteger, Description: "Number of objects that already had a copy in the destination"},
+			{Name: KopiaSnapshotOutput, Type: kanister.ArgTypeString, Description: "Copied kopia snapshot to pass to the functions that use the destination Profile"},
+			{Name: KopiaSnapshotIDOutput, Type: kanister.ArgTypeString, Description: "ID of the copied kopia snapshot"},
+			{Name: KopiaSnapshotSizeOutput, Type: kanister.ArgTypeString, Description: "Size of the copied data in bytes"},
+			{Name: CopyArtifactsChecksumOutput, Type: kanister.ArgTypeString, Description: "SHA-256 checksum of the data of the copied kopia snapshot"},
+			versionOutputSchema,
+		},
+	}
+}
+
+func (c *copyArtifactsFunc) Validate(args map[string]any) error {
+	if err := ValidatePodLabelsAndAnnotations(c.Name(), args); err != nil {
+		return err
+	}
+
+	if err := utils.CheckSupportedArgs(c.Arguments(), args); err != nil {
+		return err
+	}
+
+	return utils.CheckRequiredArgs(c.RequiredArgs(), args)
+}
+
+func (c *copyArtifactsFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
+	metav1Time := metav1.NewTime(time.Now())
+	return crv1alpha1.PhaseProgress{
+		ProgressPercent:    c.progressPercent,
+		LastTransitionTime: &metav1Time,
+	}, nil
+}
@@ -0,0 +1,224 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+
+	"github.com/kanisterio/errkit"
+	"gopkg.in/check.v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	crfake "github.com/kanisterio/kanister/pkg/client/clientset/versioned/fake"
+	"github.com/kanisterio/kanister/pkg/kopia/snapshot"
+	"github.com/kanisterio/kanister/pkg/output"
+	"github.com/kanisterio/kanister/pkg/param"
+)
+
+type CopyArtifactsSuite struct{}
+
+var _ = check.Suite(&CopyArtifactsSuite{})
+
+// fakeKopiaExecutor runs kando location pull and push commands against an
+// in-memory repository of streams.
+type fakeKopiaExecutor struct {
+	profile   *param.Profile
+	snapshots map[string][]byte
+	// corrupt changes the data of the snapshots that are pushed
+	corrupt bool
+}
+
+func (e *fakeKopiaExecutor) Exec(ctx context.Context, cmd []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	in, err := io.ReadAll(stdin)
+	if err != nil {
+		return err
+	}
+	switch script := cmd[2]; {
+	case strings.HasPrefix(script, "kando location pull"):
+		if err := e.checkProfile(in); err != nil {
+			return err
+		}
+		snapInfo, err := snapshot.UnmarshalKopiaSnapshot(cmd[4])
+		if err != nil {
+			return err
+		}
+		data, ok := e.snapshots[snapInfo.ID]
+		if !ok {
+			return errkit.New("Snapshot not found")
+		}
+		_, err = stdout.Write(data)
+		return err
+	case strings.HasPrefix(script, "read -r profile"):
+		profile, data, _ := bytes.Cut(in, []byte("\n"))
+		if err := e.checkProfile(profile); err != nil {
+			return err
+		}
+		id := fmt.Sprintf("k%d", len(e.snapshots))
+		if e.corrupt {
+			data = append(data, '!')
+		}
+		e.snapshots[id] = data
+		snapJSON, err := snapshot.MarshalKopiaSnapshot(&snapshot.SnapshotInfo{ID: id, LogicalSize: int64(len(data))})
+		if err != nil {
+			return err
+		}
+		return output.PrintOutputTo(stdout, kopiaSnapshotKandoOutput, snapJSON)
+	}
+	return errkit.New("Unexpected command", "command", cmd)
+}
+
+func (e *fakeKopiaExecutor) checkProfile(in []byte) error {
+	var p param.Profile
+	if err := json.Unmarshal(in, &p); err != nil {
+		return err
+	}
+	if p.Location.Endpoint != e.profile.Location.Endpoint {
+		return errkit.New("Wrong profile", "endpoint", p.Location.Endpoint)
+	}
+	return nil
+}
+
+// kopiaServerProfile returns a Profile of the kopia repository server at the endpoint
+func kopiaServerProfile(endpoint string) *param.Profile {
+	p := kopiaTestProfile()
+	p.Location.Endpoint = endpoint
+	return p
+}
+
+func (s *CopyArtifactsSuite) copySnapshot(c *check.C, data []byte, corrupt bool) (map[string]interface{}, error) {
+	srcProfile := kopiaServerProfile("https://kopia.us-east.example.com:51515")
+	dstProfile := kopiaServerProfile("https://kopia.eu-west.example.com:51515")
+	srcEx := &fakeKopiaExecutor{profile: srcProfile, snapshots: map[string][]byte{"k42": data}}
+	dstEx := &fakeKopiaExecutor{profile: dstProfile, snapshots: map[string][]byte{}, corrupt: corrupt}
+	snapJSON, err := snapshot.MarshalKopiaSnapshot(&snapshot.SnapshotInfo{ID: "k42"})
+	c.Assert(err, check.IsNil)
+	return copyKopiaSnapshot(context.Background(), srcEx, dstEx, srcProfile, dstProfile, snapJSON, "/mysql-backups/dump.sql")
+}
+
+func (s *CopyArtifactsSuite) TestCopyKopiaSnapshot(c *check.C) {
+	data := make([]byte, 4<<20)
+	_, err := rand.New(rand.NewSource(1)).Read(data)
+	c.Assert(err, check.IsNil)
+	sum := sha256.Sum256(data)
+
+	out, err := s.copySnapshot(c, data, false)
+	c.Assert(err, check.IsNil)
+	c.Assert(out[KopiaSnapshotIDOutput], check.Equals, "k0")
+	c.Assert(out[KopiaSnapshotSizeOutput], check.Equals, "4194304")
+	c.Assert(out[CopyArtifactsChecksumOutput], check.Equals, hex.EncodeToString(sum[:]))
+	snapInfo, err := snapshot.UnmarshalKopiaSnapshot(out[KopiaSnapshotOutput].(string))
+	c.Assert(err, check.IsNil)
+	c.Assert(snapInfo.ID, check.Equals, "k0")
+}
+
+func (s *CopyArtifactsSuite) TestCopyKopiaSnapshotChecksumMismatch(c *check.C) {
+	_, err := s.copySnapshot(c, []byte("dump"), true)
+	c.Assert(err, check.ErrorMatches, ".*Checksum of the copied kopia snapshot doesn't match.*")
+}
+
+func (s *CopyArtifactsSuite) TestCopyKopiaSnapshotNotFound(c *check.C) {
+	srcProfile := kopiaServerProfile("https://kopia.us-east.example.com:51515")
+	dstProfile := kopiaServerProfile("https://kopia.eu-west.example.com:51515")
+	srcEx := &fakeKopiaExecutor{profile: srcProfile, snapshots: map[string][]byte{}}
+	dstEx := &fakeKopiaExecutor{profile: dstProfile, snapshots: map[string][]byte{}}
+	snapJSON, err := snapshot.MarshalKopiaSnapshot(&snapshot.SnapshotInfo{ID: "k42"})
+	c.Assert(err, check.IsNil)
+	_, err = copyKopiaSnapshot(context.Background(), srcEx, dstEx, srcProfile, dstProfile, snapJSON, "/mysql-backups/dump.sql")
+	c.Assert(err, check.ErrorMatches, ".*Failed to read kopia snapshot.*")
+}
+
+func (s *CopyArtifactsSuite) TestParseCopyArtifactsArgs(c *check.C) {
+	snapJSON, err := snapshot.MarshalKopiaSnapshot(&snapshot.SnapshotInfo{ID: "k42"})
+	c.Assert(err, check.IsNil)
+	dirSnapJSON, err := snapshot.MarshalKopiaSnapshot(&snapshot.SnapshotInfo{ID: "k43", Directory: true})
+	c.Assert(err, check.IsNil)
+	for _, tc := range []struct {
+		args   map[string]interface{}
+		errMsg string
+	}{
+		{
+			args: map[string]interface{}{CopyArtifactsPrefixArg: "backups/mysql"},
+		},
+		{
+			args: map[string]interface{}{KopiaSnapshotArg: snapJSON, CopyArtifactsPathArg: "/mysql-backups/dump.sql", CopyArtifactsNamespaceArg: "kanister"},
+		},
+		{
+			args:   map[string]interface{}{},
+			errMsg: ".*Require one argument: prefix or snapshot.*",
+		},
+		{
+			args:   map[string]interface{}{CopyArtifactsPrefixArg: "backups/mysql", KopiaSnapshotArg: snapJSON},
+			errMsg: ".*Require one argument: prefix or snapshot.*",
+		},
+		{
+			args:   map[string]interface{}{KopiaSnapshotArg: snapJSON, CopyArtifactsPathArg: "/mysql-backups/dump.sql"},
+			errMsg: ".*Arguments path and namespace are required.*",
+		},
+		{
+			args:   map[string]interface{}{KopiaSnapshotArg: dirSnapJSON, CopyArtifactsPathArg: "/mysql-backups/dump.sql", CopyArtifactsNamespaceArg: "kanister"},
+			errMsg: ".*Only kopia snapshots of a streaming file can be copied.*",
+		},
+	} {
+		tc.args[CopyArtifactsDestinationProfileArg] = "kanister/dr"
+		_, err := parseCopyArtifactsArgs(param.TemplateParams{}, tc.args)
+		if tc.errMsg == "" {
+			c.Check(err, check.IsNil)
+		} else {
+			c.Check(err, check.ErrorMatches, tc.errMsg)
+		}
+	}
+}
+
+func (s *CopyArtifactsSuite) TestCopyArtifactsProfiles(c *check.C) {
+	ctx := context.Background()
+	cli := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "dr-creds", Namespace: "kanister"},
+		Data:       map[string][]byte{"id": []byte("AKIA"), "secret": []byte("s3cr3t")},
+	})
+	crCli := crfake.NewSimpleClientset(&crv1alpha1.Profile{
+		ObjectMeta: metav1.ObjectMeta{Name: "dr", Namespace: "kanister"},
+		Location:   crv1alpha1.Location{Type: crv1alpha1.LocationTypeS3Compliant, Bucket: "dr-backups", Region: "eu-west-1"},
+		Credential: crv1alpha1.Credential{
+			Type:    crv1alpha1.CredentialTypeKeyPair,
+			KeyPair: &crv1alpha1.KeyPair{IDField: "id", SecretField: "secret", Secret: crv1alpha1.ObjectReference{Name: "dr-creds", Namespace: "kanister"}},
+		},
+	})
+	tp := param.TemplateParams{Profile: &param.Profile{
+		Location:   crv1alpha1.Location{Type: crv1alpha1.LocationTypeS3Compliant, Bucket: "backups"},
+		Credential: param.Credential{Type: param.CredentialTypeKeyPair, KeyPair: &param.KeyPair{ID: "id", Secret: "secret"}},
+	}}
+
+	src, dst, err := copyArtifactsProfiles(ctx, cli, crCli, tp, &copyArtifactsArgs{destinationProfile: "kanister/dr"})
+	c.Assert(err, check.IsNil)
+	c.Assert(src, check.Equals, tp.Profile)
+	c.Assert(dst.Location.Bucket, check.Equals, "dr-backups")
+	c.Assert(dst.Credential.KeyPair, check.DeepEquals, &param.KeyPair{ID: "AKIA", Secret: "s3cr3t"})
+
+	_, _, err = copyArtifactsProfiles(ctx, cli, crCli, tp, &copyArtifactsArgs{destinationProfile: "dr"})
+	c.Assert(err, check.ErrorMatches, ".*Profile must be referenced as <namespace>/<name>.*")
+	_, _, err = copyArtifactsProfiles(ctx, cli, crCli, tp, &copyArtifactsArgs{sourceProfile: "kanister/missing", destinationProfile: "kanister/dr"})
+	c.Assert(err, check.ErrorMatches, ".*Failed to fetch source Profile.*")
+}
@@ -300,6 +300,58 @@ func (*copyVolumeDataFunc) Arguments() []string {
 	}
 }
 
+func (*copyVolumeDataFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        CopyVolumeDataFuncName,
+		Description: "Copies the data of a PVC to the object store using restic",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        CopyVolumeDataNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the PVC",
+			},
+			{
+				Name:        CopyVolumeDataImageArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Image of the pod that copies the data, defaults to the kanister tools image",
+			},
+			{
+				Name:        CopyVolumeDataVolumeArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the PVC",
+			},
+			{
+				Name:        CopyVolumeDataArtifactPrefixArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Path in the object store to store the data in",
+			},
+			encryptionKeyArgSchema,
+			{
+				Name:        CopyVolumeDataMountPathArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Path the PVC is mounted at in the pod",
+			},
+			insecureTLSArgSchema,
+			podOverrideArgSchema,
+			podAnnotationsArgSchema,
+			podLabelsArgSchema,
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: CopyVolumeDataOutputBackupID, Type: kanister.ArgTypeString, Description: "ID of the backup"},
+			{Name: CopyVolumeDataOutputBackupRoot, Type: kanister.ArgTypeString, Description: "Path the PVC was mounted at"},
+			{Name: CopyVolumeDataOutputBackupArtifactLocation, Type: kanister.ArgTypeString, Description: "Path of the backup in the object store"},
+			{Name: CopyVolumeDataOutputBackupTag, Type: kanister.ArgTypeString, Description: "Tag of the backup"},
+			{Name: CopyVolumeDataOutputBackupFileCount, Type: kanister.ArgTypeString, Description: "Number of files in the backup"},
+			{Name: CopyVolumeDataOutputBackupSize, Type: kanister.ArgTypeString, Description: "Size of the backup"},
+			{Name: CopyVolumeDataOutputPhysicalSize, Type: kanister.ArgTypeString, Description: "Size added to the repository by the backup"},
+			versionOutputSchema,
+		},
+	}
+}
+
 func (c *copyVolumeDataFunc) Validate(args map[string]any) error {
 	if err := ValidatePodLabelsAndAnnotations(c.Name(), args); err != nil {
 		return err
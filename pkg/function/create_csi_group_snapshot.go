@@ -0,0 +1,272 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/kube"
+	"github.com/kanisterio/kanister/pkg/kube/snapshot"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/progress"
+	"github.com/kanisterio/kanister/pkg/utils"
+)
+
+func init() {
+	_ = kanister.Register(&createCSIGroupSnapshotFunc{})
+}
+
+var (
+	_ kanister.Func = (*createCSIGroupSnapshotFunc)(nil)
+)
+
+const (
+	// CreateCSIGroupSnapshotFuncName gives the name of the function
+	CreateCSIGroupSnapshotFuncName = "CreateCSIGroupSnapshot"
+	// CreateCSIGroupSnapshotNameArg provides name of the new VolumeGroupSnapshot
+	CreateCSIGroupSnapshotNameArg = "name"
+	// CreateCSIGroupSnapshotNamespaceArg mentions the namespace of the captured PVCs
+	CreateCSIGroupSnapshotNamespaceArg = "namespace"
+	// CreateCSIGroupSnapshotSelectorArg has the labels of the captured PVCs
+	CreateCSIGroupSnapshotSelectorArg = "selector"
+	// CreateCSIGroupSnapshotGroupSnapshotClassArg specifies the name of the VolumeGroupSnapshotClass
+	CreateCSIGroupSnapshotGroupSnapshotClassArg = "groupSnapshotClass"
+	// CreateCSIGroupSnapshotLabelsArg has labels that are to be added to the new VolumeGroupSnapshot
+	CreateCSIGroupSnapshotLabelsArg = "labels"
+	// CreateCSIGroupSnapshotWaitForReadyArg provides a way to return before the VolumeGroupSnapshot is ready
+	CreateCSIGroupSnapshotWaitForReadyArg = "waitForReady"
+
+	// CSIGroupSnapshotNameOutput is the key used for returning the name of the VolumeGroupSnapshot
+	CSIGroupSnapshotNameOutput = "name"
+	// CSIGroupSnapshotNamespaceOutput is the key used for returning the namespace of the VolumeGroupSnapshot
+	CSIGroupSnapshotNamespaceOutput = "namespace"
+	// CSIGroupSnapshotContentOutput is the key used for returning the name of the bound VolumeGroupSnapshotContent
+	CSIGroupSnapshotContentOutput = "groupSnapshotContent"
+	// CSIGroupSnapshotMembersOutput is the key used for returning the VolumeSnapshots of the captured PVCs
+	CSIGroupSnapshotMembersOutput = "members"
+	// CSIGroupSnapshotVolumeSnapshotsOutput is the key used for returning the map of the captured PVCs to their VolumeSnapshots
+	CSIGroupSnapshotVolumeSnapshotsOutput = "volumeSnapshots"
+	// CSIGroupSnapshotMemberPVCKey is the key of the name of the captured PVC in a member
+	CSIGroupSnapshotMemberPVCKey = "pvc"
+	// CSIGroupSnapshotMemberNameKey is the key of the name of the VolumeSnapshot in a member
+	CSIGroupSnapshotMemberNameKey = "name"
+	// CSIGroupSnapshotMemberRestoreSizeKey is the key of the restore size of the VolumeSnapshot in a member
+	CSIGroupSnapshotMemberRestoreSizeKey = "restoreSize"
+)
+
+type createCSIGroupSnapshotFunc struct {
+	progressPercent string
+}
+
+func (*createCSIGroupSnapshotFunc) Name() string {
+	return CreateCSIGroupSnapshotFuncName
+}
+
+func (c *createCSIGroupSnapshotFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	// Set progress percent
+	c.progressPercent = progress.StartedPercent
+	defer func() { c.progressPercent = progress.CompletedPercent }()
+
+	var groupSnapshotClass, name, namespace string
+	var selector, labels map[string]string
+	var waitForReady bool
+	if err := Arg(args, CreateCSIGroupSnapshotNamespaceArg, &namespace); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, CreateCSIGroupSnapshotSelectorArg, &selector); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, CreateCSIGroupSnapshotGroupSnapshotClassArg, &groupSnapshotClass); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, CreateCSIGroupSnapshotNameArg, &name, defaultGroupSnapshotName(5)); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, CreateCSIGroupSnapshotLabelsArg, &labels, map[string]string{}); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, CreateCSIGroupSnapshotWaitForReadyArg, &waitForReady, true); err != nil {
+		return nil, err
+	}
+
+	snapshotter, err := newSnapshotter()
+	if err != nil {
+		return nil, err
+	}
+	snapshotMeta := snapshot.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		Labels:    labels,
+	}
+	if err := snapshotter.CreateGroup(ctx, selector, &groupSnapshotClass, waitForReady, snapshotMeta); err != nil {
+		return nil, err
+	}
+	if !waitForReady {
+		return map[string]interface{}{
+			CSIGroupSnapshotNameOutput:      name,
+			CSIGroupSnapshotNamespaceOutput: namespace,
+		}, nil
+	}
+	return csiGroupSnapshotOutput(ctx, snapshotter, name, namespace)
+}
+
+func (*createCSIGroupSnapshotFunc) RequiredArgs() []string {
+	return []string{
+		CreateCSIGroupSnapshotNamespaceArg,
+		CreateCSIGroupSnapshotSelectorArg,
+		CreateCSIGroupSnapshotGroupSnapshotClassArg,
+	}
+}
+
+func (*createCSIGroupSnapshotFunc) Arguments() []string {
+	return []string{
+		CreateCSIGroupSnapshotNamespaceArg,
+		CreateCSIGroupSnapshotSelectorArg,
+		CreateCSIGroupSnapshotGroupSnapshotClassArg,
+		CreateCSIGroupSnapshotNameArg,
+		CreateCSIGroupSnapshotLabelsArg,
+		CreateCSIGroupSnapshotWaitForReadyArg,
+	}
+}
+
+func (*createCSIGroupSnapshotFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        CreateCSIGroupSnapshotFuncName,
+		Description: "Creates a VolumeGroupSnapshot of the PVCs selected by labels, all PVCs are captured at the same point in time",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        CreateCSIGroupSnapshotNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the PVCs",
+			},
+			{
+				Name:        CreateCSIGroupSnapshotSelectorArg,
+				Type:        kanister.ArgTypeMap,
+				Required:    true,
+				Description: "Labels of the PVCs",
+			},
+			{
+				Name:        CreateCSIGroupSnapshotGroupSnapshotClassArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the VolumeGroupSnapshotClass",
+			},
+			{
+				Name:        CreateCSIGroupSnapshotNameArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Name of the VolumeGroupSnapshot, defaults to group-snapshot with a random suffix",
+			},
+			{
+				Name:        CreateCSIGroupSnapshotLabelsArg,
+				Type:        kanister.ArgTypeMap,
+				Description: "Labels added to the VolumeGroupSnapshot",
+			},
+			{
+				Name:        CreateCSIGroupSnapshotWaitForReadyArg,
+				Type:        kanister.ArgTypeBoolean,
+				Description: "Wait until the VolumeGroupSnapshot is ready, the members are only output if it's set",
+				Default:     true,
+			},
+		},
+		Outputs: csiGroupSnapshotOutputSchema,
+	}
+}
+
+func (c *createCSIGroupSnapshotFunc) Validate(args map[string]any) error {
+	if err := utils.CheckSupportedArgs(c.Arguments(), args); err != nil {
+		return err
+	}
+
+	return utils.CheckRequiredArgs(c.RequiredArgs(), args)
+}
+
+func (c *createCSIGroupSnapshotFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
+	metav1Time := metav1.NewTime(time.Now())
+	return crv1alpha1.PhaseProgress{
+		ProgressPercent:    c.progressPercent,
+		LastTransitionTime: &metav1Time,
+	}, nil
+}
+
+var csiGroupSnapshotOutputSchema = []kanister.OutputSchema{
+	{Name: CSIGroupSnapshotNameOutput, Type: kanister.ArgTypeString, Description: "Name of the VolumeGroupSnapshot"},
+	{Name: CSIGroupSnapshotNamespaceOutput, Type: kanister.ArgTypeString, Description: "Namespace of the VolumeGroupSnapshot"},
+	{Name: CSIGroupSnapshotContentOutput, Type: kanister.ArgTypeString, Description: "Name of the bound VolumeGroupSnapshotContent"},
+	{Name: CSIGroupSnapshotMembersOutput, Type: kanister.ArgTypeList, Description: "VolumeSnapshots of the PVCs, each with the pvc, name and restoreSize keys"},
+	{Name: CSIGroupSnapshotVolumeSnapshotsOutput, Type: kanister.ArgTypeMap, Description: "Map of the PVC names to the names of their VolumeSnapshots"},
+}
+
+// csiGroupSnapshotOutput returns the outputs of a ready VolumeGroupSnapshot
+// with the VolumeSnapshot of every captured PVC.
+func csiGroupSnapshotOutput(ctx context.Context, snapshotter snapshot.Snapshotter, name, namespace string) (map[string]interface{}, error) {
+	vgs, err := snapshotter.GetGroup(ctx, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+	groupMembers, err := snapshotter.ListGroupMembers(ctx, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+	var content string
+	if vgs.Status != nil && vgs.Status.BoundVolumeGroupSnapshotContentName != nil {
+		content = *vgs.Status.BoundVolumeGroupSnapshotContentName
+	}
+	members := make([]interface{}, 0, len(groupMembers))
+	volumeSnapshots := make(map[string]interface{}, len(groupMembers))
+	for _, m := range groupMembers {
+		var restoreSize string
+		if m.RestoreSize != nil {
+			restoreSize = m.RestoreSize.String()
+		}
+		members = append(members, map[string]interface{}{
+			CSIGroupSnapshotMemberPVCKey:         m.PVC,
+			CSIGroupSnapshotMemberNameKey:        m.VolumeSnapshot,
+			CSIGroupSnapshotMemberRestoreSizeKey: restoreSize,
+		})
+		volumeSnapshots[m.PVC] = m.VolumeSnapshot
+	}
+	return map[string]interface{}{
+		CSIGroupSnapshotNameOutput:            name,
+		CSIGroupSnapshotNamespaceOutput:       namespace,
+		CSIGroupSnapshotContentOutput:         content,
+		CSIGroupSnapshotMembersOutput:         members,
+		CSIGroupSnapshotVolumeSnapshotsOutput: volumeSnapshots,
+	}, nil
+}
+
+func newSnapshotter() (snapshot.Snapshotter, error) {
+	kubeCli, err := kube.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	dynCli, err := kube.NewDynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.NewSnapshotter(kubeCli, dynCli), nil
+}
+
+// defaultGroupSnapshotName generates group snapshot name using group-snapshot-<randomValue>
+func defaultGroupSnapshotName(len int) string {
+	return fmt.Sprintf("group-snapshot-%s", rand.String(len))
+}
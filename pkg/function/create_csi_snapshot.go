@@ -135,6 +135,50 @@ func (*createCSISnapshotFunc) Arguments() []string {
 	}
 }
 
+func (*createCSISnapshotFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        CreateCSISnapshotFuncName,
+		Description: "Creates a VolumeSnapshot of a PVC",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        CreateCSISnapshotPVCNameArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the PVC",
+			},
+			{
+				Name:        CreateCSISnapshotNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the PVC",
+			},
+			{
+				Name:        CreateCSISnapshotSnapshotClassArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the VolumeSnapshotClass",
+			},
+			{
+				Name:        CreateCSISnapshotNameArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Name of the VolumeSnapshot, defaults to the PVC name with a random suffix",
+			},
+			{
+				Name:        CreateCSISnapshotLabelsArg,
+				Type:        kanister.ArgTypeMap,
+				Description: "Labels added to the VolumeSnapshot",
+			},
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: CreateCSISnapshotNameArg, Type: kanister.ArgTypeString, Description: "Name of the VolumeSnapshot"},
+			{Name: CreateCSISnapshotPVCNameArg, Type: kanister.ArgTypeString, Description: "Name of the PVC"},
+			{Name: CreateCSISnapshotNamespaceArg, Type: kanister.ArgTypeString, Description: "Namespace of the VolumeSnapshot"},
+			{Name: CreateCSISnapshotRestoreSizeArg, Type: kanister.ArgTypeString, Description: "Minimum size of a volume restored from the snapshot"},
+			{Name: CreateCSISnapshotSnapshotContentNameArg, Type: kanister.ArgTypeString, Description: "Name of the bound VolumeSnapshotContent"},
+		},
+	}
+}
+
 func (c *createCSISnapshotFunc) Validate(args map[string]any) error {
 	if err := utils.CheckSupportedArgs(c.Arguments(), args); err != nil {
 		return err
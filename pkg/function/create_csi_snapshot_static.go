@@ -148,6 +148,49 @@ func (*createCSISnapshotStaticFunc) Arguments() []string {
 	}
 }
 
+func (*createCSISnapshotStaticFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        CreateCSISnapshotStaticFuncName,
+		Description: "Creates a VolumeSnapshot and a VolumeSnapshotContent for an existing storage snapshot",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        CreateCSISnapshotStaticNameArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the VolumeSnapshot",
+			},
+			{
+				Name:        CreateCSISnapshotStaticNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the VolumeSnapshot",
+			},
+			{
+				Name:        CreateCSISnapshotStaticDriverArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the CSI driver",
+			},
+			{
+				Name:        CreateCSISnapshotStaticSnapshotHandleArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "ID of the snapshot on the storage backend",
+			},
+			{
+				Name:        CreateCSISnapshotStaticSnapshotClassArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the VolumeSnapshotClass",
+			},
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: CreateCSISnapshotStaticOutputRestoreSize, Type: kanister.ArgTypeString, Description: "Minimum size of a volume restored from the snapshot"},
+			{Name: CreateCSISnapshotStaticOutputSnapshotContentName, Type: kanister.ArgTypeString, Description: "Name of the VolumeSnapshotContent"},
+		},
+	}
+}
+
 func (c *createCSISnapshotStaticFunc) Validate(args map[string]any) error {
 	if err := utils.CheckSupportedArgs(c.Arguments(), args); err != nil {
 		return err
@@ -204,6 +204,36 @@ func (crs *createRDSSnapshotFunc) Arguments() []string {
 	}
 }
 
+func (*createRDSSnapshotFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        CreateRDSSnapshotFuncName,
+		Description: "Creates a snapshot of an RDS instance or Aurora cluster",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        CreateRDSSnapshotInstanceIDArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "ID of the RDS instance or Aurora cluster",
+			},
+			{
+				Name:        CreateRDSSnapshotDBEngine,
+				Type:        kanister.ArgTypeString,
+				Description: "Engine of the database, needs to be set for Aurora clusters",
+			},
+			credentialsSourceArgSchema,
+			credentialsSecretArgSchema,
+			regionArgSchema,
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: CreateRDSSnapshotSnapshotID, Type: kanister.ArgTypeString, Description: "ID of the snapshot"},
+			{Name: CreateRDSSnapshotInstanceIDArg, Type: kanister.ArgTypeString, Description: "ID of the instance"},
+			{Name: CreateRDSSnapshotSecurityGroupID, Type: kanister.ArgTypeString, Description: "YAML list of the security group IDs of the instance"},
+			{Name: CreateRDSSnapshotAllocatedStorage, Type: kanister.ArgTypeString, Description: "Storage allocated to the instance"},
+			{Name: CreateRDSSnapshotDBSubnetGroup, Type: kanister.ArgTypeString, Description: "Subnet group of the instance"},
+		},
+	}
+}
+
 func (crs *createRDSSnapshotFunc) Validate(args map[string]any) error {
 	if err := utils.CheckSupportedArgs(crs.Arguments(), args); err != nil {
 		return err
@@ -0,0 +1,143 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"context"
+	"time"
+
+	"gopkg.in/check.v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kanisterio/kanister/pkg/kube/snapshot"
+)
+
+type CSIGroupSnapshotTestSuite struct{}
+
+var _ = check.Suite(&CSIGroupSnapshotTestSuite{})
+
+const testGroupSnapshotNamespace = "test-csi-group-snapshot"
+
+// newReadyGroupSnapshotter returns a snapshotter with a ready group snapshot
+// of the data and wal PVCs.
+func newReadyGroupSnapshotter(c *check.C) (*fake.Clientset, snapshot.Snapshotter) {
+	ctx := context.Background()
+	ns := testGroupSnapshotNamespace
+	kubeCli := fake.NewSimpleClientset(
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: ns, Labels: map[string]string{"app": "db"}}},
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "wal", Namespace: ns, Labels: map[string]string{"app": "db"}}},
+	)
+	member := func(name, pvc, size string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "snapshot.storage.k8s.io/v1",
+			"kind":       snapshot.VolSnapKind,
+			"metadata":   map[string]interface{}{"name": name, "namespace": ns},
+			"spec": map[string]interface{}{
+				"source": map[string]interface{}{"persistentVolumeClaimName": pvc},
+			},
+			"status": map[string]interface{}{
+				"readyToUse":              true,
+				"restoreSize":             size,
+				"volumeGroupSnapshotName": "group",
+			},
+		}}
+	}
+	groupContent := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": snapshot.GroupSnapshotGroupName + "/" + snapshot.GroupSnapshotVersion,
+		"kind":       snapshot.VolGroupSnapContentKind,
+		"metadata":   map[string]interface{}{"name": "group-content"},
+	}}
+	dynCli := dynfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		snapshot.VolSnapGVR:      "VolumeSnapshotList",
+		snapshot.VolGroupSnapGVR: "VolumeGroupSnapshotList",
+	}, groupContent, member("group-wal", "wal", "2Gi"), member("group-data", "data", "1Gi"))
+	snapshotter := snapshot.NewSnapshotter(kubeCli, dynCli)
+
+	class := "group-class"
+	err := snapshotter.CreateGroup(ctx, map[string]string{"app": "db"}, &class, false, snapshot.ObjectMeta{Name: "group", Namespace: ns})
+	c.Assert(err, check.IsNil)
+	us, err := dynCli.Resource(snapshot.VolGroupSnapGVR).Namespace(ns).Get(ctx, "group", metav1.GetOptions{})
+	c.Assert(err, check.IsNil)
+	us.Object["status"] = map[string]interface{}{
+		"boundVolumeGroupSnapshotContentName": "group-content",
+		"creationTime":                        time.Now().UTC().Format(time.RFC3339),
+		"readyToUse":                          true,
+	}
+	_, err = dynCli.Resource(snapshot.VolGroupSnapGVR).Namespace(ns).Update(ctx, us, metav1.UpdateOptions{})
+	c.Assert(err, check.IsNil)
+	return kubeCli, snapshotter
+}
+
+func (s *CSIGroupSnapshotTestSuite) TestCSIGroupSnapshotOutput(c *check.C) {
+	_, snapshotter := newReadyGroupSnapshotter(c)
+	out, err := csiGroupSnapshotOutput(context.Background(), snapshotter, "group", testGroupSnapshotNamespace)
+	c.Assert(err, check.IsNil)
+	c.Assert(out, check.DeepEquals, map[string]interface{}{
+		CSIGroupSnapshotNameOutput:      "group",
+		CSIGroupSnapshotNamespaceOutput: testGroupSnapshotNamespace,
+		CSIGroupSnapshotContentOutput:   "group-content",
+		CSIGroupSnapshotMembersOutput: []interface{}{
+			map[string]interface{}{"pvc": "data", "name": "group-data", "restoreSize": "1Gi"},
+			map[string]interface{}{"pvc": "wal", "name": "group-wal", "restoreSize": "2Gi"},
+		},
+		CSIGroupSnapshotVolumeSnapshotsOutput: map[string]interface{}{"data": "group-data", "wal": "group-wal"},
+	})
+}
+
+func (s *CSIGroupSnapshotTestSuite) TestRestoreCSIGroupSnapshot(c *check.C) {
+	ctx := context.Background()
+	kubeCli, snapshotter := newReadyGroupSnapshotter(c)
+	restoreArgs := restoreCSISnapshotArgs{
+		Namespace:    testGroupSnapshotNamespace,
+		StorageClass: "test-storage-class",
+		AccessModes:  []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		VolumeMode:   corev1.PersistentVolumeFilesystem,
+		Labels:       map[string]string{"restored": "true"},
+	}
+	// The restored PVCs keep the captured names unless they are renamed
+	err := kubeCli.CoreV1().PersistentVolumeClaims(testGroupSnapshotNamespace).Delete(ctx, "wal", metav1.DeleteOptions{})
+	c.Assert(err, check.IsNil)
+	pvcs, err := restoreCSIGroupSnapshot(ctx, kubeCli, snapshotter, "group", map[string]string{"data": "data-restored"}, restoreArgs)
+	c.Assert(err, check.IsNil)
+	c.Assert(pvcs, check.DeepEquals, map[string]interface{}{"data": "data-restored", "wal": "wal"})
+
+	_, err = kubeCli.CoreV1().PersistentVolumeClaims(testGroupSnapshotNamespace).Get(ctx, "wal", metav1.GetOptions{})
+	c.Assert(err, check.IsNil)
+	pvc, err := kubeCli.CoreV1().PersistentVolumeClaims(testGroupSnapshotNamespace).Get(ctx, "data-restored", metav1.GetOptions{})
+	c.Assert(err, check.IsNil)
+	c.Assert(pvc.Spec.DataSource.Kind, check.Equals, "VolumeSnapshot")
+	c.Assert(pvc.Spec.DataSource.Name, check.Equals, "group-data")
+	size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	c.Assert(size.String(), check.Equals, "1Gi")
+	c.Assert(pvc.Labels, check.DeepEquals, map[string]string{"restored": "true"})
+}
+
+func (s *CSIGroupSnapshotTestSuite) TestRestoreCSIGroupSnapshotExistingPVC(c *check.C) {
+	kubeCli, snapshotter := newReadyGroupSnapshotter(c)
+	restoreArgs := restoreCSISnapshotArgs{
+		Namespace:    testGroupSnapshotNamespace,
+		StorageClass: "test-storage-class",
+		AccessModes:  []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		VolumeMode:   corev1.PersistentVolumeFilesystem,
+	}
+	_, err := restoreCSIGroupSnapshot(context.Background(), kubeCli, snapshotter, "group", nil, restoreArgs)
+	c.Assert(err, check.ErrorMatches, "Failed to restore PVC from VolumeSnapshot.*already exists.*")
+}
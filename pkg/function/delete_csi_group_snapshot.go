@@ -0,0 +1,142 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/kube/snapshot"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/poll"
+	"github.com/kanisterio/kanister/pkg/progress"
+	"github.com/kanisterio/kanister/pkg/utils"
+)
+
+func init() {
+	_ = kanister.Register(&deleteCSIGroupSnapshotFunc{})
+}
+
+var (
+	_ kanister.Func = (*deleteCSIGroupSnapshotFunc)(nil)
+)
+
+const (
+	// DeleteCSIGroupSnapshotFuncName gives the name of the function
+	DeleteCSIGroupSnapshotFuncName = "DeleteCSIGroupSnapshot"
+	// DeleteCSIGroupSnapshotNameArg provides name of the VolumeGroupSnapshot that needs to be deleted
+	DeleteCSIGroupSnapshotNameArg = "name"
+	// DeleteCSIGroupSnapshotNamespaceArg mentions the namespace where the VolumeGroupSnapshot resides
+	DeleteCSIGroupSnapshotNamespaceArg = "namespace"
+)
+
+type deleteCSIGroupSnapshotFunc struct {
+	progressPercent string
+}
+
+func (*deleteCSIGroupSnapshotFunc) Name() string {
+	return DeleteCSIGroupSnapshotFuncName
+}
+
+func (d *deleteCSIGroupSnapshotFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	// Set progress percent
+	d.progressPercent = progress.StartedPercent
+	defer func() { d.progressPercent = progress.CompletedPercent }()
+
+	var name, namespace string
+	if err := Arg(args, DeleteCSIGroupSnapshotNameArg, &name); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, DeleteCSIGroupSnapshotNamespaceArg, &namespace); err != nil {
+		return nil, err
+	}
+	snapshotter, err := newSnapshotter()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := snapshotter.DeleteGroup(ctx, name, namespace); err != nil {
+		return nil, err
+	}
+	if err := waitForCSIGroupSnapshotDeletion(ctx, snapshotter, name, namespace); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (*deleteCSIGroupSnapshotFunc) RequiredArgs() []string {
+	return []string{
+		DeleteCSIGroupSnapshotNameArg,
+		DeleteCSIGroupSnapshotNamespaceArg,
+	}
+}
+
+func (*deleteCSIGroupSnapshotFunc) Arguments() []string {
+	return []string{
+		DeleteCSIGroupSnapshotNameArg,
+		DeleteCSIGroupSnapshotNamespaceArg,
+	}
+}
+
+func (*deleteCSIGroupSnapshotFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        DeleteCSIGroupSnapshotFuncName,
+		Description: "Deletes a VolumeGroupSnapshot together with the VolumeSnapshots of its PVCs",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        DeleteCSIGroupSnapshotNameArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the VolumeGroupSnapshot",
+			},
+			{
+				Name:        DeleteCSIGroupSnapshotNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the VolumeGroupSnapshot",
+			},
+		},
+	}
+}
+
+func (d *deleteCSIGroupSnapshotFunc) Validate(args map[string]any) error {
+	if err := utils.CheckSupportedArgs(d.Arguments(), args); err != nil {
+		return err
+	}
+
+	return utils.CheckRequiredArgs(d.RequiredArgs(), args)
+}
+
+func (d *deleteCSIGroupSnapshotFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
+	metav1Time := metav1.NewTime(time.Now())
+	return crv1alpha1.PhaseProgress{
+		ProgressPercent:    d.progressPercent,
+		LastTransitionTime: &metav1Time,
+	}, nil
+}
+
+func waitForCSIGroupSnapshotDeletion(ctx context.Context, snapshotter snapshot.Snapshotter, name, namespace string) error {
+	return poll.Wait(ctx, func(context.Context) (done bool, err error) {
+		_, err = snapshotter.GetGroup(ctx, name, namespace)
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+}
@@ -101,6 +101,27 @@ func (*deleteCSISnapshotFunc) Arguments() []string {
 	}
 }
 
+func (*deleteCSISnapshotFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        DeleteCSISnapshotFuncName,
+		Description: "Deletes a VolumeSnapshot",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        DeleteCSISnapshotNameArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the VolumeSnapshot",
+			},
+			{
+				Name:        DeleteCSISnapshotNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the VolumeSnapshot",
+			},
+		},
+	}
+}
+
 func (d *deleteCSISnapshotFunc) Validate(args map[string]any) error {
 	if err := utils.CheckSupportedArgs(d.Arguments(), args); err != nil {
 		return err
@@ -89,6 +89,21 @@ func (*deleteCSISnapshotContentFunc) Arguments() []string {
 	}
 }
 
+func (*deleteCSISnapshotContentFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        DeleteCSISnapshotContentFuncName,
+		Description: "Deletes a VolumeSnapshotContent",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        DeleteCSISnapshotContentNameArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the VolumeSnapshotContent",
+			},
+		},
+	}
+}
+
 func (d *deleteCSISnapshotContentFunc) Validate(args map[string]any) error {
 	if err := utils.CheckSupportedArgs(d.Arguments(), args); err != nil {
 		return err
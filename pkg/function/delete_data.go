@@ -318,6 +318,56 @@ func (*deleteDataFunc) Arguments() []string {
 	}
 }
 
+func (*deleteDataFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        DeleteDataFuncName,
+		Description: "Deletes restic backups from the object store",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        DeleteDataNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace to create the pod that deletes the data in",
+			},
+			{
+				Name:        DeleteDataImageArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Image of the pod, defaults to the kanister tools image",
+			},
+			{
+				Name:        DeleteDataBackupArtifactPrefixArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Path of the repository in the object store",
+			},
+			{
+				Name:        DeleteDataBackupIdentifierArg,
+				Type:        kanister.ArgTypeString,
+				Description: "ID of the backup to delete, either it or backupTag is required",
+			},
+			{
+				Name:        DeleteDataBackupTagArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Tag of the backup to delete, either it or backupID is required",
+			},
+			encryptionKeyArgSchema,
+			{
+				Name:        DeleteDataReclaimSpace,
+				Type:        kanister.ArgTypeBoolean,
+				Description: "Prune the repository to reclaim the space of the deleted data",
+				Default:     false,
+			},
+			insecureTLSArgSchema,
+			podOverrideArgSchema,
+			podAnnotationsArgSchema,
+			podLabelsArgSchema,
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: DeleteDataOutputSpaceFreed, Type: kanister.ArgTypeString, Description: "Space freed by pruning the repository"},
+		},
+	}
+}
+
 func (d *deleteDataFunc) Validate(args map[string]any) error {
 	if err := ValidatePodLabelsAndAnnotations(d.Name(), args); err != nil {
 		return err
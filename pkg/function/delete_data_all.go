@@ -182,6 +182,49 @@ func (*deleteDataAllFunc) Arguments() []string {
 	}
 }
 
+func (*deleteDataAllFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        DeleteDataAllFuncName,
+		Description: "Deletes the restic backups created by BackupDataAll from the object store",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        DeleteDataAllNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace to create the pod that deletes the data in",
+			},
+			{
+				Name:        DeleteDataAllImageArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Image of the pod, defaults to the kanister tools image",
+			},
+			{
+				Name:        DeleteDataAllBackupArtifactPrefixArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Path of the repository in the object store",
+			},
+			{
+				Name:        DeleteDataAllBackupInfo,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Output of BackupDataAll",
+			},
+			encryptionKeyArgSchema,
+			{
+				Name:        DeleteDataAllReclaimSpace,
+				Type:        kanister.ArgTypeBoolean,
+				Description: "Prune the repository to reclaim the space of the deleted data",
+				Default:     false,
+			},
+			insecureTLSArgSchema,
+			podOverrideArgSchema,
+			podAnnotationsArgSchema,
+			podLabelsArgSchema,
+		},
+	}
+}
+
 func (d *deleteDataAllFunc) Validate(args map[string]any) error {
 	if err := ValidatePodLabelsAndAnnotations(d.Name(), args); err != nil {
 		return err
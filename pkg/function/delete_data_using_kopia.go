@@ -0,0 +1,228 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/kanisterio/errkit"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/consts"
+	"github.com/kanisterio/kanister/pkg/ephemeral"
+	"github.com/kanisterio/kanister/pkg/format"
+	"github.com/kanisterio/kanister/pkg/kube"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/progress"
+	"github.com/kanisterio/kanister/pkg/utils"
+)
+
+const (
+	// DeleteDataUsingKopiaFuncName gives the name of the function
+	DeleteDataUsingKopiaFuncName  = "DeleteDataUsingKopia"
+	deleteDataUsingKopiaJobPrefix = "delete-data-kopia-"
+)
+
+func init() {
+	_ = kanister.Register(&deleteDataUsingKopiaFunc{})
+}
+
+var _ kanister.Func = (*deleteDataUsingKopiaFunc)(nil)
+
+type deleteDataUsingKopiaFunc struct {
+	progressPercent string
+}
+
+func (*deleteDataUsingKopiaFunc) Name() string {
+	return DeleteDataUsingKopiaFuncName
+}
+
+func (d *deleteDataUsingKopiaFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	// Set progress percent
+	d.progressPercent = progress.StartedPercent
+	defer func() { d.progressPercent = progress.CompletedPercent }()
+
+	var namespace, image, snapJSON string
+	var bpAnnotations, bpLabels map[string]string
+	if err := Arg(args, DeleteDataNamespaceArg, &namespace); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, KopiaSnapshotArg, &snapJSON); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, DeleteDataImageArg, &image, consts.GetKanisterToolsImage()); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, PodAnnotationsArg, &bpAnnotations, nil); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, PodLabelsArg, &bpLabels, nil); err != nil {
+		return nil, err
+	}
+	if err := validateKopiaSnapshot(snapJSON); err != nil {
+		return nil, err
+	}
+	podOverride, err := GetPodSpecOverride(tp, args, PodOverrideArg)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := bpAnnotations
+	labels := bpLabels
+	if tp.PodAnnotations != nil {
+		// merge the actionset annotations with blueprint annotations
+		var actionSetAnn ActionSetAnnotations = tp.PodAnnotations
+		annotations = actionSetAnn.MergeBPAnnotations(bpAnnotations)
+	}
+
+	if tp.PodLabels != nil {
+		// merge the actionset labels with blueprint labels
+		var actionSetLabels ActionSetLabels = tp.PodLabels
+		labels = actionSetLabels.MergeBPLabels(bpLabels)
+	}
+
+	if err = validateKopiaProfile(tp.Profile); err != nil {
+		return nil, err
+	}
+
+	cli, err := kube.NewClient()
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to create Kubernetes client")
+	}
+	return deleteDataUsingKopia(ctx, cli, tp.Profile, namespace, image, snapJSON, podOverride, annotations, labels)
+}
+
+func deleteDataUsingKopia(
+	ctx context.Context,
+	cli kubernetes.Interface,
+	profile *param.Profile,
+	namespace,
+	image,
+	snapJSON string,
+	podOverride crv1alpha1.JSONMap,
+	annotations,
+	labels map[string]string,
+) (map[string]interface{}, error) {
+	options := &kube.PodOptions{
+		Namespace:    namespace,
+		GenerateName: deleteDataUsingKopiaJobPrefix,
+		Image:        image,
+		Command:      []string{"sh", "-c", "tail -f /dev/null"},
+		PodOverride:  podOverride,
+		Annotations:  annotations,
+		Labels:       labels,
+	}
+
+	// Apply the registered ephemeral pod changes.
+	if err := ephemeral.PodOptions.Apply(options); err != nil {
+		return nil, errkit.Wrap(err, "Failed to apply ephemeral pod options")
+	}
+
+	pr := kube.NewPodRunner(cli, options)
+	return pr.Run(ctx, func(ctx context.Context, pc kube.PodController) (map[string]interface{}, error) {
+		pod := pc.Pod()
+		if err := pc.WaitForPodReady(ctx); err != nil {
+			return nil, errkit.Wrap(err, "Failed while waiting for Pod to be ready", "pod", pod.Name)
+		}
+		ex, err := pc.GetCommandExecutor()
+		if err != nil {
+			return nil, err
+		}
+		stdin, err := kopiaProfileStdin(profile)
+		if err != nil {
+			return nil, err
+		}
+		var stdout, stderr bytes.Buffer
+		err = ex.Exec(ctx, kopiaDeleteCommand(snapJSON), stdin, &stdout, &stderr)
+		format.LogWithCtx(ctx, pod.Name, pod.Spec.Containers[0].Name, stdout.String())
+		format.LogWithCtx(ctx, pod.Name, pod.Spec.Containers[0].Name, stderr.String())
+		if err != nil {
+			return nil, errkit.Wrap(err, "Failed to delete kopia snapshot")
+		}
+		return nil, nil
+	})
+}
+
+func (*deleteDataUsingKopiaFunc) RequiredArgs() []string {
+	return []string{
+		DeleteDataNamespaceArg,
+		KopiaSnapshotArg,
+	}
+}
+
+func (*deleteDataUsingKopiaFunc) Arguments() []string {
+	return []string{
+		DeleteDataNamespaceArg,
+		KopiaSnapshotArg,
+		DeleteDataImageArg,
+		PodOverrideArg,
+		PodAnnotationsArg,
+		PodLabelsArg,
+	}
+}
+
+func (*deleteDataUsingKopiaFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        DeleteDataUsingKopiaFuncName,
+		Description: "Deletes a kopia snapshot created with BackupDataUsingKopia from the kopia repository server",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        DeleteDataNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace to run the pod that deletes the snapshot in",
+			},
+			{
+				Name:        KopiaSnapshotArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Kopia snapshot output by BackupDataUsingKopia",
+			},
+			{
+				Name:        DeleteDataImageArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Image of the pod that deletes the snapshot, the image needs to have kando installed",
+			},
+			podOverrideArgSchema,
+			podAnnotationsArgSchema,
+			podLabelsArgSchema,
+		},
+	}
+}
+
+func (d *deleteDataUsingKopiaFunc) Validate(args map[string]any) error {
+	if err := ValidatePodLabelsAndAnnotations(d.Name(), args); err != nil {
+		return err
+	}
+
+	if err := utils.CheckSupportedArgs(d.Arguments(), args); err != nil {
+		return err
+	}
+
+	return utils.CheckRequiredArgs(d.RequiredArgs(), args)
+}
+
+func (d *deleteDataUsingKopiaFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
+	metav1Time := metav1.NewTime(time.Now())
+	return crv1alpha1.PhaseProgress{
+		ProgressPercent:    d.progressPercent,
+		LastTransitionTime: &metav1Time,
+	}, nil
+}
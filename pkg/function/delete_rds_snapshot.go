@@ -141,6 +141,29 @@ func (*deleteRDSSnapshotFunc) Arguments() []string {
 	}
 }
 
+func (*deleteRDSSnapshotFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        DeleteRDSSnapshotFuncName,
+		Description: "Deletes a snapshot of an RDS instance or Aurora cluster",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        DeleteRDSSnapshotSnapshotIDArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "ID of the snapshot",
+			},
+			{
+				Name:        CreateRDSSnapshotDBEngine,
+				Type:        kanister.ArgTypeString,
+				Description: "Engine of the database, needs to be set for Aurora clusters",
+			},
+			credentialsSourceArgSchema,
+			credentialsSecretArgSchema,
+			regionArgSchema,
+		},
+	}
+}
+
 func (d *deleteRDSSnapshotFunc) Validate(args map[string]any) error {
 	if err := utils.CheckSupportedArgs(d.Arguments(), args); err != nil {
 		return err
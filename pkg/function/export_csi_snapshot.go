@@ -0,0 +1,330 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/kanisterio/errkit"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/consts"
+	"github.com/kanisterio/kanister/pkg/field"
+	"github.com/kanisterio/kanister/pkg/format"
+	"github.com/kanisterio/kanister/pkg/kube"
+	"github.com/kanisterio/kanister/pkg/kube/snapshot"
+	"github.com/kanisterio/kanister/pkg/kube/volume"
+	"github.com/kanisterio/kanister/pkg/log"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/progress"
+	"github.com/kanisterio/kanister/pkg/utils"
+)
+
+func init() {
+	_ = kanister.Register(&exportCSISnapshotFunc{})
+}
+
+var (
+	_ kanister.Func = (*exportCSISnapshotFunc)(nil)
+)
+
+const (
+	// ExportCSISnapshotFuncName gives the name of the function
+	ExportCSISnapshotFuncName = "ExportCSISnapshot"
+	// ExportCSISnapshotNameArg provides name of the VolumeSnapshot
+	ExportCSISnapshotNameArg = "name"
+	// ExportCSISnapshotNamespaceArg mentions the namespace of the VolumeSnapshot
+	ExportCSISnapshotNamespaceArg = "namespace"
+	// ExportCSISnapshotStorageClassArg specifies the StorageClass of the temporary PVC
+	ExportCSISnapshotStorageClassArg = "storageClass"
+	// ExportCSISnapshotRestoreSizeArg overrides the size of the temporary PVC
+	ExportCSISnapshotRestoreSizeArg = "restoreSize"
+	// ExportCSISnapshotImageArg provides the image of the pod that exports the data
+	ExportCSISnapshotImageArg = "image"
+	// ExportCSISnapshotRestoreSizeOutput is the key used for returning the restore size of the VolumeSnapshot
+	ExportCSISnapshotRestoreSizeOutput = "restoreSize"
+
+	exportCSISnapshotJobPrefix = "export-csi-snapshot-"
+	// exportCSISnapshotLabel marks the temporary PVCs with the name of the exported VolumeSnapshot
+	exportCSISnapshotLabel = "kanister.io/exported-volume-snapshot"
+)
+
+type exportCSISnapshotFunc struct {
+	progressPercent string
+}
+
+func (*exportCSISnapshotFunc) Name() string {
+	return ExportCSISnapshotFuncName
+}
+
+type exportCSISnapshotArgs struct {
+	name         string
+	namespace    string
+	storageClass string
+	restoreSize  string
+	image        string
+	podOverride  crv1alpha1.JSONMap
+	annotations  map[string]string
+	labels       map[string]string
+}
+
+func (e *exportCSISnapshotFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	// Set progress percent
+	e.progressPercent = progress.StartedPercent
+	defer func() { e.progressPercent = progress.CompletedPercent }()
+
+	var a exportCSISnapshotArgs
+	var bpAnnotations, bpLabels map[string]string
+	if err := Arg(args, ExportCSISnapshotNameArg, &a.name); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, ExportCSISnapshotNamespaceArg, &a.namespace); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, ExportCSISnapshotStorageClassArg, &a.storageClass, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, ExportCSISnapshotRestoreSizeArg, &a.restoreSize, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, ExportCSISnapshotImageArg, &a.image, consts.GetKanisterToolsImage()); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, PodAnnotationsArg, &bpAnnotations, nil); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, PodLabelsArg, &bpLabels, nil); err != nil {
+		return nil, err
+	}
+	podOverride, err := GetPodSpecOverride(tp, args, PodOverrideArg)
+	if err != nil {
+		return nil, err
+	}
+	a.podOverride = podOverride
+
+	a.annotations = bpAnnotations
+	a.labels = bpLabels
+	if tp.PodAnnotations != nil {
+		// merge the actionset annotations with blueprint annotations
+		var actionSetAnn ActionSetAnnotations = tp.PodAnnotations
+		a.annotations = actionSetAnn.MergeBPAnnotations(bpAnnotations)
+	}
+
+	if tp.PodLabels != nil {
+		// merge the actionset labels with blueprint labels
+		var actionSetLabels ActionSetLabels = tp.PodLabels
+		a.labels = actionSetLabels.MergeBPLabels(bpLabels)
+	}
+
+	if err := validateKopiaProfile(tp.Profile); err != nil {
+		return nil, err
+	}
+	kubeCli, err := kube.NewClient()
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to create Kubernetes client")
+	}
+	dynCli, err := kube.NewDynamicClient()
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to create dynamic Kubernetes client")
+	}
+	return exportCSISnapshot(ctx, kubeCli, dynCli, tp.Profile, a)
+}
+
+// exportCSISnapshotMountPath returns the path the temporary PVC is mounted at.
+// The path only depends on the snapshotted PVC, so that the kopia snapshots
+// of its VolumeSnapshots are incremental.
+func exportCSISnapshotMountPath(namespace, pvc string) string {
+	return fmt.Sprintf("/mnt/export/%s/%s", namespace, pvc)
+}
+
+// exportCSISnapshot provisions a temporary PVC from the VolumeSnapshot and
+// pushes its content to the kopia repository server. The temporary PVC is
+// deleted even if the export fails.
+func exportCSISnapshot(ctx context.Context, kubeCli kubernetes.Interface, dynCli dynamic.Interface, profile *param.Profile, a exportCSISnapshotArgs) (map[string]interface{}, error) {
+	vs, err := snapshot.NewSnapshotter(kubeCli, dynCli).Get(ctx, a.name, a.namespace)
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to get VolumeSnapshot", "volumeSnapshot", a.name, "namespace", a.namespace)
+	}
+	if vs.Status == nil || vs.Status.ReadyToUse == nil || !*vs.Status.ReadyToUse {
+		return nil, errkit.New("VolumeSnapshot is not ready", "volumeSnapshot", a.name, "namespace", a.namespace)
+	}
+	sourcePVC := a.name
+	if vs.Spec.Source.PersistentVolumeClaimName != nil {
+		sourcePVC = *vs.Spec.Source.PersistentVolumeClaimName
+	}
+
+	pvc, err := volume.CreatePVCFromSnapshot(ctx, &volume.CreatePVCFromSnapshotArgs{
+		KubeCli:          kubeCli,
+		DynCli:           dynCli,
+		Namespace:        a.namespace,
+		StorageClassName: a.storageClass,
+		SnapshotName:     a.name,
+		RestoreSize:      a.restoreSize,
+		Labels:           map[string]string{exportCSISnapshotLabel: a.name},
+	})
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to create temporary PVC from VolumeSnapshot", "volumeSnapshot", a.name)
+	}
+	defer func() {
+		if err := volume.DeletePVC(kubeCli, a.namespace, pvc); err != nil {
+			log.WithError(err).Print("Failed to delete temporary PVC", field.M{"PVC": pvc, "Namespace": a.namespace})
+		}
+	}()
+
+	mountPath := exportCSISnapshotMountPath(a.namespace, sourcePVC)
+	podFunc := func(ctx context.Context, pc kube.PodController) (map[string]interface{}, error) {
+		pod := pc.Pod()
+		if err := pc.WaitForPodReady(ctx); err != nil {
+			return nil, errkit.Wrap(err, "Failed while waiting for Pod to be ready", "pod", pod.Name)
+		}
+		ex, err := pc.GetCommandExecutor()
+		if err != nil {
+			return nil, err
+		}
+		stdin, err := kopiaProfileStdin(profile)
+		if err != nil {
+			return nil, err
+		}
+		var stdout, stderr bytes.Buffer
+		err = ex.Exec(ctx, kopiaPushCommand(mountPath), stdin, &stdout, &stderr)
+		format.LogWithCtx(ctx, pod.Name, pod.Spec.Containers[0].Name, stdout.String())
+		format.LogWithCtx(ctx, pod.Name, pod.Spec.Containers[0].Name, stderr.String())
+		if err != nil {
+			return nil, errkit.Wrap(err, "Failed to export VolumeSnapshot", "volumeSnapshot", a.name)
+		}
+		snapJSON, snapInfo, err := kopiaSnapshotFromLog(ctx, stdout.String())
+		if err != nil {
+			return nil, errkit.Wrap(err, "Failed to parse kopia snapshot from the export logs")
+		}
+		var restoreSize string
+		if vs.Status.RestoreSize != nil {
+			restoreSize = vs.Status.RestoreSize.String()
+		}
+		return map[string]interface{}{
+			KopiaSnapshotOutput:                snapJSON,
+			KopiaSnapshotIDOutput:              snapInfo.ID,
+			KopiaSnapshotSizeOutput:            strconv.FormatInt(snapInfo.LogicalSize, 10),
+			ExportCSISnapshotRestoreSizeOutput: restoreSize,
+			FunctionOutputVersion:              kanister.DefaultVersion,
+		}, nil
+	}
+	return PrepareAndRunPod(
+		ctx,
+		kubeCli,
+		a.namespace,
+		exportCSISnapshotJobPrefix,
+		a.image,
+		[]string{"sh", "-c", "tail -f /dev/null"},
+		map[string]string{pvc: mountPath},
+		a.podOverride,
+		a.annotations,
+		a.labels,
+		podFunc,
+	)
+}
+
+func (*exportCSISnapshotFunc) RequiredArgs() []string {
+	return []string{
+		ExportCSISnapshotNameArg,
+		ExportCSISnapshotNamespaceArg,
+	}
+}
+
+func (*exportCSISnapshotFunc) Arguments() []string {
+	return []string{
+		ExportCSISnapshotNameArg,
+		ExportCSISnapshotNamespaceArg,
+		ExportCSISnapshotStorageClassArg,
+		ExportCSISnapshotRestoreSizeArg,
+		ExportCSISnapshotImageArg,
+		PodOverrideArg,
+		PodAnnotationsArg,
+		PodLabelsArg,
+	}
+}
+
+func (*exportCSISnapshotFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        ExportCSISnapshotFuncName,
+		Description: "Exports the content of a VolumeSnapshot to a kopia repository server through a temporary PVC",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        ExportCSISnapshotNameArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the VolumeSnapshot",
+			},
+			{
+				Name:        ExportCSISnapshotNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the VolumeSnapshot",
+			},
+			{
+				Name:        ExportCSISnapshotStorageClassArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Name of the StorageClass of the temporary PVC, defaults to the default StorageClass",
+			},
+			{
+				Name:        ExportCSISnapshotRestoreSizeArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Size of the temporary PVC if it's larger than the restore size of the VolumeSnapshot, e.g. `1Gi`",
+			},
+			{
+				Name:        ExportCSISnapshotImageArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Image of the pod that exports the data, needs to have kando installed",
+			},
+			podOverrideArgSchema,
+			podAnnotationsArgSchema,
+			podLabelsArgSchema,
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: KopiaSnapshotOutput, Type: kanister.ArgTypeString, Description: "Kopia snapshot to pass to ImportCSISnapshot and DeleteDataUsingKopia"},
+			{Name: KopiaSnapshotIDOutput, Type: kanister.ArgTypeString, Description: "ID of the kopia snapshot"},
+			{Name: KopiaSnapshotSizeOutput, Type: kanister.ArgTypeString, Description: "Size of the exported data in bytes"},
+			{Name: ExportCSISnapshotRestoreSizeOutput, Type: kanister.ArgTypeString, Description: "Restore size of the VolumeSnapshot"},
+			versionOutputSchema,
+		},
+	}
+}
+
+func (e *exportCSISnapshotFunc) Validate(args map[string]any) error {
+	if err := ValidatePodLabelsAndAnnotations(e.Name(), args); err != nil {
+		return err
+	}
+
+	if err := utils.CheckSupportedArgs(e.Arguments(), args); err != nil {
+		return err
+	}
+
+	return utils.CheckRequiredArgs(e.RequiredArgs(), args)
+}
+
+func (e *exportCSISnapshotFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
+	metav1Time := metav1.NewTime(time.Now())
+	return crv1alpha1.PhaseProgress{
+		ProgressPercent:    e.progressPercent,
+		LastTransitionTime: &metav1Time,
+	}, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"context"
+
+	"gopkg.in/check.v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kanisterio/kanister/pkg/kube/snapshot"
+)
+
+type ExportCSISnapshotTestSuite struct{}
+
+var _ = check.Suite(&ExportCSISnapshotTestSuite{})
+
+func (s *ExportCSISnapshotTestSuite) TestExportCSISnapshotMountPath(c *check.C) {
+	// Exports of different VolumeSnapshots of a PVC share the kopia source
+	c.Assert(exportCSISnapshotMountPath("ns", "data"), check.Equals, "/mnt/export/ns/data")
+}
+
+func (s *ExportCSISnapshotTestSuite) TestExportCSISnapshotNotReady(c *check.C) {
+	ns := "test-export-csi-snapshot"
+	vs := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "snapshot.storage.k8s.io/v1",
+		"kind":       snapshot.VolSnapKind,
+		"metadata":   map[string]interface{}{"name": "snap", "namespace": ns},
+		"spec": map[string]interface{}{
+			"source": map[string]interface{}{"persistentVolumeClaimName": "data"},
+		},
+	}}
+	kubeCli := fake.NewSimpleClientset()
+	dynCli := dynfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		snapshot.VolSnapGVR: "VolumeSnapshotList",
+	}, vs)
+	_, err := exportCSISnapshot(context.Background(), kubeCli, dynCli, nil, exportCSISnapshotArgs{name: "snap", namespace: ns})
+	c.Assert(err, check.ErrorMatches, "VolumeSnapshot is not ready.*")
+
+	// No temporary PVC is left behind
+	pvcs, err := kubeCli.CoreV1().PersistentVolumeClaims(ns).List(context.Background(), metav1.ListOptions{})
+	c.Assert(err, check.IsNil)
+	c.Assert(pvcs.Items, check.HasLen, 0)
+}
+
+func (s *ExportCSISnapshotTestSuite) TestImportPVCManifest(c *check.C) {
+	a := importCSISnapshotArgs{
+		namespace:    "ns",
+		pvc:          "data",
+		restoreSize:  resource.MustParse("1Gi"),
+		storageClass: "test-storage-class",
+		accessModes:  []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+	}
+	pvc := newImportPVCManifest(a)
+	c.Assert(pvc.Name, check.Equals, "data")
+	c.Assert(pvc.Namespace, check.Equals, "ns")
+	c.Assert(pvc.Spec.DataSource, check.IsNil)
+	c.Assert(*pvc.Spec.StorageClassName, check.Equals, "test-storage-class")
+	c.Assert(pvc.Spec.AccessModes, check.DeepEquals, a.accessModes)
+	size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	c.Assert(size.String(), check.Equals, "1Gi")
+
+	a.storageClass = ""
+	c.Assert(newImportPVCManifest(a).Spec.StorageClassName, check.IsNil)
+}
+
+func (s *ExportCSISnapshotTestSuite) TestImportCSISnapshotExistingPVC(c *check.C) {
+	ctx := context.Background()
+	kubeCli := fake.NewSimpleClientset(&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "ns"}})
+	a := importCSISnapshotArgs{
+		namespace:   "ns",
+		pvc:         "data",
+		restoreSize: resource.MustParse("1Gi"),
+		accessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+	}
+	_, err := importCSISnapshot(ctx, kubeCli, nil, a)
+	c.Assert(err, check.ErrorMatches, "Failed to create PVC.*already exists.*")
+
+	// The existing PVC is not deleted
+	_, err = kubeCli.CoreV1().PersistentVolumeClaims("ns").Get(ctx, "data", metav1.GetOptions{})
+	c.Assert(err, check.IsNil)
+}
@@ -315,6 +315,82 @@ func (*exportRDSSnapshotToLocationFunc) Arguments() []string {
 	}
 }
 
+func (*exportRDSSnapshotToLocationFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        ExportRDSSnapshotToLocFuncName,
+		Description: "Creates a temporary RDS instance from a snapshot and exports a dump of its databases to the object store",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        ExportRDSSnapshotToLocNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace to create the pod that exports the data in",
+			},
+			{
+				Name:        ExportRDSSnapshotToLocInstanceIDArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "ID of the RDS instance",
+			},
+			{
+				Name:        ExportRDSSnapshotToLocSnapshotIDArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "ID of the snapshot",
+			},
+			{
+				Name:        ExportRDSSnapshotToLocDBEngineArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Engine of the database",
+				Enum:        []string{string(PostgrSQLEngine)},
+			},
+			{
+				Name:        ExportRDSSnapshotToLocDBUsernameArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Username of the database",
+			},
+			{
+				Name:        ExportRDSSnapshotToLocDBPasswordArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Password of the database",
+			},
+			{
+				Name:        ExportRDSSnapshotToLocBackupArtPrefixArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Path in the object store to store the dump in, defaults to the instance ID",
+			},
+			{
+				Name:        ExportRDSSnapshotToLocDatabasesArg,
+				Type:        kanister.ArgTypeAny,
+				Description: "List of the databases to export, or a YAML formatted list. Defaults to all the databases",
+			},
+			{
+				Name:        ExportRDSSnapshotToLocSecGrpIDArg,
+				Type:        kanister.ArgTypeAny,
+				Description: "List of the security group IDs of the temporary instance, or a YAML formatted list",
+			},
+			{
+				Name:        ExportRDSSnapshotToLocDBSubnetGroupArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Subnet group of the temporary instance",
+				Default:     "default",
+			},
+			podAnnotationsArgSchema,
+			podLabelsArgSchema,
+			credentialsSourceArgSchema,
+			credentialsSecretArgSchema,
+			regionArgSchema,
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: ExportRDSSnapshotToLocSnapshotIDArg, Type: kanister.ArgTypeString, Description: "ID of the snapshot"},
+			{Name: ExportRDSSnapshotToLocInstanceIDArg, Type: kanister.ArgTypeString, Description: "ID of the instance"},
+			{Name: ExportRDSSnapshotToLocSecGrpIDArg, Type: kanister.ArgTypeString, Description: "YAML list of the security group IDs of the instance"},
+			{Name: ExportRDSSnapshotToLocBackupID, Type: kanister.ArgTypeString, Description: "ID of the exported dump"},
+		},
+	}
+}
+
 func (e *exportRDSSnapshotToLocationFunc) Validate(args map[string]any) error {
 	if err := ValidatePodLabelsAndAnnotations(e.Name(), args); err != nil {
 		return err
@@ -0,0 +1,375 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/kanisterio/errkit"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/ephemeral"
+	"github.com/kanisterio/kanister/pkg/format"
+	"github.com/kanisterio/kanister/pkg/kube"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/progress"
+	"github.com/kanisterio/kanister/pkg/utils"
+)
+
+const (
+	// FreezeVolumeFuncName gives the name of the function
+	FreezeVolumeFuncName = "FreezeVolume"
+	// FreezeVolumeNamespaceArg provides the namespace of the PVC
+	FreezeVolumeNamespaceArg = "namespace"
+	// FreezeVolumePVCArg provides the name of the PVC
+	FreezeVolumePVCArg = "pvc"
+	// FreezeVolumeImageArg provides the image of the pod that freezes the volume
+	FreezeVolumeImageArg = "image"
+	// FreezeVolumeTimeoutArg provides the time after which the volume is thawed
+	FreezeVolumeTimeoutArg = "timeout"
+	// FreezeVolumePodOutput is the key used for returning the name of the pod that froze the volume
+	FreezeVolumePodOutput = "pod"
+	// FreezeVolumeNodeOutput is the key used for returning the node the volume is mounted on
+	FreezeVolumeNodeOutput = "node"
+	// FreezeVolumeMountPathOutput is the key used for returning the path of the mount on the node
+	FreezeVolumeMountPathOutput = "mountPath"
+
+	// FreezeVolumeLabel is set to the name of the PVC on the pod that froze it
+	FreezeVolumeLabel = "kanister.io/frozen-volume"
+	// FreezeVolumeMountPathAnnotation is set to the path of the frozen mount
+	// on the pod that froze it
+	FreezeVolumeMountPathAnnotation = "kanister.io/frozen-volume-path"
+
+	defaultFreezeVolumeTimeout = 10 * time.Minute
+	freezeVolumeJobPrefix      = "freeze-volume-"
+	// freezeVolumeGracePeriod leaves the preStop hook of the pod that froze
+	// the volume time to thaw it when the pod is deleted
+	freezeVolumeGracePeriod = int64(30)
+	freezeVolumeKubeletDir  = "/var/lib/kubelet"
+)
+
+func init() {
+	_ = kanister.Register(&freezeVolumeFunc{})
+}
+
+var _ kanister.Func = (*freezeVolumeFunc)(nil)
+
+type freezeVolumeFunc struct {
+	progressPercent string
+}
+
+func (*freezeVolumeFunc) Name() string {
+	return FreezeVolumeFuncName
+}
+
+// hostCommand returns the command that runs cmd in the mount namespace of
+// the node. It needs a privileged pod with hostPID.
+func hostCommand(cmd ...string) []string {
+	return append([]string{"nsenter", "--target", "1", "--mount", "--"}, cmd...)
+}
+
+// shellCommand quotes the arguments of cmd for sh. The arguments must not
+// contain single quotes.
+func shellCommand(cmd []string) string {
+	quoted := make([]string, 0, len(cmd))
+	for _, arg := range cmd {
+		quoted = append(quoted, "'"+arg+"'")
+	}
+	return strings.Join(quoted, " ")
+}
+
+func fsfreezeCommand(mountPath string, freeze bool) []string {
+	op := "--unfreeze"
+	if freeze {
+		op = "--freeze"
+	}
+	return hostCommand("fsfreeze", op, mountPath)
+}
+
+// volumeMount returns the running pod that mounts the PVC and the path of
+// the mount on its node.
+func volumeMount(ctx context.Context, cli kubernetes.Interface, namespace, pvcName string) (*corev1.Pod, string, error) {
+	pvc, err := cli.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, pvcName, metav1.GetOptions{})
+	if err != nil {
+		return nil, "", errkit.Wrap(err, "Failed to retrieve PVC.", "namespace", namespace, "name", pvcName)
+	}
+	if pvc.Spec.VolumeMode != nil && *pvc.Spec.VolumeMode == corev1.PersistentVolumeBlock {
+		return nil, "", errkit.New("PVC must have volumeMode Filesystem", "namespace", namespace, "name", pvcName)
+	}
+	if pvc.Spec.VolumeName == "" {
+		return nil, "", errkit.New("PVC is not bound", "namespace", namespace, "name", pvcName)
+	}
+	pv, err := cli.CoreV1().PersistentVolumes().Get(ctx, pvc.Spec.VolumeName, metav1.GetOptions{})
+	if err != nil {
+		return nil, "", errkit.Wrap(err, "Failed to retrieve PV.", "name", pvc.Spec.VolumeName)
+	}
+	// The kubelet mounts the volumes of a pod in a directory named after the
+	// volume plugin and the PV.
+	var pluginDir, mountDir string
+	switch {
+	case pv.Spec.CSI != nil:
+		pluginDir, mountDir = "kubernetes.io~csi", "mount"
+	case pv.Spec.Local != nil:
+		pluginDir = "kubernetes.io~local-volume"
+	default:
+		return nil, "", errkit.New("Volume type not supported, PV must be a CSI or local volume", "name", pv.Name)
+	}
+
+	pods, err := cli.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, "", errkit.Wrap(err, "Failed to list pods", "namespace", namespace)
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != corev1.PodRunning || pod.Spec.NodeName == "" {
+			continue
+		}
+		for _, vol := range pod.Spec.Volumes {
+			if vol.PersistentVolumeClaim != nil && vol.PersistentVolumeClaim.ClaimName == pvcName {
+				mountPath := path.Join(freezeVolumeKubeletDir, "pods", string(pod.UID), "volumes", pluginDir, pv.Name, mountDir)
+				return pod, mountPath, nil
+			}
+		}
+	}
+	return nil, "", errkit.New("PVC is not mounted by a running pod", "namespace", namespace, "name", pvcName)
+}
+
+// freezeVolumePodOptions returns the options of the pod that freezes the
+// volume mounted at mountPath on the node. The pod thaws the volume when the
+// timeout expires or when it's deleted.
+func freezeVolumePodOptions(a blockVolumePodArgs, node, mountPath string, timeout time.Duration) (*kube.PodOptions, error) {
+	podOverride, err := kube.CreateAndMergeJSONPatch(crv1alpha1.JSONMap{"hostPID": true}, a.podOverride)
+	if err != nil {
+		return nil, err
+	}
+	thaw := shellCommand(fsfreezeCommand(mountPath, false))
+	privileged := true
+	options := &kube.PodOptions{
+		Namespace:    a.namespace,
+		GenerateName: freezeVolumeJobPrefix,
+		Image:        a.image,
+		// The watchdog thaws the volume if it isn't thawed in time
+		Command: []string{"sh", "-c", fmt.Sprintf("trap 'exit 0' TERM; sleep %d & wait $!; %s", int(timeout.Seconds()), thaw)},
+		Lifecycle: &corev1.Lifecycle{
+			PreStop: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{Command: []string{"sh", "-c", thaw + " || true"}},
+			},
+		},
+		ContainerSecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+		NodeName:                 node,
+		RestartPolicy:            corev1.RestartPolicyNever,
+		PodOverride:              podOverride,
+		Annotations:              a.annotations,
+		Labels:                   a.labels,
+	}
+	options.AddLabels(map[string]string{FreezeVolumeLabel: a.pvc})
+	options.AddAnnotations(map[string]string{FreezeVolumeMountPathAnnotation: mountPath})
+
+	// Apply the registered ephemeral pod changes.
+	if err := ephemeral.PodOptions.Apply(options); err != nil {
+		return nil, errkit.Wrap(err, "Failed to apply ephemeral pod options")
+	}
+	return options, nil
+}
+
+// freezeVolume starts the pod and freezes the volume mounted at mountPath.
+// The pod is deleted if the volume can't be frozen, otherwise it keeps
+// running until the volume is thawed.
+func freezeVolume(ctx context.Context, pc kube.PodController, mountPath string) (err error) {
+	if err := pc.StartPod(ctx); err != nil {
+		return errkit.Wrap(err, "Failed to create pod to freeze the volume")
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if stopErr := pc.StopPod(context.Background(), kube.PodControllerInfiniteStopTime, freezeVolumeGracePeriod); stopErr != nil {
+			err = errkit.Append(err, stopErr)
+		}
+	}()
+	if err := pc.WaitForPodReady(ctx); err != nil {
+		return errkit.Wrap(err, "Failed while waiting for Pod to be ready", "pod", pc.PodName())
+	}
+	ex, err := pc.GetCommandExecutor()
+	if err != nil {
+		return err
+	}
+	var stdout, stderr bytes.Buffer
+	err = ex.Exec(ctx, fsfreezeCommand(mountPath, true), nil, &stdout, &stderr)
+	format.LogWithCtx(ctx, pc.PodName(), kube.DefaultContainerName, stdout.String())
+	format.LogWithCtx(ctx, pc.PodName(), kube.DefaultContainerName, stderr.String())
+	if err != nil {
+		return errkit.Wrap(err, "Failed to freeze the volume", "mountPath", mountPath)
+	}
+	return nil
+}
+
+// checkNotFrozen returns an error if a freeze pod of the PVC is running. The
+// pods that finished after the timeout expired are deleted, since the
+// volume is thawed already.
+func checkNotFrozen(ctx context.Context, cli kubernetes.Interface, namespace, pvc string) error {
+	pods, err := cli.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: FreezeVolumeLabel + "=" + pvc})
+	if err != nil {
+		return errkit.Wrap(err, "Failed to list pods", "namespace", namespace)
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		switch pod.Status.Phase {
+		case corev1.PodRunning:
+			return errkit.New("Volume is already frozen", "pvc", pvc, "pod", pod.Name)
+		case corev1.PodSucceeded, corev1.PodFailed:
+			if err := kube.DeletePod(ctx, cli, pod); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (f *freezeVolumeFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	// Set progress percent
+	f.progressPercent = progress.StartedPercent
+	defer func() { f.progressPercent = progress.CompletedPercent }()
+
+	a, err := parseBlockVolumePodArgs(tp, args)
+	if err != nil {
+		return nil, err
+	}
+	var timeout string
+	if err := OptArg(args, FreezeVolumeTimeoutArg, &timeout, defaultFreezeVolumeTimeout.String()); err != nil {
+		return nil, err
+	}
+	timeoutDur, err := time.ParseDuration(timeout)
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to parse timeout")
+	}
+	if timeoutDur < time.Second {
+		return nil, errkit.New("Timeout must be at least 1s", "timeout", timeout)
+	}
+	cli, err := kube.NewClient()
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to create Kubernetes client")
+	}
+	if err := checkNotFrozen(ctx, cli, a.namespace, a.pvc); err != nil {
+		return nil, err
+	}
+	pod, mountPath, err := volumeMount(ctx, cli, a.namespace, a.pvc)
+	if err != nil {
+		return nil, err
+	}
+	options, err := freezeVolumePodOptions(a, pod.Spec.NodeName, mountPath, timeoutDur)
+	if err != nil {
+		return nil, err
+	}
+	pc := kube.NewPodController(cli, options)
+	if err := freezeVolume(ctx, pc, mountPath); err != nil {
+		return nil, errkit.Wrap(err, "Failed to freeze volume", "pvc", a.pvc)
+	}
+	return map[string]interface{}{
+		FreezeVolumePodOutput:       pc.PodName(),
+		FreezeVolumeNodeOutput:      pod.Spec.NodeName,
+		FreezeVolumeMountPathOutput: mountPath,
+		FunctionOutputVersion:       kanister.DefaultVersion,
+	}, nil
+}
+
+func (*freezeVolumeFunc) RequiredArgs() []string {
+	return []string{
+		FreezeVolumeNamespaceArg,
+		FreezeVolumePVCArg,
+	}
+}
+
+func (*freezeVolumeFunc) Arguments() []string {
+	return []string{
+		FreezeVolumeNamespaceArg,
+		FreezeVolumePVCArg,
+		FreezeVolumeImageArg,
+		FreezeVolumeTimeoutArg,
+		PodOverrideArg,
+		PodAnnotationsArg,
+		PodLabelsArg,
+	}
+}
+
+func (*freezeVolumeFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        FreezeVolumeFuncName,
+		Description: "Freezes the filesystem of a PVC from a privileged pod on the node it's mounted on, until ThawVolume or the timeout",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        FreezeVolumeNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the PVC",
+			},
+			{
+				Name:        FreezeVolumePVCArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the PVC, needs to be mounted by a running pod",
+			},
+			{
+				Name:        FreezeVolumeImageArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Image of the pod that freezes the volume, needs to have nsenter installed",
+			},
+			{
+				Name:        FreezeVolumeTimeoutArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Duration after which the volume is thawed if ThawVolume isn't run",
+				Default:     defaultFreezeVolumeTimeout.String(),
+			},
+			podOverrideArgSchema,
+			podAnnotationsArgSchema,
+			podLabelsArgSchema,
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: FreezeVolumePodOutput, Type: kanister.ArgTypeString, Description: "Name of the pod that froze the volume"},
+			{Name: FreezeVolumeNodeOutput, Type: kanister.ArgTypeString, Description: "Node the volume is mounted on"},
+			{Name: FreezeVolumeMountPathOutput, Type: kanister.ArgTypeString, Description: "Path of the frozen mount on the node"},
+			versionOutputSchema,
+		},
+	}
+}
+
+func (f *freezeVolumeFunc) Validate(args map[string]any) error {
+	if err := ValidatePodLabelsAndAnnotations(f.Name(), args); err != nil {
+		return err
+	}
+
+	if err := utils.CheckSupportedArgs(f.Arguments(), args); err != nil {
+		return err
+	}
+
+	return utils.CheckRequiredArgs(f.RequiredArgs(), args)
+}
+
+func (f *freezeVolumeFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
+	metav1Time := metav1.NewTime(time.Now())
+	return crv1alpha1.PhaseProgress{
+		ProgressPercent:    f.progressPercent,
+		LastTransitionTime: &metav1Time,
+	}, nil
+}
@@ -0,0 +1,226 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/kanisterio/errkit"
+	"gopkg.in/check.v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/kube"
+)
+
+type FreezeVolumeSuite struct{}
+
+var _ = check.Suite(&FreezeVolumeSuite{})
+
+func newFreezeVolumePVC(name, pv string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+		Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: pv},
+	}
+}
+
+func newFreezeVolumePod(name, pvc string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", UID: types.UID("uid-" + name)},
+		Spec: corev1.PodSpec{
+			NodeName: "node-1",
+			Volumes: []corev1.Volume{{
+				Name: "data",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvc},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func (s *FreezeVolumeSuite) TestVolumeMount(c *check.C) {
+	ctx := context.Background()
+	block := corev1.PersistentVolumeBlock
+	blockPVC := newFreezeVolumePVC("block", "pv-block")
+	blockPVC.Spec.VolumeMode = &block
+	cli := fake.NewSimpleClientset(
+		newFreezeVolumePVC("data", "pv-csi"),
+		newFreezeVolumePVC("local", "pv-local"),
+		newFreezeVolumePVC("nfs", "pv-nfs"),
+		newFreezeVolumePVC("unused", "pv-unused"),
+		newFreezeVolumePVC("unbound", ""),
+		blockPVC,
+		&corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-csi"},
+			Spec: corev1.PersistentVolumeSpec{PersistentVolumeSource: corev1.PersistentVolumeSource{
+				CSI: &corev1.CSIPersistentVolumeSource{Driver: "csi.example.com"},
+			}},
+		},
+		&corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-local"},
+			Spec: corev1.PersistentVolumeSpec{PersistentVolumeSource: corev1.PersistentVolumeSource{
+				Local: &corev1.LocalVolumeSource{Path: "/mnt/disks/1"},
+			}},
+		},
+		&corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-nfs"},
+			Spec: corev1.PersistentVolumeSpec{PersistentVolumeSource: corev1.PersistentVolumeSource{
+				NFS: &corev1.NFSVolumeSource{Server: "nfs", Path: "/"},
+			}},
+		},
+		&corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-unused"},
+			Spec: corev1.PersistentVolumeSpec{PersistentVolumeSource: corev1.PersistentVolumeSource{
+				CSI: &corev1.CSIPersistentVolumeSource{Driver: "csi.example.com"},
+			}},
+		},
+		newFreezeVolumePod("pending", "data", corev1.PodPending),
+		newFreezeVolumePod("app", "data", corev1.PodRunning),
+		newFreezeVolumePod("local-app", "local", corev1.PodRunning),
+		newFreezeVolumePod("nfs-app", "nfs", corev1.PodRunning),
+		newFreezeVolumePod("unused-app", "unused", corev1.PodSucceeded),
+	)
+
+	pod, mountPath, err := volumeMount(ctx, cli, "ns", "data")
+	c.Assert(err, check.IsNil)
+	c.Assert(pod.Name, check.Equals, "app")
+	c.Assert(mountPath, check.Equals, "/var/lib/kubelet/pods/uid-app/volumes/kubernetes.io~csi/pv-csi/mount")
+
+	pod, mountPath, err = volumeMount(ctx, cli, "ns", "local")
+	c.Assert(err, check.IsNil)
+	c.Assert(pod.Name, check.Equals, "local-app")
+	c.Assert(mountPath, check.Equals, "/var/lib/kubelet/pods/uid-local-app/volumes/kubernetes.io~local-volume/pv-local")
+
+	for pvc, msg := range map[string]string{
+		"nfs":     "Volume type not supported.*",
+		"unused":  "PVC is not mounted by a running pod.*",
+		"unbound": "PVC is not bound.*",
+		"block":   "PVC must have volumeMode Filesystem.*",
+	} {
+		_, _, err = volumeMount(ctx, cli, "ns", pvc)
+		c.Assert(err, check.ErrorMatches, msg, check.Commentf("PVC %s", pvc))
+	}
+}
+
+func (s *FreezeVolumeSuite) TestFreezeVolumePodOptions(c *check.C) {
+	a := blockVolumePodArgs{
+		namespace:   "ns",
+		pvc:         "data",
+		image:       "tools",
+		podOverride: crv1alpha1.JSONMap{"tolerations": []interface{}{map[string]interface{}{"operator": "Exists"}}},
+		labels:      map[string]string{"app": "backup"},
+	}
+	mountPath := "/var/lib/kubelet/pods/uid/volumes/kubernetes.io~csi/pv/mount"
+	options, err := freezeVolumePodOptions(a, "node-1", mountPath, 5*time.Minute)
+	c.Assert(err, check.IsNil)
+	c.Assert(options.Namespace, check.Equals, "ns")
+	c.Assert(options.NodeName, check.Equals, "node-1")
+	c.Assert(*options.ContainerSecurityContext.Privileged, check.Equals, true)
+	c.Assert(options.PodOverride["hostPID"], check.Equals, true)
+	c.Assert(options.PodOverride["tolerations"], check.NotNil)
+	c.Assert(options.Labels, check.DeepEquals, map[string]string{"app": "backup", FreezeVolumeLabel: "data"})
+	c.Assert(options.Annotations[FreezeVolumeMountPathAnnotation], check.Equals, mountPath)
+
+	thaw := "'nsenter' '--target' '1' '--mount' '--' 'fsfreeze' '--unfreeze' '" + mountPath + "'"
+	c.Assert(options.Command[2], check.Equals, "trap 'exit 0' TERM; sleep 300 & wait $!; "+thaw)
+	c.Assert(options.Lifecycle.PreStop.Exec.Command, check.DeepEquals, []string{"sh", "-c", thaw + " || true"})
+}
+
+func (s *FreezeVolumeSuite) TestFreezeVolume(c *check.C) {
+	ctx := context.Background()
+	ex := &kube.FakePodCommandExecutor{}
+	pc := &kube.FakePodController{GetCommandExecutorRet: ex}
+	err := freezeVolume(ctx, pc, "/mnt")
+	c.Assert(err, check.IsNil)
+	c.Assert(pc.StartPodCalled, check.Equals, true)
+	c.Assert(pc.WaitForPodReadyCalled, check.Equals, true)
+	c.Assert(pc.StopPodCalled, check.Equals, false)
+
+	ex = &kube.FakePodCommandExecutor{ExecErr: errkit.New("fsfreeze: /mnt: freeze failed")}
+	pc = &kube.FakePodController{GetCommandExecutorRet: ex}
+	err = freezeVolume(ctx, pc, "/mnt")
+	c.Assert(err, check.ErrorMatches, "Failed to freeze the volume.*")
+	c.Assert(pc.StopPodCalled, check.Equals, true)
+	c.Assert(pc.InStopPodGracePeriod, check.Equals, freezeVolumeGracePeriod)
+
+	pc = &kube.FakePodController{WaitForPodReadyErr: errkit.New("not ready")}
+	err = freezeVolume(ctx, pc, "/mnt")
+	c.Assert(err, check.NotNil)
+	c.Assert(pc.StopPodCalled, check.Equals, true)
+}
+
+func (s *FreezeVolumeSuite) TestThawVolumeAfterTimeout(c *check.C) {
+	ctx := context.Background()
+	pod := newFreezeVolumePod("freeze-volume-abc", "data", corev1.PodSucceeded)
+	cli := fake.NewSimpleClientset(pod)
+	err := thawVolume(ctx, cli, pod)
+	c.Assert(err, check.IsNil)
+	_, err = cli.CoreV1().Pods("ns").Get(ctx, pod.Name, metav1.GetOptions{})
+	c.Assert(apierrors.IsNotFound(err), check.Equals, true)
+}
+
+func (s *FreezeVolumeSuite) TestUnfreezeVolume(c *check.C) {
+	ctx := context.Background()
+	pod := newFreezeVolumePod("freeze-volume-abc", "data", corev1.PodRunning)
+	pod.Spec.Containers = []corev1.Container{{Name: kube.DefaultContainerName}}
+	ex := &kube.FakePodCommandExecutor{}
+	c.Assert(unfreezeVolume(ctx, ex, pod, "/mnt"), check.IsNil)
+
+	// A volume that isn't frozen is thawed already
+	ex = &kube.FakePodCommandExecutor{
+		ExecErr:    errkit.New("command terminated with exit code 1"),
+		ExecStderr: "fsfreeze: /mnt: unfreeze failed: Invalid argument",
+	}
+	c.Assert(unfreezeVolume(ctx, ex, pod, "/mnt"), check.IsNil)
+
+	ex = &kube.FakePodCommandExecutor{
+		ExecErr:    errkit.New("command terminated with exit code 1"),
+		ExecStderr: "fsfreeze: /mnt: unfreeze failed: Operation not permitted",
+	}
+	c.Assert(unfreezeVolume(ctx, ex, pod, "/mnt"), check.ErrorMatches, "Failed to thaw the volume.*")
+}
+
+func (s *FreezeVolumeSuite) TestCheckNotFrozen(c *check.C) {
+	ctx := context.Background()
+	succeeded := newFreezeVolumePod("freeze-volume-abc", "data", corev1.PodSucceeded)
+	succeeded.Labels = map[string]string{FreezeVolumeLabel: "data"}
+	failed := newFreezeVolumePod("freeze-volume-def", "data", corev1.PodFailed)
+	failed.Labels = map[string]string{FreezeVolumeLabel: "data"}
+	cli := fake.NewSimpleClientset(succeeded, failed)
+	c.Assert(checkNotFrozen(ctx, cli, "ns", "data"), check.IsNil)
+	// The finished pods are deleted
+	pods, err := cli.CoreV1().Pods("ns").List(ctx, metav1.ListOptions{})
+	c.Assert(err, check.IsNil)
+	c.Assert(pods.Items, check.HasLen, 0)
+
+	running := newFreezeVolumePod("freeze-volume-ghi", "data", corev1.PodRunning)
+	running.Labels = map[string]string{FreezeVolumeLabel: "data"}
+	cli = fake.NewSimpleClientset(running)
+	c.Assert(checkNotFrozen(ctx, cli, "ns", "data"), check.ErrorMatches, "Volume is already frozen.*")
+}
+
+func (s *FreezeVolumeSuite) TestShellCommand(c *check.C) {
+	cmd := shellCommand([]string{"fsfreeze", "--freeze", "/a b"})
+	c.Assert(cmd, check.Equals, "'fsfreeze' '--freeze' '/a b'")
+	c.Assert(strings.Count(cmd, "'"), check.Equals, 6)
+}
@@ -0,0 +1,712 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jpillora/backoff"
+	"github.com/kanisterio/errkit"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	k8sjsonpath "k8s.io/client-go/util/jsonpath"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/consts"
+	"github.com/kanisterio/kanister/pkg/ephemeral"
+	"github.com/kanisterio/kanister/pkg/field"
+	"github.com/kanisterio/kanister/pkg/kube"
+	"github.com/kanisterio/kanister/pkg/log"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/poll"
+	"github.com/kanisterio/kanister/pkg/progress"
+	"github.com/kanisterio/kanister/pkg/utils"
+)
+
+const (
+	// HTTPRequestFuncName gives the name of the function
+	HTTPRequestFuncName = "HTTPRequest"
+	// HTTPRequestMethodArg is the method of the request
+	HTTPRequestMethodArg = "method"
+	// HTTPRequestURLArg is the URL of the request
+	HTTPRequestURLArg = "url"
+	// HTTPRequestHeadersArg are the headers of the request
+	HTTPRequestHeadersArg = "headers"
+	// HTTPRequestBodyArg is the body of the request
+	HTTPRequestBodyArg = "body"
+	// HTTPRequestCredentialsSecretArg is the name of the secret with the credentials of the request
+	HTTPRequestCredentialsSecretArg = "credentialsSecret"
+	// HTTPRequestCACertArg is the PEM encoded CA certificate used to verify the server
+	HTTPRequestCACertArg = "caCert"
+	// HTTPRequestExpectedStatusCodesArg are the status codes of a successful response
+	HTTPRequestExpectedStatusCodesArg = "expectedStatusCodes"
+	// HTTPRequestRetriesArg is the number of times a failed request is retried
+	HTTPRequestRetriesArg = "retries"
+	// HTTPRequestRetryIntervalArg is the time to wait before the first retry
+	HTTPRequestRetryIntervalArg = "retryInterval"
+	// HTTPRequestTimeoutArg is the timeout of each attempt of the request
+	HTTPRequestTimeoutArg = "timeout"
+	// HTTPRequestFieldsArg maps output names to jsonpaths of the fields of the response
+	HTTPRequestFieldsArg = "fields"
+	// HTTPRequestRunInPodArg sends the request from a pod instead of the controller
+	HTTPRequestRunInPodArg = "runInPod"
+	// HTTPRequestNamespaceArg is the namespace of the pod that sends the request
+	HTTPRequestNamespaceArg = "namespace"
+	// HTTPRequestImageArg is the image of the pod that sends the request
+	HTTPRequestImageArg = "image"
+	// HTTPRequestStatusCodeOutput is the status code of the response
+	HTTPRequestStatusCodeOutput = "statusCode"
+	// HTTPRequestBodyOutput is the body of the response
+	HTTPRequestBodyOutput = "body"
+	// HTTPRequestFieldsOutput is the output with the fields of the response
+	HTTPRequestFieldsOutput = "fields"
+
+	// Keys of the credentials in the secret
+	httpRequestUsernameKey = "username"
+	httpRequestPasswordKey = "password"
+	httpRequestTokenKey    = "token"
+
+	defaultHTTPRequestRetryInterval = "1s"
+	defaultHTTPRequestTimeout       = "1m"
+	maxHTTPRequestRetryInterval     = time.Minute
+	httpRequestJobPrefix            = "http-request-"
+	httpRequestCACertPath           = "/tmp/kanister-http-request-ca.pem"
+	// maxHTTPRequestResponseSize is the size of the response body that is read
+	maxHTTPRequestResponseSize = 1 << 20
+	// maxHTTPRequestBodyOutputSize is the size of the body in the output,
+	// which is stored in the status of the ActionSet
+	maxHTTPRequestBodyOutputSize = 4 << 10
+)
+
+var (
+	errRetryableHTTPRequest = errkit.NewSentinelErr("Retryable HTTP request error")
+	httpRequestMethods      = []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+)
+
+func init() {
+	_ = kanister.Register(&httpRequestFunc{})
+}
+
+var _ kanister.Func = (*httpRequestFunc)(nil)
+
+type httpRequestFunc struct {
+	progressPercent string
+}
+
+func (*httpRequestFunc) Name() string {
+	return HTTPRequestFuncName
+}
+
+type httpRequestArgs struct {
+	method              string
+	url                 string
+	headers             map[string]string
+	body                string
+	insecureTLS         bool
+	caCert              string
+	expectedStatusCodes []int
+	retries             int
+	retryInterval       time.Duration
+	timeout             time.Duration
+	fields              map[string]string
+	runInPod            bool
+	namespace           string
+	image               string
+	podOverride         crv1alpha1.JSONMap
+	annotations         map[string]string
+	labels              map[string]string
+}
+
+type httpResponse struct {
+	statusCode int
+	body       []byte
+}
+
+// httpRequestDoer sends the request once
+type httpRequestDoer func(ctx context.Context) (*httpResponse, error)
+
+func parseHTTPRequestArgs(tp param.TemplateParams, args map[string]interface{}) (*httpRequestArgs, error) {
+	a := &httpRequestArgs{}
+	var retryInterval, timeout, credentialsSecret string
+	var bpAnnotations, bpLabels map[string]string
+	if err := OptArg(args, HTTPRequestMethodArg, &a.method, http.MethodGet); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, HTTPRequestURLArg, &a.url); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, HTTPRequestHeadersArg, &a.headers, nil); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, HTTPRequestBodyArg, &a.body, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, HTTPRequestCredentialsSecretArg, &credentialsSecret, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, InsecureTLS, &a.insecureTLS, false); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, HTTPRequestCACertArg, &a.caCert, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, HTTPRequestExpectedStatusCodesArg, &a.expectedStatusCodes, nil); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, HTTPRequestRetriesArg, &a.retries, 0); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, HTTPRequestRetryIntervalArg, &retryInterval, defaultHTTPRequestRetryInterval); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, HTTPRequestTimeoutArg, &timeout, defaultHTTPRequestTimeout); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, HTTPRequestFieldsArg, &a.fields, nil); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, HTTPRequestRunInPodArg, &a.runInPod, false); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, HTTPRequestNamespaceArg, &a.namespace, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, HTTPRequestImageArg, &a.image, consts.GetKanisterToolsImage()); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, PodAnnotationsArg, &bpAnnotations, nil); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, PodLabelsArg, &bpLabels, nil); err != nil {
+		return nil, err
+	}
+
+	a.method = strings.ToUpper(a.method)
+	if !slices.Contains(httpRequestMethods, a.method) {
+		return nil, errkit.New("Unsupported method", "method", a.method)
+	}
+	if a.retries < 0 {
+		return nil, errkit.New("Retries must not be negative", "retries", a.retries)
+	}
+	var err error
+	if a.retryInterval, err = time.ParseDuration(retryInterval); err != nil {
+		return nil, errkit.Wrap(err, "Failed to parse retryInterval")
+	}
+	if a.timeout, err = time.ParseDuration(timeout); err != nil {
+		return nil, errkit.Wrap(err, "Failed to parse timeout")
+	}
+	if err := setHTTPRequestCredentials(tp, credentialsSecret, a); err != nil {
+		return nil, err
+	}
+
+	if a.runInPod && a.namespace == "" {
+		return nil, errkit.New("Namespace is required to run the request in a pod")
+	}
+	if a.podOverride, err = GetPodSpecOverride(tp, args, PodOverrideArg); err != nil {
+		return nil, err
+	}
+	a.annotations = bpAnnotations
+	a.labels = bpLabels
+	if tp.PodAnnotations != nil {
+		// merge the actionset annotations with blueprint annotations
+		var actionSetAnn ActionSetAnnotations = tp.PodAnnotations
+		a.annotations = actionSetAnn.MergeBPAnnotations(bpAnnotations)
+	}
+	if tp.PodLabels != nil {
+		// merge the actionset labels with blueprint labels
+		var actionSetLabels ActionSetLabels = tp.PodLabels
+		a.labels = actionSetLabels.MergeBPLabels(bpLabels)
+	}
+	return a, nil
+}
+
+// setHTTPRequestCredentials sets the Authorization header from the secret,
+// which can have a username and a password for basic authentication or a
+// bearer token.
+func setHTTPRequestCredentials(tp param.TemplateParams, secretName string, a *httpRequestArgs) error {
+	if secretName == "" {
+		return nil
+	}
+	secret, ok := getParamSecret(tp, secretName)
+	if !ok {
+		return errkit.New("Secret not found in the secrets of the ActionSet or the phase", "secret", secretName)
+	}
+	var auth string
+	switch {
+	case len(secret.Data[httpRequestTokenKey]) != 0:
+		auth = "Bearer " + string(secret.Data[httpRequestTokenKey])
+	case len(secret.Data[httpRequestUsernameKey]) != 0:
+		creds := string(secret.Data[httpRequestUsernameKey]) + ":" + string(secret.Data[httpRequestPasswordKey])
+		auth = "Basic " + base64.StdEncoding.EncodeToString([]byte(creds))
+	default:
+		return errkit.New("Secret must have a token or a username and password", "secret", secretName)
+	}
+	headers := make(map[string]string, len(a.headers)+1)
+	for k, v := range a.headers {
+		headers[k] = v
+	}
+	headers["Authorization"] = auth
+	a.headers = headers
+	return nil
+}
+
+// sendHTTPRequest sends the request with do until the response has one of
+// the expected status codes. Requests that fail or get a response that
+// may be temporary, e.g. 503, are retried with exponential backoff.
+func sendHTTPRequest(ctx context.Context, a *httpRequestArgs, do httpRequestDoer) (*httpResponse, error) {
+	var resp *httpResponse
+	b := backoff.Backoff{Min: a.retryInterval, Max: maxHTTPRequestRetryInterval, Factor: 2}
+	isRetryable := func(err error) bool {
+		if errkit.Is(err, errRetryableHTTPRequest) {
+			log.Print("Retrying HTTP request", field.M{"URL": a.url, "Error": err.Error()})
+			return true
+		}
+		return false
+	}
+	err := poll.WaitWithBackoffWithRetries(ctx, b, a.retries, isRetryable, func(ctx context.Context) (bool, error) {
+		attemptCtx, cancel := context.WithTimeout(ctx, a.timeout)
+		defer cancel()
+		r, err := do(attemptCtx)
+		if err != nil {
+			return false, errkit.Wrap(errRetryableHTTPRequest, err.Error())
+		}
+		resp = r
+		if expectedHTTPStatus(a.expectedStatusCodes, r.statusCode) {
+			return true, nil
+		}
+		err = errkit.New("Unexpected status code", "statusCode", r.statusCode, "body", truncateHTTPBody(r.body))
+		if r.statusCode == http.StatusRequestTimeout || r.statusCode == http.StatusTooManyRequests || r.statusCode >= http.StatusInternalServerError {
+			return false, errkit.Wrap(errRetryableHTTPRequest, err.Error())
+		}
+		return false, err
+	})
+	if err != nil {
+		return nil, errkit.Wrap(err, "HTTP request failed", "method", a.method, "url", a.url)
+	}
+	return resp, nil
+}
+
+// expectedHTTPStatus checks if the status code is expected, any 2xx status
+// code is expected if no status codes are set.
+func expectedHTTPStatus(expected []int, statusCode int) bool {
+	if len(expected) == 0 {
+		return statusCode >= 200 && statusCode < 300
+	}
+	for _, code := range expected {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+func truncateHTTPBody(body []byte) string {
+	const maxLen = 512
+	if len(body) > maxLen {
+		return string(body[:maxLen]) + "..."
+	}
+	return string(body)
+}
+
+func newHTTPClient(a *httpRequestArgs) (*http.Client, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: a.insecureTLS} //nolint:gosec // skipping the verification is opt-in
+	if a.caCert != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(a.caCert)) {
+			return nil, errkit.New("Failed to parse caCert")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport}, nil
+}
+
+// controllerHTTPRequestDoer sends the request from the controller
+func controllerHTTPRequestDoer(client *http.Client, a *httpRequestArgs) httpRequestDoer {
+	return func(ctx context.Context) (*httpResponse, error) {
+		var body io.Reader
+		if a.body != "" {
+			body = strings.NewReader(a.body)
+		}
+		req, err := http.NewRequestWithContext(ctx, a.method, a.url, body)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range a.headers {
+			req.Header.Set(k, v)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close() //nolint:errcheck
+		respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPRequestResponseSize))
+		if err != nil {
+			return nil, errkit.Wrap(err, "Failed to read response body")
+		}
+		return &httpResponse{statusCode: resp.StatusCode, body: respBody}, nil
+	}
+}
+
+// curlConfigQuote quotes a value of the curl config file
+func curlConfigQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// curlConfig returns the curl config file for the request. The config is
+// passed to curl on stdin so that the credentials aren't in its arguments.
+func curlConfig(a *httpRequestArgs) string {
+	lines := []string{
+		"silent",
+		"show-error",
+		"url = " + curlConfigQuote(a.url),
+		"max-time = " + strconv.Itoa(int(a.timeout.Seconds())),
+		// The status code is written after the body
+		`write-out = "\\n%{http_code}"`,
+	}
+	if a.method == http.MethodHead {
+		lines = append(lines, "head", `output = "/dev/null"`)
+	} else {
+		lines = append(lines, "request = "+curlConfigQuote(a.method))
+	}
+	names := make([]string, 0, len(a.headers))
+	for k := range a.headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		lines = append(lines, "header = "+curlConfigQuote(k+": "+a.headers[k]))
+	}
+	if a.body != "" {
+		lines = append(lines, "data-raw = "+curlConfigQuote(a.body))
+	}
+	if a.insecureTLS {
+		lines = append(lines, "insecure")
+	}
+	if a.caCert != "" {
+		lines = append(lines, "cacert = "+curlConfigQuote(httpRequestCACertPath))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// parseCurlOutput splits the output of curl into the body and the status code
+func parseCurlOutput(out string) (*httpResponse, error) {
+	i := strings.LastIndex(out, "\n")
+	if i < 0 {
+		return nil, errkit.New("Failed to find the status code in the output of curl")
+	}
+	statusCode, err := strconv.Atoi(strings.TrimSpace(out[i+1:]))
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to parse the status code in the output of curl")
+	}
+	body := out[:i]
+	if len(body) > maxHTTPRequestResponseSize {
+		body = body[:maxHTTPRequestResponseSize]
+	}
+	return &httpResponse{statusCode: statusCode, body: []byte(body)}, nil
+}
+
+// podHTTPRequestDoer sends the request from the pod with curl
+func podHTTPRequestDoer(ex kube.PodCommandExecutor, a *httpRequestArgs) httpRequestDoer {
+	config := curlConfig(a)
+	return func(ctx context.Context) (*httpResponse, error) {
+		var stdout, stderr bytes.Buffer
+		err := ex.Exec(ctx, []string{"curl", "--config", "-"}, strings.NewReader(config), &stdout, &stderr)
+		if err != nil {
+			return nil, errkit.Wrap(err, "Failed to run curl", "stderr", stderr.String())
+		}
+		return parseCurlOutput(stdout.String())
+	}
+}
+
+func runHTTPRequestPod(ctx context.Context, cli kubernetes.Interface, a *httpRequestArgs) (*httpResponse, error) {
+	options := &kube.PodOptions{
+		Namespace:    a.namespace,
+		GenerateName: httpRequestJobPrefix,
+		Image:        a.image,
+		Command:      []string{"sh", "-c", "tail -f /dev/null"},
+		PodOverride:  a.podOverride,
+		Annotations:  a.annotations,
+		Labels:       a.labels,
+	}
+
+	// Apply the registered ephemeral pod changes.
+	if err := ephemeral.PodOptions.Apply(options); err != nil {
+		return nil, errkit.Wrap(err, "Failed to apply ephemeral pod options")
+	}
+	// Mark pod with label having key `kanister.io/JobID`, the value of which is a reference to the origin of the pod.
+	kube.AddLabelsToPodOptionsFromContext(ctx, options, path.Join(consts.LabelPrefix, consts.LabelSuffixJobID))
+
+	var resp *httpResponse
+	pr := kube.NewPodRunner(cli, options)
+	_, err := pr.Run(ctx, func(ctx context.Context, pc kube.PodController) (map[string]interface{}, error) {
+		if err := pc.WaitForPodReady(ctx); err != nil {
+			return nil, errkit.Wrap(err, "Failed while waiting for Pod to be ready", "pod", pc.PodName())
+		}
+		if a.caCert != "" {
+			pfw, err := pc.GetFileWriter()
+			if err != nil {
+				return nil, errkit.Wrap(err, "Unable to write caCert")
+			}
+			if _, err := pfw.Write(ctx, httpRequestCACertPath, strings.NewReader(a.caCert)); err != nil {
+				return nil, errkit.Wrap(err, "Unable to write caCert")
+			}
+		}
+		ex, err := pc.GetCommandExecutor()
+		if err != nil {
+			return nil, err
+		}
+		resp, err = sendHTTPRequest(ctx, a, podHTTPRequestDoer(ex, a))
+		return nil, err
+	})
+	return resp, err
+}
+
+// httpRequestOutput returns the output with the status code, the body and
+// the fields of the JSON response. The body in the output is truncated.
+func httpRequestOutput(resp *httpResponse, fields map[string]string) (map[string]interface{}, error) {
+	body := resp.body
+	if len(body) > maxHTTPRequestBodyOutputSize {
+		body = body[:maxHTTPRequestBodyOutputSize]
+	}
+	out := map[string]interface{}{
+		HTTPRequestStatusCodeOutput: resp.statusCode,
+		HTTPRequestBodyOutput:       string(body),
+		FunctionOutputVersion:       kanister.DefaultVersion,
+	}
+	if len(fields) == 0 {
+		return out, nil
+	}
+	// Numbers are kept as they are, e.g. large IDs aren't rounded
+	var data interface{}
+	dec := json.NewDecoder(bytes.NewReader(resp.body))
+	dec.UseNumber()
+	if err := dec.Decode(&data); err != nil {
+		return nil, errkit.Wrap(err, "Failed to parse the response as JSON")
+	}
+	values := make(map[string]interface{}, len(fields))
+	for name, p := range fields {
+		jp := k8sjsonpath.New(name)
+		if err := jp.Parse(p); err != nil {
+			return nil, errkit.Wrap(err, "Failed to parse jsonpath", "field", name, "jsonpath", p)
+		}
+		var buf bytes.Buffer
+		if err := jp.Execute(&buf, data); err != nil {
+			return nil, errkit.Wrap(err, "Failed to resolve jsonpath", "field", name, "jsonpath", p)
+		}
+		values[name] = buf.String()
+	}
+	out[HTTPRequestFieldsOutput] = values
+	return out, nil
+}
+
+func (h *httpRequestFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	// Set progress percent
+	h.progressPercent = progress.StartedPercent
+	defer func() { h.progressPercent = progress.CompletedPercent }()
+
+	a, err := parseHTTPRequestArgs(tp, args)
+	if err != nil {
+		return nil, err
+	}
+	var resp *httpResponse
+	if a.runInPod {
+		cli, err := kube.NewClient()
+		if err != nil {
+			return nil, errkit.Wrap(err, "Failed to create Kubernetes client")
+		}
+		resp, err = runHTTPRequestPod(ctx, cli, a)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		client, err := newHTTPClient(a)
+		if err != nil {
+			return nil, err
+		}
+		resp, err = sendHTTPRequest(ctx, a, controllerHTTPRequestDoer(client, a))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return httpRequestOutput(resp, a.fields)
+}
+
+func (*httpRequestFunc) RequiredArgs() []string {
+	return []string{HTTPRequestURLArg}
+}
+
+func (*httpRequestFunc) Arguments() []string {
+	return []string{
+		HTTPRequestMethodArg,
+		HTTPRequestURLArg,
+		HTTPRequestHeadersArg,
+		HTTPRequestBodyArg,
+		HTTPRequestCredentialsSecretArg,
+		InsecureTLS,
+		HTTPRequestCACertArg,
+		HTTPRequestExpectedStatusCodesArg,
+		HTTPRequestRetriesArg,
+		HTTPRequestRetryIntervalArg,
+		HTTPRequestTimeoutArg,
+		HTTPRequestFieldsArg,
+		HTTPRequestRunInPodArg,
+		HTTPRequestNamespaceArg,
+		HTTPRequestImageArg,
+		PodOverrideArg,
+		PodAnnotationsArg,
+		PodLabelsArg,
+	}
+}
+
+func (*httpRequestFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        HTTPRequestFuncName,
+		Description: "Sends an HTTP request from the controller or a pod, with retries, and returns fields of the response",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        HTTPRequestMethodArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Method of the request",
+				Default:     http.MethodGet,
+				Enum:        httpRequestMethods,
+			},
+			{
+				Name:        HTTPRequestURLArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "URL of the request",
+			},
+			{
+				Name:        HTTPRequestHeadersArg,
+				Type:        kanister.ArgTypeMap,
+				Description: "Headers of the request",
+			},
+			{
+				Name:        HTTPRequestBodyArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Body of the request",
+			},
+			{
+				Name:        HTTPRequestCredentialsSecretArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Name of a secret of the ActionSet or the phase with a token, or a username and password, to authenticate the request",
+			},
+			{
+				Name:        InsecureTLS,
+				Type:        kanister.ArgTypeBoolean,
+				Description: "Skip the TLS verification of the server",
+				Default:     false,
+			},
+			{
+				Name:        HTTPRequestCACertArg,
+				Type:        kanister.ArgTypeString,
+				Description: "PEM encoded CA certificate used to verify the server",
+			},
+			{
+				Name:        HTTPRequestExpectedStatusCodesArg,
+				Type:        kanister.ArgTypeList,
+				Description: "Status codes of a successful response, defaults to any 2xx status code",
+			},
+			{
+				Name:        HTTPRequestRetriesArg,
+				Type:        kanister.ArgTypeInteger,
+				Description: "Number of times a request that fails or gets a 408, 429 or 5xx response is retried",
+				Default:     0,
+			},
+			{
+				Name:        HTTPRequestRetryIntervalArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Time to wait before the first retry, doubled for every retry up to 1m",
+				Default:     defaultHTTPRequestRetryInterval,
+			},
+			{
+				Name:        HTTPRequestTimeoutArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Timeout of each attempt of the request",
+				Default:     defaultHTTPRequestTimeout,
+			},
+			{
+				Name:        HTTPRequestFieldsArg,
+				Type:        kanister.ArgTypeMap,
+				Description: "Names and jsonpaths of the fields of the JSON response to return",
+			},
+			{
+				Name:        HTTPRequestRunInPodArg,
+				Type:        kanister.ArgTypeBoolean,
+				Description: "Send the request with curl from a pod instead of the controller",
+				Default:     false,
+			},
+			{
+				Name:        HTTPRequestNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Namespace of the pod, required if runInPod is true",
+			},
+			{
+				Name:        HTTPRequestImageArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Image of the pod, needs to have curl installed",
+			},
+			podOverrideArgSchema,
+			podAnnotationsArgSchema,
+			podLabelsArgSchema,
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: HTTPRequestStatusCodeOutput, Type: kanister.ArgTypeInteger, Description: "Status code of the response"},
+			{Name: HTTPRequestBodyOutput, Type: kanister.ArgTypeString, Description: "Body of the response, truncated to 4 KiB"},
+			{Name: HTTPRequestFieldsOutput, Type: kanister.ArgTypeMap, Description: "Fields of the response"},
+			versionOutputSchema,
+		},
+	}
+}
+
+func (h *httpRequestFunc) Validate(args map[string]any) error {
+	if err := ValidatePodLabelsAndAnnotations(h.Name(), args); err != nil {
+		return err
+	}
+
+	if err := utils.CheckSupportedArgs(h.Arguments(), args); err != nil {
+		return err
+	}
+
+	return utils.CheckRequiredArgs(h.RequiredArgs(), args)
+}
+
+func (h *httpRequestFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
+	metav1Time := metav1.NewTime(time.Now())
+	return crv1alpha1.PhaseProgress{
+		ProgressPercent:    h.progressPercent,
+		LastTransitionTime: &metav1Time,
+	}, nil
+}
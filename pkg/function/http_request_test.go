@@ -0,0 +1,239 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+
+	"gopkg.in/check.v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kanisterio/kanister/pkg/param"
+)
+
+type HTTPRequestSuite struct{}
+
+var _ = check.Suite(&HTTPRequestSuite{})
+
+// newHTTPRequestTestServer returns a server that responds with the status
+// codes in order, and with the last one to all further requests.
+func newHTTPRequestTestServer(c *check.C, calls *int32, statusCodes ...int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(calls, 1))
+		code := statusCodes[min(n, len(statusCodes))-1]
+		if user, pass, ok := r.BasicAuth(); ok && (user != "admin" || pass != "secret") {
+			code = http.StatusUnauthorized
+		}
+		w.WriteHeader(code)
+		if code == http.StatusNoContent {
+			return
+		}
+		_, err := w.Write([]byte(`{"snapshot": {"state": "SUCCESS", "indices": ["a", "b"]}}`))
+		c.Check(err, check.IsNil)
+	}))
+}
+
+func (s *HTTPRequestSuite) parseArgs(c *check.C, tp param.TemplateParams, args map[string]interface{}) *httpRequestArgs {
+	a, err := parseHTTPRequestArgs(tp, args)
+	c.Assert(err, check.IsNil)
+	return a
+}
+
+func (s *HTTPRequestSuite) TestSendHTTPRequestRetries(c *check.C) {
+	var calls int32
+	srv := newHTTPRequestTestServer(c, &calls, http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK)
+	defer srv.Close()
+	a := s.parseArgs(c, param.TemplateParams{}, map[string]interface{}{
+		HTTPRequestURLArg:           srv.URL,
+		HTTPRequestRetriesArg:       2,
+		HTTPRequestRetryIntervalArg: "1ms",
+		HTTPRequestFieldsArg:        map[string]interface{}{"state": "{.snapshot.state}", "index": "{.snapshot.indices[1]}"},
+	})
+	client, err := newHTTPClient(a)
+	c.Assert(err, check.IsNil)
+	resp, err := sendHTTPRequest(context.Background(), a, controllerHTTPRequestDoer(client, a))
+	c.Assert(err, check.IsNil)
+	c.Assert(calls, check.Equals, int32(3))
+	out, err := httpRequestOutput(resp, a.fields)
+	c.Assert(err, check.IsNil)
+	c.Assert(out[HTTPRequestStatusCodeOutput], check.Equals, http.StatusOK)
+	c.Assert(out[HTTPRequestFieldsOutput], check.DeepEquals, map[string]interface{}{"state": "SUCCESS", "index": "b"})
+}
+
+func (s *HTTPRequestSuite) TestSendHTTPRequestStatusCodes(c *check.C) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		statusCodes []int
+		args        map[string]interface{}
+		calls       int32
+		errChecker  check.Checker
+	}{
+		{
+			// Not retried
+			statusCodes: []int{http.StatusNotFound},
+			args:        map[string]interface{}{HTTPRequestRetriesArg: 3},
+			calls:       1,
+			errChecker:  check.NotNil,
+		},
+		{
+			statusCodes: []int{http.StatusNotFound},
+			args:        map[string]interface{}{HTTPRequestExpectedStatusCodesArg: []interface{}{200, 404}},
+			calls:       1,
+			errChecker:  check.IsNil,
+		},
+		{
+			statusCodes: []int{http.StatusBadGateway},
+			args:        map[string]interface{}{HTTPRequestRetriesArg: 2},
+			calls:       3,
+			errChecker:  check.NotNil,
+		},
+		{
+			statusCodes: []int{http.StatusNoContent},
+			args:        map[string]interface{}{HTTPRequestMethodArg: "delete"},
+			calls:       1,
+			errChecker:  check.IsNil,
+		},
+	} {
+		var calls int32
+		srv := newHTTPRequestTestServer(c, &calls, tc.statusCodes...)
+		tc.args[HTTPRequestURLArg] = srv.URL
+		tc.args[HTTPRequestRetryIntervalArg] = "1ms"
+		a := s.parseArgs(c, param.TemplateParams{}, tc.args)
+		client, err := newHTTPClient(a)
+		c.Assert(err, check.IsNil)
+		_, err = sendHTTPRequest(ctx, a, controllerHTTPRequestDoer(client, a))
+		srv.Close()
+		c.Check(err, tc.errChecker, check.Commentf("Args %v", tc.args))
+		c.Check(calls, check.Equals, tc.calls, check.Commentf("Args %v", tc.args))
+	}
+}
+
+func (s *HTTPRequestSuite) TestHTTPRequestCredentials(c *check.C) {
+	tp := param.TemplateParams{
+		Secrets: map[string]corev1.Secret{
+			"basic":   {Data: map[string][]byte{"username": []byte("admin"), "password": []byte("secret")}},
+			"wrong":   {Data: map[string][]byte{"username": []byte("admin"), "password": []byte("guess")}},
+			"token":   {Data: map[string][]byte{"token": []byte("abc")}},
+			"invalid": {Data: map[string][]byte{"key": []byte("abc")}},
+		},
+	}
+	var calls int32
+	srv := newHTTPRequestTestServer(c, &calls, http.StatusOK)
+	defer srv.Close()
+
+	a := s.parseArgs(c, tp, map[string]interface{}{HTTPRequestURLArg: srv.URL, HTTPRequestCredentialsSecretArg: "basic"})
+	client, err := newHTTPClient(a)
+	c.Assert(err, check.IsNil)
+	_, err = sendHTTPRequest(context.Background(), a, controllerHTTPRequestDoer(client, a))
+	c.Assert(err, check.IsNil)
+
+	a = s.parseArgs(c, tp, map[string]interface{}{HTTPRequestURLArg: srv.URL, HTTPRequestCredentialsSecretArg: "wrong"})
+	_, err = sendHTTPRequest(context.Background(), a, controllerHTTPRequestDoer(client, a))
+	c.Assert(err, check.ErrorMatches, "HTTP request failed.*")
+
+	a = s.parseArgs(c, tp, map[string]interface{}{
+		HTTPRequestURLArg:               srv.URL,
+		HTTPRequestCredentialsSecretArg: "token",
+		HTTPRequestHeadersArg:           map[string]interface{}{"Accept": "application/json"},
+	})
+	c.Assert(a.headers, check.DeepEquals, map[string]string{"Accept": "application/json", "Authorization": "Bearer abc"})
+
+	for _, secret := range []string{"invalid", "missing"} {
+		_, err = parseHTTPRequestArgs(tp, map[string]interface{}{HTTPRequestURLArg: srv.URL, HTTPRequestCredentialsSecretArg: secret})
+		c.Assert(err, check.NotNil)
+	}
+}
+
+func (s *HTTPRequestSuite) TestParseHTTPRequestArgsErrors(c *check.C) {
+	for _, args := range []map[string]interface{}{
+		{},
+		{HTTPRequestURLArg: "http://example.com", HTTPRequestMethodArg: "TRACE"},
+		{HTTPRequestURLArg: "http://example.com", HTTPRequestRetriesArg: -1},
+		{HTTPRequestURLArg: "http://example.com", HTTPRequestTimeoutArg: "1 minute"},
+		{HTTPRequestURLArg: "http://example.com", HTTPRequestRunInPodArg: true},
+	} {
+		_, err := parseHTTPRequestArgs(param.TemplateParams{}, args)
+		c.Assert(err, check.NotNil, check.Commentf("Args %v", args))
+	}
+}
+
+func (s *HTTPRequestSuite) TestCurlConfig(c *check.C) {
+	a := s.parseArgs(c, param.TemplateParams{}, map[string]interface{}{
+		HTTPRequestMethodArg:  "PUT",
+		HTTPRequestURLArg:     "https://es:9200/_snapshot/repo/snap",
+		HTTPRequestHeadersArg: map[string]interface{}{"Content-Type": "application/json", "Authorization": "Basic YTpi"},
+		HTTPRequestBodyArg:    "{\"indices\": \"a\\b\"}\n",
+		InsecureTLS:           true,
+		HTTPRequestCACertArg:  "cert",
+		HTTPRequestTimeoutArg: "30s",
+	})
+	c.Assert(curlConfig(a), check.Equals, `silent
+show-error
+url = "https://es:9200/_snapshot/repo/snap"
+max-time = 30
+write-out = "\\n%{http_code}"
+request = "PUT"
+header = "Authorization: Basic YTpi"
+header = "Content-Type: application/json"
+data-raw = "{\"indices\": \"a\\b\"}\n"
+insecure
+cacert = "/tmp/kanister-http-request-ca.pem"
+`)
+
+	a = s.parseArgs(c, param.TemplateParams{}, map[string]interface{}{
+		HTTPRequestMethodArg: "HEAD",
+		HTTPRequestURLArg:    "http://example.com",
+	})
+	c.Assert(curlConfig(a), check.Equals, `silent
+show-error
+url = "http://example.com"
+max-time = 60
+write-out = "\\n%{http_code}"
+head
+output = "/dev/null"
+`)
+}
+
+func (s *HTTPRequestSuite) TestHTTPRequestOutput(c *check.C) {
+	resp := &httpResponse{
+		statusCode: http.StatusOK,
+		body:       []byte(`{"id": 12345678901234567890, "size": 1.5, "name": "` + strings.Repeat("a", maxHTTPRequestBodyOutputSize) + `"}`),
+	}
+	out, err := httpRequestOutput(resp, map[string]string{"id": "{.id}", "size": "{.size}"})
+	c.Assert(err, check.IsNil)
+	c.Assert(out[HTTPRequestFieldsOutput], check.DeepEquals, map[string]interface{}{"id": "12345678901234567890", "size": "1.5"})
+	// The body in the output is truncated
+	c.Assert(out[HTTPRequestBodyOutput], check.HasLen, maxHTTPRequestBodyOutputSize)
+	c.Assert(strings.HasPrefix(string(resp.body), out[HTTPRequestBodyOutput].(string)), check.Equals, true)
+}
+
+func (s *HTTPRequestSuite) TestParseCurlOutput(c *check.C) {
+	resp, err := parseCurlOutput("{\"acknowledged\": true}\n\n200")
+	c.Assert(err, check.IsNil)
+	c.Assert(resp.statusCode, check.Equals, 200)
+	c.Assert(string(resp.body), check.Equals, "{\"acknowledged\": true}\n")
+
+	resp, err = parseCurlOutput("\n404")
+	c.Assert(err, check.IsNil)
+	c.Assert(resp.statusCode, check.Equals, 404)
+	c.Assert(resp.body, check.HasLen, 0)
+
+	_, err = parseCurlOutput("")
+	c.Assert(err, check.NotNil)
+}
@@ -0,0 +1,345 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/kanisterio/errkit"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/consts"
+	"github.com/kanisterio/kanister/pkg/field"
+	"github.com/kanisterio/kanister/pkg/format"
+	"github.com/kanisterio/kanister/pkg/kube"
+	"github.com/kanisterio/kanister/pkg/kube/volume"
+	"github.com/kanisterio/kanister/pkg/log"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/progress"
+	"github.com/kanisterio/kanister/pkg/utils"
+)
+
+func init() {
+	_ = kanister.Register(&importCSISnapshotFunc{})
+}
+
+var (
+	_ kanister.Func = (*importCSISnapshotFunc)(nil)
+)
+
+const (
+	// ImportCSISnapshotFuncName gives the name of the function
+	ImportCSISnapshotFuncName = "ImportCSISnapshot"
+	// ImportCSISnapshotNamespaceArg mentions the namespace of the restored PVC
+	ImportCSISnapshotNamespaceArg = "namespace"
+	// ImportCSISnapshotPVCArg provides the name of the restored PVC
+	ImportCSISnapshotPVCArg = "pvc"
+	// ImportCSISnapshotRestoreSizeArg gives the storage capacity of the restored PVC
+	ImportCSISnapshotRestoreSizeArg = "restoreSize"
+	// ImportCSISnapshotStorageClassArg specifies the StorageClass of the restored PVC
+	ImportCSISnapshotStorageClassArg = "storageClass"
+	// ImportCSISnapshotAccessModesArg lists down the accessmodes of the restored PVC
+	ImportCSISnapshotAccessModesArg = "accessModes"
+	// ImportCSISnapshotImageArg provides the image of the pod that imports the data
+	ImportCSISnapshotImageArg = "image"
+	// ImportCSISnapshotPVCOutput is the key used for returning the name of the restored PVC
+	ImportCSISnapshotPVCOutput = "pvc"
+
+	importCSISnapshotJobPrefix = "import-csi-snapshot-"
+	importCSISnapshotMountPath = "/mnt/import"
+)
+
+type importCSISnapshotFunc struct {
+	progressPercent string
+}
+
+func (*importCSISnapshotFunc) Name() string {
+	return ImportCSISnapshotFuncName
+}
+
+type importCSISnapshotArgs struct {
+	namespace    string
+	pvc          string
+	snapshot     string
+	restoreSize  resource.Quantity
+	storageClass string
+	accessModes  []corev1.PersistentVolumeAccessMode
+	image        string
+	podOverride  crv1alpha1.JSONMap
+	annotations  map[string]string
+	labels       map[string]string
+}
+
+func (i *importCSISnapshotFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	// Set progress percent
+	i.progressPercent = progress.StartedPercent
+	defer func() { i.progressPercent = progress.CompletedPercent }()
+
+	var a importCSISnapshotArgs
+	var restoreSize string
+	var bpAnnotations, bpLabels map[string]string
+	if err := Arg(args, ImportCSISnapshotNamespaceArg, &a.namespace); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, ImportCSISnapshotPVCArg, &a.pvc); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, KopiaSnapshotArg, &a.snapshot); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, ImportCSISnapshotRestoreSizeArg, &restoreSize); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, ImportCSISnapshotStorageClassArg, &a.storageClass, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, ImportCSISnapshotAccessModesArg, &a.accessModes, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}); err != nil {
+		return nil, err
+	}
+	if err := validateVolumeAccessModesArg(a.accessModes); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, ImportCSISnapshotImageArg, &a.image, consts.GetKanisterToolsImage()); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, PodAnnotationsArg, &bpAnnotations, nil); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, PodLabelsArg, &bpLabels, nil); err != nil {
+		return nil, err
+	}
+	podOverride, err := GetPodSpecOverride(tp, args, PodOverrideArg)
+	if err != nil {
+		return nil, err
+	}
+	a.podOverride = podOverride
+
+	a.annotations = bpAnnotations
+	a.labels = bpLabels
+	if tp.PodAnnotations != nil {
+		// merge the actionset annotations with blueprint annotations
+		var actionSetAnn ActionSetAnnotations = tp.PodAnnotations
+		a.annotations = actionSetAnn.MergeBPAnnotations(bpAnnotations)
+	}
+
+	if tp.PodLabels != nil {
+		// merge the actionset labels with blueprint labels
+		var actionSetLabels ActionSetLabels = tp.PodLabels
+		a.labels = actionSetLabels.MergeBPLabels(bpLabels)
+	}
+
+	size, err := resource.ParseQuantity(restoreSize)
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to parse restore size", "restoreSize", restoreSize)
+	}
+	if size.IsZero() {
+		return nil, errkit.New("Failed to import CSI snapshot. restoreSize argument cannot be zero")
+	}
+	a.restoreSize = size
+
+	if err := validateKopiaProfile(tp.Profile); err != nil {
+		return nil, err
+	}
+	if err := validateKopiaSnapshot(a.snapshot); err != nil {
+		return nil, err
+	}
+	kubeCli, err := getClient()
+	if err != nil {
+		return nil, err
+	}
+	return importCSISnapshot(ctx, kubeCli, tp.Profile, a)
+}
+
+// newImportPVCManifest returns the manifest of the empty PVC the kopia
+// snapshot is imported into.
+func newImportPVCManifest(a importCSISnapshotArgs) *corev1.PersistentVolumeClaim {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      a.pvc,
+			Namespace: a.namespace,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: a.accessModes,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: a.restoreSize,
+				},
+			},
+		},
+	}
+	if a.storageClass != "" {
+		pvc.Spec.StorageClassName = &a.storageClass
+	}
+	return pvc
+}
+
+// importCSISnapshot creates a fresh PVC and pulls the kopia snapshot into it.
+// The PVC is deleted if the import fails.
+func importCSISnapshot(ctx context.Context, kubeCli kubernetes.Interface, profile *param.Profile, a importCSISnapshotArgs) (out map[string]interface{}, err error) {
+	if _, err := kubeCli.CoreV1().PersistentVolumeClaims(a.namespace).Create(ctx, newImportPVCManifest(a), metav1.CreateOptions{}); err != nil {
+		return nil, errkit.Wrap(err, "Failed to create PVC", "pvc", a.pvc, "namespace", a.namespace)
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if derr := volume.DeletePVC(kubeCli, a.namespace, a.pvc); derr != nil {
+			log.WithError(derr).Print("Failed to delete PVC after failed import", field.M{"PVC": a.pvc, "Namespace": a.namespace})
+		}
+	}()
+
+	podFunc := func(ctx context.Context, pc kube.PodController) (map[string]interface{}, error) {
+		pod := pc.Pod()
+		if err := pc.WaitForPodReady(ctx); err != nil {
+			return nil, errkit.Wrap(err, "Failed while waiting for Pod to be ready", "pod", pod.Name)
+		}
+		ex, err := pc.GetCommandExecutor()
+		if err != nil {
+			return nil, err
+		}
+		stdin, err := kopiaProfileStdin(profile)
+		if err != nil {
+			return nil, err
+		}
+		var stdout, stderr bytes.Buffer
+		err = ex.Exec(ctx, kopiaPullCommand(a.snapshot, importCSISnapshotMountPath), stdin, &stdout, &stderr)
+		format.LogWithCtx(ctx, pod.Name, pod.Spec.Containers[0].Name, stdout.String())
+		format.LogWithCtx(ctx, pod.Name, pod.Spec.Containers[0].Name, stderr.String())
+		if err != nil {
+			return nil, errkit.Wrap(err, "Failed to import kopia snapshot", "pvc", a.pvc)
+		}
+		return map[string]interface{}{
+			ImportCSISnapshotPVCOutput: a.pvc,
+		}, nil
+	}
+	return PrepareAndRunPod(
+		ctx,
+		kubeCli,
+		a.namespace,
+		importCSISnapshotJobPrefix,
+		a.image,
+		[]string{"sh", "-c", "tail -f /dev/null"},
+		map[string]string{a.pvc: importCSISnapshotMountPath},
+		a.podOverride,
+		a.annotations,
+		a.labels,
+		podFunc,
+	)
+}
+
+func (*importCSISnapshotFunc) RequiredArgs() []string {
+	return []string{
+		ImportCSISnapshotNamespaceArg,
+		ImportCSISnapshotPVCArg,
+		KopiaSnapshotArg,
+		ImportCSISnapshotRestoreSizeArg,
+	}
+}
+
+func (*importCSISnapshotFunc) Arguments() []string {
+	return []string{
+		ImportCSISnapshotNamespaceArg,
+		ImportCSISnapshotPVCArg,
+		KopiaSnapshotArg,
+		ImportCSISnapshotRestoreSizeArg,
+		ImportCSISnapshotStorageClassArg,
+		ImportCSISnapshotAccessModesArg,
+		ImportCSISnapshotImageArg,
+		PodOverrideArg,
+		PodAnnotationsArg,
+		PodLabelsArg,
+	}
+}
+
+func (*importCSISnapshotFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        ImportCSISnapshotFuncName,
+		Description: "Imports a VolumeSnapshot exported with ExportCSISnapshot into a new PVC",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        ImportCSISnapshotNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the restored PVC",
+			},
+			{
+				Name:        ImportCSISnapshotPVCArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the restored PVC, must not exist",
+			},
+			{
+				Name:        KopiaSnapshotArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Kopia snapshot returned by ExportCSISnapshot",
+			},
+			{
+				Name:        ImportCSISnapshotRestoreSizeArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Storage capacity of the restored PVC, e.g. `1Gi`",
+			},
+			{
+				Name:        ImportCSISnapshotStorageClassArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Name of the StorageClass of the restored PVC, defaults to the default StorageClass",
+			},
+			{
+				Name:        ImportCSISnapshotAccessModesArg,
+				Type:        kanister.ArgTypeList,
+				Description: "Access modes of the restored PVC, defaults to `ReadWriteOnce`",
+			},
+			{
+				Name:        ImportCSISnapshotImageArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Image of the pod that imports the data, needs to have kando installed",
+			},
+			podOverrideArgSchema,
+			podAnnotationsArgSchema,
+			podLabelsArgSchema,
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: ImportCSISnapshotPVCOutput, Type: kanister.ArgTypeString, Description: "Name of the restored PVC"},
+		},
+	}
+}
+
+func (i *importCSISnapshotFunc) Validate(args map[string]any) error {
+	if err := ValidatePodLabelsAndAnnotations(i.Name(), args); err != nil {
+		return err
+	}
+
+	if err := utils.CheckSupportedArgs(i.Arguments(), args); err != nil {
+		return err
+	}
+
+	return utils.CheckRequiredArgs(i.RequiredArgs(), args)
+}
+
+func (i *importCSISnapshotFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
+	metav1Time := metav1.NewTime(time.Now())
+	return crv1alpha1.PhaseProgress{
+		ProgressPercent:    i.progressPercent,
+		LastTransitionTime: &metav1Time,
+	}, nil
+}
@@ -0,0 +1,246 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/kanisterio/errkit"
+
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/kopia/snapshot"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/progress"
+)
+
+const (
+	// KopiaSnapshotArg provides the kopia snapshot produced by BackupDataUsingKopia
+	KopiaSnapshotArg = "snapshot"
+	// KopiaSnapshotOutput is the key used for returning the kopia snapshot
+	KopiaSnapshotOutput = "snapshot"
+	// KopiaSnapshotIDOutput is the key used for returning the ID of the kopia snapshot
+	KopiaSnapshotIDOutput = "snapshotID"
+	// KopiaSnapshotSizeOutput is the key used for returning the size of the kopia snapshot
+	KopiaSnapshotSizeOutput = "size"
+
+	// kopiaSnapshotKandoOutput is the key of the kopia snapshot printed by `kando location push`
+	kopiaSnapshotKandoOutput = "kopiaSnapshot"
+	// kopiaChecksumKandoOutput is the key of the checksum printed by `kando location push-block` and `pull-block`
+	kopiaChecksumKandoOutput = "checksum"
+	// kopiaRemovedSnapshotsKandoOutput is the key of the snapshots removed by `kando location apply-retention`
+	kopiaRemovedSnapshotsKandoOutput = "removedSnapshots"
+)
+
+// validateKopiaProfile checks that the profile points to a kopia repository
+// server and has its credentials.
+func validateKopiaProfile(profile *param.Profile) error {
+	if err := ValidateProfile(profile); err != nil {
+		return errkit.Wrap(err, "Failed to validate Profile")
+	}
+	if profile.Location.Type != crv1alpha1.LocationTypeKopia || profile.Credential.Type != param.CredentialTypeKopia {
+		return errkit.New(fmt.Sprintf("Profile must have a %s location and %s credentials", crv1alpha1.LocationTypeKopia, param.CredentialTypeKopia))
+	}
+	return nil
+}
+
+// kopiaProfileStdin returns the profile to pass to kando on stdin, so the kopia
+// server credentials aren't part of the command.
+func kopiaProfileStdin(profile *param.Profile) (*bytes.Reader, error) {
+	b, err := json.Marshal(profile)
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to marshal Profile")
+	}
+	return bytes.NewReader(b), nil
+}
+
+// kopiaPushCommand snapshots includePath to the kopia repository server.
+// The profile is read from stdin.
+func kopiaPushCommand(includePath string) []string {
+	return []string{
+		"sh", "-c",
+		`kando location push --profile "$(cat)" --output-name ` + kopiaSnapshotKandoOutput + ` "$1"`,
+		"sh", includePath,
+	}
+}
+
+// kopiaCheckCommand checks that the kopia repository server can be reached
+// and the repository can be opened. The profile is read from stdin.
+func kopiaCheckCommand() []string {
+	return []string{
+		"sh", "-c",
+		`kando location check --profile "$(cat)"`,
+	}
+}
+
+// kopiaPullCommand restores the kopia snapshot to restorePath. The profile
+// is read from stdin.
+func kopiaPullCommand(snapshotJSON, restorePath string) []string {
+	return []string{
+		"sh", "-c",
+		`kando location pull --profile "$(cat)" --kopia-snapshot "$1" "$2"`,
+		"sh", snapshotJSON, restorePath,
+	}
+}
+
+// kopiaStreamPullCommand writes the data of the streaming file at path in the
+// kopia snapshot to stdout. The profile is read from stdin.
+func kopiaStreamPullCommand(snapshotJSON, path string) []string {
+	return []string{
+		"sh", "-c",
+		`kando location pull --profile "$(cat)" --kopia-snapshot "$1" --path "$2" -`,
+		"sh", snapshotJSON, path,
+	}
+}
+
+// kopiaStreamPushCommand snapshots the data read from stdin as a streaming
+// file at path. The profile is read from the first line of stdin.
+func kopiaStreamPushCommand(path string) []string {
+	return []string{
+		"sh", "-c",
+		`read -r profile && cat | kando location push --profile "$profile" --path "$1" --output-name ` + kopiaSnapshotKandoOutput + ` -`,
+		"sh", path,
+	}
+}
+
+// kopiaDeleteCommand deletes the kopia snapshot. The profile is read from
+// stdin.
+func kopiaDeleteCommand(snapshotJSON string) []string {
+	return []string{
+		"sh", "-c",
+		`kando location delete --profile "$(cat)" --kopia-snapshot "$1"`,
+		"sh", snapshotJSON,
+	}
+}
+
+// kopiaPushBlockCommand snapshots the block device to the kopia repository
+// server as a streaming file at path. The profile is read from stdin.
+func kopiaPushBlockCommand(path, device string) []string {
+	return []string{
+		"sh", "-c",
+		`kando location push-block --profile "$(cat)" --path "$1" --output-name ` + kopiaSnapshotKandoOutput + ` "$2"`,
+		"sh", path, device,
+	}
+}
+
+// kopiaPullBlockCommand writes the kopia snapshot back to the block device
+// and verifies it. The profile is read from stdin.
+func kopiaPullBlockCommand(snapshotJSON, path, checksum, device string, skipZeroes bool) []string {
+	script := `kando location pull-block --profile "$(cat)" --kopia-snapshot "$1" --path "$2" --checksum "$3"`
+	if skipZeroes {
+		script += " --skip-zeroes"
+	}
+	return []string{"sh", "-c", script + ` "$4"`, "sh", snapshotJSON, path, checksum, device}
+}
+
+// kopiaSnapshotFromLog returns the kopia snapshot printed by `kando location push`.
+func kopiaSnapshotFromLog(ctx context.Context, stdout string) (string, *snapshot.SnapshotInfo, error) {
+	out, err := parseLogAndCreateOutput(ctx, stdout)
+	if err != nil {
+		return "", nil, err
+	}
+	snapJSON, ok := out[kopiaSnapshotKandoOutput].(string)
+	if !ok {
+		return "", nil, errkit.New("Failed to find kopia snapshot in the backup logs")
+	}
+	snapInfo, err := snapshot.UnmarshalKopiaSnapshot(snapJSON)
+	if err != nil {
+		return "", nil, err
+	}
+	return snapJSON, &snapInfo, nil
+}
+
+// validateKopiaSnapshot checks that the snapshot arg is a kopia snapshot
+// produced by BackupDataUsingKopia.
+func validateKopiaSnapshot(snapJSON string) error {
+	_, err := snapshot.UnmarshalKopiaSnapshot(snapJSON)
+	return errkit.Wrap(err, "Invalid kopia snapshot", "arg", KopiaSnapshotArg)
+}
+
+// kopiaUploadProgress tracks the upload progress of kopia snapshots that are
+// created in parallel, from the progress printed by `kando location push`.
+type kopiaUploadProgress struct {
+	mu       sync.Mutex
+	percents []int
+}
+
+// reset starts tracking the progress of n snapshots.
+func (p *kopiaUploadProgress) reset(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.percents = make([]int, n)
+}
+
+func (p *kopiaUploadProgress) set(i, percent int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if i < len(p.percents) {
+		p.percents[i] = percent
+	}
+}
+
+// percent returns the average progress of the snapshots.
+func (p *kopiaUploadProgress) percent() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.percents) == 0 {
+		return progress.StartedPercent
+	}
+	sum := 0
+	for _, percent := range p.percents {
+		sum += percent
+	}
+	return strconv.Itoa(sum / len(p.percents))
+}
+
+// writer returns a writer for the stdout of the push of the i-th snapshot,
+// which updates its progress.
+func (p *kopiaUploadProgress) writer(i int) *kopiaProgressWriter {
+	return &kopiaProgressWriter{onProgress: func(percent int) { p.set(i, percent) }}
+}
+
+// maxKopiaProgressLineLen is the length of the partial line kept by
+// kopiaProgressWriter.
+const maxKopiaProgressLineLen = 1024
+
+// kopiaProgressWriter calls onProgress for each line with the upload progress
+// written to it.
+type kopiaProgressWriter struct {
+	onProgress func(percent int)
+	line       []byte
+}
+
+func (w *kopiaProgressWriter) Write(b []byte) (int, error) {
+	w.line = append(w.line, b...)
+	for {
+		i := bytes.IndexByte(w.line, '\n')
+		if i < 0 {
+			break
+		}
+		if percent, ok := snapshot.ParseUploadProgress(string(w.line[:i])); ok {
+			w.onProgress(percent)
+		}
+		w.line = w.line[i+1:]
+	}
+	// The progress lines are short, longer lines don't need to be kept.
+	if len(w.line) > maxKopiaProgressLineLen {
+		w.line = w.line[:0]
+	}
+	return len(b), nil
+}
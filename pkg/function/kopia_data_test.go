@@ -0,0 +1,271 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/kanisterio/errkit"
+	"gopkg.in/check.v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/kopia/snapshot"
+	"github.com/kanisterio/kanister/pkg/output"
+	"github.com/kanisterio/kanister/pkg/param"
+)
+
+type KopiaDataSuite struct{}
+
+var _ = check.Suite(&KopiaDataSuite{})
+
+func kopiaTestProfile() *param.Profile {
+	return &param.Profile{
+		Location: crv1alpha1.Location{
+			Type:     crv1alpha1.LocationTypeKopia,
+			Endpoint: "https://kopia-server:51515",
+		},
+		Credential: param.Credential{
+			Type: param.CredentialTypeKopia,
+			KopiaServerSecret: &param.KopiaServerCreds{
+				Username: "user",
+				Hostname: "host",
+				Password: "pass'word $(id)",
+				Cert:     "cert",
+			},
+		},
+	}
+}
+
+func (s *KopiaDataSuite) TestValidateKopiaProfile(c *check.C) {
+	c.Assert(validateKopiaProfile(kopiaTestProfile()), check.IsNil)
+
+	p := kopiaTestProfile()
+	p.Location.Type = crv1alpha1.LocationTypeS3Compliant
+	c.Assert(validateKopiaProfile(p), check.ErrorMatches, "Profile must have a kopia location and kopia credentials")
+
+	p = kopiaTestProfile()
+	p.Credential.KopiaServerSecret.Password = ""
+	c.Assert(validateKopiaProfile(p), check.ErrorMatches, "Failed to validate Profile.*UserPassphrase is not set.*")
+
+	c.Assert(validateKopiaProfile(nil), check.NotNil)
+}
+
+func (s *KopiaDataSuite) TestKopiaSnapshotFromLog(c *check.C) {
+	snapJSON := `{"id":"k123","logicalSize":42,"physicalSize":0}`
+	var log bytes.Buffer
+	log.WriteString("Snapshotting /data\n")
+	c.Assert(output.PrintOutputTo(&log, kopiaSnapshotKandoOutput, snapJSON), check.IsNil)
+
+	out, snapInfo, err := kopiaSnapshotFromLog(context.Background(), log.String())
+	c.Assert(err, check.IsNil)
+	c.Assert(out, check.Equals, snapJSON)
+	c.Assert(snapInfo.ID, check.Equals, "k123")
+	c.Assert(snapInfo.LogicalSize, check.Equals, int64(42))
+
+	_, _, err = kopiaSnapshotFromLog(context.Background(), "Snapshotting /data\n")
+	c.Assert(err, check.ErrorMatches, "Failed to find kopia snapshot in the backup logs")
+
+	c.Assert(validateKopiaSnapshot(snapJSON), check.IsNil)
+	c.Assert(validateKopiaSnapshot(`{"id":""}`), check.ErrorMatches, "Invalid kopia snapshot.*")
+}
+
+// TestKopiaCommands runs the commands with a kando stub that prints its
+// arguments and stdin, to check that the profile is only passed on stdin.
+func (s *KopiaDataSuite) TestKopiaCommands(c *check.C) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		c.Skip("sh is not available")
+	}
+	dir := c.MkDir()
+	stub := "#!/bin/sh\nfor a in \"$@\"; do echo \"arg:$a\"; done\n"
+	c.Assert(os.WriteFile(filepath.Join(dir, "kando"), []byte(stub), 0o755), check.IsNil)
+
+	profile := kopiaTestProfile()
+	snapJSON := `{"id":"k123","logicalSize":42,"physicalSize":0}`
+	for _, tc := range []struct {
+		cmd      []string
+		expected []string
+	}{
+		{
+			cmd:      kopiaPushCommand("/data dir"),
+			expected: []string{"location", "push", "--profile", "PROFILE", "--output-name", "kopiaSnapshot", "/data dir"},
+		},
+		{
+			cmd:      kopiaPullCommand(snapJSON, "/restore"),
+			expected: []string{"location", "pull", "--profile", "PROFILE", "--kopia-snapshot", snapJSON, "/restore"},
+		},
+		{
+			cmd:      kopiaStreamPullCommand(snapJSON, "/mysql-backups/dump.sql"),
+			expected: []string{"location", "pull", "--profile", "PROFILE", "--kopia-snapshot", snapJSON, "--path", "/mysql-backups/dump.sql", "-"},
+		},
+		{
+			cmd:      kopiaCheckCommand(),
+			expected: []string{"location", "check", "--profile", "PROFILE"},
+		},
+		{
+			cmd:      kopiaDeleteCommand(snapJSON),
+			expected: []string{"location", "delete", "--profile", "PROFILE", "--kopia-snapshot", snapJSON},
+		},
+		{
+			cmd:      kopiaPushBlockCommand(blockVolumeSnapshotPath("ns", "pvc"), blockVolumeDevicePath),
+			expected: []string{"location", "push-block", "--profile", "PROFILE", "--path", "/kanister-block/ns/pvc/device", "--output-name", "kopiaSnapshot", "/dev/kanister-block"},
+		},
+		{
+			cmd:      kopiaPullBlockCommand(snapJSON, "/kanister-block/ns/pvc/device", "abc", blockVolumeDevicePath, false),
+			expected: []string{"location", "pull-block", "--profile", "PROFILE", "--kopia-snapshot", snapJSON, "--path", "/kanister-block/ns/pvc/device", "--checksum", "abc", "/dev/kanister-block"},
+		},
+		{
+			cmd:      kopiaPullBlockCommand(snapJSON, "/kanister-block/ns/pvc/device", "", blockVolumeDevicePath, true),
+			expected: []string{"location", "pull-block", "--profile", "PROFILE", "--kopia-snapshot", snapJSON, "--path", "/kanister-block/ns/pvc/device", "--checksum", "", "--skip-zeroes", "/dev/kanister-block"},
+		},
+	} {
+		c.Assert(strings.Join(tc.cmd, " "), check.Not(check.Matches), ".*pass'word.*")
+		stdin, err := kopiaProfileStdin(profile)
+		c.Assert(err, check.IsNil)
+		profileJSON := make([]byte, stdin.Len())
+		_, err = stdin.ReadAt(profileJSON, 0)
+		c.Assert(err, check.IsNil)
+
+		cmd := exec.Command(tc.cmd[0], tc.cmd[1:]...)
+		cmd.Env = append(os.Environ(), "PATH="+dir+":"+os.Getenv("PATH"))
+		cmd.Stdin = stdin
+		out, err := cmd.CombinedOutput()
+		c.Assert(err, check.IsNil, check.Commentf("%s", out))
+
+		var args []string
+		for _, l := range strings.Split(strings.TrimSuffix(string(out), "\n"), "\n") {
+			args = append(args, strings.TrimPrefix(l, "arg:"))
+		}
+		for i, a := range tc.expected {
+			if a == "PROFILE" {
+				tc.expected[i] = string(profileJSON)
+			}
+		}
+		c.Assert(args, check.DeepEquals, tc.expected)
+	}
+}
+
+// TestKopiaStreamPushCommand checks that the push command reads the profile
+// from the first line of stdin and pipes the rest of stdin to kando.
+func (s *KopiaDataSuite) TestKopiaStreamPushCommand(c *check.C) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		c.Skip("sh is not available")
+	}
+	dir := c.MkDir()
+	stub := "#!/bin/sh\nfor a in \"$@\"; do echo \"arg:$a\"; done\n[ -p /dev/stdin ] && echo \"data:$(cat)\"\n"
+	c.Assert(os.WriteFile(filepath.Join(dir, "kando"), []byte(stub), 0o755), check.IsNil)
+
+	stdin, err := kopiaProfileStdin(kopiaTestProfile())
+	c.Assert(err, check.IsNil)
+	profileJSON, err := io.ReadAll(stdin)
+	c.Assert(err, check.IsNil)
+
+	cmd := kopiaStreamPushCommand("/mysql-backups/dump.sql")
+	c.Assert(strings.Join(cmd, " "), check.Not(check.Matches), ".*pass'word.*")
+	ex := exec.Command(cmd[0], cmd[1:]...)
+	ex.Env = append(os.Environ(), "PATH="+dir+":"+os.Getenv("PATH"))
+	ex.Stdin = io.MultiReader(bytes.NewReader(profileJSON), strings.NewReader("\nline 1\nline 2"))
+	out, err := ex.CombinedOutput()
+	c.Assert(err, check.IsNil, check.Commentf("%s", out))
+	c.Assert(string(out), check.Equals, strings.Join([]string{
+		"arg:location", "arg:push", "arg:--profile", "arg:" + string(profileJSON), "arg:--path", "arg:/mysql-backups/dump.sql",
+		"arg:--output-name", "arg:kopiaSnapshot", "arg:-", "data:line 1", "line 2",
+	}, "\n")+"\n")
+}
+
+func (s *KopiaDataSuite) TestKopiaUploadProgress(c *check.C) {
+	var p kopiaUploadProgress
+	c.Assert(p.percent(), check.Equals, "0")
+	p.reset(2)
+	w0, w1 := p.writer(0), p.writer(1)
+
+	// Lines can be split across writes and other lines are ignored.
+	_, err := io.WriteString(w0, "Snapshotting /data ...\n"+snapshot.UploadProgressPrefix+" 4")
+	c.Assert(err, check.IsNil)
+	c.Assert(p.percent(), check.Equals, "0")
+	_, err = io.WriteString(w0, "0\n")
+	c.Assert(err, check.IsNil)
+	c.Assert(p.percent(), check.Equals, "20")
+
+	_, err = io.WriteString(w1, snapshot.UploadProgressPrefix+" 10\n"+snapshot.UploadProgressPrefix+" 90\n")
+	c.Assert(err, check.IsNil)
+	c.Assert(p.percent(), check.Equals, "65")
+
+	// Long lines without a newline aren't kept.
+	_, err = io.WriteString(w1, strings.Repeat("x", 2*maxKopiaProgressLineLen))
+	c.Assert(err, check.IsNil)
+	c.Assert(len(w1.line), check.Equals, 0)
+}
+
+func (s *KopiaDataSuite) TestKopiaCheckRepositoryOutputs(c *check.C) {
+	for _, tc := range []struct {
+		err               error
+		stderr            string
+		passwordIncorrect string
+		repoUnavailable   string
+		errMsg            string
+	}{
+		{passwordIncorrect: "false", repoUnavailable: "false"},
+		{
+			err:               errkit.New("command terminated with exit code 1"),
+			stderr:            "Failed connecting to the Kopia API Server: rpc error: code = PermissionDenied desc = access denied for user@host",
+			passwordIncorrect: "true",
+			repoUnavailable:   "false",
+		},
+		{
+			err:               errkit.New("command terminated with exit code 1"),
+			stderr:            "Failed connecting to the Kopia API Server: dial tcp: connection refused",
+			passwordIncorrect: "false",
+			repoUnavailable:   "true",
+		},
+		{
+			err:    errkit.New("command terminated with exit code 1"),
+			stderr: "Failed to list kopia snapshot sources",
+			errMsg: "Failed to check kopia repository.*",
+		},
+	} {
+		out, err := kopiaCheckRepositoryOutputs(tc.err, tc.stderr)
+		if tc.errMsg != "" {
+			c.Assert(err, check.ErrorMatches, tc.errMsg)
+			continue
+		}
+		c.Assert(err, check.IsNil)
+		c.Assert(out[CheckRepositoryPasswordIncorrect], check.Equals, tc.passwordIncorrect)
+		c.Assert(out[CheckRepositoryRepoDoesNotExist], check.Equals, tc.repoUnavailable)
+	}
+}
+
+func (s *KopiaDataSuite) TestRunBlockVolumePodRequiresBlockPVC(c *check.C) {
+	filesystem := corev1.PersistentVolumeFilesystem
+	cli := fake.NewSimpleClientset(&corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pvc"},
+		Spec:       corev1.PersistentVolumeClaimSpec{VolumeMode: &filesystem},
+	})
+	a := blockVolumePodArgs{namespace: "ns", pvc: "pvc"}
+	_, err := runBlockVolumePod(context.Background(), cli, a, backupBlockVolumeJobPrefix, kopiaTestProfile(), nil)
+	c.Assert(err, check.ErrorMatches, "PVC must have volumeMode Block.*")
+
+	a.pvc = "missing"
+	_, err = runBlockVolumePod(context.Background(), cli, a, backupBlockVolumeJobPrefix, kopiaTestProfile(), nil)
+	c.Assert(err, check.ErrorMatches, "Failed to retrieve PVC.*")
+}
@@ -18,7 +18,6 @@ import (
 	"bytes"
 	"context"
 	"io"
-	"os"
 	"strings"
 	"time"
 
@@ -26,6 +25,7 @@ import (
 
 	kanister "github.com/kanisterio/kanister/pkg"
 	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/format"
 	"github.com/kanisterio/kanister/pkg/kube"
 	"github.com/kanisterio/kanister/pkg/output"
 	"github.com/kanisterio/kanister/pkg/param"
@@ -58,13 +58,15 @@ func (*kubeExecFunc) Name() string {
 	return KubeExecFuncName
 }
 
-func parseLogAndCreateOutput(out string) (map[string]interface{}, error) {
+// parseLogAndCreateOutput logs the lines of out, passed through the
+// redaction function of ctx, and returns the phase outputs found in them.
+func parseLogAndCreateOutput(ctx context.Context, out string) (map[string]interface{}, error) {
 	if out == "" {
 		return nil, nil
 	}
 
 	reader := io.NopCloser(strings.NewReader(out))
-	output, err := output.LogAndParse(context.Background(), reader)
+	output, err := output.LogAndParse(ctx, reader)
 
 	// For some reason we expect empty output to be returned as nil here
 	if len(output) == 0 {
@@ -97,15 +99,18 @@ func (kef *kubeExecFunc) Exec(ctx context.Context, tp param.TemplateParams, args
 		return nil, err
 	}
 
-	var (
-		bufStdout  = &bytes.Buffer{}
-		outWriters = io.MultiWriter(os.Stdout, bufStdout)
-	)
-	if err := kube.ExecOutput(ctx, cli, namespace, pod, container, cmd, nil, outWriters, os.Stderr); err != nil {
+	// The command output may contain the values of the options that were set
+	// from Secrets, so it's redacted before it's logged.
+	ctx = output.WithRedaction(ctx, tp.Redact)
+	var bufStdout, bufStderr bytes.Buffer
+	err = kube.ExecOutput(ctx, cli, namespace, pod, container, cmd, nil, &bufStdout, &bufStderr)
+	format.LogWithCtx(ctx, pod, container, bufStderr.String())
+	if err != nil {
+		format.LogWithCtx(ctx, pod, container, bufStdout.String())
 		return nil, err
 	}
 
-	return parseLogAndCreateOutput(bufStdout.String())
+	return parseLogAndCreateOutput(ctx, bufStdout.String())
 }
 
 func (*kubeExecFunc) RequiredArgs() []string {
@@ -125,6 +130,38 @@ func (*kubeExecFunc) Arguments() []string {
 	}
 }
 
+func (*kubeExecFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        KubeExecFuncName,
+		Description: "Executes a command in a container of a running pod. Key-value pairs printed with `kando output` become outputs",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        KubeExecNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the pod",
+			},
+			{
+				Name:        KubeExecPodNameArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the pod",
+			},
+			{
+				Name:        KubeExecCommandArg,
+				Type:        kanister.ArgTypeList,
+				Required:    true,
+				Description: "Command to execute",
+			},
+			{
+				Name:        KubeExecContainerNameArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Name of the container, defaults to the first container of the pod",
+			},
+		},
+	}
+}
+
 func (kef *kubeExecFunc) Validate(args map[string]any) error {
 	if err := utils.CheckSupportedArgs(kef.Arguments(), args); err != nil {
 		return err
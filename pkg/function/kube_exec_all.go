@@ -102,6 +102,39 @@ func (*kubeExecAllFunc) Arguments() []string {
 	}
 }
 
+func (*kubeExecAllFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        KubeExecAllFuncName,
+		Description: "Executes a command in containers of multiple running pods",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        KubeExecAllNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the pods",
+			},
+			{
+				Name:        KubeExecAllPodsNameArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Space separated names of the pods",
+			},
+			{
+				Name:        KubeExecAllContainersNameArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Space separated names of the containers",
+			},
+			{
+				Name:        KubeExecAllCommandArg,
+				Type:        kanister.ArgTypeList,
+				Required:    true,
+				Description: "Command to execute",
+			},
+		},
+	}
+}
+
 func (kef *kubeExecAllFunc) Validate(args map[string]any) error {
 	if err := utils.CheckSupportedArgs(kef.Arguments(), args); err != nil {
 		return err
@@ -142,7 +175,7 @@ func execAll(ctx context.Context, cli kubernetes.Interface, namespace string, ps
 	if len(errs) != 0 {
 		return nil, errkit.New(strings.Join(errs, "\n"))
 	}
-	out, err := parseLogAndCreateOutput(output)
+	out, err := parseLogAndCreateOutput(ctx, output)
 	if err != nil {
 		return nil, err
 	}
@@ -187,7 +187,7 @@ func (s *KubeExecTest) TestParseLogAndCreateOutput(c *check.C) {
 		{"###Phase-output###: Invalid message", nil, check.NotNil, check.IsNil},
 		{"Random message", nil, check.IsNil, check.IsNil},
 	} {
-		out, err := parseLogAndCreateOutput(tc.log)
+		out, err := parseLogAndCreateOutput(context.Background(), tc.log)
 		c.Check(err, tc.errChecker)
 		c.Check(out, tc.outChecker)
 		if out != nil {
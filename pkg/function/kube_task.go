@@ -67,6 +67,8 @@ type kubeTaskConfig struct {
 	// (e.g. minting a short-lived cloud credential into an ephemeral Secret and
 	// projecting it into the pod) against this pod's options.
 	tp *param.TemplateParams
+	// redact, when set, is applied to the pod logs before they are logged.
+	redact func(string) string
 }
 
 // withPodCredentialInjection runs the registered ephemeral pod credential
@@ -83,6 +85,12 @@ func withPodCredentialInjection(tp param.TemplateParams) kubeTaskOption {
 	return func(c *kubeTaskConfig) { c.tp = &tp }
 }
 
+// withLogRedaction redacts the values of the options that were set from
+// Secrets from the logs of the pod.
+func withLogRedaction(tp param.TemplateParams) kubeTaskOption {
+	return func(c *kubeTaskConfig) { c.redact = tp.Redact }
+}
+
 func kubeTask(
 	ctx context.Context,
 	cli kubernetes.Interface,
@@ -129,11 +137,11 @@ func kubeTask(
 	// Mark pod with label having key `kanister.io/JobID`, the value of which is a reference to the origin of the pod.
 	kube.AddLabelsToPodOptionsFromContext(ctx, options, path.Join(consts.LabelPrefix, consts.LabelSuffixJobID))
 	pr := kube.NewPodRunner(cli, options)
-	podFunc := kubeTaskPodFunc()
+	podFunc := kubeTaskPodFunc(cfg.redact)
 	return pr.Run(ctx, podFunc)
 }
 
-func kubeTaskPodFunc() func(ctx context.Context, pc kube.PodController) (map[string]interface{}, error) {
+func kubeTaskPodFunc(redact func(string) string) func(ctx context.Context, pc kube.PodController) (map[string]interface{}, error) {
 	return func(ctx context.Context, pc kube.PodController) (map[string]interface{}, error) {
 		if err := pc.WaitForPodReady(ctx); err != nil {
 			return nil, errkit.Wrap(err, "Failed while waiting for Pod to be ready", "pod", pc.PodName())
@@ -144,7 +152,7 @@ func kubeTaskPodFunc() func(ctx context.Context, pc kube.PodController) (map[str
 		if err != nil {
 			return nil, errkit.Wrap(err, "Failed to fetch logs from the pod")
 		}
-		out, err := output.LogAndParse(ctx, r)
+		out, err := output.LogAndParseRedacted(ctx, r, redact)
 		if err != nil {
 			return nil, err
 		}
@@ -214,6 +222,7 @@ func (ktf *kubeTaskFunc) Exec(ctx context.Context, tp param.TemplateParams, args
 		annotations,
 		labels,
 		withPodCredentialInjection(tp),
+		withLogRedaction(tp),
 	)
 }
 
@@ -235,6 +244,35 @@ func (*kubeTaskFunc) Arguments() []string {
 	}
 }
 
+func (*kubeTaskFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        KubeTaskFuncName,
+		Description: "Runs a command in a new pod. Key-value pairs printed with `kando output` become outputs",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        KubeTaskImageArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Image of the pod",
+			},
+			{
+				Name:        KubeTaskCommandArg,
+				Type:        kanister.ArgTypeList,
+				Required:    true,
+				Description: "Command to run",
+			},
+			{
+				Name:        KubeTaskNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Namespace to create the pod in, defaults to the namespace of the controller",
+			},
+			podOverrideArgSchema,
+			podAnnotationsArgSchema,
+			podLabelsArgSchema,
+		},
+	}
+}
+
 func (ktf *kubeTaskFunc) Validate(args map[string]any) error {
 	if err := ValidatePodLabelsAndAnnotations(ktf.Name(), args); err != nil {
 		return err
@@ -27,6 +27,7 @@ import (
 
 	kanister "github.com/kanisterio/kanister/pkg"
 	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/jsonpath"
 	"github.com/kanisterio/kanister/pkg/kube"
 	"github.com/kanisterio/kanister/pkg/param"
 	"github.com/kanisterio/kanister/pkg/progress"
@@ -52,6 +53,22 @@ const (
 	KubeOpsObjectReferenceArg = "objectReference"
 	// KubeOpsOperationArg is the kubeops operation needs to be executed
 	KubeOpsOperationArg = "operation"
+	// KubeOpsFieldManagerArg is the field manager of the apply operation
+	KubeOpsFieldManagerArg = "fieldManager"
+	// KubeOpsForceConflictsArg takes over fields owned by other field managers in the apply operation
+	KubeOpsForceConflictsArg = "forceConflicts"
+	// KubeOpsFieldsArg maps output names to jsonpaths of the fields returned by the get operation
+	KubeOpsFieldsArg = "fields"
+	// KubeOpsWaitForArg is the condition to wait for after the operation, e.g. `condition=Available`
+	KubeOpsWaitForArg = "waitFor"
+	// KubeOpsWaitTimeoutArg is the timeout of waiting for the condition
+	KubeOpsWaitTimeoutArg = "waitTimeout"
+	// KubeOpsObjectsOutput is the output with the references of all resources of a multi-document spec
+	KubeOpsObjectsOutput = "objects"
+	// KubeOpsFieldsOutput is the output with the fields returned by the get operation
+	KubeOpsFieldsOutput = "fields"
+
+	defaultKubeOpsWaitTimeout = "5m"
 )
 
 type kubeops struct {
@@ -62,71 +79,210 @@ func (*kubeops) Name() string {
 	return KubeOpsFuncName
 }
 
+// kubeopsArgs are the args of KubeOps
+type kubeopsArgs struct {
+	spec        string
+	op          kube.Operation
+	namespace   string
+	objRef      crv1alpha1.ObjectReference
+	apply       kube.ApplyOptions
+	fields      map[string]string
+	waitFor     string
+	waitTimeout string
+}
+
 func (k *kubeops) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
 	// Set progress percent
 	k.progressPercent = progress.StartedPercent
 	defer func() { k.progressPercent = progress.CompletedPercent }()
 
-	var spec, namespace string
-	var op kube.Operation
-	var objRefArg crv1alpha1.ObjectReference
-	if err := OptArg(args, KubeOpsSpecArg, &spec, ""); err != nil {
-		return nil, err
-	}
-	if err := Arg(args, KubeOpsOperationArg, &op); err != nil {
-		return nil, err
-	}
-	if err := OptArg(args, KubeOpsNamespaceArg, &namespace, metav1.NamespaceDefault); err != nil {
+	ka, err := parseKubeOpsArgs(args)
+	if err != nil {
 		return nil, err
 	}
-	if ArgExists(args, KubeOpsObjectReferenceArg) {
-		if err := OptArg(args, KubeOpsObjectReferenceArg, &objRefArg, nil); err != nil {
+	var cond *kube.WaitCondition
+	if ka.waitFor != "" {
+		if ka.op == kube.DeleteOperation {
+			return nil, errkit.New(fmt.Sprintf("%s is not supported for %s operation", KubeOpsWaitForArg, kube.DeleteOperation))
+		}
+		c, err := kube.ParseWaitCondition(ka.waitFor)
+		if err != nil {
 			return nil, err
 		}
+		cond = &c
+	}
+	if len(ka.fields) != 0 && ka.op != kube.GetOperation {
+		return nil, errkit.New(fmt.Sprintf("%s is only supported for %s operation", KubeOpsFieldsArg, kube.GetOperation))
 	}
 	dynCli, err := kube.NewDynamicClient()
 	if err != nil {
 		return nil, err
 	}
-	objRef, err := execKubeOperation(ctx, dynCli, op, namespace, spec, objRefArg)
+	objRefs, err := execKubeOperation(ctx, dynCli, ka)
 	if err != nil {
 		return nil, err
 	}
-	objRefJSON, err := json.Marshal(objRef)
-	if err != nil {
-		return nil, err
+	if cond != nil {
+		if err := waitForKubeOpsCondition(ctx, dynCli, objRefs, *cond, ka.waitTimeout); err != nil {
+			return nil, err
+		}
 	}
-	// Convert objRef to map[string]interface{}
-	var out map[string]interface{}
-	if err := json.Unmarshal(objRefJSON, &out); err != nil {
-		return nil, err
+	return kubeOpsOutput(ctx, dynCli, objRefs, ka)
+}
+
+func parseKubeOpsArgs(args map[string]interface{}) (kubeopsArgs, error) {
+	var ka kubeopsArgs
+	if err := OptArg(args, KubeOpsSpecArg, &ka.spec, ""); err != nil {
+		return ka, err
 	}
-	return out, nil
+	if err := Arg(args, KubeOpsOperationArg, &ka.op); err != nil {
+		return ka, err
+	}
+	if err := OptArg(args, KubeOpsNamespaceArg, &ka.namespace, metav1.NamespaceDefault); err != nil {
+		return ka, err
+	}
+	if ArgExists(args, KubeOpsObjectReferenceArg) {
+		if err := OptArg(args, KubeOpsObjectReferenceArg, &ka.objRef, nil); err != nil {
+			return ka, err
+		}
+	}
+	if err := OptArg(args, KubeOpsFieldManagerArg, &ka.apply.FieldManager, kube.DefaultFieldManager); err != nil {
+		return ka, err
+	}
+	if err := OptArg(args, KubeOpsForceConflictsArg, &ka.apply.Force, false); err != nil {
+		return ka, err
+	}
+	if err := OptArg(args, KubeOpsFieldsArg, &ka.fields, nil); err != nil {
+		return ka, err
+	}
+	if err := OptArg(args, KubeOpsWaitForArg, &ka.waitFor, ""); err != nil {
+		return ka, err
+	}
+	if err := OptArg(args, KubeOpsWaitTimeoutArg, &ka.waitTimeout, defaultKubeOpsWaitTimeout); err != nil {
+		return ka, err
+	}
+	return ka, nil
 }
 
-func execKubeOperation(ctx context.Context, dynCli dynamic.Interface, op kube.Operation, namespace, spec string, objRef crv1alpha1.ObjectReference) (*crv1alpha1.ObjectReference, error) {
+func execKubeOperation(ctx context.Context, dynCli dynamic.Interface, ka kubeopsArgs) ([]crv1alpha1.ObjectReference, error) {
 	kubeopsOp := kube.NewKubectlOperations(dynCli)
-	switch op {
+	switch ka.op {
 	case kube.CreateOperation:
-		if len(spec) == 0 {
+		if len(ka.spec) == 0 {
 			return nil, errkit.New(fmt.Sprintf("spec cannot be empty for %s operation", kube.CreateOperation))
 		}
-		return kubeopsOp.Create(strings.NewReader(spec), namespace)
+		return kubeopsOp.CreateAll(strings.NewReader(ka.spec), ka.namespace)
+	case kube.ApplyOperation:
+		if len(ka.spec) == 0 {
+			return nil, errkit.New(fmt.Sprintf("spec cannot be empty for %s operation", kube.ApplyOperation))
+		}
+		return kubeopsOp.Apply(strings.NewReader(ka.spec), ka.namespace, ka.apply)
 	case kube.DeleteOperation:
-		if objRef.Name == "" ||
-			objRef.APIVersion == "" ||
-			objRef.Resource == "" {
-			return nil, errkit.New(fmt.Sprintf("missing one or more required fields name/namespace/group/apiVersion/resource in objectReference for %s operation", kube.DeleteOperation))
+		if err := checkKubeOpsObjectReference(ka.objRef, kube.DeleteOperation); err != nil {
+			return nil, err
 		}
-		return kubeopsOp.Delete(ctx, objRef, namespace)
+		objRef, err := kubeopsOp.Delete(ctx, ka.objRef, ka.namespace)
+		return []crv1alpha1.ObjectReference{*objRef}, err
 	case kube.PatchOperation:
-		if len(spec) == 0 {
+		if len(ka.spec) == 0 {
 			return nil, errkit.New(fmt.Sprintf("spec cannot be empty for %s operation", kube.PatchOperation))
 		}
-		return kubeopsOp.Patch(ctx, objRef, spec)
+		objRef, err := kubeopsOp.Patch(ctx, ka.objRef, ka.spec)
+		return []crv1alpha1.ObjectReference{*objRef}, err
+	case kube.GetOperation:
+		if err := checkKubeOpsObjectReference(ka.objRef, kube.GetOperation); err != nil {
+			return nil, err
+		}
+		objRef := ka.objRef
+		if objRef.Namespace == "" {
+			objRef.Namespace = ka.namespace
+		}
+		if _, err := kubeopsOp.Get(ctx, objRef, ka.namespace); err != nil {
+			return nil, err
+		}
+		return []crv1alpha1.ObjectReference{objRef}, nil
+	}
+
+	return nil, errkit.New(fmt.Sprintf("invalid operation '%s'", ka.op))
+}
+
+func checkKubeOpsObjectReference(objRef crv1alpha1.ObjectReference, op kube.Operation) error {
+	if objRef.Name == "" ||
+		objRef.APIVersion == "" ||
+		objRef.Resource == "" {
+		return errkit.New(fmt.Sprintf("missing one or more required fields name/namespace/group/apiVersion/resource in objectReference for %s operation", op))
+	}
+	return nil
+}
+
+// waitForKubeOpsCondition waits till all the resources meet the condition
+func waitForKubeOpsCondition(ctx context.Context, dynCli dynamic.Interface, objRefs []crv1alpha1.ObjectReference, cond kube.WaitCondition, timeout string) error {
+	timeoutDur, err := time.ParseDuration(timeout)
+	if err != nil {
+		return errkit.Wrap(err, "Failed to parse timeout")
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeoutDur)
+	defer cancel()
+	kubeopsOp := kube.NewKubectlOperations(dynCli)
+	for _, objRef := range objRefs {
+		if err := kubeopsOp.Wait(ctx, objRef, cond); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// kubeOpsOutput returns the reference of the last resource, the references
+// of all the resources if there are multiple, and the fields of the get
+// operation.
+func kubeOpsOutput(ctx context.Context, dynCli dynamic.Interface, objRefs []crv1alpha1.ObjectReference, ka kubeopsArgs) (map[string]interface{}, error) {
+	if len(objRefs) == 0 {
+		return nil, errkit.New("No resources found in spec")
+	}
+	out, err := objectReferenceOutput(objRefs[len(objRefs)-1])
+	if err != nil {
+		return nil, err
+	}
+	if len(objRefs) > 1 {
+		objects := make([]interface{}, 0, len(objRefs))
+		for _, objRef := range objRefs {
+			o, err := objectReferenceOutput(objRef)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, o)
+		}
+		out[KubeOpsObjectsOutput] = objects
+	}
+	if len(ka.fields) != 0 {
+		obj, err := kube.NewKubectlOperations(dynCli).Get(ctx, objRefs[0], ka.namespace)
+		if err != nil {
+			return nil, err
+		}
+		fields := make(map[string]interface{}, len(ka.fields))
+		for name, path := range ka.fields {
+			v, err := jsonpath.ResolveJsonpathToString(obj, path)
+			if err != nil {
+				return nil, errkit.Wrap(err, "Failed to resolve jsonpath", "field", name, "jsonpath", path)
+			}
+			fields[name] = v
+		}
+		out[KubeOpsFieldsOutput] = fields
 	}
+	return out, nil
+}
 
-	return nil, errkit.New(fmt.Sprintf("invalid operation '%s'", op))
+// objectReferenceOutput converts objRef to map[string]interface{}
+func objectReferenceOutput(objRef crv1alpha1.ObjectReference) (map[string]interface{}, error) {
+	objRefJSON, err := json.Marshal(objRef)
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(objRefJSON, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
 func (*kubeops) RequiredArgs() []string {
@@ -141,6 +297,87 @@ func (*kubeops) Arguments() []string {
 		KubeOpsOperationArg,
 		KubeOpsNamespaceArg,
 		KubeOpsObjectReferenceArg,
+		KubeOpsFieldManagerArg,
+		KubeOpsForceConflictsArg,
+		KubeOpsFieldsArg,
+		KubeOpsWaitForArg,
+		KubeOpsWaitTimeoutArg,
+	}
+}
+
+func (*kubeops) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        KubeOpsFuncName,
+		Description: "Creates, applies, patches, gets or deletes Kubernetes resources",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        KubeOpsSpecArg,
+				Type:        kanister.ArgTypeString,
+				Description: "YAML spec of the resources to create or apply, or of the patch",
+			},
+			{
+				Name:        KubeOpsOperationArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Operation to perform",
+				Enum: []string{
+					string(kube.CreateOperation),
+					string(kube.ApplyOperation),
+					string(kube.PatchOperation),
+					string(kube.GetOperation),
+					string(kube.DeleteOperation),
+				},
+			},
+			{
+				Name:        KubeOpsNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Namespace of the resource",
+				Default:     metav1.NamespaceDefault,
+			},
+			{
+				Name:        KubeOpsObjectReferenceArg,
+				Type:        kanister.ArgTypeMap,
+				Description: "Reference of the resource to patch, get or delete",
+			},
+			{
+				Name:        KubeOpsFieldManagerArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Field manager of the apply operation",
+				Default:     kube.DefaultFieldManager,
+			},
+			{
+				Name:        KubeOpsForceConflictsArg,
+				Type:        kanister.ArgTypeBoolean,
+				Description: "Take over the fields owned by other field managers in the apply operation",
+				Default:     false,
+			},
+			{
+				Name:        KubeOpsFieldsArg,
+				Type:        kanister.ArgTypeMap,
+				Description: "Names and jsonpaths of the fields returned by the get operation",
+			},
+			{
+				Name:        KubeOpsWaitForArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Condition to wait for after the operation, `condition=<type>[=<status>]` or `jsonpath={<jsonpath>}=<value>`",
+			},
+			{
+				Name:        KubeOpsWaitTimeoutArg,
+				Type:        kanister.ArgTypeDuration,
+				Description: "Timeout of waiting for the condition",
+				Default:     defaultKubeOpsWaitTimeout,
+			},
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: "apiVersion", Type: kanister.ArgTypeString, Description: "API version of the resource"},
+			{Name: "group", Type: kanister.ArgTypeString, Description: "API group of the resource"},
+			{Name: "resource", Type: kanister.ArgTypeString, Description: "Resource type"},
+			{Name: "kind", Type: kanister.ArgTypeString, Description: "Kind of the resource"},
+			{Name: "name", Type: kanister.ArgTypeString, Description: "Name of the resource"},
+			{Name: "namespace", Type: kanister.ArgTypeString, Description: "Namespace of the resource"},
+			{Name: KubeOpsObjectsOutput, Type: kanister.ArgTypeList, Description: "References of all the resources of a multi-document spec"},
+			{Name: KubeOpsFieldsOutput, Type: kanister.ArgTypeMap, Description: "Fields returned by the get operation"},
+		},
 	}
 }
 
@@ -391,3 +391,56 @@ func getSampleCRD() *extensionsv1.CustomResourceDefinition {
 		},
 	}
 }
+
+func (s *KubeOpsSuite) TestKubeOpsApplyGet(c *check.C) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	tp := param.TemplateParams{}
+	action := "test"
+	svcName := fmt.Sprintf("%s-%s", testServiceName, rand.String(8))
+	spec := fmt.Sprintf(serviceSpec, svcName, s.namespace, "ClusterIP") + "\n---\n" + deploySpec
+	getPhase := crv1alpha1.BlueprintPhase{
+		Name: "getDeploy",
+		Func: KubeOpsFuncName,
+		Args: map[string]interface{}{
+			KubeOpsOperationArg: "get",
+			KubeOpsNamespaceArg: s.namespace,
+			KubeOpsObjectReferenceArg: map[string]interface{}{
+				"apiVersion": "v1",
+				"group":      "apps",
+				"resource":   "deployments",
+				"name":       "test-deployment",
+			},
+			KubeOpsFieldsArg: map[string]interface{}{
+				"replicas": "{.spec.replicas}",
+			},
+			KubeOpsWaitForArg: "condition=Available",
+		},
+	}
+	// Apply is idempotent
+	for i := 0; i < 2; i++ {
+		bp := newCreateResourceBlueprint(crv1alpha1.BlueprintPhase{
+			Name: "applyDeploy",
+			Func: KubeOpsFuncName,
+			Args: map[string]interface{}{
+				KubeOpsOperationArg:      "apply",
+				KubeOpsNamespaceArg:      s.namespace,
+				KubeOpsSpecArg:           spec,
+				KubeOpsForceConflictsArg: true,
+			},
+		}, getPhase)
+		phases, err := kanister.GetPhases(bp, action, kanister.DefaultVersion, tp)
+		c.Assert(err, check.IsNil)
+		out, err := phases[0].Exec(ctx, bp, action, tp)
+		c.Assert(err, check.IsNil)
+		c.Assert(out["name"], check.Equals, "test-deployment")
+		c.Assert(out[KubeOpsObjectsOutput], check.HasLen, 2)
+
+		out, err = phases[1].Exec(ctx, bp, action, tp)
+		c.Assert(err, check.IsNil)
+		c.Assert(out[KubeOpsFieldsOutput], check.DeepEquals, map[string]interface{}{"replicas": "1"})
+	}
+	svc, err := s.kubeCli.CoreV1().Services(s.namespace).Get(ctx, svcName, metav1.GetOptions{})
+	c.Assert(err, check.IsNil)
+	c.Assert(svc.GetManagedFields()[0].Manager, check.Equals, kube.DefaultFieldManager)
+}
@@ -75,6 +75,21 @@ func (*locationDeleteFunc) Arguments() []string {
 	return []string{LocationDeleteArtifactArg}
 }
 
+func (*locationDeleteFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        LocationDeleteFuncName,
+		Description: "Deletes an artifact from the object store of the profile",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        LocationDeleteArtifactArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Path of the artifact in the object store",
+			},
+		},
+	}
+}
+
 func (l *locationDeleteFunc) Validate(args map[string]any) error {
 	if err := utils.CheckSupportedArgs(l.Arguments(), args); err != nil {
 		return err
@@ -519,6 +519,73 @@ func (*multiContainerRunFunc) Arguments() []string {
 	}
 }
 
+func (*multiContainerRunFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        MultiContainerRunFuncName,
+		Description: "Runs a pod with a background and an output container that share a volume. Key-value pairs printed with `kando output` by the output container become outputs",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        MultiContainerRunNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Namespace to create the pod in, defaults to the namespace of the controller",
+			},
+			{
+				Name:        MultiContainerRunInitImageArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Image of the init container",
+			},
+			{
+				Name:        MultiContainerRunInitCommandArg,
+				Type:        kanister.ArgTypeList,
+				Description: "Command of the init container",
+			},
+			{
+				Name:        MultiContainerRunBackgroundImageArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Image of the background container",
+			},
+			{
+				Name:        MultiContainerRunBackgroundCommandArg,
+				Type:        kanister.ArgTypeList,
+				Required:    true,
+				Description: "Command of the background container",
+			},
+			{
+				Name:        MultiContainerRunOutputImageArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Image of the output container",
+			},
+			{
+				Name:        MultiContainerRunOutputCommandArg,
+				Type:        kanister.ArgTypeList,
+				Required:    true,
+				Description: "Command of the output container",
+			},
+			{
+				Name:        MultiContainerRunVolumeMediumArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Medium of the shared volume, e.g. `Memory`",
+			},
+			{
+				Name:        MultiContainerRunVolumeSizeLimitArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Size limit of the shared volume, e.g. `1Gi`",
+			},
+			{
+				Name:        MultiContainerRunSharedDirArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Path the shared volume is mounted at",
+				Default:     ktpDefaultSharedDir,
+			},
+			podOverrideArgSchema,
+			podLabelsArgSchema,
+			podAnnotationsArgSchema,
+		},
+	}
+}
+
 func (ktpf *multiContainerRunFunc) Validate(args map[string]any) error {
 	if err := ValidatePodLabelsAndAnnotations(ktpf.Name(), args); err != nil {
 		return err
@@ -0,0 +1,539 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/kanisterio/errkit"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/field"
+	"github.com/kanisterio/kanister/pkg/log"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/progress"
+	"github.com/kanisterio/kanister/pkg/utils"
+)
+
+const (
+	// NotifyFuncName gives the name of the function
+	NotifyFuncName = "Notify"
+	// NotifyURLArg is the URL of the webhook
+	NotifyURLArg = "url"
+	// NotifyMessageArg is the message of the notification
+	NotifyMessageArg = "message"
+	// NotifyDetailsArg are additional details added to the notification
+	NotifyDetailsArg = "details"
+	// NotifyPayloadArg replaces the JSON payload of the notification
+	NotifyPayloadArg = "payload"
+	// NotifyContentTypeArg is the content type of the payload
+	NotifyContentTypeArg = "contentType"
+	// NotifyHeadersArg are the headers of the request
+	NotifyHeadersArg = "headers"
+	// NotifyOnArg sets whether the notification is sent always, on failure or on success of the action
+	NotifyOnArg = "notifyOn"
+	// NotifySigningSecretArg is the name of the secret with the key used to sign the payload
+	NotifySigningSecretArg = "signingSecret"
+	// NotifySignatureHeaderArg is the header with the signature of the payload
+	NotifySignatureHeaderArg = "signatureHeader"
+	// NotifyCloudEventsArg wraps the payload in a CloudEvents envelope
+	NotifyCloudEventsArg = "cloudEvents"
+	// NotifyCloudEventTypeArg is the type of the CloudEvent
+	NotifyCloudEventTypeArg = "cloudEventType"
+	// NotifySentOutput is true if the notification was sent
+	NotifySentOutput = "sent"
+	// NotifyStatusCodeOutput is the status code of the response
+	NotifyStatusCodeOutput = "statusCode"
+
+	NotifyOnAlways  = "always"
+	NotifyOnFailure = "failure"
+	NotifyOnSuccess = "success"
+
+	// notifySigningKey is the key of the signing key in the secret
+	notifySigningKey = "key"
+
+	defaultNotifyContentType     = "application/json"
+	defaultNotifySignatureHeader = "X-Kanister-Signature"
+	notifyCloudEventsContentType = "application/cloudevents+json; charset=UTF-8"
+	notifyCloudEventsSource      = "kanister.io"
+	notifyCloudEventFailedType   = "io.kanister.action.failed"
+	notifyCloudEventSuccessType  = "io.kanister.action.succeeded"
+)
+
+var notifyOnValues = []string{NotifyOnAlways, NotifyOnFailure, NotifyOnSuccess}
+
+func init() {
+	_ = kanister.Register(&notifyFunc{})
+}
+
+var _ kanister.Func = (*notifyFunc)(nil)
+
+type notifyFunc struct {
+	progressPercent string
+}
+
+func (*notifyFunc) Name() string {
+	return NotifyFuncName
+}
+
+type notifyArgs struct {
+	request         *httpRequestArgs
+	message         string
+	details         map[string]string
+	payload         string
+	contentType     string
+	notifyOn        string
+	signingSecret   string
+	signatureHeader string
+	cloudEvents     bool
+	cloudEventType  string
+}
+
+// notification is the default payload of a notification
+type notification struct {
+	Message     string                         `json:"message"`
+	ActionSet   string                         `json:"actionSet,omitempty"`
+	Namespace   string                         `json:"namespace,omitempty"`
+	Action      string                         `json:"action,omitempty"`
+	Blueprint   string                         `json:"blueprint,omitempty"`
+	Failed      bool                           `json:"failed"`
+	FailedPhase string                         `json:"failedPhase,omitempty"`
+	Error       string                         `json:"error,omitempty"`
+	Artifacts   map[string]crv1alpha1.Artifact `json:"artifacts,omitempty"`
+	Details     map[string]string              `json:"details,omitempty"`
+	Time        string                         `json:"time,omitempty"`
+}
+
+// cloudEvent is a CloudEvent in the structured content mode
+type cloudEvent struct {
+	SpecVersion     string      `json:"specversion"`
+	ID              string      `json:"id"`
+	Source          string      `json:"source"`
+	Type            string      `json:"type"`
+	Subject         string      `json:"subject,omitempty"`
+	Time            string      `json:"time"`
+	DataContentType string      `json:"datacontenttype"`
+	Data            interface{} `json:"data"`
+}
+
+func parseNotifyArgs(args map[string]interface{}) (*notifyArgs, error) {
+	a := &notifyArgs{
+		request: &httpRequestArgs{method: http.MethodPost},
+	}
+	var retryInterval, timeout string
+	if err := Arg(args, NotifyURLArg, &a.request.url); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, NotifyMessageArg, &a.message, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, NotifyDetailsArg, &a.details, nil); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, NotifyPayloadArg, &a.payload, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, NotifyContentTypeArg, &a.contentType, defaultNotifyContentType); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, NotifyHeadersArg, &a.request.headers, nil); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, NotifyOnArg, &a.notifyOn, NotifyOnAlways); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, NotifySigningSecretArg, &a.signingSecret, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, NotifySignatureHeaderArg, &a.signatureHeader, defaultNotifySignatureHeader); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, NotifyCloudEventsArg, &a.cloudEvents, false); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, NotifyCloudEventTypeArg, &a.cloudEventType, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, InsecureTLS, &a.request.insecureTLS, false); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, HTTPRequestCACertArg, &a.request.caCert, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, HTTPRequestExpectedStatusCodesArg, &a.request.expectedStatusCodes, nil); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, HTTPRequestRetriesArg, &a.request.retries, 0); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, HTTPRequestRetryIntervalArg, &retryInterval, defaultHTTPRequestRetryInterval); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, HTTPRequestTimeoutArg, &timeout, defaultHTTPRequestTimeout); err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(notifyOnValues, a.notifyOn) {
+		return nil, errkit.New("Unsupported notifyOn", "notifyOn", a.notifyOn)
+	}
+	if a.request.retries < 0 {
+		return nil, errkit.New("Retries must not be negative", "retries", a.request.retries)
+	}
+	var err error
+	if a.request.retryInterval, err = time.ParseDuration(retryInterval); err != nil {
+		return nil, errkit.Wrap(err, "Failed to parse retryInterval")
+	}
+	if a.request.timeout, err = time.ParseDuration(timeout); err != nil {
+		return nil, errkit.Wrap(err, "Failed to parse timeout")
+	}
+	return a, nil
+}
+
+// actionFailed returns whether a phase of the action failed, which is only
+// known in the deferPhase.
+func actionFailed(tp param.TemplateParams) bool {
+	return tp.Action != nil && tp.Action.Failed
+}
+
+// shouldNotify checks if the notification needs to be sent for the result
+// of the action.
+func shouldNotify(notifyOn string, failed bool) bool {
+	switch notifyOn {
+	case NotifyOnFailure:
+		return failed
+	case NotifyOnSuccess:
+		return !failed
+	default:
+		return true
+	}
+}
+
+// defaultNotifyMessage describes the result of the action
+func defaultNotifyMessage(action *param.ActionParams) string {
+	if action == nil {
+		return ""
+	}
+	if action.Failed {
+		return fmt.Sprintf("Action %s of ActionSet %s/%s failed in phase %s: %s", action.Name, action.Namespace, action.ActionSet, action.FailedPhase, action.Error)
+	}
+	return fmt.Sprintf("Action %s of ActionSet %s/%s succeeded", action.Name, action.Namespace, action.ActionSet)
+}
+
+// newNotification returns the default payload with the details of the
+// ActionSet, the failure of the action and the input artifacts.
+func newNotification(tp param.TemplateParams, a *notifyArgs) notification {
+	n := notification{
+		Message:   a.message,
+		Artifacts: tp.ArtifactsIn,
+		Details:   a.details,
+		Time:      tp.Time,
+	}
+	if tp.Action != nil {
+		n.ActionSet = tp.Action.ActionSet
+		n.Namespace = tp.Action.Namespace
+		n.Action = tp.Action.Name
+		n.Blueprint = tp.Action.Blueprint
+		n.Failed = tp.Action.Failed
+		n.FailedPhase = tp.Action.FailedPhase
+		n.Error = tp.Action.Error
+	}
+	if n.Message == "" {
+		n.Message = defaultNotifyMessage(tp.Action)
+	}
+	return n
+}
+
+// notifyBody returns the body of the request and its content type. The
+// payload arg replaces the default payload, and the payload is wrapped in a
+// CloudEvent if cloudEvents is set.
+func notifyBody(tp param.TemplateParams, a *notifyArgs, now time.Time) ([]byte, string, error) {
+	var payload []byte
+	contentType := a.contentType
+	if a.payload != "" {
+		payload = []byte(a.payload)
+	} else {
+		var err error
+		if payload, err = json.Marshal(newNotification(tp, a)); err != nil {
+			return nil, "", errkit.Wrap(err, "Failed to marshal notification")
+		}
+		contentType = defaultNotifyContentType
+	}
+	if !a.cloudEvents {
+		return payload, contentType, nil
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, "", errkit.Wrap(err, "Failed to generate CloudEvent ID")
+	}
+	ce := cloudEvent{
+		SpecVersion:     "1.0",
+		ID:              id.String(),
+		Source:          notifyCloudEventsSource,
+		Type:            a.cloudEventType,
+		Time:            now.UTC().Format(time.RFC3339),
+		DataContentType: contentType,
+		Data:            string(payload),
+	}
+	if json.Valid(payload) {
+		ce.Data = json.RawMessage(payload)
+	}
+	if tp.Action != nil {
+		ce.Source = fmt.Sprintf("%s/namespaces/%s/actionsets/%s", notifyCloudEventsSource, tp.Action.Namespace, tp.Action.ActionSet)
+		ce.Subject = tp.Action.Name
+	}
+	if ce.Type == "" {
+		ce.Type = notifyCloudEventSuccessType
+		if actionFailed(tp) {
+			ce.Type = notifyCloudEventFailedType
+		}
+	}
+	body, err := json.Marshal(ce)
+	if err != nil {
+		return nil, "", errkit.Wrap(err, "Failed to marshal CloudEvent")
+	}
+	return body, notifyCloudEventsContentType, nil
+}
+
+// signNotification returns the hex encoded HMAC-SHA256 signature of the body
+// prefixed with the algorithm, e.g. `sha256=...`.
+func signNotification(key, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body) //nolint:errcheck
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// notifyRequest sets the body and the headers of the request
+func notifyRequest(tp param.TemplateParams, a *notifyArgs, now time.Time) error {
+	body, contentType, err := notifyBody(tp, a, now)
+	if err != nil {
+		return err
+	}
+	headers := make(map[string]string, len(a.request.headers)+2)
+	headers["Content-Type"] = contentType
+	for k, v := range a.request.headers {
+		headers[k] = v
+	}
+	if a.signingSecret != "" {
+		secret, ok := getParamSecret(tp, a.signingSecret)
+		if !ok {
+			return errkit.New("Secret not found in the secrets of the ActionSet or the phase", "secret", a.signingSecret)
+		}
+		key := secret.Data[notifySigningKey]
+		if len(key) == 0 {
+			return errkit.New("Secret must have a signing key", "secret", a.signingSecret, "key", notifySigningKey)
+		}
+		headers[a.signatureHeader] = signNotification(key, body)
+	}
+	a.request.body = string(body)
+	a.request.headers = headers
+	return nil
+}
+
+func (n *notifyFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	// Set progress percent
+	n.progressPercent = progress.StartedPercent
+	defer func() { n.progressPercent = progress.CompletedPercent }()
+
+	a, err := parseNotifyArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	if !shouldNotify(a.notifyOn, actionFailed(tp)) {
+		log.Print("Skipping notification", field.M{"NotifyOn": a.notifyOn, "Failed": actionFailed(tp)})
+		return map[string]interface{}{
+			NotifySentOutput:      false,
+			FunctionOutputVersion: kanister.DefaultVersion,
+		}, nil
+	}
+	if err := notifyRequest(tp, a, time.Now()); err != nil {
+		return nil, err
+	}
+	client, err := newHTTPClient(a.request)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := sendHTTPRequest(ctx, a.request, controllerHTTPRequestDoer(client, a.request))
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to send notification")
+	}
+	return map[string]interface{}{
+		NotifySentOutput:       true,
+		NotifyStatusCodeOutput: resp.statusCode,
+		FunctionOutputVersion:  kanister.DefaultVersion,
+	}, nil
+}
+
+func (*notifyFunc) RequiredArgs() []string {
+	return []string{NotifyURLArg}
+}
+
+func (*notifyFunc) Arguments() []string {
+	return []string{
+		NotifyURLArg,
+		NotifyMessageArg,
+		NotifyDetailsArg,
+		NotifyPayloadArg,
+		NotifyContentTypeArg,
+		NotifyHeadersArg,
+		NotifyOnArg,
+		NotifySigningSecretArg,
+		NotifySignatureHeaderArg,
+		NotifyCloudEventsArg,
+		NotifyCloudEventTypeArg,
+		InsecureTLS,
+		HTTPRequestCACertArg,
+		HTTPRequestExpectedStatusCodesArg,
+		HTTPRequestRetriesArg,
+		HTTPRequestRetryIntervalArg,
+		HTTPRequestTimeoutArg,
+	}
+}
+
+func (*notifyFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        NotifyFuncName,
+		Description: "Posts a notification about the action to a webhook, with optional signing and CloudEvents envelope",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        NotifyURLArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "URL of the webhook",
+			},
+			{
+				Name:        NotifyMessageArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Message of the notification, defaults to a message about the result of the action",
+			},
+			{
+				Name:        NotifyDetailsArg,
+				Type:        kanister.ArgTypeMap,
+				Description: "Additional details of the notification, e.g. the output artifacts",
+			},
+			{
+				Name:        NotifyPayloadArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Payload that replaces the default JSON payload of the notification",
+			},
+			{
+				Name:        NotifyContentTypeArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Content type of the payload",
+				Default:     defaultNotifyContentType,
+			},
+			{
+				Name:        NotifyHeadersArg,
+				Type:        kanister.ArgTypeMap,
+				Description: "Headers of the request",
+			},
+			{
+				Name:        NotifyOnArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Send the notification always, or only on failure or on success of the action",
+				Default:     NotifyOnAlways,
+				Enum:        notifyOnValues,
+			},
+			{
+				Name:        NotifySigningSecretArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Name of a secret of the ActionSet or the phase with the key used to sign the payload with HMAC-SHA256",
+			},
+			{
+				Name:        NotifySignatureHeaderArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Header with the signature of the payload",
+				Default:     defaultNotifySignatureHeader,
+			},
+			{
+				Name:        NotifyCloudEventsArg,
+				Type:        kanister.ArgTypeBoolean,
+				Description: "Wrap the payload in a CloudEvents envelope",
+				Default:     false,
+			},
+			{
+				Name:        NotifyCloudEventTypeArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Type of the CloudEvent, defaults to io.kanister.action.failed or io.kanister.action.succeeded",
+			},
+			{
+				Name:        InsecureTLS,
+				Type:        kanister.ArgTypeBoolean,
+				Description: "Skip the TLS verification of the webhook",
+				Default:     false,
+			},
+			{
+				Name:        HTTPRequestCACertArg,
+				Type:        kanister.ArgTypeString,
+				Description: "PEM encoded CA certificate used to verify the webhook",
+			},
+			{
+				Name:        HTTPRequestExpectedStatusCodesArg,
+				Type:        kanister.ArgTypeList,
+				Description: "Status codes of a successful response, defaults to any 2xx status code",
+			},
+			{
+				Name:        HTTPRequestRetriesArg,
+				Type:        kanister.ArgTypeInteger,
+				Description: "Number of times a request that fails or gets a 408, 429 or 5xx response is retried",
+				Default:     0,
+			},
+			{
+				Name:        HTTPRequestRetryIntervalArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Time to wait before the first retry, doubled for every retry up to 1m",
+				Default:     defaultHTTPRequestRetryInterval,
+			},
+			{
+				Name:        HTTPRequestTimeoutArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Timeout of each attempt of the request",
+				Default:     defaultHTTPRequestTimeout,
+			},
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: NotifySentOutput, Type: kanister.ArgTypeBoolean, Description: "Whether the notification was sent"},
+			{Name: NotifyStatusCodeOutput, Type: kanister.ArgTypeInteger, Description: "Status code of the response"},
+			versionOutputSchema,
+		},
+	}
+}
+
+func (n *notifyFunc) Validate(args map[string]any) error {
+	if err := utils.CheckSupportedArgs(n.Arguments(), args); err != nil {
+		return err
+	}
+
+	return utils.CheckRequiredArgs(n.RequiredArgs(), args)
+}
+
+func (n *notifyFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
+	metav1Time := metav1.NewTime(time.Now())
+	return crv1alpha1.PhaseProgress{
+		ProgressPercent:    n.progressPercent,
+		LastTransitionTime: &metav1Time,
+	}, nil
+}
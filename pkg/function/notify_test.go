@@ -0,0 +1,206 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/check.v1"
+	corev1 "k8s.io/api/core/v1"
+
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/param"
+)
+
+type NotifySuite struct{}
+
+var _ = check.Suite(&NotifySuite{})
+
+func (s *NotifySuite) failedActionParams() param.TemplateParams {
+	return param.TemplateParams{
+		Action: &param.ActionParams{
+			Name:        "backup",
+			ActionSet:   "backup-x7k2p",
+			Namespace:   "kanister",
+			Blueprint:   "mysql-blueprint",
+			Failed:      true,
+			FailedPhase: "dumpToObjectStore",
+			Error:       "command terminated with exit code 1",
+		},
+		ArtifactsIn: map[string]crv1alpha1.Artifact{
+			"mysqlCloudDump": {KeyValue: map[string]string{"s3path": "s3://bucket/dump.gz"}},
+		},
+		Secrets: map[string]corev1.Secret{
+			"webhook": {Data: map[string][]byte{"key": []byte("s3cr3t")}},
+		},
+	}
+}
+
+func (s *NotifySuite) TestNotifyPayload(c *check.C) {
+	tp := s.failedActionParams()
+	a, err := parseNotifyArgs(map[string]interface{}{
+		NotifyURLArg:     "http://example.com",
+		NotifyDetailsArg: map[string]interface{}{"cluster": "prod"},
+	})
+	c.Assert(err, check.IsNil)
+	body, contentType, err := notifyBody(tp, a, time.Now())
+	c.Assert(err, check.IsNil)
+	c.Assert(contentType, check.Equals, "application/json")
+	var n notification
+	c.Assert(json.Unmarshal(body, &n), check.IsNil)
+	c.Assert(n, check.DeepEquals, notification{
+		Message:     "Action backup of ActionSet kanister/backup-x7k2p failed in phase dumpToObjectStore: command terminated with exit code 1",
+		ActionSet:   "backup-x7k2p",
+		Namespace:   "kanister",
+		Action:      "backup",
+		Blueprint:   "mysql-blueprint",
+		Failed:      true,
+		FailedPhase: "dumpToObjectStore",
+		Error:       "command terminated with exit code 1",
+		Artifacts:   tp.ArtifactsIn,
+		Details:     map[string]string{"cluster": "prod"},
+	})
+
+	// The payload replaces the default payload
+	a, err = parseNotifyArgs(map[string]interface{}{
+		NotifyURLArg:         "http://example.com",
+		NotifyPayloadArg:     "backup failed",
+		NotifyContentTypeArg: "text/plain",
+	})
+	c.Assert(err, check.IsNil)
+	body, contentType, err = notifyBody(tp, a, time.Now())
+	c.Assert(err, check.IsNil)
+	c.Assert(string(body), check.Equals, "backup failed")
+	c.Assert(contentType, check.Equals, "text/plain")
+}
+
+func (s *NotifySuite) TestNotifyCloudEvent(c *check.C) {
+	tp := s.failedActionParams()
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		args        map[string]interface{}
+		contentType string
+		eventType   string
+		data        string
+	}{
+		{
+			args:        map[string]interface{}{NotifyPayloadArg: `{"text": "backup failed"}`},
+			contentType: "application/json",
+			eventType:   "io.kanister.action.failed",
+			data:        `{"text":"backup failed"}`,
+		},
+		{
+			args:        map[string]interface{}{NotifyPayloadArg: "backup failed", NotifyContentTypeArg: "text/plain", NotifyCloudEventTypeArg: "com.example.backup"},
+			contentType: "text/plain",
+			eventType:   "com.example.backup",
+			data:        `"backup failed"`,
+		},
+	} {
+		tc.args[NotifyURLArg] = "http://example.com"
+		tc.args[NotifyCloudEventsArg] = true
+		a, err := parseNotifyArgs(tc.args)
+		c.Assert(err, check.IsNil)
+		body, contentType, err := notifyBody(tp, a, now)
+		c.Assert(err, check.IsNil)
+		c.Assert(contentType, check.Equals, "application/cloudevents+json; charset=UTF-8")
+		var ce map[string]json.RawMessage
+		c.Assert(json.Unmarshal(body, &ce), check.IsNil)
+		c.Check(string(ce["specversion"]), check.Equals, `"1.0"`)
+		c.Check(string(ce["source"]), check.Equals, `"kanister.io/namespaces/kanister/actionsets/backup-x7k2p"`)
+		c.Check(string(ce["type"]), check.Equals, `"`+tc.eventType+`"`)
+		c.Check(string(ce["subject"]), check.Equals, `"backup"`)
+		c.Check(string(ce["time"]), check.Equals, `"2024-05-01T10:00:00Z"`)
+		c.Check(string(ce["datacontenttype"]), check.Equals, `"`+tc.contentType+`"`)
+		c.Check(string(ce["data"]), check.Equals, tc.data)
+		c.Check(string(ce["id"]), check.Not(check.Equals), "")
+	}
+}
+
+func (s *NotifySuite) TestNotifySignedWithRetries(c *check.C) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		c.Check(err, check.IsNil)
+		c.Check(r.Method, check.Equals, http.MethodPost)
+		c.Check(r.Header.Get("Content-Type"), check.Equals, "application/json")
+		c.Check(r.Header.Get("X-Kanister-Signature"), check.Equals, signNotification([]byte("s3cr3t"), body))
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	out, err := (&notifyFunc{}).Exec(context.Background(), s.failedActionParams(), map[string]interface{}{
+		NotifyURLArg:                srv.URL,
+		NotifySigningSecretArg:      "webhook",
+		HTTPRequestRetriesArg:       1,
+		HTTPRequestRetryIntervalArg: "1ms",
+	})
+	c.Assert(err, check.IsNil)
+	c.Assert(calls, check.Equals, int32(2))
+	c.Assert(out[NotifySentOutput], check.Equals, true)
+	c.Assert(out[NotifyStatusCodeOutput], check.Equals, http.StatusAccepted)
+}
+
+func (s *NotifySuite) TestNotifyOn(c *check.C) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer srv.Close()
+
+	succeeded := param.TemplateParams{Action: &param.ActionParams{Name: "backup"}}
+	for _, tc := range []struct {
+		tp       param.TemplateParams
+		notifyOn string
+		sent     bool
+	}{
+		{tp: s.failedActionParams(), notifyOn: NotifyOnAlways, sent: true},
+		{tp: s.failedActionParams(), notifyOn: NotifyOnFailure, sent: true},
+		{tp: s.failedActionParams(), notifyOn: NotifyOnSuccess, sent: false},
+		{tp: succeeded, notifyOn: NotifyOnFailure, sent: false},
+		{tp: succeeded, notifyOn: NotifyOnSuccess, sent: true},
+	} {
+		atomic.StoreInt32(&calls, 0)
+		out, err := (&notifyFunc{}).Exec(context.Background(), tc.tp, map[string]interface{}{
+			NotifyURLArg: srv.URL,
+			NotifyOnArg:  tc.notifyOn,
+		})
+		c.Assert(err, check.IsNil)
+		c.Check(out[NotifySentOutput], check.Equals, tc.sent)
+		c.Check(calls == 1, check.Equals, tc.sent)
+	}
+
+	_, err := parseNotifyArgs(map[string]interface{}{NotifyURLArg: srv.URL, NotifyOnArg: "never"})
+	c.Assert(err, check.ErrorMatches, ".*Unsupported notifyOn.*")
+}
+
+func (s *NotifySuite) TestNotifySigningSecretNotFound(c *check.C) {
+	a, err := parseNotifyArgs(map[string]interface{}{
+		NotifyURLArg:           "http://example.com",
+		NotifySigningSecretArg: "missing",
+	})
+	c.Assert(err, check.IsNil)
+	err = notifyRequest(param.TemplateParams{CurrentPhase: &param.Phase{}}, a, time.Now())
+	c.Assert(err, check.ErrorMatches, ".*Secret not found.*")
+}
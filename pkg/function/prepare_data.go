@@ -71,6 +71,14 @@ func getVolumes(tp param.TemplateParams) (map[string]string, error) {
 		podsToPvcs = tp.Deployment.PersistentVolumeClaims
 	case tp.StatefulSet != nil:
 		podsToPvcs = tp.StatefulSet.PersistentVolumeClaims
+	case tp.DaemonSet != nil:
+		podsToPvcs = tp.DaemonSet.PersistentVolumeClaims
+	case tp.ReplicaSet != nil:
+		podsToPvcs = tp.ReplicaSet.PersistentVolumeClaims
+	case tp.Job != nil:
+		podsToPvcs = tp.Job.PersistentVolumeClaims
+	case tp.CronJob != nil:
+		podsToPvcs = tp.CronJob.PersistentVolumeClaims
 	default:
 		return nil, errkit.New("Failed to get volumes")
 	}
@@ -158,7 +166,7 @@ func prepareDataPodFunc(failOnError bool) func(ctx context.Context, pc kube.PodC
 		logs := string(bytes)
 
 		format.LogWithCtx(ctx, pod.Name, pod.Spec.Containers[0].Name, logs)
-		out, err := parseLogAndCreateOutput(logs)
+		out, err := parseLogAndCreateOutput(ctx, logs)
 		if err != nil {
 			return nil, errkit.Wrap(err, "Failed to parse phase output")
 		}
@@ -273,6 +281,52 @@ func (*prepareDataFunc) Arguments() []string {
 	}
 }
 
+func (*prepareDataFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        PrepareDataFuncName,
+		Description: "Runs a command in a new pod with PVCs mounted",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        PrepareDataNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the PVCs",
+			},
+			{
+				Name:        PrepareDataImageArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Image of the pod",
+			},
+			{
+				Name:        PrepareDataCommandArg,
+				Type:        kanister.ArgTypeList,
+				Required:    true,
+				Description: "Command to run",
+			},
+			{
+				Name:        PrepareDataVolumes,
+				Type:        kanister.ArgTypeMap,
+				Description: "Map of the PVC names to their mount paths, defaults to the PVCs of the workload",
+			},
+			{
+				Name:        PrepareDataServiceAccount,
+				Type:        kanister.ArgTypeString,
+				Description: "Service account of the pod",
+			},
+			podOverrideArgSchema,
+			podAnnotationsArgSchema,
+			podLabelsArgSchema,
+			{
+				Name:        PrepareDataFailOnErrorArg,
+				Type:        kanister.ArgTypeBoolean,
+				Description: "Fail if the command fails",
+				Default:     false,
+			},
+		},
+	}
+}
+
 func (p *prepareDataFunc) Validate(args map[string]any) error {
 	if err := ValidatePodLabelsAndAnnotations(p.Name(), args); err != nil {
 		return err
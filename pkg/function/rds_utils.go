@@ -89,13 +89,19 @@ func getParamSecret(tp param.TemplateParams, secretName string) (*corev1.Secret,
 	if secret, ok := tp.Secrets[secretName]; ok {
 		return &secret, ok
 	}
-	// Technically tp.CurrentPhase should always be set
-	if tp.CurrentPhase == nil {
-		log.Info().Print("WARNING: CurrentPhase is not set in phase execution!")
+	if tp.CurrentPhase != nil {
+		if secret, ok := tp.CurrentPhase.Secrets[secretName]; ok {
+			return &secret, ok
+		}
 		return nil, false
-	} else if secret, ok := tp.CurrentPhase.Secrets[secretName]; ok {
-		return &secret, ok
 	}
+	// CurrentPhase isn't set for the deferPhase, which has its own secrets
+	if tp.DeferPhase != nil {
+		if secret, ok := tp.DeferPhase.Secrets[secretName]; ok {
+			return &secret, ok
+		}
+	}
+	log.Info().Print("WARNING: CurrentPhase is not set in phase execution!")
 	return nil, false
 }
 
@@ -0,0 +1,204 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+
+	"github.com/kanisterio/errkit"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// resourceArchiveVersion is the version of the format of the archives
+	// written by BackupResources.
+	resourceArchiveVersion      = "v1"
+	resourceArchiveMetadataFile = "metadata.json"
+	resourceArchiveDir          = "resources"
+	resourceArchiveExtension    = ".tar.gz"
+	// resourceArchiveCoreGroup is the directory name of the core API group
+	resourceArchiveCoreGroup = "core"
+)
+
+// resourceArchiveMetadata is stored in the metadata file of a resource
+// archive and lists the resources in the archive.
+type resourceArchiveMetadata struct {
+	Version   string                 `json:"version"`
+	Namespace string                 `json:"namespace"`
+	Resources []resourceArchiveEntry `json:"resources"`
+}
+
+// resourceArchiveEntry references the manifest of a resource in the archive.
+type resourceArchiveEntry struct {
+	Group    string `json:"group"`
+	Version  string `json:"version"`
+	Resource string `json:"resource"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+}
+
+// archivedResource is a manifest together with the resource type it was
+// listed with.
+type archivedResource struct {
+	GVR    schema.GroupVersionResource
+	Object unstructured.Unstructured
+}
+
+func resourceArchivePath(gvr schema.GroupVersionResource, name string) string {
+	group := gvr.Group
+	if group == "" {
+		group = resourceArchiveCoreGroup
+	}
+	return path.Join(resourceArchiveDir, group, gvr.Version, gvr.Resource, name+".yaml")
+}
+
+// writeResourceArchive writes the resources of the namespace as YAML
+// manifests to a gzipped tar archive. The resources are sorted by type and
+// name so that archives of the same resources are identical.
+func writeResourceArchive(w io.Writer, namespace string, resources []archivedResource) error {
+	sort.SliceStable(resources, func(i, j int) bool {
+		if a, b := resources[i].GVR.String(), resources[j].GVR.String(); a != b {
+			return a < b
+		}
+		return resources[i].Object.GetName() < resources[j].Object.GetName()
+	})
+
+	metadata := resourceArchiveMetadata{
+		Version:   resourceArchiveVersion,
+		Namespace: namespace,
+		Resources: make([]resourceArchiveEntry, 0, len(resources)),
+	}
+	manifests := make([][]byte, 0, len(resources))
+	for _, r := range resources {
+		data, err := yaml.Marshal(r.Object.Object)
+		if err != nil {
+			return errkit.Wrap(err, "Failed to marshal manifest", "resource", r.GVR.String(), "name", r.Object.GetName())
+		}
+		manifests = append(manifests, data)
+		metadata.Resources = append(metadata.Resources, resourceArchiveEntry{
+			Group:    r.GVR.Group,
+			Version:  r.GVR.Version,
+			Resource: r.GVR.Resource,
+			Name:     r.Object.GetName(),
+			Path:     resourceArchivePath(r.GVR, r.Object.GetName()),
+		})
+	}
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return errkit.Wrap(err, "Failed to marshal archive metadata")
+	}
+
+	// The metadata comes first, so that readers can check the version of
+	// the archive before reading the manifests
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+	if err := writeTarFile(tw, resourceArchiveMetadataFile, data); err != nil {
+		return err
+	}
+	for i, entry := range metadata.Resources {
+		if err := writeTarFile(tw, entry.Path, manifests[i]); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return errkit.Wrap(err, "Failed to close archive")
+	}
+	return errkit.Wrap(gzw.Close(), "Failed to close archive")
+}
+
+// readResourceArchive reads the metadata and the manifests of a resource
+// archive, in the order they're listed in the metadata.
+func readResourceArchive(r io.Reader) (*resourceArchiveMetadata, []archivedResource, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, errkit.Wrap(err, "Failed to read archive")
+	}
+	defer gzr.Close() //nolint:errcheck
+	tr := tar.NewReader(gzr)
+
+	var metadata *resourceArchiveMetadata
+	files := map[string][]byte{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, errkit.Wrap(err, "Failed to read archive")
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, nil, errkit.Wrap(err, fmt.Sprintf("Failed to read %s from archive", hdr.Name))
+		}
+		if hdr.Name != resourceArchiveMetadataFile {
+			files[hdr.Name] = data
+			continue
+		}
+		metadata = &resourceArchiveMetadata{}
+		if err := json.Unmarshal(data, metadata); err != nil {
+			return nil, nil, errkit.Wrap(err, "Failed to unmarshal archive metadata")
+		}
+		if metadata.Version != resourceArchiveVersion {
+			return nil, nil, errkit.New("Unsupported archive version", "version", metadata.Version)
+		}
+	}
+	if metadata == nil {
+		return nil, nil, errkit.New("Archive has no metadata", "file", resourceArchiveMetadataFile)
+	}
+
+	resources := make([]archivedResource, 0, len(metadata.Resources))
+	for _, entry := range metadata.Resources {
+		data, ok := files[entry.Path]
+		if !ok {
+			return nil, nil, errkit.New("Manifest missing from archive", "path", entry.Path)
+		}
+		r := archivedResource{
+			GVR: schema.GroupVersionResource{Group: entry.Group, Version: entry.Version, Resource: entry.Resource},
+		}
+		// Unmarshal the JSON like the API machinery does, to keep integers
+		jsonData, err := yaml.YAMLToJSON(data)
+		if err != nil {
+			return nil, nil, errkit.Wrap(err, "Failed to convert manifest to JSON", "path", entry.Path)
+		}
+		if err := r.Object.UnmarshalJSON(jsonData); err != nil {
+			return nil, nil, errkit.Wrap(err, "Failed to unmarshal manifest", "path", entry.Path)
+		}
+		resources = append(resources, r)
+	}
+	return metadata, resources, nil
+}
+
+func writeTarFile(tw *tar.Writer, name string, data []byte) error {
+	hdr := &tar.Header{
+		Name: name,
+		Mode: 0o644,
+		Size: int64(len(data)),
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return errkit.Wrap(err, fmt.Sprintf("Failed to write %s to archive", name))
+	}
+	if _, err := tw.Write(data); err != nil {
+		return errkit.Wrap(err, fmt.Sprintf("Failed to write %s to archive", name))
+	}
+	return nil
+}
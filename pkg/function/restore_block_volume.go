@@ -0,0 +1,192 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"context"
+	"time"
+
+	"github.com/kanisterio/errkit"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/kube"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/progress"
+	"github.com/kanisterio/kanister/pkg/utils"
+)
+
+const (
+	// RestoreBlockVolumeFuncName gives the name of the function
+	RestoreBlockVolumeFuncName = "RestoreBlockVolume"
+	// RestoreBlockVolumeChecksumArg provides the checksum output by BackupBlockVolume
+	RestoreBlockVolumeChecksumArg = "checksum"
+	// RestoreBlockVolumeSkipZeroesArg provides a way to skip writing zeroed blocks
+	RestoreBlockVolumeSkipZeroesArg = "skipZeroes"
+
+	restoreBlockVolumeJobPrefix = "restore-block-volume-"
+)
+
+func init() {
+	_ = kanister.Register(&restoreBlockVolumeFunc{})
+}
+
+var _ kanister.Func = (*restoreBlockVolumeFunc)(nil)
+
+type restoreBlockVolumeFunc struct {
+	progressPercent string
+}
+
+func (*restoreBlockVolumeFunc) Name() string {
+	return RestoreBlockVolumeFuncName
+}
+
+func (r *restoreBlockVolumeFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	// Set progress percent
+	r.progressPercent = progress.StartedPercent
+	defer func() { r.progressPercent = progress.CompletedPercent }()
+
+	var snapJSON, checksum string
+	var skipZeroes bool
+	if err := Arg(args, KopiaSnapshotArg, &snapJSON); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, RestoreBlockVolumeChecksumArg, &checksum, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, RestoreBlockVolumeSkipZeroesArg, &skipZeroes, false); err != nil {
+		return nil, err
+	}
+	if err := validateKopiaSnapshot(snapJSON); err != nil {
+		return nil, err
+	}
+	a, err := parseBlockVolumePodArgs(tp, args)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateKopiaProfile(tp.Profile); err != nil {
+		return nil, err
+	}
+	cli, err := kube.NewClient()
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to create Kubernetes client")
+	}
+	// Only the name of the streaming file is used to find the data in the
+	// snapshot, so the snapshot can be restored to any PVC
+	cmd := kopiaPullBlockCommand(snapJSON, blockVolumeSnapshotPath(a.namespace, a.pvc), checksum, blockVolumeDevicePath, skipZeroes)
+	stdout, err := runBlockVolumePod(ctx, cli, a, restoreBlockVolumeJobPrefix, tp.Profile, cmd)
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to restore block volume", "pvc", a.pvc)
+	}
+	out, err := parseLogAndCreateOutput(ctx, stdout)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		BlockVolumeChecksumOutput: out[kopiaChecksumKandoOutput],
+	}, nil
+}
+
+func (*restoreBlockVolumeFunc) RequiredArgs() []string {
+	return []string{
+		BlockVolumeNamespaceArg,
+		BlockVolumePVCArg,
+		KopiaSnapshotArg,
+	}
+}
+
+func (*restoreBlockVolumeFunc) Arguments() []string {
+	return []string{
+		BlockVolumeNamespaceArg,
+		BlockVolumePVCArg,
+		KopiaSnapshotArg,
+		RestoreBlockVolumeChecksumArg,
+		RestoreBlockVolumeSkipZeroesArg,
+		BlockVolumeImageArg,
+		PodOverrideArg,
+		PodAnnotationsArg,
+		PodLabelsArg,
+	}
+}
+
+func (*restoreBlockVolumeFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        RestoreBlockVolumeFuncName,
+		Description: "Restores a kopia snapshot created with BackupBlockVolume to a PVC with volumeMode Block",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        BlockVolumeNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the PVC",
+			},
+			{
+				Name:        BlockVolumePVCArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the PVC with volumeMode Block, it has to be at least as large as the backed up volume",
+			},
+			{
+				Name:        KopiaSnapshotArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Kopia snapshot output by BackupBlockVolume",
+			},
+			{
+				Name:        RestoreBlockVolumeChecksumArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Checksum output by BackupBlockVolume to verify the restored data against",
+			},
+			{
+				Name:        RestoreBlockVolumeSkipZeroesArg,
+				Type:        kanister.ArgTypeBoolean,
+				Description: "Don't write zeroed blocks, only set it if the volume is zeroed, e.g. newly provisioned",
+				Default:     false,
+			},
+			{
+				Name:        BlockVolumeImageArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Image of the pod the PVC is attached to, needs to have kando installed",
+			},
+			podOverrideArgSchema,
+			podAnnotationsArgSchema,
+			podLabelsArgSchema,
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: BlockVolumeChecksumOutput, Type: kanister.ArgTypeString, Description: "SHA-256 checksum of the restored data"},
+		},
+	}
+}
+
+func (r *restoreBlockVolumeFunc) Validate(args map[string]any) error {
+	if err := ValidatePodLabelsAndAnnotations(r.Name(), args); err != nil {
+		return err
+	}
+
+	if err := utils.CheckSupportedArgs(r.Arguments(), args); err != nil {
+		return err
+	}
+
+	return utils.CheckRequiredArgs(r.RequiredArgs(), args)
+}
+
+func (r *restoreBlockVolumeFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
+	metav1Time := metav1.NewTime(time.Now())
+	return crv1alpha1.PhaseProgress{
+		ProgressPercent:    r.progressPercent,
+		LastTransitionTime: &metav1Time,
+	}, nil
+}
@@ -0,0 +1,246 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"context"
+	"time"
+
+	"github.com/kanisterio/errkit"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/kube/snapshot"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/progress"
+	"github.com/kanisterio/kanister/pkg/utils"
+)
+
+func init() {
+	_ = kanister.Register(&restoreCSIGroupSnapshotFunc{})
+}
+
+var (
+	_ kanister.Func = (*restoreCSIGroupSnapshotFunc)(nil)
+)
+
+const (
+	// RestoreCSIGroupSnapshotFuncName gives the name of the function
+	RestoreCSIGroupSnapshotFuncName = "RestoreCSIGroupSnapshot"
+	// RestoreCSIGroupSnapshotNameArg provides name of the VolumeGroupSnapshot
+	RestoreCSIGroupSnapshotNameArg = "name"
+	// RestoreCSIGroupSnapshotNamespaceArg mentions the namespace of the VolumeGroupSnapshot and the restored PVCs
+	RestoreCSIGroupSnapshotNamespaceArg = "namespace"
+	// RestoreCSIGroupSnapshotStorageClassArg specifies the name of the StorageClass of the restored PVCs
+	RestoreCSIGroupSnapshotStorageClassArg = "storageClass"
+	// RestoreCSIGroupSnapshotPVCNamesArg maps the names of the captured PVCs to the names of the restored PVCs
+	RestoreCSIGroupSnapshotPVCNamesArg = "pvcNames"
+	// RestoreCSIGroupSnapshotAccessModesArg lists down the accessmodes for the underlying PVs
+	RestoreCSIGroupSnapshotAccessModesArg = "accessModes"
+	// RestoreCSIGroupSnapshotVolumeModeArg defines mode of the volumes
+	RestoreCSIGroupSnapshotVolumeModeArg = "volumeMode"
+	// RestoreCSIGroupSnapshotLabelsArg has labels that will be added to the restored PVCs
+	RestoreCSIGroupSnapshotLabelsArg = "labels"
+	// RestoreCSIGroupSnapshotPVCsOutput is the key used for returning the map of the captured PVCs to the restored PVCs
+	RestoreCSIGroupSnapshotPVCsOutput = "pvcs"
+)
+
+type restoreCSIGroupSnapshotFunc struct {
+	progressPercent string
+}
+
+func (*restoreCSIGroupSnapshotFunc) Name() string {
+	return RestoreCSIGroupSnapshotFuncName
+}
+
+func (r *restoreCSIGroupSnapshotFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	// Set progress percent
+	r.progressPercent = progress.StartedPercent
+	defer func() { r.progressPercent = progress.CompletedPercent }()
+
+	var name string
+	var pvcNames map[string]string
+	var restoreArgs restoreCSISnapshotArgs
+	if err := Arg(args, RestoreCSIGroupSnapshotNameArg, &name); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, RestoreCSIGroupSnapshotNamespaceArg, &restoreArgs.Namespace); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, RestoreCSIGroupSnapshotStorageClassArg, &restoreArgs.StorageClass); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, RestoreCSIGroupSnapshotPVCNamesArg, &pvcNames, nil); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, RestoreCSIGroupSnapshotAccessModesArg, &restoreArgs.AccessModes, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}); err != nil {
+		return nil, err
+	}
+	if err := validateVolumeAccessModesArg(restoreArgs.AccessModes); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, RestoreCSIGroupSnapshotVolumeModeArg, &restoreArgs.VolumeMode, corev1.PersistentVolumeFilesystem); err != nil {
+		return nil, err
+	}
+	if err := validateVolumeModeArg(restoreArgs.VolumeMode); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, RestoreCSIGroupSnapshotLabelsArg, &restoreArgs.Labels, nil); err != nil {
+		return nil, err
+	}
+
+	kubeCli, err := getClient()
+	if err != nil {
+		return nil, err
+	}
+	snapshotter, err := newSnapshotter()
+	if err != nil {
+		return nil, err
+	}
+	pvcs, err := restoreCSIGroupSnapshot(ctx, kubeCli, snapshotter, name, pvcNames, restoreArgs)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		RestoreCSIGroupSnapshotPVCsOutput: pvcs,
+	}, nil
+}
+
+// restoreCSIGroupSnapshot creates a PVC from the VolumeSnapshot of every
+// member of the group snapshot and returns the map of the captured PVCs to
+// the restored PVCs. The restored PVCs have the names of the captured PVCs,
+// unless they are renamed by pvcNames.
+func restoreCSIGroupSnapshot(
+	ctx context.Context,
+	kubeCli kubernetes.Interface,
+	snapshotter snapshot.Snapshotter,
+	name string,
+	pvcNames map[string]string,
+	restoreArgs restoreCSISnapshotArgs,
+) (map[string]interface{}, error) {
+	members, err := snapshotter.ListGroupMembers(ctx, name, restoreArgs.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	pvcs := make(map[string]interface{}, len(members))
+	for _, m := range members {
+		if m.RestoreSize == nil || m.RestoreSize.IsZero() {
+			return nil, errkit.New("Failed to restore CSI group snapshot. VolumeSnapshot has no restore size", "volumeGroupSnapshot", name, "volumeSnapshot", m.VolumeSnapshot)
+		}
+		args := restoreArgs
+		args.Name = m.VolumeSnapshot
+		args.PVC = m.PVC
+		if newName, ok := pvcNames[m.PVC]; ok {
+			args.PVC = newName
+		}
+		args.RestoreSize = m.RestoreSize
+		if _, err := restoreCSISnapshot(ctx, kubeCli, args); err != nil {
+			return nil, errkit.Wrap(err, "Failed to restore PVC from VolumeSnapshot", "pvc", args.PVC, "volumeSnapshot", m.VolumeSnapshot)
+		}
+		pvcs[m.PVC] = args.PVC
+	}
+	return pvcs, nil
+}
+
+func (*restoreCSIGroupSnapshotFunc) RequiredArgs() []string {
+	return []string{
+		RestoreCSIGroupSnapshotNameArg,
+		RestoreCSIGroupSnapshotNamespaceArg,
+		RestoreCSIGroupSnapshotStorageClassArg,
+	}
+}
+
+func (*restoreCSIGroupSnapshotFunc) Arguments() []string {
+	return []string{
+		RestoreCSIGroupSnapshotNameArg,
+		RestoreCSIGroupSnapshotNamespaceArg,
+		RestoreCSIGroupSnapshotStorageClassArg,
+		RestoreCSIGroupSnapshotPVCNamesArg,
+		RestoreCSIGroupSnapshotAccessModesArg,
+		RestoreCSIGroupSnapshotVolumeModeArg,
+		RestoreCSIGroupSnapshotLabelsArg,
+	}
+}
+
+func (*restoreCSIGroupSnapshotFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        RestoreCSIGroupSnapshotFuncName,
+		Description: "Restores new PVCs from the VolumeSnapshots of a VolumeGroupSnapshot",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        RestoreCSIGroupSnapshotNameArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the VolumeGroupSnapshot",
+			},
+			{
+				Name:        RestoreCSIGroupSnapshotNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the VolumeGroupSnapshot and the new PVCs",
+			},
+			{
+				Name:        RestoreCSIGroupSnapshotStorageClassArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the StorageClass of the new PVCs",
+			},
+			{
+				Name:        RestoreCSIGroupSnapshotPVCNamesArg,
+				Type:        kanister.ArgTypeMap,
+				Description: "Map of the names of the captured PVCs to the names of the new PVCs, the captured names are used by default",
+			},
+			{
+				Name:        RestoreCSIGroupSnapshotAccessModesArg,
+				Type:        kanister.ArgTypeList,
+				Description: "Access modes of the new PVCs, defaults to ReadWriteOnce",
+			},
+			{
+				Name:        RestoreCSIGroupSnapshotVolumeModeArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Volume mode of the new PVCs",
+				Default:     string(corev1.PersistentVolumeFilesystem),
+				Enum:        []string{string(corev1.PersistentVolumeFilesystem), string(corev1.PersistentVolumeBlock)},
+			},
+			{
+				Name:        RestoreCSIGroupSnapshotLabelsArg,
+				Type:        kanister.ArgTypeMap,
+				Description: "Labels added to the new PVCs",
+			},
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: RestoreCSIGroupSnapshotPVCsOutput, Type: kanister.ArgTypeMap, Description: "Map of the names of the captured PVCs to the names of the new PVCs"},
+		},
+	}
+}
+
+func (r *restoreCSIGroupSnapshotFunc) Validate(args map[string]any) error {
+	if err := utils.CheckSupportedArgs(r.Arguments(), args); err != nil {
+		return err
+	}
+
+	return utils.CheckRequiredArgs(r.RequiredArgs(), args)
+}
+
+func (r *restoreCSIGroupSnapshotFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
+	metav1Time := metav1.NewTime(time.Now())
+	return crv1alpha1.PhaseProgress{
+		ProgressPercent:    r.progressPercent,
+		LastTransitionTime: &metav1Time,
+	}, nil
+}
@@ -162,6 +162,62 @@ func (*restoreCSISnapshotFunc) Arguments() []string {
 	}
 }
 
+func (*restoreCSISnapshotFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        RestoreCSISnapshotFuncName,
+		Description: "Restores a new PVC from a VolumeSnapshot",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        RestoreCSISnapshotNameArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the VolumeSnapshot",
+			},
+			{
+				Name:        RestoreCSISnapshotPVCNameArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the new PVC",
+			},
+			{
+				Name:        RestoreCSISnapshotNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the VolumeSnapshot and the new PVC",
+			},
+			{
+				Name:        RestoreCSISnapshotStorageClassArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the StorageClass of the new PVC",
+			},
+			{
+				Name:        RestoreCSISnapshotRestoreSizeArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Size of the new PVC, e.g. `1Gi`",
+			},
+			{
+				Name:        RestoreCSISnapshotAccessModesArg,
+				Type:        kanister.ArgTypeList,
+				Description: "Access modes of the new PVC, defaults to ReadWriteOnce",
+			},
+			{
+				Name:        RestoreCSISnapshotVolumeModeArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Volume mode of the new PVC",
+				Default:     string(corev1.PersistentVolumeFilesystem),
+				Enum:        []string{string(corev1.PersistentVolumeFilesystem), string(corev1.PersistentVolumeBlock)},
+			},
+			{
+				Name:        RestoreCSISnapshotLabelsArg,
+				Type:        kanister.ArgTypeMap,
+				Description: "Labels added to the new PVC",
+			},
+		},
+	}
+}
+
 func (r *restoreCSISnapshotFunc) Validate(args map[string]any) error {
 	if err := utils.CheckSupportedArgs(r.Arguments(), args); err != nil {
 		return err
@@ -0,0 +1,428 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kanisterio/errkit"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/field"
+	"github.com/kanisterio/kanister/pkg/kube"
+	"github.com/kanisterio/kanister/pkg/kube/snapshot"
+	"github.com/kanisterio/kanister/pkg/log"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/poll"
+	"github.com/kanisterio/kanister/pkg/progress"
+	"github.com/kanisterio/kanister/pkg/utils"
+)
+
+func init() {
+	_ = kanister.Register(&restoreCSISnapshotToNamespaceFunc{})
+}
+
+var (
+	_ kanister.Func = (*restoreCSISnapshotToNamespaceFunc)(nil)
+)
+
+const (
+	// RestoreCSISnapshotToNamespaceFuncName gives the name of the function
+	RestoreCSISnapshotToNamespaceFuncName = "RestoreCSISnapshotToNamespace"
+	// RestoreCSISnapshotToNamespaceNameArg provides name of the source VolumeSnapshot
+	RestoreCSISnapshotToNamespaceNameArg = "name"
+	// RestoreCSISnapshotToNamespaceNamespaceArg mentions the namespace of the source VolumeSnapshot
+	RestoreCSISnapshotToNamespaceNamespaceArg = "namespace"
+	// RestoreCSISnapshotToNamespaceTargetNamespaceArg mentions the namespace of the newly restored PVC
+	RestoreCSISnapshotToNamespaceTargetNamespaceArg = "targetNamespace"
+	// RestoreCSISnapshotToNamespaceDeletionPolicyArg sets the deletion policy of the intermediate VolumeSnapshotContent
+	RestoreCSISnapshotToNamespaceDeletionPolicyArg = "deletionPolicy"
+	// RestoreCSISnapshotToNamespaceCleanupArg tells whether the intermediate VolumeSnapshot and VolumeSnapshotContent are deleted
+	RestoreCSISnapshotToNamespaceCleanupArg = "cleanup"
+	// RestoreCSISnapshotToNamespacePVCOutput is the key used for returning the name of the restored PVC
+	RestoreCSISnapshotToNamespacePVCOutput = "pvc"
+	// RestoreCSISnapshotToNamespaceSnapshotOutput is the key used for returning the name of the kept intermediate VolumeSnapshot
+	RestoreCSISnapshotToNamespaceSnapshotOutput = "volumeSnapshot"
+	// RestoreCSISnapshotToNamespaceContentOutput is the key used for returning the name of the kept intermediate VolumeSnapshotContent
+	RestoreCSISnapshotToNamespaceContentOutput = "volumeSnapshotContent"
+)
+
+type restoreCSISnapshotToNamespaceFunc struct {
+	progressPercent string
+}
+
+type restoreCSISnapshotToNamespaceArgs struct {
+	name           string
+	namespace      string
+	deletionPolicy string
+	cleanup        bool
+	// restore has the arguments of the restored PVC, its Name is set to the
+	// intermediate VolumeSnapshot.
+	restore restoreCSISnapshotArgs
+}
+
+func (*restoreCSISnapshotToNamespaceFunc) Name() string {
+	return RestoreCSISnapshotToNamespaceFuncName
+}
+
+func (r *restoreCSISnapshotToNamespaceFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	// Set progress percent
+	r.progressPercent = progress.StartedPercent
+	defer func() { r.progressPercent = progress.CompletedPercent }()
+
+	var restoreSize string
+	var a restoreCSISnapshotToNamespaceArgs
+	if err := Arg(args, RestoreCSISnapshotToNamespaceNameArg, &a.name); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, RestoreCSISnapshotToNamespaceNamespaceArg, &a.namespace); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, RestoreCSISnapshotToNamespaceTargetNamespaceArg, &a.restore.Namespace); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, RestoreCSISnapshotPVCNameArg, &a.restore.PVC); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, RestoreCSISnapshotStorageClassArg, &a.restore.StorageClass); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, RestoreCSISnapshotRestoreSizeArg, &restoreSize, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, RestoreCSISnapshotAccessModesArg, &a.restore.AccessModes, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}); err != nil {
+		return nil, err
+	}
+	if err := validateVolumeAccessModesArg(a.restore.AccessModes); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, RestoreCSISnapshotVolumeModeArg, &a.restore.VolumeMode, corev1.PersistentVolumeFilesystem); err != nil {
+		return nil, err
+	}
+	if err := validateVolumeModeArg(a.restore.VolumeMode); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, RestoreCSISnapshotLabelsArg, &a.restore.Labels, nil); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, RestoreCSISnapshotToNamespaceDeletionPolicyArg, &a.deletionPolicy, snapshot.DeletionPolicyRetain); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, RestoreCSISnapshotToNamespaceCleanupArg, &a.cleanup, true); err != nil {
+		return nil, err
+	}
+	if err := validateDeletionPolicyArg(a.deletionPolicy, a.cleanup); err != nil {
+		return nil, err
+	}
+	if restoreSize != "" {
+		size, err := resource.ParseQuantity(restoreSize)
+		if err != nil {
+			return nil, errkit.Wrap(err, "Failed to parse restore size", "restoreSize", restoreSize)
+		}
+		a.restore.RestoreSize = &size
+	}
+
+	kubeCli, err := kube.NewClient()
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to create Kubernetes client")
+	}
+	dynCli, err := kube.NewDynamicClient()
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to create dynamic Kubernetes client")
+	}
+	return restoreCSISnapshotToNamespace(ctx, kubeCli, dynCli, a)
+}
+
+// restoreCSISnapshotToNamespace creates a VolumeSnapshotContent for the CSI
+// snapshot that backs the source VolumeSnapshot, pre-bound to a new
+// VolumeSnapshot in the target namespace, and restores the PVC from it.
+// The intermediate objects are deleted if the restore fails, or once the PVC
+// is bound if cleanup is set. They are kept if the StorageClass binds volumes
+// only once a pod uses the PVC.
+func restoreCSISnapshotToNamespace(ctx context.Context, kubeCli kubernetes.Interface, dynCli dynamic.Interface, a restoreCSISnapshotToNamespaceArgs) (out map[string]interface{}, err error) {
+	snapshotter := snapshot.NewSnapshotter(kubeCli, dynCli)
+	src, err := snapshotter.GetSource(ctx, a.name, a.namespace)
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to get source of VolumeSnapshot", "volumeSnapshot", a.name, "namespace", a.namespace)
+	}
+	if a.restore.RestoreSize == nil {
+		if src.RestoreSize == nil {
+			return nil, errkit.New("Restore size of VolumeSnapshot is unknown, restoreSize argument is required", "volumeSnapshot", a.name)
+		}
+		a.restore.RestoreSize = resource.NewQuantity(*src.RestoreSize, resource.BinarySI)
+	}
+
+	waitForConsumer, err := isWaitForFirstConsumer(ctx, kubeCli, a.restore.StorageClass)
+	if err != nil {
+		return nil, err
+	}
+
+	targetNS := a.restore.Namespace
+	snapshotMeta := snapshot.ObjectMeta{
+		Name:      fmt.Sprintf("%s-%s", a.name, rand.String(5)),
+		Namespace: targetNS,
+	}
+	contentMeta := snapshot.ObjectMeta{
+		Name: snapshotMeta.Name + "-content-" + string(uuid.NewUUID()),
+	}
+	// The content shares the CSI snapshot with the source VolumeSnapshot, so it
+	// must not delete it while it is deleted by this function
+	if err := snapshotter.CreateContentFromSource(ctx, src, snapshotMeta.Name, targetNS, snapshot.DeletionPolicyRetain, contentMeta); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err == nil && (!a.cleanup || waitForConsumer) {
+			return
+		}
+		// The phase context may already be done after a timeout or cancellation
+		deleteIntermediateSnapshot(context.Background(), snapshotter, snapshotMeta, contentMeta)
+	}()
+
+	snap := snapshot.UnstructuredVolumeSnapshot(snapshot.VolSnapGVR, "", src.VolumeSnapshotClassName, snapshotMeta, contentMeta)
+	if _, err := dynCli.Resource(snapshot.VolSnapGVR).Namespace(targetNS).Create(ctx, snap, metav1.CreateOptions{}); err != nil {
+		return nil, errkit.Wrap(err, "Failed to create VolumeSnapshot", "volumeSnapshot", snapshotMeta.Name, "namespace", targetNS)
+	}
+	if err := snapshotter.WaitOnReadyToUse(ctx, snapshotMeta.Name, targetNS); err != nil {
+		return nil, errkit.Wrap(err, "Failed while waiting for VolumeSnapshot to be ready", "volumeSnapshot", snapshotMeta.Name, "namespace", targetNS)
+	}
+
+	a.restore.Name = snapshotMeta.Name
+	if _, err := restoreCSISnapshot(ctx, kubeCli, a.restore); err != nil {
+		return nil, errkit.Wrap(err, "Failed to restore PVC from VolumeSnapshot", "pvc", a.restore.PVC, "namespace", targetNS)
+	}
+	if !a.cleanup || waitForConsumer {
+		if a.deletionPolicy != snapshot.DeletionPolicyRetain {
+			if err := setContentDeletionPolicy(ctx, dynCli, contentMeta.Name, a.deletionPolicy); err != nil {
+				return nil, err
+			}
+		}
+		if a.cleanup {
+			log.Print("StorageClass binds volumes on first consumer, keeping intermediate VolumeSnapshot", field.M{"StorageClass": a.restore.StorageClass, "VolumeSnapshot": snapshotMeta.Name, "Namespace": targetNS})
+		}
+		return map[string]interface{}{
+			RestoreCSISnapshotToNamespacePVCOutput:      a.restore.PVC,
+			RestoreCSISnapshotToNamespaceSnapshotOutput: snapshotMeta.Name,
+			RestoreCSISnapshotToNamespaceContentOutput:  contentMeta.Name,
+		}, nil
+	}
+
+	// The VolumeSnapshot has to exist until the volume of the PVC is provisioned
+	if err := waitForPVCBound(ctx, kubeCli, targetNS, a.restore.PVC); err != nil {
+		if derr := kubeCli.CoreV1().PersistentVolumeClaims(targetNS).Delete(context.Background(), a.restore.PVC, metav1.DeleteOptions{}); derr != nil {
+			log.WithError(derr).Print("Failed to delete PVC", field.M{"PVC": a.restore.PVC, "Namespace": targetNS})
+		}
+		return nil, errkit.Wrap(err, "Failed while waiting for PVC to be bound", "pvc", a.restore.PVC, "namespace", targetNS)
+	}
+	return map[string]interface{}{
+		RestoreCSISnapshotToNamespacePVCOutput: a.restore.PVC,
+	}, nil
+}
+
+// deleteIntermediateSnapshot deletes the VolumeSnapshot and the
+// VolumeSnapshotContent created to restore the PVC. Failures are only logged
+// so that they don't hide the result of the restore.
+func deleteIntermediateSnapshot(ctx context.Context, snapshotter snapshot.Snapshotter, snapshotMeta, contentMeta snapshot.ObjectMeta) {
+	if _, err := snapshotter.Delete(ctx, snapshotMeta.Name, snapshotMeta.Namespace); err != nil {
+		log.WithError(err).Print("Failed to delete intermediate VolumeSnapshot", field.M{"VolumeSnapshot": snapshotMeta.Name, "Namespace": snapshotMeta.Namespace})
+	}
+	if err := snapshotter.DeleteContent(ctx, contentMeta.Name); err != nil {
+		log.WithError(err).Print("Failed to delete intermediate VolumeSnapshotContent", field.M{"VolumeSnapshotContent": contentMeta.Name})
+	}
+}
+
+// isWaitForFirstConsumer returns true if the StorageClass delays the binding of
+// volumes until a pod uses the PVC.
+func isWaitForFirstConsumer(ctx context.Context, kubeCli kubernetes.Interface, storageClass string) (bool, error) {
+	sc, err := kubeCli.StorageV1().StorageClasses().Get(ctx, storageClass, metav1.GetOptions{})
+	if err != nil {
+		return false, errkit.Wrap(err, "Failed to get StorageClass", "storageClass", storageClass)
+	}
+	return sc.VolumeBindingMode != nil && *sc.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer, nil
+}
+
+// setContentDeletionPolicy sets the deletion policy of a VolumeSnapshotContent.
+func setContentDeletionPolicy(ctx context.Context, dynCli dynamic.Interface, name, deletionPolicy string) error {
+	data, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{"deletionPolicy": deletionPolicy},
+	})
+	if err != nil {
+		return errkit.Wrap(err, "Failed to marshal deletion policy patch")
+	}
+	if _, err := dynCli.Resource(snapshot.VolSnapContentGVR).Patch(ctx, name, types.MergePatchType, data, metav1.PatchOptions{}); err != nil {
+		return errkit.Wrap(err, "Failed to set deletion policy of VolumeSnapshotContent", "volumeSnapshotContent", name, "deletionPolicy", deletionPolicy)
+	}
+	return nil
+}
+
+func waitForPVCBound(ctx context.Context, kubeCli kubernetes.Interface, namespace, name string) error {
+	return poll.Wait(ctx, func(ctx context.Context) (bool, error) {
+		pvc, err := kubeCli.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return pvc.Status.Phase == corev1.ClaimBound, nil
+	})
+}
+
+// validateDeletionPolicyArg rejects the Delete policy with cleanup, since
+// deleting the intermediate VolumeSnapshotContent would then delete the CSI
+// snapshot of the source VolumeSnapshot.
+func validateDeletionPolicyArg(deletionPolicy string, cleanup bool) error {
+	switch deletionPolicy {
+	case snapshot.DeletionPolicyRetain:
+		return nil
+	case snapshot.DeletionPolicyDelete:
+		if cleanup {
+			return errkit.New("deletionPolicy Delete requires cleanup to be false", "deletionPolicy", deletionPolicy)
+		}
+		return nil
+	default:
+		return errkit.New("Given deletionPolicy is not supported", "deletionPolicy", deletionPolicy)
+	}
+}
+
+func (*restoreCSISnapshotToNamespaceFunc) RequiredArgs() []string {
+	return []string{
+		RestoreCSISnapshotToNamespaceNameArg,
+		RestoreCSISnapshotToNamespaceNamespaceArg,
+		RestoreCSISnapshotToNamespaceTargetNamespaceArg,
+		RestoreCSISnapshotPVCNameArg,
+		RestoreCSISnapshotStorageClassArg,
+	}
+}
+
+func (*restoreCSISnapshotToNamespaceFunc) Arguments() []string {
+	return []string{
+		RestoreCSISnapshotToNamespaceNameArg,
+		RestoreCSISnapshotToNamespaceNamespaceArg,
+		RestoreCSISnapshotToNamespaceTargetNamespaceArg,
+		RestoreCSISnapshotPVCNameArg,
+		RestoreCSISnapshotStorageClassArg,
+		RestoreCSISnapshotRestoreSizeArg,
+		RestoreCSISnapshotAccessModesArg,
+		RestoreCSISnapshotVolumeModeArg,
+		RestoreCSISnapshotLabelsArg,
+		RestoreCSISnapshotToNamespaceDeletionPolicyArg,
+		RestoreCSISnapshotToNamespaceCleanupArg,
+	}
+}
+
+func (*restoreCSISnapshotToNamespaceFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        RestoreCSISnapshotToNamespaceFuncName,
+		Description: "Restores a new PVC in another namespace from a VolumeSnapshot",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        RestoreCSISnapshotToNamespaceNameArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the source VolumeSnapshot",
+			},
+			{
+				Name:        RestoreCSISnapshotToNamespaceNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the source VolumeSnapshot",
+			},
+			{
+				Name:        RestoreCSISnapshotToNamespaceTargetNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the new PVC",
+			},
+			{
+				Name:        RestoreCSISnapshotPVCNameArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the new PVC",
+			},
+			{
+				Name:        RestoreCSISnapshotStorageClassArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the StorageClass of the new PVC",
+			},
+			{
+				Name:        RestoreCSISnapshotRestoreSizeArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Size of the new PVC, e.g. `1Gi`, defaults to the restore size of the VolumeSnapshot",
+			},
+			{
+				Name:        RestoreCSISnapshotAccessModesArg,
+				Type:        kanister.ArgTypeList,
+				Description: "Access modes of the new PVC, defaults to ReadWriteOnce",
+			},
+			{
+				Name:        RestoreCSISnapshotVolumeModeArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Volume mode of the new PVC",
+				Default:     string(corev1.PersistentVolumeFilesystem),
+				Enum:        []string{string(corev1.PersistentVolumeFilesystem), string(corev1.PersistentVolumeBlock)},
+			},
+			{
+				Name:        RestoreCSISnapshotLabelsArg,
+				Type:        kanister.ArgTypeMap,
+				Description: "Labels added to the new PVC",
+			},
+			{
+				Name:        RestoreCSISnapshotToNamespaceDeletionPolicyArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Deletion policy of the intermediate VolumeSnapshotContent, `Delete` deletes the CSI snapshot of the source VolumeSnapshot with it",
+				Default:     snapshot.DeletionPolicyRetain,
+				Enum:        []string{snapshot.DeletionPolicyRetain, snapshot.DeletionPolicyDelete},
+			},
+			{
+				Name:        RestoreCSISnapshotToNamespaceCleanupArg,
+				Type:        kanister.ArgTypeBoolean,
+				Description: "Delete the intermediate VolumeSnapshot and VolumeSnapshotContent once the new PVC is bound",
+				Default:     true,
+			},
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: RestoreCSISnapshotToNamespacePVCOutput, Type: kanister.ArgTypeString, Description: "Name of the new PVC"},
+			{Name: RestoreCSISnapshotToNamespaceSnapshotOutput, Type: kanister.ArgTypeString, Description: "Name of the intermediate VolumeSnapshot if it's not cleaned up"},
+			{Name: RestoreCSISnapshotToNamespaceContentOutput, Type: kanister.ArgTypeString, Description: "Name of the intermediate VolumeSnapshotContent if it's not cleaned up"},
+		},
+	}
+}
+
+func (r *restoreCSISnapshotToNamespaceFunc) Validate(args map[string]any) error {
+	if err := utils.CheckSupportedArgs(r.Arguments(), args); err != nil {
+		return err
+	}
+
+	return utils.CheckRequiredArgs(r.RequiredArgs(), args)
+}
+
+func (r *restoreCSISnapshotToNamespaceFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
+	metav1Time := metav1.NewTime(time.Now())
+	return crv1alpha1.PhaseProgress{
+		ProgressPercent:    r.progressPercent,
+		LastTransitionTime: &metav1Time,
+	}, nil
+}
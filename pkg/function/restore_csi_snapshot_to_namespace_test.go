@@ -0,0 +1,240 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"context"
+	"time"
+
+	"gopkg.in/check.v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kanisterio/kanister/pkg/kube/snapshot"
+)
+
+type RestoreCSISnapshotToNamespaceTestSuite struct{}
+
+var _ = check.Suite(&RestoreCSISnapshotToNamespaceTestSuite{})
+
+const (
+	testSourceSnapshotNamespace = "test-source"
+	testTargetSnapshotNamespace = "test-target"
+)
+
+// newCrossNamespaceClients returns clients with a ready source VolumeSnapshot
+// and a StorageClass with the given volume binding mode.
+// Created VolumeSnapshots are ready and created PVCs are bound right away.
+func newCrossNamespaceClients(bindingMode storagev1.VolumeBindingMode, objects ...runtime.Object) (*fake.Clientset, *dynfake.FakeDynamicClient) {
+	sc := &storagev1.StorageClass{
+		ObjectMeta:        metav1.ObjectMeta{Name: "test-storage-class"},
+		VolumeBindingMode: &bindingMode,
+	}
+	kubeCli := fake.NewSimpleClientset(append(objects, sc)...)
+	kubeCli.PrependReactor("create", "persistentvolumeclaims", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		pvc := action.(k8stesting.CreateAction).GetObject().(*corev1.PersistentVolumeClaim)
+		pvc.Status.Phase = corev1.ClaimBound
+		return false, nil, nil
+	})
+	vs := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "snapshot.storage.k8s.io/v1",
+		"kind":       snapshot.VolSnapKind,
+		"metadata":   map[string]interface{}{"name": "snap", "namespace": testSourceSnapshotNamespace},
+		"spec": map[string]interface{}{
+			"source": map[string]interface{}{"persistentVolumeClaimName": "data"},
+		},
+		"status": map[string]interface{}{
+			"readyToUse":                     true,
+			"boundVolumeSnapshotContentName": "snap-content",
+		},
+	}}
+	vsc := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "snapshot.storage.k8s.io/v1",
+		"kind":       snapshot.VolSnapContentKind,
+		"metadata":   map[string]interface{}{"name": "snap-content"},
+		"spec": map[string]interface{}{
+			"driver":                  "hostpath.csi.k8s.io",
+			"deletionPolicy":          snapshot.DeletionPolicyDelete,
+			"volumeSnapshotClassName": "csi-hostpath-snapclass",
+		},
+		"status": map[string]interface{}{
+			"snapshotHandle": "handle",
+			"restoreSize":    int64(1 << 30),
+		},
+	}}
+	dynCli := dynfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		snapshot.VolSnapGVR:        "VolumeSnapshotList",
+		snapshot.VolSnapContentGVR: "VolumeSnapshotContentList",
+	}, vs, vsc)
+	dynCli.PrependReactor("create", "volumesnapshots", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		us := action.(k8stesting.CreateAction).GetObject().(*unstructured.Unstructured)
+		us.Object["status"] = map[string]interface{}{
+			"readyToUse":   true,
+			"creationTime": time.Now().UTC().Format(time.RFC3339),
+		}
+		return false, nil, nil
+	})
+	return kubeCli, dynCli
+}
+
+func newCrossNamespaceArgs(cleanup bool) restoreCSISnapshotToNamespaceArgs {
+	return restoreCSISnapshotToNamespaceArgs{
+		name:           "snap",
+		namespace:      testSourceSnapshotNamespace,
+		deletionPolicy: snapshot.DeletionPolicyRetain,
+		cleanup:        cleanup,
+		restore: restoreCSISnapshotArgs{
+			PVC:          "data-restored",
+			Namespace:    testTargetSnapshotNamespace,
+			StorageClass: "test-storage-class",
+			AccessModes:  []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			VolumeMode:   corev1.PersistentVolumeFilesystem,
+		},
+	}
+}
+
+func (s *RestoreCSISnapshotToNamespaceTestSuite) TestRestoreCSISnapshotToNamespace(c *check.C) {
+	ctx := context.Background()
+	kubeCli, dynCli := newCrossNamespaceClients(storagev1.VolumeBindingImmediate)
+	out, err := restoreCSISnapshotToNamespace(ctx, kubeCli, dynCli, newCrossNamespaceArgs(true))
+	c.Assert(err, check.IsNil)
+	c.Assert(out, check.DeepEquals, map[string]interface{}{RestoreCSISnapshotToNamespacePVCOutput: "data-restored"})
+
+	pvc, err := kubeCli.CoreV1().PersistentVolumeClaims(testTargetSnapshotNamespace).Get(ctx, "data-restored", metav1.GetOptions{})
+	c.Assert(err, check.IsNil)
+	size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	c.Assert(size.String(), check.Equals, "1Gi")
+
+	// Only the source VolumeSnapshot and its content are left
+	vsList, err := dynCli.Resource(snapshot.VolSnapGVR).Namespace(testTargetSnapshotNamespace).List(ctx, metav1.ListOptions{})
+	c.Assert(err, check.IsNil)
+	c.Assert(vsList.Items, check.HasLen, 0)
+	vscList, err := dynCli.Resource(snapshot.VolSnapContentGVR).List(ctx, metav1.ListOptions{})
+	c.Assert(err, check.IsNil)
+	c.Assert(vscList.Items, check.HasLen, 1)
+	c.Assert(vscList.Items[0].GetName(), check.Equals, "snap-content")
+}
+
+func (s *RestoreCSISnapshotToNamespaceTestSuite) TestRestoreCSISnapshotToNamespaceCleanupRetains(c *check.C) {
+	ctx := context.Background()
+	kubeCli, dynCli := newCrossNamespaceClients(storagev1.VolumeBindingImmediate)
+	var policies []string
+	dynCli.PrependReactor("delete", "volumesnapshotcontents", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		name := action.(k8stesting.DeleteAction).GetName()
+		vsc, err := dynCli.Tracker().Get(snapshot.VolSnapContentGVR, "", name)
+		c.Assert(err, check.IsNil)
+		policy, _, err := unstructured.NestedString(vsc.(*unstructured.Unstructured).Object, "spec", "deletionPolicy")
+		c.Assert(err, check.IsNil)
+		policies = append(policies, policy)
+		return false, nil, nil
+	})
+	_, err := restoreCSISnapshotToNamespace(ctx, kubeCli, dynCli, newCrossNamespaceArgs(true))
+	c.Assert(err, check.IsNil)
+	c.Assert(policies, check.DeepEquals, []string{snapshot.DeletionPolicyRetain})
+}
+
+func (s *RestoreCSISnapshotToNamespaceTestSuite) TestRestoreCSISnapshotToNamespaceWaitForFirstConsumer(c *check.C) {
+	ctx := context.Background()
+	kubeCli, dynCli := newCrossNamespaceClients(storagev1.VolumeBindingWaitForFirstConsumer)
+	kubeCli.PrependReactor("create", "persistentvolumeclaims", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		pvc := action.(k8stesting.CreateAction).GetObject().(*corev1.PersistentVolumeClaim)
+		pvc.Status.Phase = corev1.ClaimPending
+		return false, nil, nil
+	})
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	out, err := restoreCSISnapshotToNamespace(ctx, kubeCli, dynCli, newCrossNamespaceArgs(true))
+	c.Assert(err, check.IsNil)
+	vsName := out[RestoreCSISnapshotToNamespaceSnapshotOutput].(string)
+	vscName := out[RestoreCSISnapshotToNamespaceContentOutput].(string)
+
+	// The intermediate objects are kept until a pod uses the PVC
+	_, err = dynCli.Resource(snapshot.VolSnapGVR).Namespace(testTargetSnapshotNamespace).Get(ctx, vsName, metav1.GetOptions{})
+	c.Assert(err, check.IsNil)
+	_, err = dynCli.Resource(snapshot.VolSnapContentGVR).Get(ctx, vscName, metav1.GetOptions{})
+	c.Assert(err, check.IsNil)
+	_, err = kubeCli.CoreV1().PersistentVolumeClaims(testTargetSnapshotNamespace).Get(ctx, "data-restored", metav1.GetOptions{})
+	c.Assert(err, check.IsNil)
+}
+
+func (s *RestoreCSISnapshotToNamespaceTestSuite) TestRestoreCSISnapshotToNamespaceNoCleanupDelete(c *check.C) {
+	ctx := context.Background()
+	kubeCli, dynCli := newCrossNamespaceClients(storagev1.VolumeBindingImmediate)
+	a := newCrossNamespaceArgs(false)
+	a.deletionPolicy = snapshot.DeletionPolicyDelete
+	out, err := restoreCSISnapshotToNamespace(ctx, kubeCli, dynCli, a)
+	c.Assert(err, check.IsNil)
+
+	vsc, err := dynCli.Resource(snapshot.VolSnapContentGVR).Get(ctx, out[RestoreCSISnapshotToNamespaceContentOutput].(string), metav1.GetOptions{})
+	c.Assert(err, check.IsNil)
+	policy, _, err := unstructured.NestedString(vsc.Object, "spec", "deletionPolicy")
+	c.Assert(err, check.IsNil)
+	c.Assert(policy, check.Equals, snapshot.DeletionPolicyDelete)
+}
+
+func (s *RestoreCSISnapshotToNamespaceTestSuite) TestRestoreCSISnapshotToNamespaceNoCleanup(c *check.C) {
+	ctx := context.Background()
+	kubeCli, dynCli := newCrossNamespaceClients(storagev1.VolumeBindingImmediate)
+	out, err := restoreCSISnapshotToNamespace(ctx, kubeCli, dynCli, newCrossNamespaceArgs(false))
+	c.Assert(err, check.IsNil)
+	vsName := out[RestoreCSISnapshotToNamespaceSnapshotOutput].(string)
+	vscName := out[RestoreCSISnapshotToNamespaceContentOutput].(string)
+
+	pvc, err := kubeCli.CoreV1().PersistentVolumeClaims(testTargetSnapshotNamespace).Get(ctx, "data-restored", metav1.GetOptions{})
+	c.Assert(err, check.IsNil)
+	c.Assert(pvc.Spec.DataSource.Name, check.Equals, vsName)
+
+	vsc, err := dynCli.Resource(snapshot.VolSnapContentGVR).Get(ctx, vscName, metav1.GetOptions{})
+	c.Assert(err, check.IsNil)
+	policy, _, err := unstructured.NestedString(vsc.Object, "spec", "deletionPolicy")
+	c.Assert(err, check.IsNil)
+	c.Assert(policy, check.Equals, snapshot.DeletionPolicyRetain)
+	ref, _, err := unstructured.NestedStringMap(vsc.Object, "spec", "volumeSnapshotRef")
+	c.Assert(err, check.IsNil)
+	c.Assert(ref["name"], check.Equals, vsName)
+	c.Assert(ref["namespace"], check.Equals, testTargetSnapshotNamespace)
+}
+
+func (s *RestoreCSISnapshotToNamespaceTestSuite) TestRestoreCSISnapshotToNamespaceExistingPVC(c *check.C) {
+	ctx := context.Background()
+	kubeCli, dynCli := newCrossNamespaceClients(storagev1.VolumeBindingImmediate, &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "data-restored", Namespace: testTargetSnapshotNamespace},
+	})
+	_, err := restoreCSISnapshotToNamespace(ctx, kubeCli, dynCli, newCrossNamespaceArgs(false))
+	c.Assert(err, check.ErrorMatches, "Failed to restore PVC from VolumeSnapshot.*already exists.*")
+
+	// The intermediate objects are deleted although cleanup isn't set
+	vsList, err := dynCli.Resource(snapshot.VolSnapGVR).Namespace(testTargetSnapshotNamespace).List(ctx, metav1.ListOptions{})
+	c.Assert(err, check.IsNil)
+	c.Assert(vsList.Items, check.HasLen, 0)
+	vscList, err := dynCli.Resource(snapshot.VolSnapContentGVR).List(ctx, metav1.ListOptions{})
+	c.Assert(err, check.IsNil)
+	c.Assert(vscList.Items, check.HasLen, 1)
+}
+
+func (s *RestoreCSISnapshotToNamespaceTestSuite) TestValidateDeletionPolicyArg(c *check.C) {
+	c.Assert(validateDeletionPolicyArg(snapshot.DeletionPolicyRetain, true), check.IsNil)
+	c.Assert(validateDeletionPolicyArg(snapshot.DeletionPolicyRetain, false), check.IsNil)
+	c.Assert(validateDeletionPolicyArg(snapshot.DeletionPolicyDelete, false), check.IsNil)
+	c.Assert(validateDeletionPolicyArg(snapshot.DeletionPolicyDelete, true), check.ErrorMatches, "deletionPolicy Delete requires cleanup to be false.*")
+	c.Assert(validateDeletionPolicyArg("Orphan", false), check.NotNil)
+}
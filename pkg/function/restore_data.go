@@ -199,7 +199,7 @@ func restoreDataPodFunc(
 		if err != nil {
 			return nil, errkit.Wrap(err, "Failed to restore backup")
 		}
-		out, err := parseLogAndCreateOutput(stdout)
+		out, err := parseLogAndCreateOutput(ctx, stdout)
 		return out, errkit.Wrap(err, "Failed to parse phase output")
 	}
 }
@@ -326,6 +326,69 @@ func (*restoreDataFunc) Arguments() []string {
 	}
 }
 
+func (*restoreDataFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        RestoreDataFuncName,
+		Description: "Restores data backed up with restic to the volumes of a pod or to PVCs",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        RestoreDataNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the pod or PVCs",
+			},
+			{
+				Name:        RestoreDataImageArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Image of the pod that restores the data, needs to have restic installed",
+			},
+			{
+				Name:        RestoreDataBackupArtifactPrefixArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Path of the repository in the object store",
+			},
+			{
+				Name:        RestoreDataRestorePathArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Path to restore the data to",
+				Default:     "/",
+			},
+			encryptionKeyArgSchema,
+			{
+				Name:        RestoreDataPodArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Name of the pod to restore the volumes of, either it or volumes is required",
+			},
+			{
+				Name:        RestoreDataVolsArg,
+				Type:        kanister.ArgTypeMap,
+				Description: "Map of the PVC names to their mount paths, either it or pod is required",
+			},
+			{
+				Name:        RestoreDataBackupTagArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Tag of the backup, either it or backupIdentifier is required",
+			},
+			{
+				Name:        RestoreDataBackupIdentifierArg,
+				Type:        kanister.ArgTypeString,
+				Description: "ID of the backup, either it or backupTag is required",
+			},
+			{
+				Name:        RestoreDataBackupPathArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Path within the backup to restore from",
+			},
+			podOverrideArgSchema,
+			insecureTLSArgSchema,
+			podAnnotationsArgSchema,
+			podLabelsArgSchema,
+		},
+	}
+}
+
 func (r *restoreDataFunc) Validate(args map[string]any) error {
 	if err := ValidatePodLabelsAndAnnotations(r.Name(), args); err != nil {
 		return err
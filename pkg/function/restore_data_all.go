@@ -99,6 +99,14 @@ func validateAndGetRestoreAllOptArgs(args map[string]interface{}, tp param.Templ
 			ps = tp.Deployment.Pods
 		case tp.StatefulSet != nil:
 			ps = tp.StatefulSet.Pods
+		case tp.DaemonSet != nil:
+			ps = tp.DaemonSet.Pods
+		case tp.ReplicaSet != nil:
+			ps = tp.ReplicaSet.Pods
+		case tp.Job != nil:
+			ps = tp.Job.Pods
+		case tp.CronJob != nil:
+			ps = tp.CronJob.Pods
 		default:
 			return restorePath, encryptionKey, ps, insecureTLS, podOverride, errkit.New("Unsupported workload type")
 		}
@@ -246,6 +254,55 @@ func (*restoreDataAllFunc) Arguments() []string {
 	}
 }
 
+func (*restoreDataAllFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        RestoreDataAllFuncName,
+		Description: "Restores the data backed up with BackupDataAll to the volumes of the pods of a workload",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        RestoreDataAllNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the pods",
+			},
+			{
+				Name:        RestoreDataAllImageArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Image of the pods that restore the data, needs to have restic installed",
+			},
+			{
+				Name:        RestoreDataAllBackupArtifactPrefixArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Path of the repository in the object store",
+			},
+			{
+				Name:        RestoreDataAllBackupInfo,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Output of BackupDataAll",
+			},
+			{
+				Name:        RestoreDataAllRestorePathArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Path to restore the data to",
+				Default:     "/",
+			},
+			encryptionKeyArgSchema,
+			{
+				Name:        RestoreDataAllPodsArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Space separated names of the pods to restore, defaults to the pods of the workload",
+			},
+			podOverrideArgSchema,
+			insecureTLSArgSchema,
+			podAnnotationsArgSchema,
+			podLabelsArgSchema,
+		},
+	}
+}
+
 func (r *restoreDataAllFunc) Validate(args map[string]any) error {
 	if err := ValidatePodLabelsAndAnnotations(r.Name(), args); err != nil {
 		return err
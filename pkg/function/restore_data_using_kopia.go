@@ -0,0 +1,264 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kanisterio/errkit"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/consts"
+	"github.com/kanisterio/kanister/pkg/format"
+	"github.com/kanisterio/kanister/pkg/kube"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/progress"
+	"github.com/kanisterio/kanister/pkg/utils"
+)
+
+const (
+	// RestoreDataUsingKopiaFuncName gives the name of the function
+	RestoreDataUsingKopiaFuncName  = "RestoreDataUsingKopia"
+	restoreDataUsingKopiaJobPrefix = "restore-data-kopia-"
+)
+
+func init() {
+	_ = kanister.Register(&restoreDataUsingKopiaFunc{})
+}
+
+var _ kanister.Func = (*restoreDataUsingKopiaFunc)(nil)
+
+type restoreDataUsingKopiaFunc struct {
+	progressPercent string
+}
+
+func (*restoreDataUsingKopiaFunc) Name() string {
+	return RestoreDataUsingKopiaFuncName
+}
+
+func (r *restoreDataUsingKopiaFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	// Set progress percent
+	r.progressPercent = progress.StartedPercent
+	defer func() { r.progressPercent = progress.CompletedPercent }()
+
+	var namespace, image, snapJSON, restorePath, pod string
+	var vols map[string]string
+	var bpAnnotations, bpLabels map[string]string
+	if err := Arg(args, RestoreDataNamespaceArg, &namespace); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, KopiaSnapshotArg, &snapJSON); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, RestoreDataImageArg, &image, consts.GetKanisterToolsImage()); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, RestoreDataRestorePathArg, &restorePath, "/"); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, RestoreDataPodArg, &pod, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, RestoreDataVolsArg, &vols, nil); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, PodAnnotationsArg, &bpAnnotations, nil); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, PodLabelsArg, &bpLabels, nil); err != nil {
+		return nil, err
+	}
+	if (pod != "") == (len(vols) > 0) {
+		return nil, errkit.New(fmt.Sprintf("Require one argument: %s or %s", RestoreDataPodArg, RestoreDataVolsArg))
+	}
+	if err := validateKopiaSnapshot(snapJSON); err != nil {
+		return nil, err
+	}
+	podOverride, err := GetPodSpecOverride(tp, args, PodOverrideArg)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := bpAnnotations
+	labels := bpLabels
+	if tp.PodAnnotations != nil {
+		// merge the actionset annotations with blueprint annotations
+		var actionSetAnn ActionSetAnnotations = tp.PodAnnotations
+		annotations = actionSetAnn.MergeBPAnnotations(bpAnnotations)
+	}
+
+	if tp.PodLabels != nil {
+		// merge the actionset labels with blueprint labels
+		var actionSetLabels ActionSetLabels = tp.PodLabels
+		labels = actionSetLabels.MergeBPLabels(bpLabels)
+	}
+
+	if err = validateKopiaProfile(tp.Profile); err != nil {
+		return nil, err
+	}
+
+	if len(vols) == 0 {
+		if vols, err = FetchPodVolumes(pod, tp); err != nil {
+			return nil, err
+		}
+	}
+	cli, err := kube.NewClient()
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to create Kubernetes client")
+	}
+	return restoreDataUsingKopia(ctx, cli, tp.Profile, namespace, image, snapJSON, restorePath, vols, podOverride, annotations, labels)
+}
+
+func restoreDataUsingKopia(
+	ctx context.Context,
+	cli kubernetes.Interface,
+	profile *param.Profile,
+	namespace,
+	image,
+	snapJSON,
+	restorePath string,
+	vols map[string]string,
+	podOverride crv1alpha1.JSONMap,
+	annotations,
+	labels map[string]string,
+) (map[string]interface{}, error) {
+	podFunc := func(ctx context.Context, pc kube.PodController) (map[string]interface{}, error) {
+		pod := pc.Pod()
+		if err := pc.WaitForPodReady(ctx); err != nil {
+			return nil, errkit.Wrap(err, "Failed while waiting for Pod to be ready", "pod", pod.Name)
+		}
+		ex, err := pc.GetCommandExecutor()
+		if err != nil {
+			return nil, err
+		}
+		stdin, err := kopiaProfileStdin(profile)
+		if err != nil {
+			return nil, err
+		}
+		var stdout, stderr bytes.Buffer
+		err = ex.Exec(ctx, kopiaPullCommand(snapJSON, restorePath), stdin, &stdout, &stderr)
+		format.LogWithCtx(ctx, pod.Name, pod.Spec.Containers[0].Name, stdout.String())
+		format.LogWithCtx(ctx, pod.Name, pod.Spec.Containers[0].Name, stderr.String())
+		if err != nil {
+			return nil, errkit.Wrap(err, "Failed to restore kopia snapshot")
+		}
+		return nil, nil
+	}
+	return PrepareAndRunPod(
+		ctx,
+		cli,
+		namespace,
+		restoreDataUsingKopiaJobPrefix,
+		image,
+		[]string{"sh", "-c", "tail -f /dev/null"},
+		vols,
+		podOverride,
+		annotations,
+		labels,
+		podFunc,
+	)
+}
+
+func (*restoreDataUsingKopiaFunc) RequiredArgs() []string {
+	return []string{
+		RestoreDataNamespaceArg,
+		KopiaSnapshotArg,
+	}
+}
+
+func (*restoreDataUsingKopiaFunc) Arguments() []string {
+	return []string{
+		RestoreDataNamespaceArg,
+		KopiaSnapshotArg,
+		RestoreDataImageArg,
+		RestoreDataRestorePathArg,
+		RestoreDataPodArg,
+		RestoreDataVolsArg,
+		PodOverrideArg,
+		PodAnnotationsArg,
+		PodLabelsArg,
+	}
+}
+
+func (*restoreDataUsingKopiaFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        RestoreDataUsingKopiaFuncName,
+		Description: "Restores a kopia snapshot created with BackupDataUsingKopia to volumes in a new pod",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        RestoreDataNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the pod or PVCs",
+			},
+			{
+				Name:        KopiaSnapshotArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Kopia snapshot output by BackupDataUsingKopia",
+			},
+			{
+				Name:        RestoreDataImageArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Image of the pod that restores the data, the image needs to have kando installed",
+			},
+			{
+				Name:        RestoreDataRestorePathArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Path to restore the data to",
+				Default:     "/",
+			},
+			{
+				Name:        RestoreDataPodArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Name of the pod to restore the volumes of, either it or volumes is required",
+			},
+			{
+				Name:        RestoreDataVolsArg,
+				Type:        kanister.ArgTypeMap,
+				Description: "Map of the PVC names to their mount paths, either it or pod is required",
+			},
+			podOverrideArgSchema,
+			podAnnotationsArgSchema,
+			podLabelsArgSchema,
+		},
+	}
+}
+
+func (r *restoreDataUsingKopiaFunc) Validate(args map[string]any) error {
+	if err := ValidatePodLabelsAndAnnotations(r.Name(), args); err != nil {
+		return err
+	}
+
+	if err := utils.CheckSupportedArgs(r.Arguments(), args); err != nil {
+		return err
+	}
+
+	return utils.CheckRequiredArgs(r.RequiredArgs(), args)
+}
+
+func (r *restoreDataUsingKopiaFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
+	metav1Time := metav1.NewTime(time.Now())
+	return crv1alpha1.PhaseProgress{
+		ProgressPercent:    r.progressPercent,
+		LastTransitionTime: &metav1Time,
+	}, nil
+}
@@ -115,6 +115,75 @@ func (*restoreRDSSnapshotFunc) Arguments() []string {
 	}
 }
 
+func (*restoreRDSSnapshotFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        RestoreRDSSnapshotFuncName,
+		Description: "Restores an RDS instance or Aurora cluster from a snapshot or from a dump in the object store",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        RestoreRDSSnapshotInstanceID,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "ID of the RDS instance or Aurora cluster",
+			},
+			{
+				Name:        RestoreRDSSnapshotSnapshotID,
+				Type:        kanister.ArgTypeString,
+				Description: "ID of the snapshot to restore from",
+			},
+			{
+				Name:        RestoreRDSSnapshotDBEngine,
+				Type:        kanister.ArgTypeString,
+				Description: "Engine of the database",
+			},
+			{
+				Name:        RestoreRDSSnapshotBackupArtifactPrefix,
+				Type:        kanister.ArgTypeString,
+				Description: "Path of the dump in the object store, required to restore from a dump",
+			},
+			{
+				Name:        RestoreRDSSnapshotBackupID,
+				Type:        kanister.ArgTypeString,
+				Description: "ID of the dump, required to restore from a dump",
+			},
+			{
+				Name:        RestoreRDSSnapshotUsername,
+				Type:        kanister.ArgTypeString,
+				Description: "Username of the database, required to restore from a dump",
+			},
+			{
+				Name:        RestoreRDSSnapshotPassword,
+				Type:        kanister.ArgTypeString,
+				Description: "Password of the database, required to restore from a dump",
+			},
+			{
+				Name:        RestoreRDSSnapshotNamespace,
+				Type:        kanister.ArgTypeString,
+				Description: "Namespace to create the pod that restores the dump in, required to restore from a dump",
+			},
+			{
+				Name:        RestoreRDSSnapshotSecGrpID,
+				Type:        kanister.ArgTypeAny,
+				Description: "List of the security group IDs of the restored instance, or a YAML formatted list",
+			},
+			{
+				Name:        RestoreRDSSnapshotDBSubnetGroup,
+				Type:        kanister.ArgTypeString,
+				Description: "Subnet group of the restored instance",
+				Default:     "default",
+			},
+			podAnnotationsArgSchema,
+			podLabelsArgSchema,
+			credentialsSourceArgSchema,
+			credentialsSecretArgSchema,
+			regionArgSchema,
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: RestoreRDSSnapshotEndpoint, Type: kanister.ArgTypeString, Description: "Endpoint of the restored instance"},
+		},
+	}
+}
+
 func (r *restoreRDSSnapshotFunc) Validate(args map[string]any) error {
 	if err := ValidatePodLabelsAndAnnotations(r.Name(), args); err != nil {
 		return err
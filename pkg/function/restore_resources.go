@@ -0,0 +1,519 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/kanisterio/errkit"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/field"
+	"github.com/kanisterio/kanister/pkg/filter"
+	"github.com/kanisterio/kanister/pkg/kube"
+	"github.com/kanisterio/kanister/pkg/location"
+	"github.com/kanisterio/kanister/pkg/log"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/progress"
+	"github.com/kanisterio/kanister/pkg/utils"
+)
+
+func init() {
+	_ = kanister.Register(&restoreResourcesFunc{})
+}
+
+var (
+	_ kanister.Func = (*restoreResourcesFunc)(nil)
+)
+
+const (
+	// RestoreResourcesFuncName gives the function name
+	RestoreResourcesFuncName = "RestoreResources"
+	// RestoreResourcesPathArg provides the path of the archive output by BackupResources
+	RestoreResourcesPathArg = "path"
+	// RestoreResourcesNamespaceArg provides the namespace the resources are restored to
+	RestoreResourcesNamespaceArg = "namespace"
+	// RestoreResourcesIncludeArg lists the resources to restore
+	RestoreResourcesIncludeArg = "includeResources"
+	// RestoreResourcesExcludeArg lists the resources not to restore
+	RestoreResourcesExcludeArg = "excludeResources"
+	// RestoreResourcesLabelsArg has labels that are set on the restored resources
+	RestoreResourcesLabelsArg = "labels"
+	// RestoreResourcesAnnotationsArg has annotations that are set on the restored resources
+	RestoreResourcesAnnotationsArg = "annotations"
+	// RestoreResourcesStorageClassMappingArg maps the StorageClasses of the PVCs to new ones
+	RestoreResourcesStorageClassMappingArg = "storageClassMapping"
+	// RestoreResourcesTransformsArg lists JSON patches that are applied to the matching resources
+	RestoreResourcesTransformsArg = "transforms"
+	// RestoreResourcesDryRunArg only reports the resources that would be restored and the conflicts
+	RestoreResourcesDryRunArg = "dryRun"
+	// RestoreResourcesRestoredOutput is the key used for returning the restored resources
+	RestoreResourcesRestoredOutput = "restored"
+	// RestoreResourcesConflictsOutput is the key used for returning the resources that already exist
+	RestoreResourcesConflictsOutput = "conflicts"
+)
+
+// Tiers of the order in which resources are restored. Resources of types
+// that are not listed, e.g. custom resources, are restored last.
+const (
+	restoreTierDefinitions = iota
+	restoreTierNamespaces
+	restoreTierConfig
+	restoreTierWorkloads
+	restoreTierOther
+)
+
+var restoreTiers = map[schema.GroupResource]int{
+	{Group: "apiextensions.k8s.io", Resource: "customresourcedefinitions"}: restoreTierDefinitions,
+	{Resource: "namespaces"}:                                       restoreTierNamespaces,
+	{Resource: "serviceaccounts"}:                                  restoreTierConfig,
+	{Resource: "secrets"}:                                          restoreTierConfig,
+	{Resource: "configmaps"}:                                       restoreTierConfig,
+	{Resource: "limitranges"}:                                      restoreTierConfig,
+	{Resource: "resourcequotas"}:                                   restoreTierConfig,
+	{Resource: "persistentvolumeclaims"}:                           restoreTierConfig,
+	{Resource: "services"}:                                         restoreTierConfig,
+	{Group: "rbac.authorization.k8s.io", Resource: "roles"}:        restoreTierConfig,
+	{Group: "rbac.authorization.k8s.io", Resource: "rolebindings"}: restoreTierConfig,
+	{Group: "storage.k8s.io", Resource: "storageclasses"}:          restoreTierConfig,
+	{Resource: "pods"}:                                             restoreTierWorkloads,
+	{Resource: "replicationcontrollers"}:                           restoreTierWorkloads,
+	{Group: "apps", Resource: "deployments"}:                       restoreTierWorkloads,
+	{Group: "apps", Resource: "statefulsets"}:                      restoreTierWorkloads,
+	{Group: "apps", Resource: "daemonsets"}:                        restoreTierWorkloads,
+	{Group: "apps", Resource: "replicasets"}:                       restoreTierWorkloads,
+	{Group: "batch", Resource: "jobs"}:                             restoreTierWorkloads,
+	{Group: "batch", Resource: "cronjobs"}:                         restoreTierWorkloads,
+}
+
+func restoreTier(gvr schema.GroupVersionResource) int {
+	if tier, ok := restoreTiers[gvr.GroupResource()]; ok {
+		return tier
+	}
+	return restoreTierOther
+}
+
+// resourceTransform is a JSON patch that is applied to the resources that
+// match the requirement.
+type resourceTransform struct {
+	filter.ResourceRequirement `json:",inline"`
+	Patch                      json.RawMessage `json:"patch"`
+}
+
+type restoreResourcesArgs struct {
+	namespace           string
+	include             filter.ResourceMatcher
+	exclude             filter.ResourceMatcher
+	labels              map[string]string
+	annotations         map[string]string
+	storageClassMapping map[string]string
+	transforms          []resourceTransform
+	dryRun              bool
+}
+
+type restoreResourcesFunc struct {
+	progressPercent string
+}
+
+func (*restoreResourcesFunc) Name() string {
+	return RestoreResourcesFuncName
+}
+
+func (r *restoreResourcesFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	// Set progress percent
+	r.progressPercent = progress.StartedPercent
+	defer func() { r.progressPercent = progress.CompletedPercent }()
+
+	var path string
+	var a restoreResourcesArgs
+	var err error
+	if err := Arg(args, RestoreResourcesPathArg, &path); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, RestoreResourcesNamespaceArg, &a.namespace, ""); err != nil {
+		return nil, err
+	}
+	if a.include, err = resourceMatcherArg(args, RestoreResourcesIncludeArg); err != nil {
+		return nil, err
+	}
+	if a.exclude, err = resourceMatcherArg(args, RestoreResourcesExcludeArg); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, RestoreResourcesLabelsArg, &a.labels, nil); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, RestoreResourcesAnnotationsArg, &a.annotations, nil); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, RestoreResourcesStorageClassMappingArg, &a.storageClassMapping, nil); err != nil {
+		return nil, err
+	}
+	if err := optJSONArg(args, RestoreResourcesTransformsArg, &a.transforms); err != nil {
+		return nil, err
+	}
+	if err := validateResourceTransforms(a.transforms); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, RestoreResourcesDryRunArg, &a.dryRun, false); err != nil {
+		return nil, err
+	}
+	if err := ValidateProfile(tp.Profile); err != nil {
+		return nil, errkit.Wrap(err, "Failed to validate Profile")
+	}
+
+	var buf bytes.Buffer
+	if err := location.Read(ctx, &buf, *tp.Profile, path); err != nil {
+		return nil, errkit.Wrap(err, "Failed to read resource archive", "path", path)
+	}
+	metadata, resources, err := readResourceArchive(&buf)
+	if err != nil {
+		return nil, err
+	}
+	if a.namespace == "" {
+		a.namespace = metadata.Namespace
+	}
+	resources, err = prepareResources(resources, metadata.Namespace, a)
+	if err != nil {
+		return nil, err
+	}
+
+	dynCli, err := kube.NewDynamicClient()
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to create dynamic Kubernetes client")
+	}
+	restored, conflicts, err := restoreResources(ctx, dynCli, a.namespace, resources, a.dryRun)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		RestoreResourcesRestoredOutput:  restored,
+		RestoreResourcesConflictsOutput: conflicts,
+	}, nil
+}
+
+func validateResourceTransforms(transforms []resourceTransform) error {
+	for i, t := range transforms {
+		if _, err := jsonpatch.DecodePatch(t.Patch); err != nil {
+			return errkit.Wrap(err, "Invalid JSON patch", "transform", i)
+		}
+	}
+	return nil
+}
+
+// prepareResources filters the resources and transforms them for the
+// target namespace, sorted in the order they're restored. The filters and
+// transforms are matched against the resources as they're archived.
+func prepareResources(resources []archivedResource, sourceNamespace string, a restoreResourcesArgs) ([]archivedResource, error) {
+	prepared := make([]archivedResource, 0, len(resources))
+	for _, r := range resources {
+		name, labels := r.Object.GetName(), r.Object.GetLabels()
+		if !a.include.Empty() && !a.include.Any(name, r.GVR, labels) {
+			continue
+		}
+		if a.exclude.Any(name, r.GVR, labels) {
+			continue
+		}
+		var patches []jsonpatch.Patch
+		for _, t := range a.transforms {
+			if !t.Matches(name, r.GVR, labels) {
+				continue
+			}
+			patch, err := jsonpatch.DecodePatch(t.Patch)
+			if err != nil {
+				return nil, errkit.Wrap(err, "Invalid JSON patch")
+			}
+			patches = append(patches, patch)
+		}
+
+		obj := r.Object.DeepCopy()
+		// Cluster scoped resources don't have a namespace
+		if obj.GetNamespace() != "" {
+			obj.SetNamespace(a.namespace)
+		}
+		if r.GVR.GroupResource() == (schema.GroupResource{Group: "rbac.authorization.k8s.io", Resource: "rolebindings"}) {
+			remapSubjectNamespaces(obj, sourceNamespace, a.namespace)
+		}
+		if err := remapStorageClasses(r.GVR, obj, a.storageClassMapping); err != nil {
+			return nil, err
+		}
+		obj.SetLabels(mergeStringMaps(obj.GetLabels(), a.labels))
+		obj.SetAnnotations(mergeStringMaps(obj.GetAnnotations(), a.annotations))
+		for _, patch := range patches {
+			var err error
+			if obj, err = applyJSONPatch(obj, patch); err != nil {
+				return nil, errkit.Wrap(err, "Failed to transform resource", "resource", r.GVR.String(), "name", name)
+			}
+		}
+		prepared = append(prepared, archivedResource{GVR: r.GVR, Object: *obj})
+	}
+	sort.SliceStable(prepared, func(i, j int) bool {
+		return restoreTier(prepared[i].GVR) < restoreTier(prepared[j].GVR)
+	})
+	return prepared, nil
+}
+
+// remapSubjectNamespaces points the subjects of a RoleBinding in the source
+// namespace to the target namespace.
+func remapSubjectNamespaces(obj *unstructured.Unstructured, sourceNamespace, targetNamespace string) {
+	subjects, found, err := unstructured.NestedSlice(obj.Object, "subjects")
+	if !found || err != nil {
+		return
+	}
+	for _, s := range subjects {
+		if subject, ok := s.(map[string]interface{}); ok && subject["namespace"] == sourceNamespace {
+			subject["namespace"] = targetNamespace
+		}
+	}
+	_ = unstructured.SetNestedSlice(obj.Object, subjects, "subjects")
+}
+
+// remapStorageClasses replaces the StorageClasses of PVCs and of the volume
+// claim templates of StatefulSets according to the mapping.
+func remapStorageClasses(gvr schema.GroupVersionResource, obj *unstructured.Unstructured, mapping map[string]string) error {
+	if len(mapping) == 0 {
+		return nil
+	}
+	remap := func(pvc map[string]interface{}) error {
+		sc, found, err := unstructured.NestedString(pvc, "spec", "storageClassName")
+		if !found || err != nil {
+			return err
+		}
+		if to, ok := mapping[sc]; ok {
+			return unstructured.SetNestedField(pvc, to, "spec", "storageClassName")
+		}
+		return nil
+	}
+	switch gvr.GroupResource() {
+	case schema.GroupResource{Resource: "persistentvolumeclaims"}:
+		return remap(obj.Object)
+	case schema.GroupResource{Group: "apps", Resource: "statefulsets"}:
+		templates, found, err := unstructured.NestedSlice(obj.Object, "spec", "volumeClaimTemplates")
+		if !found || err != nil {
+			return err
+		}
+		for _, t := range templates {
+			if template, ok := t.(map[string]interface{}); ok {
+				if err := remap(template); err != nil {
+					return err
+				}
+			}
+		}
+		return unstructured.SetNestedSlice(obj.Object, templates, "spec", "volumeClaimTemplates")
+	}
+	return nil
+}
+
+func mergeStringMaps(base, overrides map[string]string) map[string]string {
+	if len(overrides) == 0 {
+		return base
+	}
+	merged := make(map[string]string, len(base)+len(overrides))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		merged[k] = v
+	}
+	return merged
+}
+
+func applyJSONPatch(obj *unstructured.Unstructured, patch jsonpatch.Patch) (*unstructured.Unstructured, error) {
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	patched, err := patch.Apply(data)
+	if err != nil {
+		return nil, err
+	}
+	out := &unstructured.Unstructured{}
+	if err := out.UnmarshalJSON(patched); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// restoreResources creates the resources in order. Resources that already
+// exist are left unchanged and returned as conflicts. In dry run mode the
+// resources are created with server side dry run, so that nothing is
+// persisted, and the resources rejected by admission or validation are
+// returned as conflicts as well.
+func restoreResources(ctx context.Context, dynCli dynamic.Interface, namespace string, resources []archivedResource, dryRun bool) (restored, conflicts []string, err error) {
+	restored, conflicts = []string{}, []string{}
+	opts := metav1.CreateOptions{}
+	if dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	nsCreated, err := ensureNamespace(ctx, dynCli, namespace, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, r := range resources {
+		ref := r.GVR.GroupResource().String() + "/" + r.Object.GetName()
+		var ri dynamic.ResourceInterface = dynCli.Resource(r.GVR)
+		if ns := r.Object.GetNamespace(); ns != "" {
+			ri = dynCli.Resource(r.GVR).Namespace(ns)
+		}
+		_, err := ri.Create(ctx, &r.Object, opts)
+		switch {
+		case err == nil:
+			restored = append(restored, ref)
+		case apierrors.IsAlreadyExists(err):
+			conflicts = append(conflicts, ref)
+		case dryRun && nsCreated && apierrors.IsNotFound(err):
+			// The namespace isn't persisted in dry run mode, so the
+			// resources in it can't be validated
+			restored = append(restored, ref)
+		case dryRun:
+			log.WithError(err).Print("Resource would not be restored", field.M{"Resource": ref})
+			conflicts = append(conflicts, ref)
+		default:
+			return nil, nil, errkit.Wrap(err, "Failed to create resource", "resource", ref)
+		}
+	}
+	if len(conflicts) > 0 {
+		log.Print("Resources already exist and are not restored", field.M{"Namespace": namespace, "Conflicts": conflicts})
+	}
+	return restored, conflicts, nil
+}
+
+// ensureNamespace creates the namespace if it doesn't exist and returns
+// true if it was created.
+func ensureNamespace(ctx context.Context, dynCli dynamic.Interface, namespace string, opts metav1.CreateOptions) (bool, error) {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+	_, err := dynCli.Resource(gvr).Get(ctx, namespace, metav1.GetOptions{})
+	if err == nil || !apierrors.IsNotFound(err) {
+		return false, err
+	}
+	ns := &unstructured.Unstructured{}
+	ns.SetAPIVersion("v1")
+	ns.SetKind("Namespace")
+	ns.SetName(namespace)
+	_, err = dynCli.Resource(gvr).Create(ctx, ns, opts)
+	switch {
+	case apierrors.IsAlreadyExists(err):
+		return false, nil
+	case err != nil:
+		return false, errkit.Wrap(err, "Failed to create namespace", "namespace", namespace)
+	}
+	return true, nil
+}
+
+func (*restoreResourcesFunc) RequiredArgs() []string {
+	return []string{
+		RestoreResourcesPathArg,
+	}
+}
+
+func (*restoreResourcesFunc) Arguments() []string {
+	return []string{
+		RestoreResourcesPathArg,
+		RestoreResourcesNamespaceArg,
+		RestoreResourcesIncludeArg,
+		RestoreResourcesExcludeArg,
+		RestoreResourcesLabelsArg,
+		RestoreResourcesAnnotationsArg,
+		RestoreResourcesStorageClassMappingArg,
+		RestoreResourcesTransformsArg,
+		RestoreResourcesDryRunArg,
+	}
+}
+
+func (*restoreResourcesFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        RestoreResourcesFuncName,
+		Description: "Restores the manifests backed up by BackupResources to a namespace",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        RestoreResourcesPathArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Path of the archive output by BackupResources",
+			},
+			{
+				Name:        RestoreResourcesNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Namespace to restore the resources to, defaults to the namespace of the backup",
+			},
+			{
+				Name:        RestoreResourcesIncludeArg,
+				Type:        kanister.ArgTypeList,
+				Description: "Resources to restore, matched by group, version, resource, name and labels",
+			},
+			{
+				Name:        RestoreResourcesExcludeArg,
+				Type:        kanister.ArgTypeList,
+				Description: "Resources not to restore, matched by group, version, resource, name and labels",
+			},
+			{
+				Name:        RestoreResourcesLabelsArg,
+				Type:        kanister.ArgTypeMap,
+				Description: "Labels set on the restored resources",
+			},
+			{
+				Name:        RestoreResourcesAnnotationsArg,
+				Type:        kanister.ArgTypeMap,
+				Description: "Annotations set on the restored resources",
+			},
+			{
+				Name:        RestoreResourcesStorageClassMappingArg,
+				Type:        kanister.ArgTypeMap,
+				Description: "StorageClasses of the restored PVCs and volume claim templates, keyed by the backed up StorageClass",
+			},
+			{
+				Name:        RestoreResourcesTransformsArg,
+				Type:        kanister.ArgTypeList,
+				Description: "JSON patches applied to the matching resources",
+			},
+			{
+				Name:        RestoreResourcesDryRunArg,
+				Type:        kanister.ArgTypeBoolean,
+				Description: "Only report the resources that would be restored and the conflicts",
+				Default:     false,
+			},
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: RestoreResourcesRestoredOutput, Type: kanister.ArgTypeList, Description: "Restored resources, or the resources that would be restored in dry run mode"},
+			{Name: RestoreResourcesConflictsOutput, Type: kanister.ArgTypeList, Description: "Resources that already exist and are not restored"},
+		},
+	}
+}
+
+func (r *restoreResourcesFunc) Validate(args map[string]any) error {
+	if err := utils.CheckSupportedArgs(r.Arguments(), args); err != nil {
+		return err
+	}
+
+	return utils.CheckRequiredArgs(r.RequiredArgs(), args)
+}
+
+func (r *restoreResourcesFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
+	metav1Time := metav1.NewTime(time.Now())
+	return crv1alpha1.PhaseProgress{
+		ProgressPercent:    r.progressPercent,
+		LastTransitionTime: &metav1Time,
+	}, nil
+}
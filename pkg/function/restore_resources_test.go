@@ -0,0 +1,269 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+
+	"gopkg.in/check.v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kanisterio/kanister/pkg/filter"
+)
+
+type RestoreResourcesSuite struct{}
+
+var _ = check.Suite(&RestoreResourcesSuite{})
+
+const testRestoreResourcesNamespace = "test-restore-resources"
+
+var (
+	testPVCGVR         = schema.GroupVersionResource{Version: "v1", Resource: "persistentvolumeclaims"}
+	testStatefulSetGVR = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "statefulsets"}
+	testRoleBindingGVR = schema.GroupVersionResource{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "rolebindings"}
+	testCustomGVR      = schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+	testNamespaceGVR   = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+)
+
+func newArchivedResource(gvr schema.GroupVersionResource, kind, name string, obj map[string]interface{}) archivedResource {
+	apiVersion := gvr.GroupVersion().String()
+	u := unstructured.Unstructured{Object: obj}
+	u.SetAPIVersion(apiVersion)
+	u.SetKind(kind)
+	u.SetName(name)
+	u.SetNamespace(testBackupResourcesNamespace)
+	return archivedResource{GVR: gvr, Object: u}
+}
+
+// newTestArchivedResources returns resources in the order they're archived.
+func newTestArchivedResources() []archivedResource {
+	return []archivedResource{
+		newArchivedResource(testCustomGVR, "Widget", "widget", map[string]interface{}{}),
+		newArchivedResource(testDeploymentGVR, "Deployment", "app", map[string]interface{}{
+			"metadata": map[string]interface{}{"labels": map[string]interface{}{"app": "db"}},
+			"spec":     map[string]interface{}{"replicas": int64(3)},
+		}),
+		newArchivedResource(testStatefulSetGVR, "StatefulSet", "db", map[string]interface{}{
+			"spec": map[string]interface{}{
+				"volumeClaimTemplates": []interface{}{
+					map[string]interface{}{"spec": map[string]interface{}{"storageClassName": "standard"}},
+				},
+			},
+		}),
+		newArchivedResource(testConfigMapGVR, "ConfigMap", "config", map[string]interface{}{
+			"data": map[string]interface{}{"key": "value"},
+		}),
+		newArchivedResource(testPVCGVR, "PersistentVolumeClaim", "data", map[string]interface{}{
+			"spec": map[string]interface{}{"storageClassName": "standard"},
+		}),
+		newArchivedResource(testRoleBindingGVR, "RoleBinding", "app", map[string]interface{}{
+			"subjects": []interface{}{
+				map[string]interface{}{"kind": "ServiceAccount", "name": "app", "namespace": testBackupResourcesNamespace},
+				map[string]interface{}{"kind": "ServiceAccount", "name": "monitor", "namespace": "monitoring"},
+			},
+		}),
+	}
+}
+
+func (s *RestoreResourcesSuite) TestReadResourceArchive(c *check.C) {
+	var buf bytes.Buffer
+	err := writeResourceArchive(&buf, testBackupResourcesNamespace, newTestArchivedResources())
+	c.Assert(err, check.IsNil)
+	metadata, resources, err := readResourceArchive(&buf)
+	c.Assert(err, check.IsNil)
+	c.Assert(metadata.Version, check.Equals, resourceArchiveVersion)
+	c.Assert(metadata.Namespace, check.Equals, testBackupResourcesNamespace)
+	c.Assert(archivedNames(resources), check.DeepEquals, []string{
+		"configmaps/config",
+		"persistentvolumeclaims/data",
+		"deployments/app",
+		"statefulsets/db",
+		"widgets/widget",
+		"rolebindings/app",
+	})
+	// Integers are kept
+	replicas, found, err := unstructured.NestedInt64(resources[2].Object.Object, "spec", "replicas")
+	c.Assert(err, check.IsNil)
+	c.Assert(found, check.Equals, true)
+	c.Assert(replicas, check.Equals, int64(3))
+}
+
+func (s *RestoreResourcesSuite) TestReadResourceArchiveUnsupportedVersion(c *check.C) {
+	var buf bytes.Buffer
+	err := writeResourceArchive(&buf, testBackupResourcesNamespace, nil)
+	c.Assert(err, check.IsNil)
+	metadata, _, err := readResourceArchive(bytes.NewReader(buf.Bytes()))
+	c.Assert(err, check.IsNil)
+	c.Assert(metadata.Resources, check.HasLen, 0)
+
+	metadata.Version = "v0"
+	data, err := json.Marshal(metadata)
+	c.Assert(err, check.IsNil)
+	buf.Reset()
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	c.Assert(writeTarFile(tw, resourceArchiveMetadataFile, data), check.IsNil)
+	c.Assert(tw.Close(), check.IsNil)
+	c.Assert(gzw.Close(), check.IsNil)
+	_, _, err = readResourceArchive(&buf)
+	c.Assert(err, check.ErrorMatches, "Unsupported archive version.*")
+}
+
+func (s *RestoreResourcesSuite) TestPrepareResources(c *check.C) {
+	var transforms []resourceTransform
+	err := optJSONArg(map[string]interface{}{
+		RestoreResourcesTransformsArg: []interface{}{
+			map[string]interface{}{
+				"group":    "apps",
+				"resource": "deployments",
+				"patch": []interface{}{
+					map[string]interface{}{"op": "replace", "path": "/spec/replicas", "value": 1},
+				},
+			},
+		},
+	}, RestoreResourcesTransformsArg, &transforms)
+	c.Assert(err, check.IsNil)
+	c.Assert(validateResourceTransforms(transforms), check.IsNil)
+
+	a := restoreResourcesArgs{
+		namespace:           testRestoreResourcesNamespace,
+		exclude:             filter.ResourceMatcher{{ResourceTypeRequirement: filter.ResourceTypeRequirement{Group: "example.com"}}},
+		labels:              map[string]string{"restored": "true"},
+		annotations:         map[string]string{"restored-from": testBackupResourcesNamespace},
+		storageClassMapping: map[string]string{"standard": "fast"},
+		transforms:          transforms,
+	}
+	resources, err := prepareResources(newTestArchivedResources(), testBackupResourcesNamespace, a)
+	c.Assert(err, check.IsNil)
+	// Config comes before workloads, the custom resource is excluded
+	c.Assert(archivedNames(resources), check.DeepEquals, []string{
+		"configmaps/config",
+		"persistentvolumeclaims/data",
+		"rolebindings/app",
+		"deployments/app",
+		"statefulsets/db",
+	})
+	for _, r := range resources {
+		c.Assert(r.Object.GetNamespace(), check.Equals, testRestoreResourcesNamespace)
+		c.Assert(r.Object.GetLabels()["restored"], check.Equals, "true")
+		c.Assert(r.Object.GetAnnotations()["restored-from"], check.Equals, testBackupResourcesNamespace)
+	}
+	c.Assert(resources[3].Object.GetLabels(), check.DeepEquals, map[string]string{"app": "db", "restored": "true"})
+
+	sc, _, err := unstructured.NestedString(resources[1].Object.Object, "spec", "storageClassName")
+	c.Assert(err, check.IsNil)
+	c.Assert(sc, check.Equals, "fast")
+	templates, _, err := unstructured.NestedSlice(resources[4].Object.Object, "spec", "volumeClaimTemplates")
+	c.Assert(err, check.IsNil)
+	sc, _, err = unstructured.NestedString(templates[0].(map[string]interface{}), "spec", "storageClassName")
+	c.Assert(err, check.IsNil)
+	c.Assert(sc, check.Equals, "fast")
+
+	subjects, _, err := unstructured.NestedSlice(resources[2].Object.Object, "subjects")
+	c.Assert(err, check.IsNil)
+	c.Assert(subjects[0].(map[string]interface{})["namespace"], check.Equals, testRestoreResourcesNamespace)
+	c.Assert(subjects[1].(map[string]interface{})["namespace"], check.Equals, "monitoring")
+
+	replicas, _, err := unstructured.NestedInt64(resources[3].Object.Object, "spec", "replicas")
+	c.Assert(err, check.IsNil)
+	c.Assert(replicas, check.Equals, int64(1))
+}
+
+func (s *RestoreResourcesSuite) TestValidateResourceTransforms(c *check.C) {
+	err := validateResourceTransforms([]resourceTransform{{Patch: json.RawMessage(`{"op": "replace"}`)}})
+	c.Assert(err, check.ErrorMatches, "Invalid JSON patch.*")
+}
+
+func newRestoreResourcesClient() *dynfake.FakeDynamicClient {
+	existing := &unstructured.Unstructured{}
+	existing.SetAPIVersion("v1")
+	existing.SetKind("ConfigMap")
+	existing.SetName("config")
+	existing.SetNamespace(testRestoreResourcesNamespace)
+	return dynfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		testConfigMapGVR: "ConfigMapList",
+		testNamespaceGVR: "NamespaceList",
+	}, existing)
+}
+
+func (s *RestoreResourcesSuite) TestRestoreResources(c *check.C) {
+	ctx := context.Background()
+	dynCli := newRestoreResourcesClient()
+	resources, err := prepareResources(newTestArchivedResources(), testBackupResourcesNamespace, restoreResourcesArgs{namespace: testRestoreResourcesNamespace})
+	c.Assert(err, check.IsNil)
+
+	restored, conflicts, err := restoreResources(ctx, dynCli, testRestoreResourcesNamespace, resources, false)
+	c.Assert(err, check.IsNil)
+	c.Assert(conflicts, check.DeepEquals, []string{"configmaps/config"})
+	c.Assert(restored, check.DeepEquals, []string{
+		"persistentvolumeclaims/data",
+		"rolebindings.rbac.authorization.k8s.io/app",
+		"deployments.apps/app",
+		"statefulsets.apps/db",
+		"widgets.example.com/widget",
+	})
+	_, err = dynCli.Resource(testNamespaceGVR).Get(ctx, testRestoreResourcesNamespace, metav1.GetOptions{})
+	c.Assert(err, check.IsNil)
+	_, err = dynCli.Resource(testDeploymentGVR).Namespace(testRestoreResourcesNamespace).Get(ctx, "app", metav1.GetOptions{})
+	c.Assert(err, check.IsNil)
+
+	// A second restore conflicts on all resources
+	restored, conflicts, err = restoreResources(ctx, dynCli, testRestoreResourcesNamespace, resources, false)
+	c.Assert(err, check.IsNil)
+	c.Assert(restored, check.HasLen, 0)
+	c.Assert(conflicts, check.HasLen, 6)
+}
+
+func (s *RestoreResourcesSuite) TestRestoreResourcesDryRun(c *check.C) {
+	ctx := context.Background()
+	dynCli := newRestoreResourcesClient()
+	resources, err := prepareResources(newTestArchivedResources(), testBackupResourcesNamespace, restoreResourcesArgs{namespace: testRestoreResourcesNamespace})
+	c.Assert(err, check.IsNil)
+
+	// Simulate the server side dry run, which validates the resources
+	// without persisting them
+	dynCli.PrependReactor("create", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		obj := action.(k8stesting.CreateAction).GetObject().(*unstructured.Unstructured)
+		gvr := action.GetResource()
+		if _, err := dynCli.Tracker().Get(gvr, action.GetNamespace(), obj.GetName()); err == nil {
+			return true, nil, apierrors.NewAlreadyExists(gvr.GroupResource(), obj.GetName())
+		}
+		if gvr == testStatefulSetGVR {
+			return true, nil, apierrors.NewInvalid(schema.GroupKind{Group: "apps", Kind: "StatefulSet"}, obj.GetName(), nil)
+		}
+		return true, obj, nil
+	})
+
+	restored, conflicts, err := restoreResources(ctx, dynCli, testRestoreResourcesNamespace, resources, true)
+	c.Assert(err, check.IsNil)
+	c.Assert(conflicts, check.DeepEquals, []string{"configmaps/config", "statefulsets.apps/db"})
+	c.Assert(restored, check.HasLen, 4)
+
+	// Nothing is created
+	_, err = dynCli.Resource(testNamespaceGVR).Get(ctx, testRestoreResourcesNamespace, metav1.GetOptions{})
+	c.Assert(err, check.NotNil)
+	_, err = dynCli.Resource(testDeploymentGVR).Namespace(testRestoreResourcesNamespace).Get(ctx, "app", metav1.GetOptions{})
+	c.Assert(err, check.NotNil)
+}
@@ -16,6 +16,7 @@ package function
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -24,11 +25,14 @@ import (
 	"github.com/kanisterio/errkit"
 	osversioned "github.com/openshift/client-go/apps/clientset/versioned"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 
 	kanister "github.com/kanisterio/kanister/pkg"
 	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/field"
 	"github.com/kanisterio/kanister/pkg/kube"
+	"github.com/kanisterio/kanister/pkg/log"
 	"github.com/kanisterio/kanister/pkg/param"
 	"github.com/kanisterio/kanister/pkg/progress"
 	"github.com/kanisterio/kanister/pkg/utils"
@@ -42,6 +46,17 @@ const (
 	ScaleWorkloadKindArg      = "kind"
 	ScaleWorkloadReplicas     = "replicas"
 	ScaleWorkloadWaitArg      = "waitForReady"
+	ScaleWorkloadModeArg      = "mode"
+
+	// ScaleWorkloadModeScale scales the workload to the number of replicas
+	ScaleWorkloadModeScale = "scale"
+	// ScaleWorkloadModeRestore scales the workload back to the number of
+	// replicas it had before it was scaled down
+	ScaleWorkloadModeRestore = "restore"
+
+	// ScaleWorkloadOriginalReplicasAnnotation records the number of replicas
+	// of a workload before it was scaled down
+	ScaleWorkloadOriginalReplicasAnnotation = "kanister.io/original-replicas"
 
 	outputArtifactOriginalReplicaCount = "originalReplicaCount"
 )
@@ -59,6 +74,7 @@ type scaleWorkloadFunc struct {
 	namespace       string
 	kind            string
 	name            string
+	mode            string
 	replicas        int32
 	waitForReady    bool
 }
@@ -85,41 +101,182 @@ func (s *scaleWorkloadFunc) Exec(ctx context.Context, tp param.TemplateParams, a
 	if err != nil {
 		return nil, errkit.Wrap(err, "Failed to create Kubernetes client")
 	}
-	switch strings.ToLower(s.kind) {
-	case param.StatefulSetKind:
-		count, err := kube.StatefulSetReplicas(ctx, cli, s.namespace, s.name)
+	var osCli osversioned.Interface
+	if strings.ToLower(s.kind) == param.DeploymentConfigKind {
+		osCli, err = osversioned.NewForConfig(cfg)
 		if err != nil {
-			return nil, err
+			return nil, errkit.Wrap(err, "Failed to create OpenShift client")
 		}
-		return map[string]interface{}{
-			outputArtifactOriginalReplicaCount: count,
-		}, kube.ScaleStatefulSet(ctx, cli, s.namespace, s.name, s.replicas, s.waitForReady)
-	case param.DeploymentKind:
-		count, err := kube.DeploymentReplicas(ctx, cli, s.namespace, s.name)
-		if err != nil {
-			return nil, err
+	}
+	return s.scale(ctx, cli, osCli)
+}
+
+// scale scales the workload and records the number of replicas it had
+// before it was scaled down in an annotation, which is used to restore it.
+func (s *scaleWorkloadFunc) scale(ctx context.Context, cli kubernetes.Interface, osCli osversioned.Interface) (map[string]interface{}, error) {
+	kind := strings.ToLower(s.kind)
+	obj, err := getWorkload(ctx, cli, osCli, kind, s.namespace, s.name)
+	if err != nil {
+		return nil, err
+	}
+	original, recorded, err := recordedReplicas(obj)
+	if err != nil {
+		return nil, err
+	}
+	current, err := workloadReplicas(ctx, cli, osCli, kind, s.namespace, s.name)
+	if err != nil {
+		return nil, err
+	}
+	if !recorded {
+		original = current
+	}
+	out := map[string]interface{}{
+		outputArtifactOriginalReplicaCount: original,
+	}
+
+	replicas := s.replicas
+	if s.mode == ScaleWorkloadModeRestore {
+		if !recorded {
+			log.Print("Original replica count not recorded, nothing to restore", field.M{"Kind": kind, "Namespace": s.namespace, "Name": s.name})
+			return out, nil
 		}
-		return map[string]interface{}{
-			outputArtifactOriginalReplicaCount: count,
-		}, kube.ScaleDeployment(ctx, cli, s.namespace, s.name, s.replicas, s.waitForReady)
-	case param.DeploymentConfigKind:
-		osCli, err := osversioned.NewForConfig(cfg)
-		if err != nil {
-			return nil, errkit.Wrap(err, "Failed to create OpenShift client")
+		replicas = original
+	}
+
+	// Record the replicas before scaling down, so that the annotation is
+	// there even if scaling fails. Scaling down again keeps the replicas
+	// recorded the first time.
+	if replicas < current && !recorded {
+		if err := annotateOriginalReplicas(ctx, cli, osCli, kind, s.namespace, s.name, &current); err != nil {
+			return nil, err
 		}
-		count, err := kube.DeploymentConfigReplicas(ctx, osCli, s.namespace, s.name)
-		if err != nil {
+	}
+	if err := scaleWorkload(ctx, cli, osCli, kind, s.namespace, s.name, replicas, s.waitForReady); err != nil {
+		return nil, err
+	}
+	if recorded && (s.mode == ScaleWorkloadModeRestore || replicas > current) {
+		if err := annotateOriginalReplicas(ctx, cli, osCli, kind, s.namespace, s.name, nil); err != nil {
 			return nil, err
 		}
-		return map[string]interface{}{
-			outputArtifactOriginalReplicaCount: count,
-		}, kube.ScaleDeploymentConfig(ctx, cli, osCli, s.namespace, s.name, s.replicas, s.waitForReady)
 	}
-	return nil, errkit.New("Workload type not supported " + s.kind)
+	return out, nil
+}
+
+func getWorkload(ctx context.Context, cli kubernetes.Interface, osCli osversioned.Interface, kind, namespace, name string) (metav1.Object, error) {
+	var obj metav1.Object
+	var err error
+	switch kind {
+	case param.StatefulSetKind:
+		obj, err = cli.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	case param.DeploymentKind:
+		obj, err = cli.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	case param.DeploymentConfigKind:
+		obj, err = osCli.AppsV1().DeploymentConfigs(namespace).Get(ctx, name, metav1.GetOptions{})
+	case param.ReplicaSetKind:
+		obj, err = cli.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	case param.DaemonSetKind:
+		obj, err = cli.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	case param.CronJobKind:
+		obj, err = cli.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	default:
+		return nil, errkit.New("Workload type not supported " + kind)
+	}
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to get workload", "kind", kind, "namespace", namespace, "name", name)
+	}
+	return obj, nil
+}
+
+// recordedReplicas returns the number of replicas recorded in the
+// annotations of the workload and whether they are recorded.
+func recordedReplicas(obj metav1.Object) (int32, bool, error) {
+	val, ok := obj.GetAnnotations()[ScaleWorkloadOriginalReplicasAnnotation]
+	if !ok {
+		return 0, false, nil
+	}
+	replicas, err := strconv.ParseInt(val, 10, 32)
+	if err != nil {
+		return 0, false, errkit.Wrap(err, "Failed to parse annotation "+ScaleWorkloadOriginalReplicasAnnotation, "value", val)
+	}
+	return int32(replicas), true, nil
+}
+
+// annotateOriginalReplicas sets the annotation with the original replicas,
+// or removes it if replicas is nil.
+func annotateOriginalReplicas(ctx context.Context, cli kubernetes.Interface, osCli osversioned.Interface, kind, namespace, name string, replicas *int32) error {
+	var val *string
+	if replicas != nil {
+		v := strconv.Itoa(int(*replicas))
+		val = &v
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]*string{ScaleWorkloadOriginalReplicasAnnotation: val},
+		},
+	})
+	if err != nil {
+		return errkit.Wrap(err, "Failed to marshal annotation patch")
+	}
+	switch kind {
+	case param.StatefulSetKind:
+		_, err = cli.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.MergePatchType, data, metav1.PatchOptions{})
+	case param.DeploymentKind:
+		_, err = cli.AppsV1().Deployments(namespace).Patch(ctx, name, types.MergePatchType, data, metav1.PatchOptions{})
+	case param.DeploymentConfigKind:
+		_, err = osCli.AppsV1().DeploymentConfigs(namespace).Patch(ctx, name, types.MergePatchType, data, metav1.PatchOptions{})
+	case param.ReplicaSetKind:
+		_, err = cli.AppsV1().ReplicaSets(namespace).Patch(ctx, name, types.MergePatchType, data, metav1.PatchOptions{})
+	case param.DaemonSetKind:
+		_, err = cli.AppsV1().DaemonSets(namespace).Patch(ctx, name, types.MergePatchType, data, metav1.PatchOptions{})
+	case param.CronJobKind:
+		_, err = cli.BatchV1().CronJobs(namespace).Patch(ctx, name, types.MergePatchType, data, metav1.PatchOptions{})
+	default:
+		return errkit.New("Workload type not supported " + kind)
+	}
+	if err != nil {
+		return errkit.Wrap(err, "Failed to annotate workload", "kind", kind, "namespace", namespace, "name", name)
+	}
+	return nil
+}
+
+func workloadReplicas(ctx context.Context, cli kubernetes.Interface, osCli osversioned.Interface, kind, namespace, name string) (int32, error) {
+	switch kind {
+	case param.StatefulSetKind:
+		return kube.StatefulSetReplicas(ctx, cli, namespace, name)
+	case param.DeploymentKind:
+		return kube.DeploymentReplicas(ctx, cli, namespace, name)
+	case param.DeploymentConfigKind:
+		return kube.DeploymentConfigReplicas(ctx, osCli, namespace, name)
+	case param.ReplicaSetKind:
+		return kube.ReplicaSetReplicas(ctx, cli, namespace, name)
+	case param.DaemonSetKind:
+		return kube.DaemonSetReplicas(ctx, cli, namespace, name)
+	case param.CronJobKind:
+		return kube.CronJobReplicas(ctx, cli, namespace, name)
+	}
+	return 0, errkit.New("Workload type not supported " + kind)
+}
+
+func scaleWorkload(ctx context.Context, cli kubernetes.Interface, osCli osversioned.Interface, kind, namespace, name string, replicas int32, waitForReady bool) error {
+	switch kind {
+	case param.StatefulSetKind:
+		return kube.ScaleStatefulSet(ctx, cli, namespace, name, replicas, waitForReady)
+	case param.DeploymentKind:
+		return kube.ScaleDeployment(ctx, cli, namespace, name, replicas, waitForReady)
+	case param.DeploymentConfigKind:
+		return kube.ScaleDeploymentConfig(ctx, cli, osCli, namespace, name, replicas, waitForReady)
+	case param.ReplicaSetKind:
+		return kube.ScaleReplicaSet(ctx, cli, namespace, name, replicas, waitForReady)
+	case param.DaemonSetKind:
+		return kube.ScaleDaemonSet(ctx, cli, namespace, name, replicas, waitForReady)
+	case param.CronJobKind:
+		return kube.ScaleCronJob(ctx, cli, namespace, name, replicas)
+	}
+	return errkit.New("Workload type not supported " + kind)
 }
 
 func (*scaleWorkloadFunc) RequiredArgs() []string {
-	return []string{ScaleWorkloadReplicas}
+	return nil
 }
 
 func (*scaleWorkloadFunc) Arguments() []string {
@@ -129,6 +286,60 @@ func (*scaleWorkloadFunc) Arguments() []string {
 		ScaleWorkloadNameArg,
 		ScaleWorkloadKindArg,
 		ScaleWorkloadWaitArg,
+		ScaleWorkloadModeArg,
+	}
+}
+
+func (*scaleWorkloadFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        ScaleWorkloadFuncName,
+		Description: "Scales a StatefulSet, Deployment, DeploymentConfig or ReplicaSet, pauses a DaemonSet or suspends a CronJob",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        ScaleWorkloadReplicas,
+				Type:        kanister.ArgTypeInteger,
+				Description: "Number of replicas, required unless mode is restore",
+			},
+			{
+				Name:        ScaleWorkloadNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Namespace of the workload, defaults to the namespace of the subject of the action",
+			},
+			{
+				Name:        ScaleWorkloadNameArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Name of the workload, defaults to the name of the subject of the action",
+			},
+			{
+				Name:        ScaleWorkloadKindArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Kind of the workload, defaults to the kind of the subject of the action",
+				Enum: []string{
+					param.StatefulSetKind,
+					param.DeploymentKind,
+					param.DeploymentConfigKind,
+					param.ReplicaSetKind,
+					param.DaemonSetKind,
+					param.CronJobKind,
+				},
+			},
+			{
+				Name:        ScaleWorkloadWaitArg,
+				Type:        kanister.ArgTypeBoolean,
+				Description: "Wait for the workload to be ready after scaling",
+				Default:     true,
+			},
+			{
+				Name:        ScaleWorkloadModeArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Scale the workload to the number of replicas, or restore the number of replicas it had before it was scaled down",
+				Default:     ScaleWorkloadModeScale,
+				Enum:        []string{ScaleWorkloadModeScale, ScaleWorkloadModeRestore},
+			},
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: outputArtifactOriginalReplicaCount, Type: kanister.ArgTypeInteger, Description: "Number of replicas before the workload was scaled down"},
+		},
 	}
 }
 
@@ -136,7 +347,9 @@ func (s *scaleWorkloadFunc) Validate(args map[string]any) error {
 	if err := utils.CheckSupportedArgs(s.Arguments(), args); err != nil {
 		return err
 	}
-
+	if args[ScaleWorkloadModeArg] != ScaleWorkloadModeRestore {
+		return utils.CheckRequiredArgs([]string{ScaleWorkloadReplicas}, args)
+	}
 	return utils.CheckRequiredArgs(s.RequiredArgs(), args)
 }
 
@@ -149,32 +362,24 @@ func (s *scaleWorkloadFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error
 }
 
 func (s *scaleWorkloadFunc) setArgs(tp param.TemplateParams, args map[string]interface{}) error {
-	var rep interface{}
-	waitForReady := true
-	err := Arg(args, ScaleWorkloadReplicas, &rep)
+	mode := ScaleWorkloadModeScale
+	err := OptArg(args, ScaleWorkloadModeArg, &mode, mode)
 	if err != nil {
 		return err
 	}
-
-	var namespace, kind, name string
 	var replicas int32
-
-	switch val := rep.(type) {
-	case int:
-		replicas = int32(val)
-	case int32:
-		replicas = val
-	case int64:
-		replicas = int32(val)
-	case string:
-		v, err := strconv.ParseInt(val, 10, 32)
-		if err != nil {
-			return errkit.Wrap(err, fmt.Sprintf("Cannot convert %s to int", val))
+	switch mode {
+	case ScaleWorkloadModeScale:
+		if replicas, err = replicasArg(args); err != nil {
+			return err
 		}
-		replicas = int32(v)
+	case ScaleWorkloadModeRestore:
 	default:
-		return errkit.New(fmt.Sprintf("Invalid arg type %T for Arg %s ", rep, ScaleWorkloadReplicas))
+		return errkit.New(fmt.Sprintf("Invalid mode %s", mode))
 	}
+
+	waitForReady := true
+	var namespace, kind, name string
 	// Populate default values for optional arguments from template parameters
 	switch {
 	case tp.StatefulSet != nil:
@@ -189,6 +394,18 @@ func (s *scaleWorkloadFunc) setArgs(tp param.TemplateParams, args map[string]int
 		kind = param.DeploymentConfigKind
 		name = tp.DeploymentConfig.Name
 		namespace = tp.DeploymentConfig.Namespace
+	case tp.ReplicaSet != nil:
+		kind = param.ReplicaSetKind
+		name = tp.ReplicaSet.Name
+		namespace = tp.ReplicaSet.Namespace
+	case tp.DaemonSet != nil:
+		kind = param.DaemonSetKind
+		name = tp.DaemonSet.Name
+		namespace = tp.DaemonSet.Namespace
+	case tp.CronJob != nil:
+		kind = param.CronJobKind
+		name = tp.CronJob.Name
+		namespace = tp.CronJob.Namespace
 	default:
 		if !ArgExists(args, ScaleWorkloadNamespaceArg) || !ArgExists(args, ScaleWorkloadNameArg) || !ArgExists(args, ScaleWorkloadKindArg) {
 			return errkit.New("Workload information not available via defaults or namespace/name/kind parameters")
@@ -214,7 +431,30 @@ func (s *scaleWorkloadFunc) setArgs(tp param.TemplateParams, args map[string]int
 	s.kind = kind
 	s.name = name
 	s.namespace = namespace
+	s.mode = mode
 	s.replicas = replicas
 	s.waitForReady = waitForReady
 	return nil
 }
+
+func replicasArg(args map[string]interface{}) (int32, error) {
+	var rep interface{}
+	if err := Arg(args, ScaleWorkloadReplicas, &rep); err != nil {
+		return 0, err
+	}
+	switch val := rep.(type) {
+	case int:
+		return int32(val), nil
+	case int32:
+		return val, nil
+	case int64:
+		return int32(val), nil
+	case string:
+		v, err := strconv.ParseInt(val, 10, 32)
+		if err != nil {
+			return 0, errkit.Wrap(err, fmt.Sprintf("Cannot convert %s to int", val))
+		}
+		return int32(v), nil
+	}
+	return 0, errkit.New(fmt.Sprintf("Invalid arg type %T for Arg %s ", rep, ScaleWorkloadReplicas))
+}
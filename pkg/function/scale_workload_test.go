@@ -1,8 +1,15 @@
 package function
 
 import (
+	"context"
+
 	"gopkg.in/check.v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 
+	"github.com/kanisterio/kanister/pkg/kube"
 	"github.com/kanisterio/kanister/pkg/param"
 )
 
@@ -44,3 +51,153 @@ func (s *ScaleWorkloadSuite) TestSetArgs(c *check.C) {
 		c.Assert(s.replicas, check.Equals, tc.expectedReplicas)
 	}
 }
+
+func (s *ScaleWorkloadSuite) TestSetArgsRestore(c *check.C) {
+	f := scaleWorkloadFunc{}
+	err := f.setArgs(param.TemplateParams{
+		DaemonSet: &param.DaemonSetParams{Name: "app", Namespace: "foo"},
+	}, map[string]interface{}{
+		ScaleWorkloadModeArg: ScaleWorkloadModeRestore,
+	})
+	c.Assert(err, check.IsNil)
+	c.Assert(f.mode, check.Equals, ScaleWorkloadModeRestore)
+	c.Assert(f.kind, check.Equals, param.DaemonSetKind)
+	c.Assert(f.name, check.Equals, "app")
+	c.Assert(f.namespace, check.Equals, "foo")
+
+	err = f.setArgs(param.TemplateParams{
+		DaemonSet: &param.DaemonSetParams{Name: "app", Namespace: "foo"},
+	}, map[string]interface{}{})
+	c.Assert(err, check.NotNil)
+
+	err = f.setArgs(param.TemplateParams{
+		DaemonSet: &param.DaemonSetParams{Name: "app", Namespace: "foo"},
+	}, map[string]interface{}{
+		ScaleWorkloadModeArg: "pause",
+	})
+	c.Assert(err, check.NotNil)
+}
+
+func (s *ScaleWorkloadSuite) TestValidate(c *check.C) {
+	f := &scaleWorkloadFunc{}
+	c.Assert(f.Validate(map[string]any{ScaleWorkloadReplicas: 0}), check.IsNil)
+	c.Assert(f.Validate(map[string]any{ScaleWorkloadModeArg: ScaleWorkloadModeRestore}), check.IsNil)
+	c.Assert(f.Validate(map[string]any{}), check.NotNil)
+	c.Assert(f.Validate(map[string]any{ScaleWorkloadModeArg: ScaleWorkloadModeScale}), check.NotNil)
+}
+
+func (s *ScaleWorkloadSuite) TestScaleDeploymentAndRestore(c *check.C) {
+	ctx := context.Background()
+	replicas := int32(3)
+	cli := fake.NewSimpleClientset(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "foo"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+	})
+	scale := func(mode string, replicas int32) map[string]interface{} {
+		f := &scaleWorkloadFunc{namespace: "foo", name: "app", kind: param.DeploymentKind, mode: mode, replicas: replicas}
+		out, err := f.scale(ctx, cli, nil)
+		c.Assert(err, check.IsNil)
+		return out
+	}
+	deployment := func() *appsv1.Deployment {
+		d, err := cli.AppsV1().Deployments("foo").Get(ctx, "app", metav1.GetOptions{})
+		c.Assert(err, check.IsNil)
+		return d
+	}
+
+	out := scale(ScaleWorkloadModeScale, 1)
+	c.Assert(out[outputArtifactOriginalReplicaCount], check.Equals, int32(3))
+	c.Assert(*deployment().Spec.Replicas, check.Equals, int32(1))
+	c.Assert(deployment().Annotations[ScaleWorkloadOriginalReplicasAnnotation], check.Equals, "3")
+
+	// Scaling down again keeps the replicas recorded the first time
+	out = scale(ScaleWorkloadModeScale, 0)
+	c.Assert(out[outputArtifactOriginalReplicaCount], check.Equals, int32(3))
+	c.Assert(*deployment().Spec.Replicas, check.Equals, int32(0))
+	c.Assert(deployment().Annotations[ScaleWorkloadOriginalReplicasAnnotation], check.Equals, "3")
+
+	out = scale(ScaleWorkloadModeRestore, 0)
+	c.Assert(out[outputArtifactOriginalReplicaCount], check.Equals, int32(3))
+	c.Assert(*deployment().Spec.Replicas, check.Equals, int32(3))
+	_, ok := deployment().Annotations[ScaleWorkloadOriginalReplicasAnnotation]
+	c.Assert(ok, check.Equals, false)
+
+	// Nothing to restore
+	out = scale(ScaleWorkloadModeRestore, 0)
+	c.Assert(out[outputArtifactOriginalReplicaCount], check.Equals, int32(3))
+	c.Assert(*deployment().Spec.Replicas, check.Equals, int32(3))
+
+	// Scaling up removes the recorded replicas
+	scale(ScaleWorkloadModeScale, 0)
+	scale(ScaleWorkloadModeScale, 2)
+	c.Assert(*deployment().Spec.Replicas, check.Equals, int32(2))
+	_, ok = deployment().Annotations[ScaleWorkloadOriginalReplicasAnnotation]
+	c.Assert(ok, check.Equals, false)
+}
+
+func (s *ScaleWorkloadSuite) TestPauseDaemonSet(c *check.C) {
+	ctx := context.Background()
+	cli := fake.NewSimpleClientset(&appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "foo"},
+		Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 2},
+	})
+	f := &scaleWorkloadFunc{namespace: "foo", name: "agent", kind: param.DaemonSetKind, mode: ScaleWorkloadModeScale}
+	out, err := f.scale(ctx, cli, nil)
+	c.Assert(err, check.IsNil)
+	c.Assert(out[outputArtifactOriginalReplicaCount], check.Equals, int32(2))
+	ds, err := cli.AppsV1().DaemonSets("foo").Get(ctx, "agent", metav1.GetOptions{})
+	c.Assert(err, check.IsNil)
+	c.Assert(ds.Spec.Template.Spec.NodeSelector, check.DeepEquals, map[string]string{kube.DaemonSetPausedNodeSelector: "true"})
+	c.Assert(ds.Annotations[ScaleWorkloadOriginalReplicasAnnotation], check.Equals, "2")
+
+	f.mode = ScaleWorkloadModeRestore
+	_, err = f.scale(ctx, cli, nil)
+	c.Assert(err, check.IsNil)
+	ds, err = cli.AppsV1().DaemonSets("foo").Get(ctx, "agent", metav1.GetOptions{})
+	c.Assert(err, check.IsNil)
+	c.Assert(ds.Spec.Template.Spec.NodeSelector, check.HasLen, 0)
+	c.Assert(ds.Annotations, check.HasLen, 0)
+}
+
+func (s *ScaleWorkloadSuite) TestPauseUnscheduledDaemonSet(c *check.C) {
+	ctx := context.Background()
+	cli := fake.NewSimpleClientset(&appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "foo"},
+	})
+	f := &scaleWorkloadFunc{namespace: "foo", name: "agent", kind: param.DaemonSetKind, mode: ScaleWorkloadModeScale}
+	out, err := f.scale(ctx, cli, nil)
+	c.Assert(err, check.IsNil)
+	c.Assert(out[outputArtifactOriginalReplicaCount], check.Equals, int32(1))
+	ds, err := cli.AppsV1().DaemonSets("foo").Get(ctx, "agent", metav1.GetOptions{})
+	c.Assert(err, check.IsNil)
+	c.Assert(ds.Spec.Template.Spec.NodeSelector, check.DeepEquals, map[string]string{kube.DaemonSetPausedNodeSelector: "true"})
+	c.Assert(ds.Annotations[ScaleWorkloadOriginalReplicasAnnotation], check.Equals, "1")
+
+	f.mode = ScaleWorkloadModeRestore
+	_, err = f.scale(ctx, cli, nil)
+	c.Assert(err, check.IsNil)
+	ds, err = cli.AppsV1().DaemonSets("foo").Get(ctx, "agent", metav1.GetOptions{})
+	c.Assert(err, check.IsNil)
+	c.Assert(ds.Spec.Template.Spec.NodeSelector, check.HasLen, 0)
+}
+
+func (s *ScaleWorkloadSuite) TestSuspendCronJob(c *check.C) {
+	ctx := context.Background()
+	cli := fake.NewSimpleClientset(&batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "foo"},
+	})
+	f := &scaleWorkloadFunc{namespace: "foo", name: "job", kind: param.CronJobKind, mode: ScaleWorkloadModeScale}
+	out, err := f.scale(ctx, cli, nil)
+	c.Assert(err, check.IsNil)
+	c.Assert(out[outputArtifactOriginalReplicaCount], check.Equals, int32(1))
+	cj, err := cli.BatchV1().CronJobs("foo").Get(ctx, "job", metav1.GetOptions{})
+	c.Assert(err, check.IsNil)
+	c.Assert(*cj.Spec.Suspend, check.Equals, true)
+
+	f.mode = ScaleWorkloadModeRestore
+	_, err = f.scale(ctx, cli, nil)
+	c.Assert(err, check.IsNil)
+	cj, err = cli.BatchV1().CronJobs("foo").Get(ctx, "job", metav1.GetOptions{})
+	c.Assert(err, check.IsNil)
+	c.Assert(*cj.Spec.Suspend, check.Equals, false)
+}
@@ -0,0 +1,187 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/kanisterio/errkit"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/field"
+	"github.com/kanisterio/kanister/pkg/format"
+	"github.com/kanisterio/kanister/pkg/kube"
+	"github.com/kanisterio/kanister/pkg/log"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/progress"
+	"github.com/kanisterio/kanister/pkg/utils"
+)
+
+const (
+	// ThawVolumeFuncName gives the name of the function
+	ThawVolumeFuncName = "ThawVolume"
+	// ThawVolumeNamespaceArg provides the namespace of the PVC
+	ThawVolumeNamespaceArg = "namespace"
+	// ThawVolumePVCArg provides the name of the PVC
+	ThawVolumePVCArg = "pvc"
+
+	// fsfreezeNotFrozenMsg is in the error of fsfreeze if the filesystem isn't frozen
+	fsfreezeNotFrozenMsg = "Invalid argument"
+)
+
+func init() {
+	_ = kanister.Register(&thawVolumeFunc{})
+}
+
+var _ kanister.Func = (*thawVolumeFunc)(nil)
+
+type thawVolumeFunc struct {
+	progressPercent string
+}
+
+func (*thawVolumeFunc) Name() string {
+	return ThawVolumeFuncName
+}
+
+// thawVolume thaws the volume frozen by the pod and deletes the pod. If the
+// timeout of the pod expired, the volume is already thawed.
+func thawVolume(ctx context.Context, cli kubernetes.Interface, pod *corev1.Pod) error {
+	if pod.Status.Phase != corev1.PodRunning {
+		log.Print("Volume already thawed after the timeout", field.M{"PodName": pod.Name, "Namespace": pod.Namespace})
+		return kube.DeletePod(ctx, cli, pod)
+	}
+	pc, err := kube.NewPodControllerForExistingPod(cli, pod)
+	if err != nil {
+		return err
+	}
+	ex, err := pc.GetCommandExecutor()
+	if err != nil {
+		return err
+	}
+	err = unfreezeVolume(ctx, ex, pod, pod.Annotations[FreezeVolumeMountPathAnnotation])
+	// The preStop hook of the pod tries to thaw the volume again if it failed
+	if stopErr := pc.StopPod(ctx, kube.PodControllerInfiniteStopTime, freezeVolumeGracePeriod); stopErr != nil {
+		return errkit.Append(err, stopErr)
+	}
+	return err
+}
+
+// unfreezeVolume thaws the volume mounted at mountPath. A volume that isn't
+// frozen, e.g. because it was thawed on the node, is not an error.
+func unfreezeVolume(ctx context.Context, ex kube.PodCommandExecutor, pod *corev1.Pod, mountPath string) error {
+	var stdout, stderr bytes.Buffer
+	err := ex.Exec(ctx, fsfreezeCommand(mountPath, false), nil, &stdout, &stderr)
+	format.LogWithCtx(ctx, pod.Name, pod.Spec.Containers[0].Name, stdout.String())
+	format.LogWithCtx(ctx, pod.Name, pod.Spec.Containers[0].Name, stderr.String())
+	if err == nil {
+		return nil
+	}
+	if strings.Contains(stderr.String(), fsfreezeNotFrozenMsg) {
+		log.Print("Volume is not frozen", field.M{"PodName": pod.Name, "Namespace": pod.Namespace, "MountPath": mountPath})
+		return nil
+	}
+	return errkit.Wrap(err, "Failed to thaw the volume", "mountPath", mountPath)
+}
+
+func (t *thawVolumeFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	// Set progress percent
+	t.progressPercent = progress.StartedPercent
+	defer func() { t.progressPercent = progress.CompletedPercent }()
+
+	var namespace, pvc string
+	if err := Arg(args, ThawVolumeNamespaceArg, &namespace); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, ThawVolumePVCArg, &pvc); err != nil {
+		return nil, err
+	}
+	cli, err := kube.NewClient()
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to create Kubernetes client")
+	}
+	pods, err := cli.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: FreezeVolumeLabel + "=" + pvc})
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to list pods", "namespace", namespace)
+	}
+	if len(pods.Items) == 0 {
+		// Nothing to thaw, e.g. if FreezeVolume failed
+		log.Print("Volume is not frozen", field.M{"Namespace": namespace, "PVC": pvc})
+		return nil, nil
+	}
+	for i := range pods.Items {
+		if err := thawVolume(ctx, cli, &pods.Items[i]); err != nil {
+			return nil, errkit.Wrap(err, "Failed to thaw volume", "pvc", pvc)
+		}
+	}
+	return nil, nil
+}
+
+func (*thawVolumeFunc) RequiredArgs() []string {
+	return []string{
+		ThawVolumeNamespaceArg,
+		ThawVolumePVCArg,
+	}
+}
+
+func (*thawVolumeFunc) Arguments() []string {
+	return []string{
+		ThawVolumeNamespaceArg,
+		ThawVolumePVCArg,
+	}
+}
+
+func (*thawVolumeFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        ThawVolumeFuncName,
+		Description: "Thaws the filesystem of a PVC frozen by FreezeVolume and deletes the pod that froze it",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        ThawVolumeNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the PVC",
+			},
+			{
+				Name:        ThawVolumePVCArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the PVC",
+			},
+		},
+	}
+}
+
+func (t *thawVolumeFunc) Validate(args map[string]any) error {
+	if err := utils.CheckSupportedArgs(t.Arguments(), args); err != nil {
+		return err
+	}
+
+	return utils.CheckRequiredArgs(t.RequiredArgs(), args)
+}
+
+func (t *thawVolumeFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
+	metav1Time := metav1.NewTime(time.Now())
+	return crv1alpha1.PhaseProgress{
+		ProgressPercent:    t.progressPercent,
+		LastTransitionTime: &metav1Time,
+	}, nil
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/kanisterio/kanister/pkg/format"
 	"github.com/kanisterio/kanister/pkg/kube"
 	"github.com/kanisterio/kanister/pkg/log"
+	"github.com/kanisterio/kanister/pkg/output"
 	"github.com/kanisterio/kanister/pkg/param"
 	"github.com/kanisterio/kanister/pkg/secrets"
 	"github.com/kanisterio/kanister/pkg/validate"
@@ -70,8 +71,8 @@ func ExecAndLogNoCtx(ctx context.Context, executor kube.PodCommandExecutor, cmd
 	containerName := pod.Spec.Containers[0].Name
 
 	// Log the output regardless of whether the command succeeded or failed
-	format.Log(pod.Name, containerName, stdoutBuf.String())
-	format.Log(pod.Name, containerName, stderrBuf.String())
+	format.Log(pod.Name, containerName, output.RedactWithCtx(ctx, stdoutBuf.String()))
+	format.Log(pod.Name, containerName, output.RedactWithCtx(ctx, stderrBuf.String()))
 
 	return stdoutBuf.String(), stderrBuf.String(), err
 }
@@ -96,8 +97,8 @@ func KubeExecAndLogNoCtx(ctx context.Context, cli kubernetes.Interface, namespac
 	stdout, stderr, err = kube.Exec(ctx, cli, namespace, pod, container, cmd, stdin)
 
 	// Log the output regardless of whether the command succeeded or failed
-	format.Log(pod, container, stdout)
-	format.Log(pod, container, stderr)
+	format.Log(pod, container, output.RedactWithCtx(ctx, stdout))
+	format.Log(pod, container, output.RedactWithCtx(ctx, stderr))
 
 	return stdout, stderr, err
 }
@@ -230,6 +231,26 @@ func FetchPodVolumes(pod string, tp param.TemplateParams) (map[string]string, er
 			return pvcToMountPath, nil
 		}
 		return nil, errkit.New("Failed to find volumes for the Pod: " + pod)
+	case tp.DaemonSet != nil:
+		if pvcToMountPath, ok := tp.DaemonSet.PersistentVolumeClaims[pod]; ok {
+			return pvcToMountPath, nil
+		}
+		return nil, errkit.New("Failed to find volumes for the Pod: " + pod)
+	case tp.ReplicaSet != nil:
+		if pvcToMountPath, ok := tp.ReplicaSet.PersistentVolumeClaims[pod]; ok {
+			return pvcToMountPath, nil
+		}
+		return nil, errkit.New("Failed to find volumes for the Pod: " + pod)
+	case tp.Job != nil:
+		if pvcToMountPath, ok := tp.Job.PersistentVolumeClaims[pod]; ok {
+			return pvcToMountPath, nil
+		}
+		return nil, errkit.New("Failed to find volumes for the Pod: " + pod)
+	case tp.CronJob != nil:
+		if pvcToMountPath, ok := tp.CronJob.PersistentVolumeClaims[pod]; ok {
+			return pvcToMountPath, nil
+		}
+		return nil, errkit.New("Failed to find volumes for the Pod: " + pod)
 	default:
 		return nil, errkit.New("Invalid Template Params")
 	}
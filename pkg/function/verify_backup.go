@@ -0,0 +1,700 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/kanisterio/errkit"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/consts"
+	"github.com/kanisterio/kanister/pkg/field"
+	"github.com/kanisterio/kanister/pkg/format"
+	"github.com/kanisterio/kanister/pkg/kube"
+	"github.com/kanisterio/kanister/pkg/kube/volume"
+	"github.com/kanisterio/kanister/pkg/log"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/progress"
+	"github.com/kanisterio/kanister/pkg/restic"
+	"github.com/kanisterio/kanister/pkg/utils"
+)
+
+const (
+	// VerifyBackupFuncName gives the name of the function
+	VerifyBackupFuncName = "VerifyBackup"
+	// VerifyBackupNamespaceArg provides the namespace of the verification pod
+	VerifyBackupNamespaceArg = "namespace"
+	// VerifyBackupImageArg provides the image of the verification pod
+	VerifyBackupImageArg = "image"
+	// VerifyBackupTypeArg provides the type of the backup: restic, kopia or location
+	VerifyBackupTypeArg = "type"
+	// VerifyBackupArtifactPrefixArg provides the path of the restic repository
+	VerifyBackupArtifactPrefixArg = "backupArtifactPrefix"
+	// VerifyBackupIdentifierArg provides the ID of the restic snapshot to restore
+	VerifyBackupIdentifierArg = "backupID"
+	// VerifyBackupEncryptionKeyArg provides the encryption key of the restic repository
+	VerifyBackupEncryptionKeyArg = "encryptionKey"
+	// VerifyBackupReadDataArg makes restic read and verify the data of all the snapshots
+	VerifyBackupReadDataArg = "readData"
+	// VerifyBackupRestoreArg makes the restic snapshot be restored to the scratch volume
+	VerifyBackupRestoreArg = "restore"
+	// VerifyBackupPathArg provides the path of the artifact in the location
+	VerifyBackupPathArg = "path"
+	// VerifyBackupManifestArg provides the manifest of the backup in the sha256sum format
+	VerifyBackupManifestArg = "manifest"
+	// VerifyBackupManifestPathArg provides the path of the manifest in the location
+	VerifyBackupManifestPathArg = "manifestPath"
+	// VerifyBackupScratchSizeArg provides the size of the scratch PVC
+	VerifyBackupScratchSizeArg = "scratchSize"
+	// VerifyBackupScratchStorageClassArg provides the storage class of the scratch PVC
+	VerifyBackupScratchStorageClassArg = "scratchStorageClass"
+	// VerifyBackupFailOnErrorArg makes the phase fail if the verification fails
+	VerifyBackupFailOnErrorArg = "failOnError"
+	// VerifyBackupVerifiedOutput is true if the backup was verified successfully
+	VerifyBackupVerifiedOutput = "verified"
+	// VerifyBackupVerifiedAtOutput is the time the verification finished, in RFC 3339 format
+	VerifyBackupVerifiedAtOutput = "verifiedAt"
+	// VerifyBackupReportOutput is the result of the verification as JSON
+	VerifyBackupReportOutput = "report"
+
+	// VerifyBackupTypeRestic verifies a restic repository and snapshot
+	VerifyBackupTypeRestic = "restic"
+	// VerifyBackupTypeKopia verifies a kopia snapshot
+	VerifyBackupTypeKopia = "kopia"
+	// VerifyBackupTypeLocation verifies an artifact pushed with kando location push
+	VerifyBackupTypeLocation = "location"
+
+	verifyBackupJobPrefix     = "verify-backup-"
+	verifyBackupScratchPrefix = "verify-backup-scratch-"
+	verifyBackupScratchVolume = "scratch"
+	verifyBackupScratchPath   = "/mnt/verify-backup"
+)
+
+func init() {
+	_ = kanister.Register(&verifyBackupFunc{})
+}
+
+var _ kanister.Func = (*verifyBackupFunc)(nil)
+
+type verifyBackupFunc struct {
+	progressPercent string
+}
+
+func (*verifyBackupFunc) Name() string {
+	return VerifyBackupFuncName
+}
+
+type verifyBackupArgs struct {
+	namespace            string
+	image                string
+	backupType           string
+	backupArtifactPrefix string
+	backupID             string
+	encryptionKey        string
+	readData             bool
+	insecureTLS          bool
+	restore              bool
+	snapshot             string
+	path                 string
+	manifest             string
+	manifestPath         string
+	scratchSize          string
+	scratchStorageClass  string
+	failOnError          bool
+	podOverride          crv1alpha1.JSONMap
+	annotations          map[string]string
+	labels               map[string]string
+}
+
+func parseVerifyBackupArgs(tp param.TemplateParams, args map[string]interface{}) (*verifyBackupArgs, error) {
+	a := &verifyBackupArgs{}
+	var bpAnnotations, bpLabels map[string]string
+	if err := Arg(args, VerifyBackupNamespaceArg, &a.namespace); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, VerifyBackupTypeArg, &a.backupType); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, VerifyBackupImageArg, &a.image, consts.GetKanisterToolsImage()); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, VerifyBackupArtifactPrefixArg, &a.backupArtifactPrefix, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, VerifyBackupIdentifierArg, &a.backupID, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, VerifyBackupEncryptionKeyArg, &a.encryptionKey, restic.GeneratePassword()); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, VerifyBackupReadDataArg, &a.readData, false); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, InsecureTLS, &a.insecureTLS, false); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, VerifyBackupRestoreArg, &a.restore, false); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, KopiaSnapshotArg, &a.snapshot, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, VerifyBackupPathArg, &a.path, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, VerifyBackupManifestArg, &a.manifest, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, VerifyBackupManifestPathArg, &a.manifestPath, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, VerifyBackupScratchSizeArg, &a.scratchSize, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, VerifyBackupScratchStorageClassArg, &a.scratchStorageClass, ""); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, VerifyBackupFailOnErrorArg, &a.failOnError, true); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, PodAnnotationsArg, &bpAnnotations, nil); err != nil {
+		return nil, err
+	}
+	if err := OptArg(args, PodLabelsArg, &bpLabels, nil); err != nil {
+		return nil, err
+	}
+
+	switch a.backupType {
+	case VerifyBackupTypeRestic:
+		if a.backupArtifactPrefix == "" {
+			return nil, errkit.New(fmt.Sprintf("Argument %s is required to verify a restic backup", VerifyBackupArtifactPrefixArg))
+		}
+		if a.restore && a.backupID == "" {
+			return nil, errkit.New(fmt.Sprintf("Argument %s is required to restore a restic backup", VerifyBackupIdentifierArg))
+		}
+		a.backupArtifactPrefix = ResolveArtifactPrefix(a.backupArtifactPrefix, tp.Profile)
+	case VerifyBackupTypeKopia:
+		if err := validateKopiaSnapshot(a.snapshot); err != nil {
+			return nil, err
+		}
+		// Kopia verifies the content it reads, the snapshot is always restored
+		a.restore = true
+	case VerifyBackupTypeLocation:
+		if a.path == "" {
+			return nil, errkit.New(fmt.Sprintf("Argument %s is required to verify a location artifact", VerifyBackupPathArg))
+		}
+		a.restore = true
+	default:
+		return nil, errkit.New("Unsupported backup type", "type", a.backupType)
+	}
+	if a.manifest != "" && a.manifestPath != "" {
+		return nil, errkit.New(fmt.Sprintf("Require at most one argument: %s or %s", VerifyBackupManifestArg, VerifyBackupManifestPathArg))
+	}
+	if (a.manifest != "" || a.manifestPath != "") && !a.restore {
+		return nil, errkit.New(fmt.Sprintf("Argument %s is required to compare a restic backup with a manifest", VerifyBackupRestoreArg))
+	}
+	if a.scratchSize != "" {
+		if _, err := resource.ParseQuantity(a.scratchSize); err != nil {
+			return nil, errkit.Wrap(err, "Failed to parse scratch size", "scratchSize", a.scratchSize)
+		}
+	} else if a.scratchStorageClass != "" {
+		return nil, errkit.New(fmt.Sprintf("Argument %s is required to create a scratch PVC", VerifyBackupScratchSizeArg))
+	}
+
+	var err error
+	if a.podOverride, err = GetPodSpecOverride(tp, args, PodOverrideArg); err != nil {
+		return nil, err
+	}
+	a.annotations = bpAnnotations
+	a.labels = bpLabels
+	if tp.PodAnnotations != nil {
+		// merge the actionset annotations with blueprint annotations
+		var actionSetAnn ActionSetAnnotations = tp.PodAnnotations
+		a.annotations = actionSetAnn.MergeBPAnnotations(bpAnnotations)
+	}
+	if tp.PodLabels != nil {
+		// merge the actionset labels with blueprint labels
+		var actionSetLabels ActionSetLabels = tp.PodLabels
+		a.labels = actionSetLabels.MergeBPLabels(bpLabels)
+	}
+	return a, nil
+}
+
+// validateVerifyBackupProfile checks that the backup and the manifest can be
+// read from the location of the profile.
+func validateVerifyBackupProfile(profile *param.Profile, a *verifyBackupArgs) error {
+	if a.backupType == VerifyBackupTypeKopia {
+		if err := validateKopiaProfile(profile); err != nil {
+			return err
+		}
+		if a.manifestPath != "" {
+			return errkit.New(fmt.Sprintf("Argument %s isn't supported with a %s location", VerifyBackupManifestPathArg, crv1alpha1.LocationTypeKopia))
+		}
+		return nil
+	}
+	if err := ValidateProfile(profile); err != nil {
+		return errkit.Wrap(err, "Failed to validate Profile")
+	}
+	if profile.Location.Type == crv1alpha1.LocationTypeKopia {
+		return errkit.New(fmt.Sprintf("Backups of type %s can't be verified in a %s location", a.backupType, crv1alpha1.LocationTypeKopia))
+	}
+	return nil
+}
+
+// verifyBackupReport is the result of the verification, it's returned in the
+// report output so it can be recorded as an artifact.
+type verifyBackupReport struct {
+	Type         string   `json:"type"`
+	Verified     bool     `json:"verified"`
+	VerifiedAt   string   `json:"verifiedAt"`
+	Restored     bool     `json:"restored"`
+	MatchedFiles int      `json:"matchedFiles"`
+	FailedFiles  []string `json:"failedFiles,omitempty"`
+	Error        string   `json:"error,omitempty"`
+}
+
+// verifyBackupPullCommand writes the artifact at path in the location to
+// target, or to stdout if target is `-`. The profile is read from stdin.
+func verifyBackupPullCommand(artifactPath, target string) []string {
+	return []string{
+		"sh", "-c",
+		`kando location pull --profile "$(cat)" --path "$1" "$2"`,
+		"sh", artifactPath, target,
+	}
+}
+
+// verifyBackupManifestCommand checks the files in dir against the manifest
+// read from stdin.
+func verifyBackupManifestCommand(dir string) []string {
+	return []string{"sh", "-c", `cd "$1" && sha256sum -c -`, "sh", dir}
+}
+
+// parseManifestCheck returns the number of files that matched the manifest
+// and the files that didn't, from the output of `sha256sum -c`.
+func parseManifestCheck(stdout string) (int, []string) {
+	matched := 0
+	var failed []string
+	for _, line := range strings.Split(stdout, "\n") {
+		line = strings.TrimSpace(line)
+		if _, ok := strings.CutSuffix(line, ": OK"); ok {
+			matched++
+			continue
+		}
+		if i := strings.LastIndex(line, ": FAILED"); i > 0 {
+			failed = append(failed, line[:i])
+		}
+	}
+	return matched, failed
+}
+
+// backupVerifier runs the verification commands in the verification pod.
+type backupVerifier struct {
+	ex      kube.PodCommandExecutor
+	pod     string
+	profile *param.Profile
+	args    *verifyBackupArgs
+}
+
+func (v *backupVerifier) exec(ctx context.Context, cmd []string, stdin io.Reader) (string, error) {
+	var stdout, stderr bytes.Buffer
+	err := v.ex.Exec(ctx, cmd, stdin, &stdout, &stderr)
+	format.LogWithCtx(ctx, v.pod, kube.DefaultContainerName, stdout.String())
+	format.LogWithCtx(ctx, v.pod, kube.DefaultContainerName, stderr.String())
+	return stdout.String(), err
+}
+
+// execWithProfile runs cmd with the profile on stdin.
+func (v *backupVerifier) execWithProfile(ctx context.Context, cmd []string) (string, error) {
+	stdin, err := kopiaProfileStdin(v.profile)
+	if err != nil {
+		return "", err
+	}
+	return v.exec(ctx, cmd, stdin)
+}
+
+// check verifies the integrity of the backup and restores it to the scratch
+// volume if required.
+func (v *backupVerifier) check(ctx context.Context) error {
+	a := v.args
+	switch a.backupType {
+	case VerifyBackupTypeRestic:
+		cmd, err := restic.CheckCommand(v.profile, a.backupArtifactPrefix, a.encryptionKey, a.readData, a.insecureTLS)
+		if err != nil {
+			return err
+		}
+		if _, err := v.exec(ctx, cmd, nil); err != nil {
+			return errkit.Wrap(err, "Failed to check restic repository")
+		}
+		if !a.restore {
+			return nil
+		}
+		cmd, err = restic.RestoreCommandByID(v.profile, a.backupArtifactPrefix, a.backupID, verifyBackupScratchPath, "", a.encryptionKey, a.insecureTLS)
+		if err != nil {
+			return err
+		}
+		if _, err := v.exec(ctx, cmd, nil); err != nil {
+			return errkit.Wrap(err, "Failed to restore restic snapshot", "backupID", a.backupID)
+		}
+	case VerifyBackupTypeKopia:
+		if _, err := v.execWithProfile(ctx, kopiaPullCommand(a.snapshot, verifyBackupScratchPath)); err != nil {
+			return errkit.Wrap(err, "Failed to restore kopia snapshot")
+		}
+	case VerifyBackupTypeLocation:
+		target := path.Join(verifyBackupScratchPath, path.Base(a.path))
+		if _, err := v.execWithProfile(ctx, verifyBackupPullCommand(a.path, target)); err != nil {
+			return errkit.Wrap(err, "Failed to pull artifact", "path", a.path)
+		}
+	}
+	return nil
+}
+
+// compareManifest compares the restored files with the manifest.
+func (v *backupVerifier) compareManifest(ctx context.Context, report *verifyBackupReport) error {
+	manifest := v.args.manifest
+	if v.args.manifestPath != "" {
+		var err error
+		if manifest, err = v.execWithProfile(ctx, verifyBackupPullCommand(v.args.manifestPath, "-")); err != nil {
+			return errkit.Wrap(err, "Failed to pull manifest", "path", v.args.manifestPath)
+		}
+	}
+	if manifest == "" {
+		return nil
+	}
+	stdout, err := v.exec(ctx, verifyBackupManifestCommand(verifyBackupScratchPath), strings.NewReader(manifest))
+	report.MatchedFiles, report.FailedFiles = parseManifestCheck(stdout)
+	switch {
+	case len(report.FailedFiles) > 0:
+		return errkit.New("Files don't match the manifest", "failedFiles", len(report.FailedFiles))
+	case err != nil:
+		return errkit.Wrap(err, "Failed to compare files with the manifest")
+	case report.MatchedFiles == 0:
+		return errkit.New("Manifest has no files")
+	}
+	return nil
+}
+
+// verify runs the verification. A failed verification is recorded in the
+// report rather than returned.
+func (v *backupVerifier) verify(ctx context.Context) *verifyBackupReport {
+	report := &verifyBackupReport{
+		Type:     v.args.backupType,
+		Restored: v.args.restore,
+	}
+	err := v.check(ctx)
+	if err == nil {
+		err = v.compareManifest(ctx, report)
+	}
+	if err != nil {
+		report.Error = err.Error()
+	}
+	report.Verified = err == nil
+	report.VerifiedAt = time.Now().UTC().Format(time.RFC3339)
+	return report
+}
+
+// verifyBackupOutputs returns the outputs of the function for the report. It
+// fails if the verification failed, unless failOnError is false. Since the
+// outputs of a failed phase aren't recorded, the report and the time of the
+// verification are logged and added to the details of the error instead.
+func verifyBackupOutputs(report *verifyBackupReport, failOnError bool) (map[string]interface{}, error) {
+	out, err := json.Marshal(report)
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to marshal verification report")
+	}
+	if !report.Verified && failOnError {
+		log.Print("Backup verification failed", field.M{"VerifiedAt": report.VerifiedAt, "Report": string(out)})
+		return nil, errkit.New("Backup verification failed", VerifyBackupVerifiedAtOutput, report.VerifiedAt, VerifyBackupReportOutput, string(out))
+	}
+	return map[string]interface{}{
+		VerifyBackupVerifiedOutput:   report.Verified,
+		VerifyBackupVerifiedAtOutput: report.VerifiedAt,
+		VerifyBackupReportOutput:     string(out),
+		FunctionOutputVersion:        kanister.DefaultVersion,
+	}, nil
+}
+
+// verifyBackupScratchOverride mounts an emptyDir as the scratch volume.
+func verifyBackupScratchOverride(podOverride crv1alpha1.JSONMap) (crv1alpha1.JSONMap, error) {
+	return kube.CreateAndMergeJSONPatch(crv1alpha1.JSONMap{
+		"volumes": []corev1.Volume{{
+			Name:         verifyBackupScratchVolume,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		}},
+		"containers": []corev1.Container{{
+			Name:         kube.DefaultContainerName,
+			VolumeMounts: []corev1.VolumeMount{{Name: verifyBackupScratchVolume, MountPath: verifyBackupScratchPath}},
+		}},
+	}, podOverride)
+}
+
+// createScratchPVC creates the PVC the backup is restored to.
+func createScratchPVC(ctx context.Context, cli kubernetes.Interface, a *verifyBackupArgs) (string, error) {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: verifyBackupScratchPrefix,
+			Labels:       a.labels,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse(a.scratchSize),
+				},
+			},
+		},
+	}
+	if a.scratchStorageClass != "" {
+		pvc.Spec.StorageClassName = &a.scratchStorageClass
+	}
+	pvc, err := cli.CoreV1().PersistentVolumeClaims(a.namespace).Create(ctx, pvc, metav1.CreateOptions{})
+	if err != nil {
+		return "", errkit.Wrap(err, "Failed to create scratch PVC", "namespace", a.namespace)
+	}
+	return pvc.Name, nil
+}
+
+func verifyBackup(ctx context.Context, cli kubernetes.Interface, profile *param.Profile, a *verifyBackupArgs) (_ map[string]interface{}, err error) {
+	vols := map[string]string{}
+	podOverride := a.podOverride
+	switch {
+	case !a.restore:
+	case a.scratchSize != "":
+		var pvcName string
+		if pvcName, err = createScratchPVC(ctx, cli, a); err != nil {
+			return nil, err
+		}
+		defer func() {
+			if delErr := volume.DeletePVC(cli, a.namespace, pvcName); delErr != nil {
+				err = errkit.Append(err, errkit.Wrap(delErr, "Failed to delete scratch PVC", "pvc", pvcName))
+			}
+		}()
+		vols[pvcName] = verifyBackupScratchPath
+	default:
+		if podOverride, err = verifyBackupScratchOverride(podOverride); err != nil {
+			return nil, err
+		}
+	}
+
+	podFunc := func(ctx context.Context, pc kube.PodController) (map[string]interface{}, error) {
+		if err := pc.WaitForPodReady(ctx); err != nil {
+			return nil, errkit.Wrap(err, "Failed while waiting for Pod to be ready", "pod", pc.PodName())
+		}
+		if a.backupType == VerifyBackupTypeRestic {
+			remover, err := MaybeWriteProfileCredentials(ctx, pc, profile)
+			if err != nil {
+				return nil, err
+			}
+			// Parent context could already be dead, so removing file within new context
+			defer remover.Remove(context.Background()) //nolint:errcheck
+		}
+		ex, err := pc.GetCommandExecutor()
+		if err != nil {
+			return nil, err
+		}
+		v := &backupVerifier{ex: ex, pod: pc.PodName(), profile: profile, args: a}
+		return verifyBackupOutputs(v.verify(ctx), a.failOnError)
+	}
+	return PrepareAndRunPod(
+		ctx,
+		cli,
+		a.namespace,
+		verifyBackupJobPrefix,
+		a.image,
+		[]string{"sh", "-c", "tail -f /dev/null"},
+		vols,
+		podOverride,
+		a.annotations,
+		a.labels,
+		podFunc,
+	)
+}
+
+func (v *verifyBackupFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	// Set progress percent
+	v.progressPercent = progress.StartedPercent
+	defer func() { v.progressPercent = progress.CompletedPercent }()
+
+	a, err := parseVerifyBackupArgs(tp, args)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateVerifyBackupProfile(tp.Profile, a); err != nil {
+		return nil, err
+	}
+	cli, err := kube.NewClient()
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to create Kubernetes client")
+	}
+	return verifyBackup(ctx, cli, tp.Profile, a)
+}
+
+func (*verifyBackupFunc) RequiredArgs() []string {
+	return []string{
+		VerifyBackupNamespaceArg,
+		VerifyBackupTypeArg,
+	}
+}
+
+func (*verifyBackupFunc) Arguments() []string {
+	return []string{
+		VerifyBackupNamespaceArg,
+		VerifyBackupTypeArg,
+		VerifyBackupImageArg,
+		VerifyBackupArtifactPrefixArg,
+		VerifyBackupIdentifierArg,
+		VerifyBackupEncryptionKeyArg,
+		VerifyBackupReadDataArg,
+		InsecureTLS,
+		VerifyBackupRestoreArg,
+		KopiaSnapshotArg,
+		VerifyBackupPathArg,
+		VerifyBackupManifestArg,
+		VerifyBackupManifestPathArg,
+		VerifyBackupScratchSizeArg,
+		VerifyBackupScratchStorageClassArg,
+		VerifyBackupFailOnErrorArg,
+		PodOverrideArg,
+		PodAnnotationsArg,
+		PodLabelsArg,
+	}
+}
+
+func (*verifyBackupFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        VerifyBackupFuncName,
+		Description: "Verifies that a restic, kopia or location backup can be restored and matches its manifest",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        VerifyBackupNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the verification pod",
+			},
+			{
+				Name:        VerifyBackupTypeArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Type of the backup: restic, kopia or location",
+			},
+			{
+				Name:        VerifyBackupImageArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Image of the verification pod, needs to have restic and kando installed. Defaults to the kanister tools image",
+			},
+			{
+				Name:        VerifyBackupArtifactPrefixArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Path of the restic repository, required for restic backups",
+			},
+			{
+				Name:        VerifyBackupIdentifierArg,
+				Type:        kanister.ArgTypeString,
+				Description: "ID of the restic snapshot, required to restore a restic backup",
+			},
+			encryptionKeyArgSchema,
+			{
+				Name:        VerifyBackupReadDataArg,
+				Type:        kanister.ArgTypeBoolean,
+				Description: "Read and verify the data of all the snapshots in the restic repository",
+				Default:     false,
+			},
+			insecureTLSArgSchema,
+			{
+				Name:        VerifyBackupRestoreArg,
+				Type:        kanister.ArgTypeBoolean,
+				Description: "Restore the restic snapshot to the scratch volume. Kopia snapshots and location artifacts are always restored",
+				Default:     false,
+			},
+			{
+				Name:        KopiaSnapshotArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Kopia snapshot output by BackupDataUsingKopia, required for kopia backups",
+			},
+			{
+				Name:        VerifyBackupPathArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Path of the artifact in the location, required for location backups",
+			},
+			{
+				Name:        VerifyBackupManifestArg,
+				Type:        kanister.ArgTypeString,
+				Description: "SHA-256 checksums of the backed up files in the sha256sum format, relative to the restored backup",
+			},
+			{
+				Name:        VerifyBackupManifestPathArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Path of the manifest in the location, instead of manifest",
+			},
+			{
+				Name:        VerifyBackupScratchSizeArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Size of the temporary PVC the backup is restored to. Defaults to an emptyDir volume",
+			},
+			{
+				Name:        VerifyBackupScratchStorageClassArg,
+				Type:        kanister.ArgTypeString,
+				Description: "Storage class of the temporary PVC. Defaults to the default storage class",
+			},
+			{
+				Name:        VerifyBackupFailOnErrorArg,
+				Type:        kanister.ArgTypeBoolean,
+				Description: "Fail the phase if the verification fails, otherwise the failure is only reported in the outputs",
+				Default:     true,
+			},
+			podOverrideArgSchema,
+			podAnnotationsArgSchema,
+			podLabelsArgSchema,
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: VerifyBackupVerifiedOutput, Type: kanister.ArgTypeBoolean, Description: "Whether the backup was verified successfully"},
+			{Name: VerifyBackupVerifiedAtOutput, Type: kanister.ArgTypeString, Description: "Time the verification finished, in RFC 3339 format"},
+			{Name: VerifyBackupReportOutput, Type: kanister.ArgTypeString, Description: "Result of the verification as JSON"},
+			versionOutputSchema,
+		},
+	}
+}
+
+func (v *verifyBackupFunc) Validate(args map[string]any) error {
+	if err := ValidatePodLabelsAndAnnotations(v.Name(), args); err != nil {
+		return err
+	}
+
+	if err := utils.CheckSupportedArgs(v.Arguments(), args); err != nil {
+		return err
+	}
+
+	return utils.CheckRequiredArgs(v.RequiredArgs(), args)
+}
+
+func (v *verifyBackupFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
+	metav1Time := metav1.NewTime(time.Now())
+	return crv1alpha1.PhaseProgress{
+		ProgressPercent:    v.progressPercent,
+		LastTransitionTime: &metav1Time,
+	}, nil
+}
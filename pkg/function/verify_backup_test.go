@@ -0,0 +1,309 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/kanisterio/errkit"
+	"gopkg.in/check.v1"
+	corev1 "k8s.io/api/core/v1"
+
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/kopia/snapshot"
+	"github.com/kanisterio/kanister/pkg/param"
+)
+
+type VerifyBackupSuite struct{}
+
+var _ = check.Suite(&VerifyBackupSuite{})
+
+// fakeVerifyExecutor returns the output of the commands whose script
+// contains one of its keys, and records the commands with their stdin.
+type fakeVerifyExecutor struct {
+	outputs  map[string]string
+	failures map[string]error
+	commands []string
+	stdins   []string
+}
+
+func (e *fakeVerifyExecutor) Exec(ctx context.Context, cmd []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	script := strings.Join(cmd, " ")
+	in := ""
+	if stdin != nil {
+		b, err := io.ReadAll(stdin)
+		if err != nil {
+			return err
+		}
+		in = string(b)
+	}
+	e.commands = append(e.commands, script)
+	e.stdins = append(e.stdins, in)
+	for k, out := range e.outputs {
+		if strings.Contains(script, k) {
+			if _, err := io.WriteString(stdout, out); err != nil {
+				return err
+			}
+		}
+	}
+	for k, err := range e.failures {
+		if strings.Contains(script, k) {
+			return err
+		}
+	}
+	return nil
+}
+
+func verifyTestS3Profile() *param.Profile {
+	return &param.Profile{
+		Location: crv1alpha1.Location{
+			Type:     crv1alpha1.LocationTypeS3Compliant,
+			Bucket:   "backups",
+			Endpoint: "endpoint",
+		},
+		Credential: param.Credential{
+			Type: param.CredentialTypeKeyPair,
+			KeyPair: &param.KeyPair{
+				ID:     "id",
+				Secret: "secret",
+			},
+		},
+	}
+}
+
+func (s *VerifyBackupSuite) TestParseVerifyBackupArgs(c *check.C) {
+	snapJSON, err := snapshot.MarshalKopiaSnapshot(&snapshot.SnapshotInfo{ID: "k42"})
+	c.Assert(err, check.IsNil)
+	for _, tc := range []struct {
+		args    map[string]interface{}
+		restore bool
+		errMsg  string
+	}{
+		{
+			args: map[string]interface{}{VerifyBackupTypeArg: "restic", VerifyBackupArtifactPrefixArg: "backups/mysql"},
+		},
+		{
+			args:    map[string]interface{}{VerifyBackupTypeArg: "restic", VerifyBackupArtifactPrefixArg: "backups/mysql", VerifyBackupRestoreArg: true, VerifyBackupIdentifierArg: "abc123", VerifyBackupManifestPathArg: "mysql/manifest"},
+			restore: true,
+		},
+		{
+			args:    map[string]interface{}{VerifyBackupTypeArg: "kopia", KopiaSnapshotArg: snapJSON, VerifyBackupScratchSizeArg: "10Gi"},
+			restore: true,
+		},
+		{
+			args:    map[string]interface{}{VerifyBackupTypeArg: "location", VerifyBackupPathArg: "mysql/dump.sql.gz", VerifyBackupManifestArg: "abc  dump.sql.gz"},
+			restore: true,
+		},
+		{
+			args:   map[string]interface{}{VerifyBackupTypeArg: "velero"},
+			errMsg: ".*Unsupported backup type.*",
+		},
+		{
+			args:   map[string]interface{}{VerifyBackupTypeArg: "restic"},
+			errMsg: ".*Argument backupArtifactPrefix is required.*",
+		},
+		{
+			args:   map[string]interface{}{VerifyBackupTypeArg: "restic", VerifyBackupArtifactPrefixArg: "backups/mysql", VerifyBackupRestoreArg: true},
+			errMsg: ".*Argument backupID is required.*",
+		},
+		{
+			args:   map[string]interface{}{VerifyBackupTypeArg: "restic", VerifyBackupArtifactPrefixArg: "backups/mysql", VerifyBackupManifestArg: "abc  file"},
+			errMsg: ".*Argument restore is required.*",
+		},
+		{
+			args:   map[string]interface{}{VerifyBackupTypeArg: "kopia", KopiaSnapshotArg: "{"},
+			errMsg: ".*Invalid kopia snapshot.*",
+		},
+		{
+			args:   map[string]interface{}{VerifyBackupTypeArg: "location"},
+			errMsg: ".*Argument path is required.*",
+		},
+		{
+			args:   map[string]interface{}{VerifyBackupTypeArg: "location", VerifyBackupPathArg: "dump", VerifyBackupManifestArg: "abc  dump", VerifyBackupManifestPathArg: "manifest"},
+			errMsg: ".*Require at most one argument: manifest or manifestPath.*",
+		},
+		{
+			args:   map[string]interface{}{VerifyBackupTypeArg: "location", VerifyBackupPathArg: "dump", VerifyBackupScratchSizeArg: "lots"},
+			errMsg: ".*Failed to parse scratch size.*",
+		},
+		{
+			args:   map[string]interface{}{VerifyBackupTypeArg: "location", VerifyBackupPathArg: "dump", VerifyBackupScratchStorageClassArg: "fast"},
+			errMsg: ".*Argument scratchSize is required.*",
+		},
+	} {
+		tc.args[VerifyBackupNamespaceArg] = "kanister"
+		a, err := parseVerifyBackupArgs(param.TemplateParams{Profile: verifyTestS3Profile()}, tc.args)
+		if tc.errMsg != "" {
+			c.Check(err, check.ErrorMatches, tc.errMsg)
+			continue
+		}
+		c.Assert(err, check.IsNil)
+		c.Check(a.restore, check.Equals, tc.restore)
+		c.Check(a.failOnError, check.Equals, true)
+	}
+}
+
+func (s *VerifyBackupSuite) TestValidateVerifyBackupProfile(c *check.C) {
+	c.Assert(validateVerifyBackupProfile(kopiaTestProfile(), &verifyBackupArgs{backupType: VerifyBackupTypeKopia}), check.IsNil)
+	c.Assert(validateVerifyBackupProfile(verifyTestS3Profile(), &verifyBackupArgs{backupType: VerifyBackupTypeRestic}), check.IsNil)
+	err := validateVerifyBackupProfile(kopiaTestProfile(), &verifyBackupArgs{backupType: VerifyBackupTypeKopia, manifestPath: "manifest"})
+	c.Assert(err, check.ErrorMatches, "Argument manifestPath isn't supported with a kopia location")
+	err = validateVerifyBackupProfile(kopiaTestProfile(), &verifyBackupArgs{backupType: VerifyBackupTypeLocation})
+	c.Assert(err, check.ErrorMatches, "Backups of type location can't be verified in a kopia location")
+	err = validateVerifyBackupProfile(verifyTestS3Profile(), &verifyBackupArgs{backupType: VerifyBackupTypeKopia})
+	c.Assert(err, check.ErrorMatches, "Profile must have a kopia location and kopia credentials")
+}
+
+func (s *VerifyBackupSuite) TestParseManifestCheck(c *check.C) {
+	matched, failed := parseManifestCheck(`./data/users.ibd: OK
+./data/orders.ibd: FAILED
+./data/audit: log.ibd: FAILED open or read
+./data/index.ibd: OK
+`)
+	c.Assert(matched, check.Equals, 2)
+	c.Assert(failed, check.DeepEquals, []string{"./data/orders.ibd", "./data/audit: log.ibd"})
+
+	matched, failed = parseManifestCheck("")
+	c.Assert(matched, check.Equals, 0)
+	c.Assert(failed, check.IsNil)
+}
+
+func (s *VerifyBackupSuite) TestVerifyResticBackup(c *check.C) {
+	ex := &fakeVerifyExecutor{
+		outputs: map[string]string{
+			"kando location pull": "0123  ./data/users.ibd\n",
+			"sha256sum -c":        "./data/users.ibd: OK\n",
+		},
+	}
+	v := &backupVerifier{
+		ex:      ex,
+		profile: verifyTestS3Profile(),
+		args: &verifyBackupArgs{
+			backupType:           VerifyBackupTypeRestic,
+			backupArtifactPrefix: "backups/mysql",
+			backupID:             "abc123",
+			encryptionKey:        "key",
+			readData:             true,
+			restore:              true,
+			manifestPath:         "mysql/manifest",
+		},
+	}
+	report := v.verify(context.Background())
+	c.Assert(report.Error, check.Equals, "")
+	c.Assert(report.Verified, check.Equals, true)
+	c.Assert(report.Restored, check.Equals, true)
+	c.Assert(report.MatchedFiles, check.Equals, 1)
+	c.Assert(report.VerifiedAt, check.Not(check.Equals), "")
+
+	c.Assert(ex.commands, check.HasLen, 4)
+	c.Assert(ex.commands[0], check.Matches, "(?s).*restic check --read-data$")
+	c.Assert(ex.commands[1], check.Matches, "(?s).*restic restore abc123 --target "+verifyBackupScratchPath+"$")
+	c.Assert(ex.commands[2], check.Matches, "(?s).*kando location pull.* mysql/manifest -$")
+	c.Assert(ex.stdins[3], check.Equals, "0123  ./data/users.ibd\n")
+}
+
+func (s *VerifyBackupSuite) TestVerifyKopiaBackupMismatch(c *check.C) {
+	ex := &fakeVerifyExecutor{
+		outputs: map[string]string{
+			"sha256sum -c": "./users.ibd: OK\n./orders.ibd: FAILED\n",
+		},
+		failures: map[string]error{
+			"sha256sum -c": errkit.New("command terminated with exit code 1"),
+		},
+	}
+	snapJSON, err := snapshot.MarshalKopiaSnapshot(&snapshot.SnapshotInfo{ID: "k42"})
+	c.Assert(err, check.IsNil)
+	v := &backupVerifier{
+		ex:      ex,
+		profile: kopiaTestProfile(),
+		args: &verifyBackupArgs{
+			backupType: VerifyBackupTypeKopia,
+			snapshot:   snapJSON,
+			restore:    true,
+			manifest:   "0123  ./users.ibd\n4567  ./orders.ibd\n",
+		},
+	}
+	report := v.verify(context.Background())
+	c.Assert(report.Verified, check.Equals, false)
+	c.Assert(report.MatchedFiles, check.Equals, 1)
+	c.Assert(report.FailedFiles, check.DeepEquals, []string{"./orders.ibd"})
+	c.Assert(report.Error, check.Matches, ".*Files don't match the manifest.*")
+
+	var p param.Profile
+	c.Assert(json.Unmarshal([]byte(ex.stdins[0]), &p), check.IsNil)
+	c.Assert(p.Location.Type, check.Equals, crv1alpha1.LocationTypeKopia)
+
+	_, err = verifyBackupOutputs(report, true)
+	c.Assert(err, check.ErrorMatches, ".*Backup verification failed.*")
+	// The report is kept in the details of the error
+	var ewd interface{ Details() errkit.ErrorDetails }
+	c.Assert(errors.As(err, &ewd), check.Equals, true)
+	c.Assert(ewd.Details()[VerifyBackupVerifiedAtOutput], check.Equals, report.VerifiedAt)
+	var failed verifyBackupReport
+	c.Assert(json.Unmarshal([]byte(ewd.Details()[VerifyBackupReportOutput].(string)), &failed), check.IsNil)
+	c.Assert(failed, check.DeepEquals, *report)
+	out, err := verifyBackupOutputs(report, false)
+	c.Assert(err, check.IsNil)
+	c.Assert(out[VerifyBackupVerifiedOutput], check.Equals, false)
+	c.Assert(out[VerifyBackupVerifiedAtOutput], check.Equals, report.VerifiedAt)
+	var recorded verifyBackupReport
+	c.Assert(json.Unmarshal([]byte(out[VerifyBackupReportOutput].(string)), &recorded), check.IsNil)
+	c.Assert(recorded, check.DeepEquals, *report)
+}
+
+func (s *VerifyBackupSuite) TestVerifyLocationBackupPullFailure(c *check.C) {
+	ex := &fakeVerifyExecutor{
+		failures: map[string]error{
+			"kando location pull": errkit.New("NoSuchKey"),
+		},
+	}
+	v := &backupVerifier{
+		ex:      ex,
+		profile: verifyTestS3Profile(),
+		args: &verifyBackupArgs{
+			backupType: VerifyBackupTypeLocation,
+			path:       "mysql/dump.sql.gz",
+			restore:    true,
+			manifest:   "0123  dump.sql.gz\n",
+		},
+	}
+	report := v.verify(context.Background())
+	c.Assert(report.Verified, check.Equals, false)
+	c.Assert(report.Error, check.Matches, "Failed to pull artifact.*NoSuchKey.*")
+	c.Assert(ex.commands, check.HasLen, 1)
+	c.Assert(ex.commands[0], check.Matches, ".* mysql/dump.sql.gz "+verifyBackupScratchPath+"/dump.sql.gz$")
+}
+
+func (s *VerifyBackupSuite) TestVerifyBackupScratchOverride(c *check.C) {
+	override, err := verifyBackupScratchOverride(crv1alpha1.JSONMap{
+		"containers": []corev1.Container{{
+			Name:         "container",
+			VolumeMounts: []corev1.VolumeMount{{Name: "cache", MountPath: "/cache"}},
+		}},
+	})
+	c.Assert(err, check.IsNil)
+	b, err := json.Marshal(override)
+	c.Assert(err, check.IsNil)
+	var spec corev1.PodSpec
+	c.Assert(json.Unmarshal(b, &spec), check.IsNil)
+	c.Assert(spec.Volumes, check.HasLen, 1)
+	c.Assert(spec.Volumes[0].EmptyDir, check.NotNil)
+	c.Assert(spec.Containers, check.HasLen, 1)
+	c.Assert(spec.Containers[0].VolumeMounts, check.HasLen, 2)
+}
@@ -49,6 +49,10 @@ type WaitConditions struct {
 type Condition struct {
 	ObjectReference crv1alpha1.ObjectReference `json:"objectReference,omitempty"`
 	Condition       string                     `json:"condition,omitempty"`
+	// CEL is a Common Expression Language condition that is evaluated on the
+	// object, e.g. `object.status.phase == "Running"`. It can be set instead
+	// of Condition.
+	CEL string `json:"cel,omitempty"`
 }
 
 const (
@@ -102,8 +106,18 @@ func (w *waitFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[s
 	if err != nil {
 		return nil, errkit.Wrap(err, "Failed to parse timeout")
 	}
-	err = waitForCondition(ctx, dynCli, conditions, timeoutDur, tp, evaluateWaitCondition)
-	return nil, err
+	mode, err := waitMode(rendered)
+	if err != nil {
+		return nil, err
+	}
+	if mode == WaitModePoll {
+		return nil, waitForCondition(ctx, dynCli, conditions, timeoutDur, tp, evaluateWaitCondition)
+	}
+	transitions, err := waitForConditionWatch(ctx, dynCli, conditions, timeoutDur, tp, evaluateWaitConditionObj)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{WaitTransitionsOutput: transitions}, nil
 }
 
 func (*waitFunc) RequiredArgs() []string {
@@ -117,6 +131,38 @@ func (*waitFunc) Arguments() []string {
 	return []string{
 		WaitTimeoutArg,
 		WaitConditionsArg,
+		WaitModeArg,
+	}
+}
+
+func (*waitFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        WaitFuncName,
+		Description: "Waits for conditions on Kubernetes resources that are evaluated with jsonpath",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        WaitTimeoutArg,
+				Type:        kanister.ArgTypeDuration,
+				Required:    true,
+				Description: "Maximum time to wait, e.g. `10m`",
+			},
+			{
+				Name:        WaitConditionsArg,
+				Type:        kanister.ArgTypeMap,
+				Required:    true,
+				Description: "`anyOf` or `allOf` lists of go template or CEL conditions",
+			},
+			{
+				Name:        WaitModeArg,
+				Type:        kanister.ArgTypeString,
+				Description: "`watch` the objects, or `poll` them at a fixed interval",
+				Default:     WaitModeWatch,
+				Enum:        []string{WaitModeWatch, WaitModePoll},
+			},
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: WaitTransitionsOutput, Type: kanister.ArgTypeList, Description: "Status transitions of the objects that were observed in the `watch` mode"},
+		},
 	}
 }
 
@@ -124,8 +170,11 @@ func (w *waitFunc) Validate(args map[string]any) error {
 	if err := utils.CheckSupportedArgs(w.Arguments(), args); err != nil {
 		return err
 	}
+	if err := utils.CheckRequiredArgs(w.RequiredArgs(), args); err != nil {
+		return err
+	}
 
-	return utils.CheckRequiredArgs(w.RequiredArgs(), args)
+	return validateWaitConditions(args, WaitConditionsArg)
 }
 
 func (w *waitFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
@@ -193,6 +242,14 @@ func evaluateWaitCondition(ctx context.Context, dynCli dynamic.Interface, cond C
 	if err != nil {
 		return false, err
 	}
+	return evaluateWaitConditionObj(obj, cond)
+}
+
+// evaluateWaitConditionObj evaluate the go template condition on obj
+func evaluateWaitConditionObj(obj runtime.Object, cond Condition) (bool, error) {
+	if cond.CEL != "" {
+		return evaluateCELCondition(obj, cond.CEL)
+	}
 	rcondition, err := resolveJsonpath(obj, cond.Condition)
 	if err != nil {
 		return false, err
@@ -0,0 +1,118 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"github.com/kanisterio/errkit"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// celObjectVar is the name of the object in CEL conditions, e.g.
+// `object.status.phase == "Running"`
+const celObjectVar = "object"
+
+var (
+	celEnvOnce sync.Once
+	celEnv     *cel.Env
+	celEnvErr  error
+	// celPrograms caches the compiled CEL conditions by expression
+	celPrograms sync.Map
+)
+
+func waitCELEnv() (*cel.Env, error) {
+	celEnvOnce.Do(func() {
+		celEnv, celEnvErr = cel.NewEnv(
+			cel.Variable(celObjectVar, cel.DynType),
+			ext.Strings(),
+			ext.Lists(),
+		)
+	})
+	return celEnv, celEnvErr
+}
+
+// compileCELCondition parses and type-checks a CEL condition, which has to
+// evaluate to a bool.
+func compileCELCondition(expr string) (cel.Program, error) {
+	if prg, ok := celPrograms.Load(expr); ok {
+		return prg.(cel.Program), nil
+	}
+	env, err := waitCELEnv()
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to create CEL environment")
+	}
+	ast, iss := env.Compile(expr)
+	if iss.Err() != nil {
+		return nil, errkit.New(fmt.Sprintf("Invalid CEL condition: %s", iss.Err()), "condition", expr)
+	}
+	if t := ast.OutputType(); !t.IsExactType(cel.BoolType) && !t.IsExactType(cel.DynType) {
+		return nil, errkit.New(fmt.Sprintf("CEL condition must evaluate to bool, not %s", t), "condition", expr)
+	}
+	prg, err := env.Program(ast)
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to create CEL program", "condition", expr)
+	}
+	celPrograms.Store(expr, prg)
+	return prg, nil
+}
+
+// evaluateCELCondition evaluates a CEL condition on obj
+func evaluateCELCondition(obj runtime.Object, expr string) (bool, error) {
+	prg, err := compileCELCondition(expr)
+	if err != nil {
+		return false, err
+	}
+	u, ok := obj.(runtime.Unstructured)
+	if !ok {
+		return false, errkit.New(fmt.Sprintf("Unsupported object type %T", obj))
+	}
+	out, _, err := prg.Eval(map[string]any{celObjectVar: u.UnstructuredContent()})
+	if err != nil {
+		return false, errkit.Wrap(err, "Failed to evaluate CEL condition", "condition", expr)
+	}
+	met, ok := out.Value().(bool)
+	if !ok {
+		return false, errkit.New(fmt.Sprintf("CEL condition must evaluate to bool, not %T", out.Value()), "condition", expr)
+	}
+	return met, nil
+}
+
+// validateWaitConditions checks that every condition sets either a go
+// template or a CEL condition, and compiles the CEL conditions.
+func validateWaitConditions(args map[string]any, argName string) error {
+	var conds WaitConditions
+	if err := Arg(args, argName, &conds); err != nil {
+		return err
+	}
+	for _, c := range append(append([]Condition{}, conds.AnyOf...), conds.AllOf...) {
+		if c.Condition != "" && c.CEL != "" {
+			return errkit.New("Only one of condition and cel can be set", "objectReference", c.ObjectReference.Name)
+		}
+		if c.Condition == "" && c.CEL == "" {
+			return errkit.New("One of condition and cel must be set", "objectReference", c.ObjectReference.Name)
+		}
+		if c.CEL == "" {
+			continue
+		}
+		if _, err := compileCELCondition(c.CEL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
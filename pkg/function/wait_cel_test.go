@@ -0,0 +1,87 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"gopkg.in/check.v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type WaitCELSuite struct{}
+
+var _ = check.Suite(&WaitCELSuite{})
+
+func (s *WaitCELSuite) TestEvaluateCELCondition(c *check.C) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"spec":       map[string]interface{}{"replicas": int64(3)},
+		"status": map[string]interface{}{
+			"readyReplicas": int64(3),
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Available", "status": "True"},
+			},
+		},
+	}}
+	for _, tc := range []struct {
+		cel    string
+		met    bool
+		errMsg string
+	}{
+		{cel: `object.status.readyReplicas == object.spec.replicas`, met: true},
+		{cel: `object.status.conditions.exists(c, c.type == "Available" && c.status == "True")`, met: true},
+		{cel: `object.status.conditions.exists(c, c.type == "Progressing")`, met: false},
+		{cel: `has(object.status.phase) && object.status.phase == "Running"`, met: false},
+		{cel: `object.status.phase == "Running"`, errMsg: "Failed to evaluate CEL condition.*no such key: phase.*"},
+		{cel: `object.status.readyReplicas`, errMsg: "CEL condition must evaluate to bool, not int64.*"},
+		{cel: `1 + 1`, errMsg: "CEL condition must evaluate to bool, not int.*"},
+		{cel: `object.status.phase ==`, errMsg: "(?s)Invalid CEL condition: .*Syntax error.*"},
+	} {
+		met, err := evaluateCELCondition(obj, tc.cel)
+		if tc.errMsg != "" {
+			c.Assert(err, check.ErrorMatches, tc.errMsg, check.Commentf(tc.cel))
+			continue
+		}
+		c.Assert(err, check.IsNil, check.Commentf(tc.cel))
+		c.Assert(met, check.Equals, tc.met, check.Commentf(tc.cel))
+	}
+}
+
+func (s *WaitCELSuite) TestValidateCELConditions(c *check.C) {
+	objRef := map[string]interface{}{"apiVersion": "v1", "resource": "pods", "name": "db-0", "namespace": "app"}
+	for _, tc := range []struct {
+		cond   map[string]interface{}
+		errMsg string
+	}{
+		{cond: map[string]interface{}{"objectReference": objRef, "cel": `object.status.phase == "Running"`}},
+		{cond: map[string]interface{}{"objectReference": objRef, "condition": `{{ if eq .status.phase "Running" }}true{{ end }}`}},
+		{cond: map[string]interface{}{"objectReference": objRef, "cel": `object.status.phase = "Running"`}, errMsg: "(?s)Invalid CEL condition.*"},
+		{cond: map[string]interface{}{"objectReference": objRef, "cel": `"Running"`}, errMsg: "CEL condition must evaluate to bool, not string.*"},
+		{cond: map[string]interface{}{"objectReference": objRef, "cel": `true`, "condition": `true`}, errMsg: "Only one of condition and cel can be set.*"},
+		{cond: map[string]interface{}{"objectReference": objRef}, errMsg: "One of condition and cel must be set.*"},
+	} {
+		for _, f := range []interface{ Validate(map[string]any) error }{&waitFunc{}, &waitV2Func{}} {
+			err := f.Validate(map[string]any{
+				WaitTimeoutArg:    "1m",
+				WaitConditionsArg: map[string]interface{}{"allOf": []interface{}{tc.cond}},
+			})
+			if tc.errMsg == "" {
+				c.Assert(err, check.IsNil)
+				continue
+			}
+			c.Assert(err, check.ErrorMatches, tc.errMsg)
+		}
+	}
+}
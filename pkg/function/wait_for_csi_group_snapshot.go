@@ -0,0 +1,127 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/progress"
+	"github.com/kanisterio/kanister/pkg/utils"
+)
+
+func init() {
+	_ = kanister.Register(&waitForCSIGroupSnapshotFunc{})
+}
+
+var (
+	_ kanister.Func = (*waitForCSIGroupSnapshotFunc)(nil)
+)
+
+const (
+	// WaitForCSIGroupSnapshotFuncName gives the name of the function
+	WaitForCSIGroupSnapshotFuncName = "WaitForCSIGroupSnapshot"
+	// WaitForCSIGroupSnapshotNameArg provides name of the VolumeGroupSnapshot
+	WaitForCSIGroupSnapshotNameArg = "name"
+	// WaitForCSIGroupSnapshotNamespaceArg mentions the namespace of the VolumeGroupSnapshot
+	WaitForCSIGroupSnapshotNamespaceArg = "namespace"
+)
+
+type waitForCSIGroupSnapshotFunc struct {
+	progressPercent string
+}
+
+func (*waitForCSIGroupSnapshotFunc) Name() string {
+	return WaitForCSIGroupSnapshotFuncName
+}
+
+func (w *waitForCSIGroupSnapshotFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	// Set progress percent
+	w.progressPercent = progress.StartedPercent
+	defer func() { w.progressPercent = progress.CompletedPercent }()
+
+	var name, namespace string
+	if err := Arg(args, WaitForCSIGroupSnapshotNameArg, &name); err != nil {
+		return nil, err
+	}
+	if err := Arg(args, WaitForCSIGroupSnapshotNamespaceArg, &namespace); err != nil {
+		return nil, err
+	}
+	snapshotter, err := newSnapshotter()
+	if err != nil {
+		return nil, err
+	}
+	if err := snapshotter.WaitOnGroupReadyToUse(ctx, name, namespace); err != nil {
+		return nil, err
+	}
+	return csiGroupSnapshotOutput(ctx, snapshotter, name, namespace)
+}
+
+func (*waitForCSIGroupSnapshotFunc) RequiredArgs() []string {
+	return []string{
+		WaitForCSIGroupSnapshotNameArg,
+		WaitForCSIGroupSnapshotNamespaceArg,
+	}
+}
+
+func (*waitForCSIGroupSnapshotFunc) Arguments() []string {
+	return []string{
+		WaitForCSIGroupSnapshotNameArg,
+		WaitForCSIGroupSnapshotNamespaceArg,
+	}
+}
+
+func (*waitForCSIGroupSnapshotFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        WaitForCSIGroupSnapshotFuncName,
+		Description: "Waits until a VolumeGroupSnapshot is ready and outputs the VolumeSnapshots of its PVCs",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        WaitForCSIGroupSnapshotNameArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Name of the VolumeGroupSnapshot",
+			},
+			{
+				Name:        WaitForCSIGroupSnapshotNamespaceArg,
+				Type:        kanister.ArgTypeString,
+				Required:    true,
+				Description: "Namespace of the VolumeGroupSnapshot",
+			},
+		},
+		Outputs: csiGroupSnapshotOutputSchema,
+	}
+}
+
+func (w *waitForCSIGroupSnapshotFunc) Validate(args map[string]any) error {
+	if err := utils.CheckSupportedArgs(w.Arguments(), args); err != nil {
+		return err
+	}
+
+	return utils.CheckRequiredArgs(w.RequiredArgs(), args)
+}
+
+func (w *waitForCSIGroupSnapshotFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
+	metav1Time := metav1.NewTime(time.Now())
+	return crv1alpha1.PhaseProgress{
+		ProgressPercent:    w.progressPercent,
+		LastTransitionTime: &metav1Time,
+	}, nil
+}
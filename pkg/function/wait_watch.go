@@ -0,0 +1,356 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/kanisterio/errkit"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kanisterio/kanister/pkg/field"
+	"github.com/kanisterio/kanister/pkg/log"
+	"github.com/kanisterio/kanister/pkg/param"
+)
+
+const (
+	// WaitModeArg selects how the wait functions observe the objects
+	WaitModeArg = "mode"
+	// WaitModeWatch watches the objects and evaluates the conditions when they change
+	WaitModeWatch = "watch"
+	// WaitModePoll fetches the objects and evaluates the conditions at a fixed interval
+	WaitModePoll = "poll"
+	// WaitTransitionsOutput lists the status transitions of the objects that were observed while waiting
+	WaitTransitionsOutput = "transitions"
+
+	// waitResyncPeriod is how long a watch is used before the objects are
+	// listed again, in case an event was missed
+	waitResyncPeriod  = 30 * time.Second
+	waitRetryInterval = time.Second
+	// maxWaitTransitions limits the transitions kept in the output, which is
+	// stored in the status of the ActionSet
+	maxWaitTransitions = 20
+)
+
+// evalObjectFunc evaluates a wait condition on the object it refers to.
+type evalObjectFunc func(obj runtime.Object, cond Condition) (bool, error)
+
+// waitObjectKey identifies an object that is watched.
+type waitObjectKey struct {
+	gvr       schema.GroupVersionResource
+	namespace string
+	name      string
+}
+
+func (k waitObjectKey) String() string {
+	gr := k.gvr.GroupResource().String()
+	if k.namespace == "" {
+		return fmt.Sprintf("%s/%s", gr, k.name)
+	}
+	return fmt.Sprintf("%s/%s/%s", gr, k.namespace, k.name)
+}
+
+// waitEvent is the latest state of a watched object. obj is nil if the object
+// doesn't exist.
+type waitEvent struct {
+	key waitObjectKey
+	obj *unstructured.Unstructured
+	err error
+}
+
+// watchedCondition is a condition with its rendered object reference.
+type watchedCondition struct {
+	Condition
+	key waitObjectKey
+}
+
+func waitMode(args map[string]interface{}) (string, error) {
+	var mode string
+	if err := OptArg(args, WaitModeArg, &mode, WaitModeWatch); err != nil {
+		return "", err
+	}
+	if mode != WaitModeWatch && mode != WaitModePoll {
+		return "", errkit.New(fmt.Sprintf("Invalid wait mode '%s', expected '%s' or '%s'", mode, WaitModeWatch, WaitModePoll))
+	}
+	return mode, nil
+}
+
+// waitForConditionWatch watches the objects of the conditions till the
+// conditions are met within the timeout duration. It returns the last status
+// transitions of the objects that were observed.
+func waitForConditionWatch(
+	ctx context.Context,
+	dynCli dynamic.Interface,
+	waitCond WaitConditions,
+	timeout time.Duration,
+	tp param.TemplateParams,
+	eval evalObjectFunc,
+) ([]interface{}, error) {
+	anyOf, err := resolveWatchedConditions(waitCond.AnyOf, tp)
+	if err != nil {
+		return nil, err
+	}
+	allOf, err := resolveWatchedConditions(waitCond.AllOf, tp)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	events := make(chan waitEvent)
+	watched := make(map[waitObjectKey]bool)
+	for _, c := range append(append([]watchedCondition{}, anyOf...), allOf...) {
+		if watched[c.key] {
+			continue
+		}
+		watched[c.key] = true
+		wg.Add(1)
+		go func(key waitObjectKey) {
+			defer wg.Done()
+			watchWaitObject(ctx, dynCli, key, events)
+		}(c.key)
+	}
+
+	objects := make(map[waitObjectKey]*unstructured.Unstructured, len(watched))
+	var transitions []interface{}
+	var evalErr error
+	for {
+		select {
+		case <-ctx.Done():
+			err := errkit.Wrap(ctx.Err(), "Failed to wait for the condition to be met")
+			if evalErr != nil {
+				return transitions, errkit.Wrap(err, evalErr.Error())
+			}
+			return transitions, err
+		case e := <-events:
+			if e.err != nil {
+				log.Debug().WithError(e.err).Print("Failed to watch the object", field.M{"object": e.key.String()})
+				continue
+			}
+			prev, seen := objects[e.key]
+			objects[e.key] = e.obj
+			var met bool
+			met, evalErr = evaluateWatchedConditions(anyOf, allOf, objects, eval)
+			if !seen || statusChanged(prev, e.obj) {
+				transitions = appendTransition(transitions, waitTransition(e.key, e.obj, met))
+			}
+			if met {
+				return transitions, nil
+			}
+		}
+	}
+}
+
+func resolveWatchedConditions(conds []Condition, tp param.TemplateParams) ([]watchedCondition, error) {
+	watched := make([]watchedCondition, 0, len(conds))
+	for _, cond := range conds {
+		objRef, err := resolveWaitConditionObjRefs(cond, tp)
+		if err != nil {
+			return nil, err
+		}
+		watched = append(watched, watchedCondition{
+			Condition: cond,
+			key: waitObjectKey{
+				gvr:       schema.GroupVersionResource{Group: objRef.Group, Version: objRef.APIVersion, Resource: objRef.Resource},
+				namespace: objRef.Namespace,
+				name:      objRef.Name,
+			},
+		})
+	}
+	return watched, nil
+}
+
+// evaluateWatchedConditions returns true if any of the anyOf conditions or
+// all of the allOf conditions are met. A condition on an object that doesn't
+// exist or hasn't been observed yet isn't met.
+func evaluateWatchedConditions(anyOf, allOf []watchedCondition, objects map[waitObjectKey]*unstructured.Unstructured, eval evalObjectFunc) (bool, error) {
+	evaluate := func(c watchedCondition) (bool, error) {
+		obj := objects[c.key]
+		if obj == nil {
+			return false, errkit.New("Object not found", "object", c.key.String())
+		}
+		return eval(obj, c.Condition)
+	}
+	var evalErr error
+	for _, c := range anyOf {
+		met, err := evaluate(c)
+		if err != nil {
+			evalErr = err
+			continue
+		}
+		if met {
+			return true, nil
+		}
+	}
+	if len(allOf) == 0 {
+		return false, evalErr
+	}
+	for _, c := range allOf {
+		met, err := evaluate(c)
+		if err != nil || !met {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// watchWaitObject sends the state of the object to events whenever it
+// changes, till ctx is done. The object is listed again after each resync
+// period and whenever the watch fails, e.g. because the resource version
+// is too old.
+func watchWaitObject(ctx context.Context, dynCli dynamic.Interface, key waitObjectKey, events chan<- waitEvent) {
+	ri := dynCli.Resource(key.gvr).Namespace(key.namespace)
+	selector := fields.OneTermEqualSelector("metadata.name", key.name).String()
+	for ctx.Err() == nil {
+		list, err := ri.List(ctx, metav1.ListOptions{FieldSelector: selector})
+		if err != nil {
+			sendWaitEvent(ctx, events, waitEvent{key: key, err: err})
+			sleepCtx(ctx, waitRetryInterval)
+			continue
+		}
+		var obj *unstructured.Unstructured
+		for i := range list.Items {
+			if list.Items[i].GetName() == key.name {
+				obj = &list.Items[i]
+			}
+		}
+		if !sendWaitEvent(ctx, events, waitEvent{key: key, obj: obj}) {
+			return
+		}
+		if err := watchWaitObjectUntilResync(ctx, ri, key, selector, list.GetResourceVersion(), events); err != nil {
+			sendWaitEvent(ctx, events, waitEvent{key: key, err: err})
+			sleepCtx(ctx, waitRetryInterval)
+		}
+	}
+}
+
+// watchWaitObjectUntilResync watches the object from resourceVersion till the
+// resync period has passed.
+func watchWaitObjectUntilResync(
+	ctx context.Context,
+	ri dynamic.ResourceInterface,
+	key waitObjectKey,
+	selector string,
+	resourceVersion string,
+	events chan<- waitEvent,
+) error {
+	timeout := int64(waitResyncPeriod.Seconds())
+	w, err := ri.Watch(ctx, metav1.ListOptions{
+		FieldSelector:   selector,
+		ResourceVersion: resourceVersion,
+		TimeoutSeconds:  &timeout,
+	})
+	if err != nil {
+		return err
+	}
+	defer w.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-w.ResultChan():
+			if !ok {
+				return nil
+			}
+			switch e.Type {
+			case watch.Added, watch.Modified:
+				obj, ok := e.Object.(*unstructured.Unstructured)
+				if !ok || obj.GetName() != key.name {
+					continue
+				}
+				if !sendWaitEvent(ctx, events, waitEvent{key: key, obj: obj}) {
+					return nil
+				}
+			case watch.Deleted:
+				if obj, ok := e.Object.(*unstructured.Unstructured); ok && obj.GetName() != key.name {
+					continue
+				}
+				if !sendWaitEvent(ctx, events, waitEvent{key: key}) {
+					return nil
+				}
+			case watch.Error:
+				return apierrors.FromObject(e.Object)
+			}
+		}
+	}
+}
+
+func sendWaitEvent(ctx context.Context, events chan<- waitEvent, e waitEvent) bool {
+	select {
+	case events <- e:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+	case <-ctx.Done():
+	}
+}
+
+func statusChanged(prev, obj *unstructured.Unstructured) bool {
+	if prev == nil || obj == nil {
+		return prev != obj
+	}
+	return !reflect.DeepEqual(prev.Object["status"], obj.Object["status"])
+}
+
+// appendTransition appends t to transitions, dropping the oldest transitions
+// beyond maxWaitTransitions.
+func appendTransition(transitions []interface{}, t map[string]interface{}) []interface{} {
+	transitions = append(transitions, t)
+	if len(transitions) > maxWaitTransitions {
+		transitions = append([]interface{}{}, transitions[len(transitions)-maxWaitTransitions:]...)
+	}
+	return transitions
+}
+
+// waitTransition describes the observed state of an object and whether the
+// conditions were met in that state.
+func waitTransition(key waitObjectKey, obj *unstructured.Unstructured, met bool) map[string]interface{} {
+	t := map[string]interface{}{
+		"object": key.String(),
+		"time":   time.Now().UTC().Format(time.RFC3339),
+		"exists": obj != nil,
+		"met":    met,
+	}
+	if obj != nil {
+		t["resourceVersion"] = obj.GetResourceVersion()
+		if status, ok := obj.Object["status"]; ok {
+			t["status"] = status
+		}
+	}
+	return t
+}
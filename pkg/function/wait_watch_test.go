@@ -0,0 +1,139 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package function
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"gopkg.in/check.v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakedyncli "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/param"
+)
+
+type WaitWatchSuite struct{}
+
+var _ = check.Suite(&WaitWatchSuite{})
+
+func (s *WaitWatchSuite) TestWaitForConditionWatch(c *check.C) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-0", Namespace: "app"},
+		Status:     corev1.PodStatus{Phase: corev1.PodPending},
+	}
+	other := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "app"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	dynCli := fakedyncli.NewSimpleDynamicClientWithCustomListKinds(scheme.Scheme, map[schema.GroupVersionResource]string{gvr: "PodList"}, pod, other)
+	conds := WaitConditions{
+		AnyOf: []Condition{
+			{
+				ObjectReference: crv1alpha1.ObjectReference{APIVersion: "v1", Resource: "pods", Name: "{{ .Options.pod }}", Namespace: "app"},
+				Condition:       `{{ if eq .status.phase "Running" }}true{{ end }}`,
+			},
+		},
+	}
+	tp := param.TemplateParams{
+		Time:    time.Now().String(),
+		Options: map[string]string{"pod": "db-0"},
+	}
+
+	type result struct {
+		transitions []interface{}
+		err         error
+	}
+	done := make(chan result)
+	go func() {
+		transitions, err := waitForConditionWatch(context.Background(), dynCli, conds, time.Minute, tp, evaluateWaitV2ConditionObj)
+		done <- result{transitions: transitions, err: err}
+	}()
+
+	ctx := context.Background()
+	var r result
+	for phase := corev1.PodPending; ; {
+		select {
+		case r = <-done:
+		case <-time.After(100 * time.Millisecond):
+			// Update the pod till the watch has started and the update is observed
+			if phase == corev1.PodPending {
+				phase = corev1.PodRunning
+			}
+			u, err := dynCli.Resource(gvr).Namespace("app").Get(ctx, "db-0", metav1.GetOptions{})
+			c.Assert(err, check.IsNil)
+			c.Assert(unstructured.SetNestedField(u.Object, string(phase), "status", "phase"), check.IsNil)
+			_, err = dynCli.Resource(gvr).Namespace("app").Update(ctx, u, metav1.UpdateOptions{})
+			c.Assert(err, check.IsNil)
+			continue
+		}
+		break
+	}
+	c.Assert(r.err, check.IsNil)
+	c.Assert(r.transitions, check.HasLen, 2)
+	first := r.transitions[0].(map[string]interface{})
+	c.Assert(first["object"], check.Equals, "pods/app/db-0")
+	c.Assert(first["met"], check.Equals, false)
+	c.Assert(first["status"], check.DeepEquals, map[string]interface{}{"phase": "Pending"})
+	last := r.transitions[1].(map[string]interface{})
+	c.Assert(last["met"], check.Equals, true)
+	c.Assert(last["status"], check.DeepEquals, map[string]interface{}{"phase": "Running"})
+}
+
+func (s *WaitWatchSuite) TestWaitForConditionWatchTimeout(c *check.C) {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	dynCli := fakedyncli.NewSimpleDynamicClientWithCustomListKinds(scheme.Scheme, map[schema.GroupVersionResource]string{gvr: "PodList"})
+	conds := WaitConditions{
+		AllOf: []Condition{
+			{
+				ObjectReference: crv1alpha1.ObjectReference{APIVersion: "v1", Resource: "pods", Name: "missing", Namespace: "app"},
+				Condition:       `true`,
+			},
+		},
+	}
+	transitions, err := waitForConditionWatch(context.Background(), dynCli, conds, time.Second, param.TemplateParams{Time: time.Now().String()}, evaluateWaitV2ConditionObj)
+	c.Assert(err, check.ErrorMatches, "Object not found.*Failed to wait for the condition to be met.*")
+	c.Assert(transitions, check.HasLen, 1)
+	c.Assert(transitions[0].(map[string]interface{})["exists"], check.Equals, false)
+}
+
+func (s *WaitWatchSuite) TestAppendTransition(c *check.C) {
+	var transitions []interface{}
+	for i := 0; i < maxWaitTransitions+5; i++ {
+		transitions = appendTransition(transitions, map[string]interface{}{"resourceVersion": strconv.Itoa(i)})
+	}
+	// Only the last transitions are kept
+	c.Assert(transitions, check.HasLen, maxWaitTransitions)
+	c.Assert(transitions[0].(map[string]interface{})["resourceVersion"], check.Equals, "5")
+	c.Assert(transitions[maxWaitTransitions-1].(map[string]interface{})["resourceVersion"], check.Equals, strconv.Itoa(maxWaitTransitions+4))
+}
+
+func (s *WaitWatchSuite) TestWaitMode(c *check.C) {
+	mode, err := waitMode(map[string]interface{}{})
+	c.Assert(err, check.IsNil)
+	c.Assert(mode, check.Equals, WaitModeWatch)
+	mode, err = waitMode(map[string]interface{}{WaitModeArg: WaitModePoll})
+	c.Assert(err, check.IsNil)
+	c.Assert(mode, check.Equals, WaitModePoll)
+	_, err = waitMode(map[string]interface{}{WaitModeArg: "sleep"})
+	c.Assert(err, check.ErrorMatches, "Invalid wait mode.*")
+}
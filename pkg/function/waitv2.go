@@ -80,8 +80,18 @@ func (w *waitV2Func) Exec(ctx context.Context, tp param.TemplateParams, args map
 	if err != nil {
 		return nil, errkit.Wrap(err, "Failed to parse timeout")
 	}
-	err = waitForCondition(ctx, dynCli, conditions, timeoutDur, tp, evaluateWaitV2Condition)
-	return nil, err
+	mode, err := waitMode(args)
+	if err != nil {
+		return nil, err
+	}
+	if mode == WaitModePoll {
+		return nil, waitForCondition(ctx, dynCli, conditions, timeoutDur, tp, evaluateWaitV2Condition)
+	}
+	transitions, err := waitForConditionWatch(ctx, dynCli, conditions, timeoutDur, tp, evaluateWaitV2ConditionObj)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{WaitTransitionsOutput: transitions}, nil
 }
 
 func (*waitV2Func) RequiredArgs() []string {
@@ -95,6 +105,38 @@ func (*waitV2Func) Arguments() []string {
 	return []string{
 		WaitV2TimeoutArg,
 		WaitV2ConditionsArg,
+		WaitModeArg,
+	}
+}
+
+func (*waitV2Func) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Name:        WaitV2FuncName,
+		Description: "Waits for conditions on Kubernetes resources that are evaluated with go templates",
+		Args: []kanister.ArgSchema{
+			{
+				Name:        WaitV2TimeoutArg,
+				Type:        kanister.ArgTypeDuration,
+				Required:    true,
+				Description: "Maximum time to wait, e.g. `10m`",
+			},
+			{
+				Name:        WaitV2ConditionsArg,
+				Type:        kanister.ArgTypeMap,
+				Required:    true,
+				Description: "`anyOf` or `allOf` lists of go template or CEL conditions",
+			},
+			{
+				Name:        WaitModeArg,
+				Type:        kanister.ArgTypeString,
+				Description: "`watch` the objects, or `poll` them at a fixed interval",
+				Default:     WaitModeWatch,
+				Enum:        []string{WaitModeWatch, WaitModePoll},
+			},
+		},
+		Outputs: []kanister.OutputSchema{
+			{Name: WaitTransitionsOutput, Type: kanister.ArgTypeList, Description: "Status transitions of the objects that were observed in the `watch` mode"},
+		},
 	}
 }
 
@@ -102,8 +144,11 @@ func (w *waitV2Func) Validate(args map[string]any) error {
 	if err := utils.CheckSupportedArgs(w.Arguments(), args); err != nil {
 		return err
 	}
+	if err := utils.CheckRequiredArgs(w.RequiredArgs(), args); err != nil {
+		return err
+	}
 
-	return utils.CheckRequiredArgs(w.RequiredArgs(), args)
+	return validateWaitConditions(args, WaitV2ConditionsArg)
 }
 
 func (w *waitV2Func) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
@@ -125,6 +170,14 @@ func evaluateWaitV2Condition(ctx context.Context, dynCli dynamic.Interface, cond
 	if err != nil {
 		return false, err
 	}
+	return evaluateWaitV2ConditionObj(obj, cond)
+}
+
+// evaluateWaitV2ConditionObj evaluate the go template condition on obj
+func evaluateWaitV2ConditionObj(obj runtime.Object, cond Condition) (bool, error) {
+	if cond.CEL != "" {
+		return evaluateCELCondition(obj, cond.CEL)
+	}
 	value, err := evaluateGoTemplate(obj, cond.Condition)
 	if err != nil {
 		return false, err
@@ -37,6 +37,7 @@ import (
 	"github.com/kanisterio/kanister/pkg/handler"
 	"github.com/kanisterio/kanister/pkg/kube"
 	"github.com/kanisterio/kanister/pkg/log"
+	"github.com/kanisterio/kanister/pkg/plugin"
 	"github.com/kanisterio/kanister/pkg/resource"
 	"github.com/kanisterio/kanister/pkg/validatingwebhook"
 
@@ -116,6 +117,18 @@ func Execute() {
 		return
 	}
 
+	// Register the functions served by plugins before actions can use them
+	plugins, err := plugin.Discover(ctx, plugin.ConfigFromEnv())
+	if err != nil {
+		log.WithError(err).Print("Failed to discover function plugins.")
+		return
+	}
+	defer func() {
+		if err := plugins.Close(); err != nil {
+			log.WithError(err).Print("Failed to stop function plugins")
+		}
+	}()
+
 	// Create and start the watcher.
 	ctx, cancel := context.WithCancel(ctx)
 
@@ -50,6 +50,10 @@ const (
 	secretsFlagName          = "secrets"
 	statefulSetFlagName      = "statefulset"
 	deploymentConfigFlagName = "deploymentconfig"
+	daemonSetFlagName        = "daemonset"
+	replicaSetFlagName       = "replicaset"
+	jobFlagName              = "job"
+	cronJobFlagName          = "cronjob"
 	sourceFlagName           = "from"
 	selectorFlagName         = "selector"
 	selectorKindFlag         = "kind"
@@ -104,6 +108,10 @@ func newActionSetCmd() *cobra.Command {
 	cmd.Flags().StringSliceP(statefulSetFlagName, "t", []string{}, "statefulset for the action set, comma separated namespace/name pairs (eg: --statefulset namespace1/name1,namespace2/name2)")
 	cmd.Flags().StringSliceP(deploymentConfigFlagName, "D", []string{}, "deploymentconfig for action set, comma separated namespace/name pairs "+
 		"(e.g. --deploymentconfig namespace1/name1,namespace2/name2). Will ideally be used on openshift clusters.")
+	cmd.Flags().StringSlice(daemonSetFlagName, []string{}, "daemonset for the action set, comma separated namespace/name pairs (eg: --daemonset namespace1/name1,namespace2/name2)")
+	cmd.Flags().StringSlice(replicaSetFlagName, []string{}, "replicaset for the action set, comma separated namespace/name pairs (eg: --replicaset namespace1/name1,namespace2/name2)")
+	cmd.Flags().StringSlice(jobFlagName, []string{}, "job for the action set, comma separated namespace/name pairs (eg: --job namespace1/name1,namespace2/name2)")
+	cmd.Flags().StringSlice(cronJobFlagName, []string{}, "cronjob for the action set, comma separated namespace/name pairs (eg: --cronjob namespace1/name1,namespace2/name2)")
 	cmd.Flags().StringP(selectorFlagName, "l", "", "k8s selector for objects")
 	cmd.Flags().StringP(selectorKindFlag, "k", "all", "resource kind to apply selector on. Used along with the selector specified using --selector/-l")
 	cmd.Flags().String(selectorNamespaceFlag, "", "namespace to apply selector on. Used along with the selector specified using --selector/-l")
@@ -223,6 +231,7 @@ func ChildActionSet(parent *crv1alpha1.ActionSet, params *PerformParams) (*crv1a
 			ConfigMaps:     parent.Spec.Actions[aidx].ConfigMaps,
 			Profile:        parent.Spec.Actions[aidx].Profile,
 			Options:        mergeOptions(params.Options, parent.Spec.Actions[aidx].Options),
+			OptionsFrom:    parent.Spec.Actions[aidx].OptionsFrom,
 			PodAnnotations: params.PodAnnotations,
 			PodLabels:      params.PodLabels,
 		}
@@ -442,12 +451,20 @@ func parseObjects(cmd *cobra.Command, cli kubernetes.Interface, osCli osversione
 	deployments, _ := cmd.Flags().GetStringSlice(deploymentFlagName)
 	statefulSets, _ := cmd.Flags().GetStringSlice(statefulSetFlagName)
 	deploymentConfig, _ := cmd.Flags().GetStringSlice(deploymentConfigFlagName)
+	daemonSets, _ := cmd.Flags().GetStringSlice(daemonSetFlagName)
+	replicaSets, _ := cmd.Flags().GetStringSlice(replicaSetFlagName)
+	jobs, _ := cmd.Flags().GetStringSlice(jobFlagName)
+	cronJobs, _ := cmd.Flags().GetStringSlice(cronJobFlagName)
 	pvcs, _ := cmd.Flags().GetStringSlice(pvcFlagName)
 	namespaces, _ := cmd.Flags().GetStringSlice(namespaceTargetsFlagName)
 
 	objs[param.DeploymentKind] = deployments
 	objs[param.StatefulSetKind] = statefulSets
 	objs[param.DeploymentConfigKind] = deploymentConfig
+	objs[param.DaemonSetKind] = daemonSets
+	objs[param.ReplicaSetKind] = replicaSets
+	objs[param.JobKind] = jobs
+	objs[param.CronJobKind] = cronJobs
 	objs[param.PVCKind] = pvcs
 	objs[param.NamespaceKind] = namespaces
 
@@ -503,12 +520,20 @@ func parseObjectsFromCmd(objs map[string][]string, parsed map[string]bool) ([]cr
 				objects = append(objects, crv1alpha1.ObjectReference{Kind: param.StatefulSetKind, Namespace: namespace, Name: name})
 			case param.DeploymentConfigKind:
 				objects = append(objects, crv1alpha1.ObjectReference{Kind: param.DeploymentConfigKind, Namespace: namespace, Name: name})
+			case param.DaemonSetKind:
+				objects = append(objects, crv1alpha1.ObjectReference{Kind: param.DaemonSetKind, Namespace: namespace, Name: name})
+			case param.ReplicaSetKind:
+				objects = append(objects, crv1alpha1.ObjectReference{Kind: param.ReplicaSetKind, Namespace: namespace, Name: name})
+			case param.JobKind:
+				objects = append(objects, crv1alpha1.ObjectReference{Kind: param.JobKind, Namespace: namespace, Name: name})
+			case param.CronJobKind:
+				objects = append(objects, crv1alpha1.ObjectReference{Kind: param.CronJobKind, Namespace: namespace, Name: name})
 			case param.PVCKind:
 				objects = append(objects, crv1alpha1.ObjectReference{Kind: param.PVCKind, Namespace: namespace, Name: name})
 			case param.NamespaceKind:
 				objects = append(objects, crv1alpha1.ObjectReference{Kind: param.NamespaceKind, Namespace: namespace, Name: name})
 			default:
-				return nil, errkit.New(fmt.Sprintf("unsupported or unknown object kind '%s'. Supported %s, %s and %s", kind, param.DeploymentKind, param.StatefulSetKind, param.PVCKind))
+				return nil, errUnsupportedKind(kind)
 			}
 		}
 	}
@@ -593,6 +618,30 @@ func parseObjectsFromSelector(selector, kind, sns string, cli kubernetes.Interfa
 			break
 		}
 		fallthrough
+	case param.DaemonSetKind:
+		dss, err := cli.AppsV1().DaemonSets(sns).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return nil, errkit.New(fmt.Sprintf("failed to get daemonsets using selector '%s' in namespace '%s'", selector, sns))
+		}
+		for _, d := range dss.Items {
+			appendObj(param.DaemonSetKind, d.Namespace, d.Name)
+		}
+		if kind != "all" {
+			break
+		}
+		fallthrough
+	case param.CronJobKind:
+		cjs, err := cli.BatchV1().CronJobs(sns).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return nil, errkit.New(fmt.Sprintf("failed to get cronjobs using selector '%s' in namespace '%s'", selector, sns))
+		}
+		for _, cj := range cjs.Items {
+			appendObj(param.CronJobKind, cj.Namespace, cj.Name)
+		}
+		if kind != "all" {
+			break
+		}
+		fallthrough
 	case param.PVCKind:
 		pvcs, err := cli.CoreV1().PersistentVolumeClaims(sns).List(ctx, metav1.ListOptions{LabelSelector: selector})
 		if err != nil {
@@ -601,6 +650,24 @@ func parseObjectsFromSelector(selector, kind, sns string, cli kubernetes.Interfa
 		for _, pvc := range pvcs.Items {
 			appendObj(param.PVCKind, pvc.Namespace, pvc.Name)
 		}
+	// ReplicaSets and Jobs are usually owned by Deployments and CronJobs, so
+	// they are only listed when their kind is requested, not with "all"
+	case param.ReplicaSetKind:
+		rss, err := cli.AppsV1().ReplicaSets(sns).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return nil, errkit.New(fmt.Sprintf("failed to get replicasets using selector '%s' in namespace '%s'", selector, sns))
+		}
+		for _, r := range rss.Items {
+			appendObj(param.ReplicaSetKind, r.Namespace, r.Name)
+		}
+	case param.JobKind:
+		jobs, err := cli.BatchV1().Jobs(sns).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return nil, errkit.New(fmt.Sprintf("failed to get jobs using selector '%s' in namespace '%s'", selector, sns))
+		}
+		for _, j := range jobs.Items {
+			appendObj(param.JobKind, j.Namespace, j.Name)
+		}
 	case param.NamespaceKind:
 		namespaces, err := cli.CoreV1().Namespaces().List(ctx, metav1.ListOptions{LabelSelector: selector})
 		if err != nil {
@@ -610,11 +677,26 @@ func parseObjectsFromSelector(selector, kind, sns string, cli kubernetes.Interfa
 			appendObj(param.NamespaceKind, ns.Namespace, ns.Name)
 		}
 	default:
-		return nil, errkit.New(fmt.Sprintf("unsupported or unknown object kind '%s'. Supported %s, %s and %s", kind, param.DeploymentKind, param.StatefulSetKind, param.PVCKind))
+		return nil, errUnsupportedKind(kind)
 	}
 	return objects, nil
 }
 
+func errUnsupportedKind(kind string) error {
+	supported := []string{
+		param.DeploymentKind,
+		param.StatefulSetKind,
+		param.DeploymentConfigKind,
+		param.DaemonSetKind,
+		param.ReplicaSetKind,
+		param.JobKind,
+		param.CronJobKind,
+		param.PVCKind,
+		param.NamespaceKind,
+	}
+	return errkit.New(fmt.Sprintf("unsupported or unknown object kind '%s'. Supported %s", kind, strings.Join(supported, ", ")))
+}
+
 func parseOptions(cmd *cobra.Command) (map[string]string, error) {
 	optionsFromCmd, _ := cmd.Flags().GetStringSlice(optionsFlagName)
 	options := make(map[string]string)
@@ -801,6 +883,14 @@ func verifyObjectParams(ctx context.Context, p *PerformParams, cli kubernetes.In
 		case param.DeploymentConfigKind:
 			// use open shift client to get the deployment config resource
 			_, err = osCli.AppsV1().DeploymentConfigs(obj.Namespace).Get(ctx, obj.Name, metav1.GetOptions{})
+		case param.DaemonSetKind:
+			_, err = cli.AppsV1().DaemonSets(obj.Namespace).Get(ctx, obj.Name, metav1.GetOptions{})
+		case param.ReplicaSetKind:
+			_, err = cli.AppsV1().ReplicaSets(obj.Namespace).Get(ctx, obj.Name, metav1.GetOptions{})
+		case param.JobKind:
+			_, err = cli.BatchV1().Jobs(obj.Namespace).Get(ctx, obj.Name, metav1.GetOptions{})
+		case param.CronJobKind:
+			_, err = cli.BatchV1().CronJobs(obj.Namespace).Get(ctx, obj.Name, metav1.GetOptions{})
 		case param.PVCKind:
 			_, err = cli.CoreV1().PersistentVolumeClaims(obj.Namespace).Get(ctx, obj.Name, metav1.GetOptions{})
 		case param.NamespaceKind:
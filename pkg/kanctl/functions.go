@@ -0,0 +1,44 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kanctl
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+)
+
+func newFunctionsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "functions [<name>...]",
+		Short: "Print the schema of the arguments and outputs of Kanister functions as JSON",
+		Long: "Print the schema of the arguments and outputs of the registered Kanister functions as JSON. " +
+			"If function names are given, only the schemas of those functions are printed.",
+		RunE: printFunctionSchemas,
+	}
+	return cmd
+}
+
+func printFunctionSchemas(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	out, err := kanister.ExportFuncSchemas(args...)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s\n", out)
+	return nil
+}
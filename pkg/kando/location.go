@@ -42,6 +42,10 @@ func newLocationCommand() *cobra.Command {
 	cmd.AddCommand(newLocationPushCommand())
 	cmd.AddCommand(newLocationPullCommand())
 	cmd.AddCommand(newLocationDeleteCommand())
+	cmd.AddCommand(newLocationApplyRetentionCommand())
+	cmd.AddCommand(newLocationPushBlockCommand())
+	cmd.AddCommand(newLocationPullBlockCommand())
+	cmd.AddCommand(newLocationCheckCommand())
 	cmd.PersistentFlags().StringP(pathFlagName, "s", "", "Specify a path suffix (optional)")
 	cmd.PersistentFlags().StringP(profileFlagName, "p", "", "Pass a Profile as a JSON string (required)")
 	return cmd
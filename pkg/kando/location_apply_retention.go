@@ -0,0 +1,87 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kando
+
+import (
+	"github.com/kopia/kopia/snapshot/policy"
+	"github.com/spf13/cobra"
+
+	"github.com/kanisterio/kanister/pkg/datamover"
+)
+
+const (
+	keepLastFlagName    = "keep-last"
+	keepHourlyFlagName  = "keep-hourly"
+	keepDailyFlagName   = "keep-daily"
+	keepWeeklyFlagName  = "keep-weekly"
+	keepMonthlyFlagName = "keep-monthly"
+	keepYearlyFlagName  = "keep-yearly"
+	tagFlagName         = "tag"
+
+	defaultRemovedSnapshotsOutputKey = "removedSnapshots"
+)
+
+func newLocationApplyRetentionCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply-retention",
+		Short: "Delete the kopia snapshots that are not kept by a retention policy",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := validateCommandArgs(c); err != nil {
+				return err
+			}
+			p, err := unmarshalProfileFlag(c)
+			if err != nil {
+				return err
+			}
+			tags, err := c.Flags().GetStringSlice(tagFlagName)
+			if err != nil {
+				return err
+			}
+			dataMover := datamover.NewProfileDataMover(p, c.Flag(outputNameFlagName).Value.String(), "")
+			return dataMover.ApplyRetentionPolicy(c.Context(), retentionPolicyFromFlags(c), tags)
+		},
+	}
+	cmd.Flags().Int(keepLastFlagName, 0, "Number of the latest snapshots to keep")
+	cmd.Flags().Int(keepHourlyFlagName, 0, "Number of the latest hourly snapshots to keep")
+	cmd.Flags().Int(keepDailyFlagName, 0, "Number of the latest daily snapshots to keep")
+	cmd.Flags().Int(keepWeeklyFlagName, 0, "Number of the latest weekly snapshots to keep")
+	cmd.Flags().Int(keepMonthlyFlagName, 0, "Number of the latest monthly snapshots to keep")
+	cmd.Flags().Int(keepYearlyFlagName, 0, "Number of the latest yearly snapshots to keep")
+	cmd.Flags().StringSlice(tagFlagName, nil, "Only consider the snapshots with all of these tags, formatted as key:value or key (optional)")
+	cmd.Flags().StringP(outputNameFlagName, "o", defaultRemovedSnapshotsOutputKey, "Specify a name to be used for the output with the IDs of the removed snapshots")
+	return cmd
+}
+
+// retentionPolicyFromFlags returns the kopia retention policy with the keep
+// flags that are set to a positive value.
+func retentionPolicyFromFlags(c *cobra.Command) *policy.RetentionPolicy {
+	keep := func(name string) *policy.OptionalInt {
+		n, err := c.Flags().GetInt(name)
+		if err != nil || n <= 0 {
+			return nil
+		}
+		v := policy.OptionalInt(n)
+		return &v
+	}
+	return &policy.RetentionPolicy{
+		KeepLatest:  keep(keepLastFlagName),
+		KeepHourly:  keep(keepHourlyFlagName),
+		KeepDaily:   keep(keepDailyFlagName),
+		KeepWeekly:  keep(keepWeeklyFlagName),
+		KeepMonthly: keep(keepMonthlyFlagName),
+		KeepAnnual:  keep(keepYearlyFlagName),
+	}
+}
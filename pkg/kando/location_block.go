@@ -0,0 +1,74 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kando
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/kanisterio/kanister/pkg/datamover"
+)
+
+const (
+	checksumFlagName   = "checksum"
+	skipZeroesFlagName = "skip-zeroes"
+)
+
+func newLocationPushBlockCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "push-block <device>",
+		Short: "Push a block device to a kopia repository server",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := validateCommandArgs(c); err != nil {
+				return err
+			}
+			p, err := unmarshalProfileFlag(c)
+			if err != nil {
+				return err
+			}
+			dataMover := datamover.NewProfileDataMover(p, c.Flag(outputNameFlagName).Value.String(), "")
+			return dataMover.PushBlock(c.Context(), args[0], pathFlag(c))
+		},
+	}
+	cmd.Flags().StringP(outputNameFlagName, "o", defaultKandoOutputKey, "Specify a name to be used for the output produced by kando. Set to `kandoOutput` by default")
+	return cmd
+}
+
+func newLocationPullBlockCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pull-block <device>",
+		Short: "Pull a block device from a kopia repository server and verify it",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := validateCommandArgs(c); err != nil {
+				return err
+			}
+			p, err := unmarshalProfileFlag(c)
+			if err != nil {
+				return err
+			}
+			skipZeroes, err := c.Flags().GetBool(skipZeroesFlagName)
+			if err != nil {
+				return err
+			}
+			dataMover := datamover.NewProfileDataMover(p, "", c.Flag(kopiaSnapshotFlagName).Value.String())
+			return dataMover.PullBlock(c.Context(), args[0], pathFlag(c), c.Flag(checksumFlagName).Value.String(), skipZeroes)
+		},
+	}
+	cmd.Flags().StringP(kopiaSnapshotFlagName, "k", "", "Pass the kopia snapshot information from the location push-block command (required)")
+	cmd.Flags().String(checksumFlagName, "", "Verify the data against the checksum from the location push-block command (optional)")
+	cmd.Flags().Bool(skipZeroesFlagName, false, "Don't write zeroed blocks, the device has to be zeroed (optional)")
+	return cmd
+}
@@ -0,0 +1,40 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kando
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/kanisterio/kanister/pkg/datamover"
+)
+
+func newLocationCheckCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check that a kopia repository server can be reached with the credentials of the profile",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := validateCommandArgs(c); err != nil {
+				return err
+			}
+			p, err := unmarshalProfileFlag(c)
+			if err != nil {
+				return err
+			}
+			return datamover.NewProfileDataMover(p, "", "").CheckRepository(c.Context())
+		},
+	}
+	return cmd
+}
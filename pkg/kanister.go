@@ -34,6 +34,8 @@ const (
 var (
 	funcMu sync.RWMutex
 	funcs  = make(map[string]map[semver.Version]Func)
+	// funcOpts holds the options the versions of the funcs were registered with
+	funcOpts = make(map[string]map[semver.Version]registerOptions)
 )
 
 // Func allows custom actions to be executed.
@@ -47,21 +49,8 @@ type Func interface {
 }
 
 // Register allows Funcs to be referenced by User Defined YAMLs
-func Register(f Func) error {
-	version := *semver.MustParse(DefaultVersion)
-	funcMu.Lock()
-	defer funcMu.Unlock()
-	if f == nil {
-		return errkit.New("kanister: Cannot register nil function")
-	}
-	if _, ok := funcs[f.Name()][version]; ok {
-		panic("kanister: Register called twice for function " + f.Name() + " with version " + DefaultVersion)
-	}
-	if _, ok := funcs[f.Name()]; !ok {
-		funcs[f.Name()] = make(map[semver.Version]Func)
-	}
-	funcs[f.Name()][version] = f
-	return nil
+func Register(f Func, opts ...RegisterOption) error {
+	return RegisterVersion(f, DefaultVersion, opts...)
 }
 
 func RegisteredFunctions() map[string]struct{} {
@@ -77,19 +66,59 @@ func KanisterFuncForName(funcName, version string) Func {
 }
 
 // RegisterVersion allows a Kanister Function to be registered with the given version
-func RegisterVersion(f Func, v string) error {
+func RegisterVersion(f Func, v string, opts ...RegisterOption) error {
 	version := *semver.MustParse(v)
-	funcMu.Lock()
-	defer funcMu.Unlock()
 	if f == nil {
 		return errkit.New("kanister: Cannot register nil function")
 	}
+	var o registerOptions
+	for _, opt := range opts {
+		if err := opt(&o); err != nil {
+			return errkit.Wrap(err, "kanister: Invalid register option", "function", f.Name(), "version", v)
+		}
+	}
+	funcMu.Lock()
+	defer funcMu.Unlock()
 	if _, ok := funcs[f.Name()][version]; ok {
 		panic("kanister: Register called twice for function " + f.Name() + " with version " + v)
 	}
 	if _, ok := funcs[f.Name()]; !ok {
 		funcs[f.Name()] = make(map[semver.Version]Func)
+		funcOpts[f.Name()] = make(map[semver.Version]registerOptions)
 	}
 	funcs[f.Name()][version] = f
+	funcOpts[f.Name()][version] = o
 	return nil
 }
+
+// RegisterOption sets optional properties of a registered version of a Func.
+type RegisterOption func(*registerOptions) error
+
+type registerOptions struct {
+	deprecation string
+	supports    *semver.Constraints
+}
+
+// WithDeprecation marks the registered version of the Func as deprecated.
+// The notice, e.g. the version to use instead, is reported whenever an
+// action uses this version.
+func WithDeprecation(notice string) RegisterOption {
+	return func(o *registerOptions) error {
+		o.deprecation = notice
+		return nil
+	}
+}
+
+// WithSupportedVersions declares the range of preferred versions, e.g.
+// `>=0.1.0 <1.0`, that are served by the registered version of the Func
+// when no version is registered with exactly the preferred version.
+func WithSupportedVersions(constraint string) RegisterOption {
+	return func(o *registerOptions) error {
+		c, err := parseVersionConstraint(constraint)
+		if err != nil {
+			return err
+		}
+		o.supports = c
+		return nil
+	}
+}
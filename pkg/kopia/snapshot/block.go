@@ -0,0 +1,155 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+
+	"github.com/kanisterio/errkit"
+
+	"github.com/kanisterio/kanister/pkg/kopia"
+	"github.com/kanisterio/kanister/pkg/kopia/repository"
+)
+
+// blockSize is the size of the blocks that are checked for zeroes when
+// zeroed regions are skipped during a restore
+const blockSize = 1 << 20
+
+// WriteBlock creates a kopia snapshot of a block device, stored as a kopia
+// streaming file at path, and returns the SHA-256 checksum of the data. Kopia
+// splits the data into content-defined chunks, so zeroed and unchanged
+// regions of the device are only stored once. That's the only way zeroed
+// regions are skipped during a backup, they are still read from the device,
+// since a block device doesn't tell which of its regions are allocated.
+func WriteBlock(ctx context.Context, device, path, password string) (*SnapshotInfo, string, error) {
+	f, err := os.Open(device)
+	if err != nil {
+		return nil, "", errkit.Wrap(err, "Failed to open block device", "device", device)
+	}
+	defer f.Close() //nolint:errcheck
+
+	h := sha256.New()
+	snapInfo, err := Write(ctx, io.NopCloser(io.TeeReader(f, h)), path, password)
+	if err != nil {
+		return nil, "", err
+	}
+	return snapInfo, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ReadBlock writes the kopia snapshot of a block device created with
+// WriteBlock back to the device and verifies it by reading the device again.
+// The data is verified against checksum if it's set. If skipZeroes is set,
+// zeroed blocks are not written, which requires the device to be zeroed.
+func ReadBlock(ctx context.Context, backupID, path, device, password, checksum string, skipZeroes bool) (string, error) {
+	rep, err := repository.Open(ctx, kopia.DefaultClientConfigFilePath, password, pullRepoPurpose)
+	if err != nil {
+		return "", errkit.Wrap(err, "Failed to open kopia repository")
+	}
+	defer rep.Close(ctx) //nolint:errcheck
+
+	oid, err := kopia.GetStreamingFileObjectIDFromSnapshot(ctx, rep, path, backupID)
+	if err != nil {
+		return "", err
+	}
+	r, err := rep.OpenObject(ctx, oid)
+	if err != nil {
+		return "", errkit.Wrap(err, "Failed to open kopia object", "oid", oid)
+	}
+	defer r.Close() //nolint:errcheck
+
+	f, err := os.OpenFile(device, os.O_WRONLY, 0)
+	if err != nil {
+		return "", errkit.Wrap(err, "Failed to open block device", "device", device)
+	}
+	written, snapChecksum, err := WriteDevice(f, r, skipZeroes)
+	if cErr := f.Close(); err == nil {
+		err = cErr
+	}
+	if err != nil {
+		return "", errkit.Wrap(err, "Failed to write snapshot data to the block device", "device", device)
+	}
+	if checksum != "" && checksum != snapChecksum {
+		return "", errkit.New("Checksum of the snapshot data doesn't match", "expected", checksum, "actual", snapChecksum)
+	}
+
+	deviceChecksum, err := ChecksumDevice(device, written)
+	if err != nil {
+		return "", err
+	}
+	if deviceChecksum != snapChecksum {
+		return "", errkit.New("Checksum of the restored block device doesn't match", "expected", snapChecksum, "actual", deviceChecksum)
+	}
+	return snapChecksum, nil
+}
+
+// WriteDevice copies src to the start of dst and returns the number of bytes
+// and the SHA-256 checksum of src. If skipZeroes is set, blocks that are all
+// zeroes are skipped instead of being written.
+func WriteDevice(dst io.WriteSeeker, src io.Reader, skipZeroes bool) (int64, string, error) {
+	h := sha256.New()
+	if !skipZeroes {
+		n, err := copy(dst, io.TeeReader(src, h))
+		return n, hex.EncodeToString(h.Sum(nil)), err
+	}
+
+	zeroes := make([]byte, blockSize)
+	buf := make([]byte, blockSize)
+	var n int64
+	for {
+		m, err := io.ReadFull(src, buf)
+		if m > 0 {
+			h.Write(buf[:m]) //nolint:errcheck
+			if bytes.Equal(buf[:m], zeroes[:m]) {
+				if _, sErr := dst.Seek(int64(m), io.SeekCurrent); sErr != nil {
+					return n, "", sErr
+				}
+			} else if _, wErr := dst.Write(buf[:m]); wErr != nil {
+				return n, "", wErr
+			}
+			n += int64(m)
+		}
+		switch {
+		case err == io.EOF || err == io.ErrUnexpectedEOF:
+			return n, hex.EncodeToString(h.Sum(nil)), nil
+		case err != nil:
+			return n, "", err
+		}
+	}
+}
+
+// ChecksumDevice returns the SHA-256 checksum of the first size bytes of the
+// device.
+func ChecksumDevice(device string, size int64) (string, error) {
+	f, err := os.Open(device)
+	if err != nil {
+		return "", errkit.Wrap(err, "Failed to open block device", "device", device)
+	}
+	defer f.Close() //nolint:errcheck
+
+	h := sha256.New()
+	n, err := copy(h, io.LimitReader(f, size))
+	if err != nil {
+		return "", errkit.Wrap(err, "Failed to read block device", "device", device)
+	}
+	if n != size {
+		return "", errkit.New("Block device is smaller than the snapshot", "device", device, "size", size, "read", n)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
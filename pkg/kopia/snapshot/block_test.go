@@ -0,0 +1,79 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/check.v1"
+)
+
+type BlockSuite struct{}
+
+var _ = check.Suite(&BlockSuite{})
+
+// testBlockData returns data with a zeroed block between two blocks of data
+// and a partial block at the end.
+func testBlockData() []byte {
+	data := bytes.Repeat([]byte{0xab}, blockSize)
+	data = append(data, make([]byte, blockSize)...)
+	data = append(data, bytes.Repeat([]byte{0xcd}, blockSize)...)
+	return append(data, []byte("tail")...)
+}
+
+func (s *BlockSuite) TestWriteDevice(c *check.C) {
+	data := testBlockData()
+	sum := sha256.Sum256(data)
+	for _, skipZeroes := range []bool{false, true} {
+		device := filepath.Join(c.MkDir(), "device")
+		// The device is prefilled with ones to check which blocks are written
+		c.Assert(os.WriteFile(device, bytes.Repeat([]byte{0xff}, len(data)+10), 0o600), check.IsNil)
+
+		f, err := os.OpenFile(device, os.O_WRONLY, 0)
+		c.Assert(err, check.IsNil)
+		n, checksum, err := WriteDevice(f, bytes.NewReader(data), skipZeroes)
+		c.Assert(err, check.IsNil)
+		c.Assert(f.Close(), check.IsNil)
+		c.Assert(n, check.Equals, int64(len(data)))
+		c.Assert(checksum, check.Equals, hex.EncodeToString(sum[:]))
+
+		written, err := os.ReadFile(device)
+		c.Assert(err, check.IsNil)
+		zeroed := written[blockSize : 2*blockSize]
+		if skipZeroes {
+			c.Assert(zeroed, check.DeepEquals, bytes.Repeat([]byte{0xff}, blockSize))
+			continue
+		}
+		c.Assert(written[:len(data)], check.DeepEquals, data)
+		c.Assert(written[len(data):], check.DeepEquals, bytes.Repeat([]byte{0xff}, 10))
+
+		deviceChecksum, err := ChecksumDevice(device, n)
+		c.Assert(err, check.IsNil)
+		c.Assert(deviceChecksum, check.Equals, checksum)
+	}
+}
+
+func (s *BlockSuite) TestChecksumDevice(c *check.C) {
+	device := filepath.Join(c.MkDir(), "device")
+	c.Assert(os.WriteFile(device, []byte("data"), 0o600), check.IsNil)
+	_, err := ChecksumDevice(device, 10)
+	c.Assert(err, check.ErrorMatches, "Block device is smaller than the snapshot.*")
+	_, err = ChecksumDevice(filepath.Join(c.MkDir(), "missing"), 10)
+	c.Assert(err, check.ErrorMatches, "Failed to open block device.*")
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/kopia/kopia/snapshot/upload"
+)
+
+// UploadProgressPrefix prefixes the lines with the percentage of the data
+// that was uploaded, which are printed while a directory is snapshotted.
+const UploadProgressPrefix = "###Upload-progress###:"
+
+// ParseUploadProgress returns the percentage printed in line, if line reports
+// the upload progress.
+func ParseUploadProgress(line string) (int, bool) {
+	s, ok := strings.CutPrefix(strings.TrimSpace(line), UploadProgressPrefix)
+	if !ok {
+		return 0, false
+	}
+	percent, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return 0, false
+	}
+	return percent, true
+}
+
+var _ upload.Progress = (*uploadProgress)(nil)
+
+// uploadProgress prints the percentage of the estimated data size that was
+// processed by the kopia uploader, each time it changes. The percentage stays
+// below 100 until the snapshot is saved.
+type uploadProgress struct {
+	upload.NullUploadProgress
+
+	w          io.Writer
+	mu         sync.Mutex
+	total      atomic.Int64
+	processed  atomic.Int64
+	lastReport int
+}
+
+func newUploadProgress(w io.Writer) *uploadProgress {
+	return &uploadProgress{w: w, lastReport: -1}
+}
+
+// Enabled enables the estimation of the data size.
+func (p *uploadProgress) Enabled() bool {
+	return true
+}
+
+func (p *uploadProgress) EstimatedDataSize(fileCount, totalBytes int64) {
+	p.total.Store(totalBytes)
+	p.report()
+}
+
+func (p *uploadProgress) HashedBytes(numBytes int64) {
+	p.processed.Add(numBytes)
+	p.report()
+}
+
+func (p *uploadProgress) CachedFile(fname string, numBytes int64) {
+	p.processed.Add(numBytes)
+	p.report()
+}
+
+func (p *uploadProgress) percent() int {
+	total := p.total.Load()
+	if total <= 0 {
+		return 0
+	}
+	return int(min(p.processed.Load()*100/total, 99))
+}
+
+func (p *uploadProgress) report() {
+	percent := p.percent()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if percent <= p.lastReport {
+		return
+	}
+	p.lastReport = percent
+	fmt.Fprintln(p.w, UploadProgressPrefix, percent)
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"bytes"
+	"strings"
+
+	"gopkg.in/check.v1"
+)
+
+type ProgressSuite struct{}
+
+var _ = check.Suite(&ProgressSuite{})
+
+func (s *ProgressSuite) TestUploadProgress(c *check.C) {
+	var buf bytes.Buffer
+	p := newUploadProgress(&buf)
+	p.HashedBytes(10)
+	p.EstimatedDataSize(2, 200)
+	p.HashedBytes(50)
+	p.HashedBytes(1)
+	p.CachedFile("file", 99)
+	p.CachedFile("other", 100)
+
+	var percents []int
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		percent, ok := ParseUploadProgress(line)
+		c.Assert(ok, check.Equals, true)
+		percents = append(percents, percent)
+	}
+	// The estimated size can be smaller than the processed data, the
+	// percentage stays below 100 until the snapshot is saved.
+	c.Assert(percents, check.DeepEquals, []int{0, 5, 30, 80, 99})
+}
+
+func (s *ProgressSuite) TestParseUploadProgress(c *check.C) {
+	for _, tc := range []struct {
+		line    string
+		percent int
+		ok      bool
+	}{
+		{line: UploadProgressPrefix + " 42", percent: 42, ok: true},
+		{line: "  " + UploadProgressPrefix + "7\n", percent: 7, ok: true},
+		{line: UploadProgressPrefix + " x"},
+		{line: "Snapshotting data ..."},
+	} {
+		percent, ok := ParseUploadProgress(tc.line)
+		c.Check(ok, check.Equals, tc.ok, check.Commentf("%q", tc.line))
+		c.Check(percent, check.Equals, tc.percent, check.Commentf("%q", tc.line))
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kanisterio/errkit"
+	"github.com/kopia/kopia/snapshot"
+	"github.com/kopia/kopia/snapshot/policy"
+
+	"github.com/kanisterio/kanister/pkg/kopia"
+	"github.com/kanisterio/kanister/pkg/kopia/repository"
+)
+
+const retentionRepoPurpose = "kando location apply-retention"
+
+// ApplyRetentionPolicy deletes the kopia snapshots of every source that are
+// not kept by the retention policy and returns their IDs. Only the snapshots
+// with all of the given tags are considered, where a tag is `key:value` or
+// just `key`.
+func ApplyRetentionPolicy(ctx context.Context, password string, rp *policy.RetentionPolicy, tags []string) ([]string, error) {
+	rep, err := repository.Open(ctx, kopia.DefaultClientConfigFilePath, password, retentionRepoPurpose)
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to open kopia repository")
+	}
+	defer rep.Close(ctx) //nolint:errcheck
+
+	sources, err := snapshot.ListSources(ctx, rep)
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to list kopia snapshot sources")
+	}
+	removed := []string{}
+	for _, si := range sources {
+		man, err := snapshot.ListSnapshots(ctx, rep, si)
+		if err != nil {
+			return removed, errkit.Wrap(err, "Failed to list kopia snapshots", "source", si.String())
+		}
+		for _, m := range ExpiredSnapshots(rp, FilterSnapshotsByTags(man, tags)) {
+			if err := rep.DeleteManifest(ctx, m.ID); err != nil {
+				return removed, errkit.Wrap(err, "Failed to delete kopia snapshot", "snapshotID", m.ID)
+			}
+			removed = append(removed, string(m.ID))
+		}
+	}
+	return removed, rep.Flush(ctx)
+}
+
+// ExpiredSnapshots returns the snapshots that aren't kept by the retention
+// policy. Pinned snapshots are always kept.
+func ExpiredSnapshots(rp *policy.RetentionPolicy, man []*snapshot.Manifest) []*snapshot.Manifest {
+	rp.ComputeRetentionReasons(man)
+	var expired []*snapshot.Manifest
+	for _, m := range man {
+		if len(m.RetentionReasons) == 0 && len(m.Pins) == 0 {
+			expired = append(expired, m)
+		}
+	}
+	return expired
+}
+
+// FilterSnapshotsByTags returns the snapshots with all of the given tags.
+func FilterSnapshotsByTags(man []*snapshot.Manifest, tags []string) []*snapshot.Manifest {
+	if len(tags) == 0 {
+		return man
+	}
+	var filtered []*snapshot.Manifest
+	for _, m := range man {
+		if hasTags(m, tags) {
+			filtered = append(filtered, m)
+		}
+	}
+	return filtered
+}
+
+func hasTags(m *snapshot.Manifest, tags []string) bool {
+	for _, t := range tags {
+		key, value, hasValue := strings.Cut(t, ":")
+		v, ok := m.Tags["tag:"+key]
+		if !ok || (hasValue && v != value) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kopia/kopia/fs"
+	"github.com/kopia/kopia/repo/manifest"
+	"github.com/kopia/kopia/snapshot"
+	"github.com/kopia/kopia/snapshot/policy"
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type RetentionSuite struct{}
+
+var _ = check.Suite(&RetentionSuite{})
+
+func testManifests(now time.Time) []*snapshot.Manifest {
+	var man []*snapshot.Manifest
+	for i, id := range []string{"s0", "s1", "s2", "s3", "s4"} {
+		m := &snapshot.Manifest{
+			ID:        manifest.ID(id),
+			StartTime: fs.UTCTimestampFromTime(now.Add(-time.Duration(i) * 24 * time.Hour)),
+			Tags:      map[string]string{"tag:app": "db"},
+		}
+		if i%2 == 1 {
+			m.Tags["tag:daily"] = ""
+		}
+		man = append(man, m)
+	}
+	return man
+}
+
+func manifestIDs(man []*snapshot.Manifest) []string {
+	ids := []string{}
+	for _, m := range man {
+		ids = append(ids, string(m.ID))
+	}
+	return ids
+}
+
+func (s *RetentionSuite) TestExpiredSnapshots(c *check.C) {
+	now := time.Now()
+	keepLatest := policy.OptionalInt(2)
+	rp := &policy.RetentionPolicy{KeepLatest: &keepLatest}
+	c.Assert(manifestIDs(ExpiredSnapshots(rp, testManifests(now))), check.DeepEquals, []string{"s2", "s3", "s4"})
+
+	// Pinned snapshots are kept
+	man := testManifests(now)
+	man[3].Pins = []string{"keep"}
+	c.Assert(manifestIDs(ExpiredSnapshots(rp, man)), check.DeepEquals, []string{"s2", "s4"})
+
+	// A policy that keeps nothing keeps everything
+	c.Assert(ExpiredSnapshots(&policy.RetentionPolicy{}, testManifests(now)), check.HasLen, 0)
+}
+
+func (s *RetentionSuite) TestFilterSnapshotsByTags(c *check.C) {
+	man := testManifests(time.Now())
+	c.Assert(manifestIDs(FilterSnapshotsByTags(man, nil)), check.HasLen, 5)
+	c.Assert(manifestIDs(FilterSnapshotsByTags(man, []string{"daily"})), check.DeepEquals, []string{"s1", "s3"})
+	c.Assert(manifestIDs(FilterSnapshotsByTags(man, []string{"app:db", "daily"})), check.DeepEquals, []string{"s1", "s3"})
+	c.Assert(manifestIDs(FilterSnapshotsByTags(man, []string{"app:web"})), check.DeepEquals, []string{})
+}
@@ -124,6 +124,21 @@ func Delete(ctx context.Context, backupID, path, password string) error {
 	return rep.Flush(ctx)
 }
 
+// CheckRepository checks that the kopia repository can be opened and its
+// snapshot sources can be listed
+func CheckRepository(ctx context.Context, password string) error {
+	rep, err := repository.Open(ctx, kopia.DefaultClientConfigFilePath, password, checkRepoPurpose)
+	if err != nil {
+		return errkit.Wrap(err, "Failed to open kopia repository")
+	}
+	defer rep.Close(ctx) //nolint:errcheck
+
+	if _, err := snapshot.ListSources(ctx, rep); err != nil {
+		return errkit.Wrap(err, "Failed to list kopia snapshot sources")
+	}
+	return nil
+}
+
 // findPreviousSnapshotManifest returns the list of previous snapshots for a given source,
 // including last complete snapshot
 func findPreviousSnapshotManifest(ctx context.Context, rep repo.Repository, sourceInfo snapshot.SourceInfo, noLaterThan *fs.UTCTimestamp) ([]*snapshot.Manifest, error) {
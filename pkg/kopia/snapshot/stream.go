@@ -44,6 +44,8 @@ const (
 
 	pushRepoPurpose = "kando location push"
 	pullRepoPurpose = "kando location pull"
+
+	checkRepoPurpose = "kando location check"
 )
 
 // SnapshotInfo tracks kopia snapshot information produced by a kando command in a phase
@@ -54,6 +56,8 @@ type SnapshotInfo struct {
 	LogicalSize int64 `json:"logicalSize"`
 	// PhysicalSize is the uploaded size in bytes
 	PhysicalSize int64 `json:"physicalSize"`
+	// Directory is set if the snapshot is of a directory instead of a streaming file
+	Directory bool `json:"directory,omitempty"`
 }
 
 // Validate validates SnapshotInfo field values
@@ -138,8 +142,9 @@ func WriteFile(ctx context.Context, path, sourcePath, password string) (*Snapsho
 		return nil, errkit.Wrap(err, "Unable to get local filesystem entry")
 	}
 
-	// Setup kopia uploader
+	// Setup kopia uploader, which reports the upload progress on stdout
 	u := upload.NewUploader(rep)
+	u.Progress = newUploadProgress(os.Stdout)
 
 	// Create a kopia snapshot
 	snapID, snapshotSize, err := SnapshotSource(ctx, rep, u, sourceInfo, rootDir, "Kanister Database Backup")
@@ -147,10 +152,12 @@ func WriteFile(ctx context.Context, path, sourcePath, password string) (*Snapsho
 		return nil, err
 	}
 
+	_, isDir := rootDir.(fs.Directory)
 	snapshotInfo := &SnapshotInfo{
 		ID:           snapID,
 		LogicalSize:  snapshotSize,
 		PhysicalSize: int64(0),
+		Directory:    isDir,
 	}
 	return snapshotInfo, nil
 }
@@ -97,6 +97,10 @@ func GetStreamingFileObjectIDFromSnapshot(ctx context.Context, rep repo.Reposito
 	if err != nil {
 		return object.ID{}, errkit.Wrap(err, "Failed to get nested entry from kopia snapshot", "pathBase", filepath.Base(path))
 	}
+	// Snapshots of a directory can have a directory with the same name
+	if e.IsDir() {
+		return object.ID{}, errkit.New("Kopia snapshot entry is a directory, not a streaming file", "backupId", backupID, "pathBase", filepath.Base(path))
+	}
 
 	return e.(object.HasObjectID).ObjectID(), nil
 }
@@ -18,6 +18,7 @@ import (
 	"context"
 	"io"
 
+	"github.com/kanisterio/errkit"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -44,8 +45,23 @@ const (
 	DeleteOperation Operation = "delete"
 	// PatchOperation represents kubectl patch operation
 	PatchOperation Operation = "patch"
+	// ApplyOperation represents kubectl server-side apply operation
+	ApplyOperation Operation = "apply"
+	// GetOperation represents kubectl get operation
+	GetOperation Operation = "get"
+
+	// DefaultFieldManager is the field manager of server-side apply
+	DefaultFieldManager = "kanister"
 )
 
+// ApplyOptions configure a server-side apply
+type ApplyOptions struct {
+	// FieldManager owns the applied fields. Defaults to DefaultFieldManager.
+	FieldManager string
+	// Force takes over the fields that are owned by other field managers.
+	Force bool
+}
+
 // KubectlOperation implements methods to perform kubectl operations
 type KubectlOperation struct {
 	dynCli  dynamic.Interface
@@ -62,7 +78,48 @@ func NewKubectlOperations(dynCli dynamic.Interface) *KubectlOperation {
 
 // Create k8s resource from spec manifest
 func (k *KubectlOperation) Create(spec io.Reader, namespace string) (*crv1alpha1.ObjectReference, error) {
+	objRefs, err := k.CreateAll(spec, namespace)
+	if len(objRefs) == 0 {
+		return nil, err
+	}
+	return &objRefs[len(objRefs)-1], err
+}
+
+// CreateAll creates k8s resources from spec manifest. The spec can contain
+// multiple YAML documents. It returns references to the created resources.
+func (k *KubectlOperation) CreateAll(spec io.Reader, namespace string) ([]crv1alpha1.ObjectReference, error) {
 	// TODO: Create namespace if doesn't exist before creating an resource
+	return k.visit(spec, namespace, func(info *resource.Info, namespace string) (runtime.Object, error) {
+		return resource.
+			NewHelper(info.Client, info.Mapping).
+			WithFieldManager("kanister-create").
+			Create(namespace, true, info.Object)
+	})
+}
+
+// Apply k8s resources from spec manifest with server-side apply, creating
+// the resources that don't exist. The spec can contain multiple YAML
+// documents. It returns references to the applied resources.
+func (k *KubectlOperation) Apply(spec io.Reader, namespace string, opts ApplyOptions) ([]crv1alpha1.ObjectReference, error) {
+	fieldManager := opts.FieldManager
+	if fieldManager == "" {
+		fieldManager = DefaultFieldManager
+	}
+	return k.visit(spec, namespace, func(info *resource.Info, namespace string) (runtime.Object, error) {
+		data, err := runtime.Encode(unstructured.UnstructuredJSONScheme, info.Object)
+		if err != nil {
+			return nil, err
+		}
+		return resource.
+			NewHelper(info.Client, info.Mapping).
+			WithFieldManager(fieldManager).
+			Patch(namespace, info.Name, types.ApplyPatchType, data, &metav1.PatchOptions{Force: &opts.Force})
+	})
+}
+
+// visit calls fn for each resource in spec and returns references to the
+// resources returned by fn.
+func (k *KubectlOperation) visit(spec io.Reader, namespace string, fn func(info *resource.Info, namespace string) (runtime.Object, error)) ([]crv1alpha1.ObjectReference, error) {
 	result := k.factory.NewBuilder().
 		Unstructured().
 		NamespaceParam(namespace).
@@ -73,19 +130,17 @@ func (k *KubectlOperation) Create(spec io.Reader, namespace string) (*crv1alpha1
 	if err != nil {
 		return nil, err
 	}
-	var objRef *crv1alpha1.ObjectReference
+	var objRefs []crv1alpha1.ObjectReference
 	err = result.Visit(func(info *resource.Info, err error) error {
 		if err != nil {
 			return err
 		}
+		ns := namespace
 		// Override namespace if the namespace is set in resource spec
 		if info.Namespace != "" {
-			namespace = info.Namespace
+			ns = info.Namespace
 		}
-		obj, err := resource.
-			NewHelper(info.Client, info.Mapping).
-			WithFieldManager("kanister-create").
-			Create(namespace, true, info.Object)
+		obj, err := fn(info, ns)
 		if err != nil {
 			return err
 		}
@@ -95,16 +150,50 @@ func (k *KubectlOperation) Create(spec io.Reader, namespace string) (*crv1alpha1
 			return err
 		}
 		us := unstructured.Unstructured{Object: unstructObj}
-		objRef = &crv1alpha1.ObjectReference{
+		objRefs = append(objRefs, crv1alpha1.ObjectReference{
 			APIVersion: info.Mapping.Resource.Version,
 			Group:      info.Mapping.Resource.Group,
 			Resource:   info.Mapping.Resource.Resource,
 			Name:       us.GetName(),
 			Namespace:  us.GetNamespace(),
+		})
+		return nil
+	})
+	return objRefs, err
+}
+
+// Get k8s resource referred by objectReference
+func (k *KubectlOperation) Get(ctx context.Context, objRef crv1alpha1.ObjectReference, namespace string) (*unstructured.Unstructured, error) {
+	if objRef.Namespace != "" {
+		namespace = objRef.Namespace
+	}
+	return k.dynCli.Resource(schema.GroupVersionResource{Group: objRef.Group, Version: objRef.APIVersion, Resource: objRef.Resource}).Namespace(namespace).Get(ctx, objRef.Name, metav1.GetOptions{})
+}
+
+// Wait waits till the k8s resource referred by objectReference meets the
+// condition
+func (k *KubectlOperation) Wait(ctx context.Context, objRef crv1alpha1.ObjectReference, cond WaitCondition) error {
+	var condErr error
+	err := poll.Wait(ctx, func(ctx context.Context) (bool, error) {
+		obj, err := k.Get(ctx, objRef, "")
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				condErr = err
+				return false, nil
+			}
+			return false, err
 		}
-		return err
+		met, err := cond.Met(obj)
+		condErr = err
+		return met, nil
 	})
-	return objRef, err
+	if err != nil {
+		if condErr != nil {
+			err = errkit.Append(err, condErr)
+		}
+		return errkit.Wrap(err, "Failed to wait for the condition", "condition", cond.String(), "name", objRef.Name, "namespace", objRef.Namespace)
+	}
+	return nil
 }
 
 // Delete k8s resource referred by objectReference. Waits for the resource to be deleted
@@ -0,0 +1,351 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/kanisterio/errkit"
+	v1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	pkglabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kanisterio/kanister/pkg/utils/volumesnapshot"
+)
+
+const (
+	GroupSnapshotGroupName = "groupsnapshot.storage.k8s.io"
+	GroupSnapshotVersion   = "v1beta1"
+
+	// VolumeGroupSnapshotResourcePlural is "volumegroupsnapshots"
+	VolumeGroupSnapshotResourcePlural = "volumegroupsnapshots"
+	// VolumeGroupSnapshotContentResourcePlural is "volumegroupsnapshotcontents"
+	VolumeGroupSnapshotContentResourcePlural = "volumegroupsnapshotcontents"
+
+	// Group snapshot resource Kinds
+	VolGroupSnapKind        = "VolumeGroupSnapshot"
+	VolGroupSnapContentKind = "VolumeGroupSnapshotContent"
+)
+
+var (
+	// VolGroupSnapGVR specifies GVR schema for VolumeGroupSnapshots
+	VolGroupSnapGVR = schema.GroupVersionResource{Group: GroupSnapshotGroupName, Version: GroupSnapshotVersion, Resource: VolumeGroupSnapshotResourcePlural}
+	// VolGroupSnapContentGVR specifies GVR schema for VolumeGroupSnapshotContents
+	VolGroupSnapContentGVR = schema.GroupVersionResource{Group: GroupSnapshotGroupName, Version: GroupSnapshotVersion, Resource: VolumeGroupSnapshotContentResourcePlural}
+)
+
+// VolumeGroupSnapshot is a snapshot of a group of PVCs taken at the same
+// point in time. The external-snapshotter client used by Kanister doesn't
+// have the groupsnapshot.storage.k8s.io types, so only the fields used by
+// Kanister are defined here.
+type VolumeGroupSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VolumeGroupSnapshotSpec    `json:"spec"`
+	Status *VolumeGroupSnapshotStatus `json:"status,omitempty"`
+}
+
+// VolumeGroupSnapshotSpec is the spec of a VolumeGroupSnapshot.
+type VolumeGroupSnapshotSpec struct {
+	Source                       VolumeGroupSnapshotSource `json:"source"`
+	VolumeGroupSnapshotClassName *string                   `json:"volumeGroupSnapshotClassName,omitempty"`
+}
+
+// VolumeGroupSnapshotSource selects the PVCs of a VolumeGroupSnapshot.
+type VolumeGroupSnapshotSource struct {
+	Selector                       *metav1.LabelSelector `json:"selector,omitempty"`
+	VolumeGroupSnapshotContentName *string               `json:"volumeGroupSnapshotContentName,omitempty"`
+}
+
+// VolumeGroupSnapshotStatus is the status of a VolumeGroupSnapshot.
+type VolumeGroupSnapshotStatus struct {
+	BoundVolumeGroupSnapshotContentName *string                 `json:"boundVolumeGroupSnapshotContentName,omitempty"`
+	CreationTime                        *metav1.Time            `json:"creationTime,omitempty"`
+	ReadyToUse                          *bool                   `json:"readyToUse,omitempty"`
+	Error                               *v1.VolumeSnapshotError `json:"error,omitempty"`
+}
+
+// VolumeGroupSnapshotContent is the cluster scoped content of a
+// VolumeGroupSnapshot.
+type VolumeGroupSnapshotContent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status *VolumeGroupSnapshotContentStatus `json:"status,omitempty"`
+}
+
+// VolumeGroupSnapshotContentStatus is the status of a
+// VolumeGroupSnapshotContent.
+type VolumeGroupSnapshotContentStatus struct {
+	VolumeSnapshotHandlePairList []VolumeSnapshotHandlePair `json:"volumeSnapshotHandlePairList,omitempty"`
+}
+
+// VolumeSnapshotHandlePair maps the CSI handle of a volume to the handle of
+// its snapshot in the group.
+type VolumeSnapshotHandlePair struct {
+	VolumeHandle   string `json:"volumeHandle"`
+	SnapshotHandle string `json:"snapshotHandle"`
+}
+
+// GroupMember is the VolumeSnapshot created for one PVC of a
+// VolumeGroupSnapshot.
+type GroupMember struct {
+	// PVC is the name of the PVC that was snapshotted.
+	PVC string
+	// VolumeSnapshot is the name of the VolumeSnapshot of the PVC.
+	VolumeSnapshot string
+	// RestoreSize is the minimum size of a volume restored from the snapshot.
+	RestoreSize *resource.Quantity
+}
+
+// CreateGroup creates a VolumeGroupSnapshot of the PVCs that match selector.
+func (sna *Snapshot) CreateGroup(ctx context.Context, selector map[string]string, groupSnapshotClass *string, waitForReady bool, snapshotMeta ObjectMeta) error {
+	if len(selector) == 0 {
+		return errkit.New("Label selector of the group snapshot must not be empty")
+	}
+	pvcs, err := sna.kubeCli.CoreV1().PersistentVolumeClaims(snapshotMeta.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: pkglabels.Set(selector).String(),
+	})
+	if err != nil {
+		return errkit.Wrap(err, "Failed to query PVCs", "namespace", snapshotMeta.Namespace, "selector", selector)
+	}
+	if len(pvcs.Items) == 0 {
+		return errkit.New("Failed to find PVCs matching the selector", "namespace", snapshotMeta.Namespace, "selector", selector)
+	}
+	snapshotMeta.Labels = volumesnapshot.SanitizeTags(snapshotMeta.Labels)
+	snap := UnstructuredVolumeGroupSnapshot(VolGroupSnapGVR, selector, groupSnapshotClass, snapshotMeta)
+	if _, err := sna.dynCli.Resource(VolGroupSnapGVR).Namespace(snapshotMeta.Namespace).Create(ctx, snap, metav1.CreateOptions{}); err != nil {
+		return errkit.Wrap(err, "Failed to create group snapshot resource", "name", snapshotMeta.Name, "namespace", snapshotMeta.Namespace)
+	}
+	if !waitForReady {
+		return nil
+	}
+	return sna.WaitOnGroupReadyToUse(ctx, snapshotMeta.Name, snapshotMeta.Namespace)
+}
+
+// GetGroup will return the VolumeGroupSnapshot in the 'namespace' with given 'name'.
+func (sna *Snapshot) GetGroup(ctx context.Context, name, namespace string) (*VolumeGroupSnapshot, error) {
+	us, err := sna.dynCli.Resource(VolGroupSnapGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	vgs := &VolumeGroupSnapshot{}
+	if err := TransformUnstructured(us, vgs); err != nil {
+		return nil, err
+	}
+	return vgs, nil
+}
+
+// WaitOnGroupReadyToUse will block until the VolumeGroupSnapshot in 'namespace' with name 'snapshotName'
+// has status 'ReadyToUse' or 'ctx.Done()' is signalled.
+func (sna *Snapshot) WaitOnGroupReadyToUse(ctx context.Context, snapshotName, namespace string) error {
+	return waitOnReadyToUse(ctx, sna.dynCli, VolGroupSnapGVR, snapshotName, namespace, isGroupReadyToUse)
+}
+
+// DeleteGroup will delete the VolumeGroupSnapshot together with the VolumeSnapshots of its members
+// and returns any error as a result.
+func (sna *Snapshot) DeleteGroup(ctx context.Context, name, namespace string) (*VolumeGroupSnapshot, error) {
+	snap, err := sna.GetGroup(ctx, name, namespace)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to find VolumeGroupSnapshot", "namespace", namespace, "name", name)
+	}
+	// The members are looked up before the group snapshot is deleted, since
+	// they are found through it.
+	usList, err := sna.dynCli.Resource(VolSnapGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to list VolumeSnapshots", "namespace", namespace)
+	}
+	if err := sna.dynCli.Resource(VolGroupSnapGVR).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return nil, errkit.Wrap(err, "Failed to delete VolumeGroupSnapshot", "namespace", namespace, "name", name)
+	}
+	for i := range usList.Items {
+		if !isGroupMember(&usList.Items[i], snap) {
+			continue
+		}
+		// The snapshot controller may have deleted the member already
+		if _, err := sna.Delete(ctx, usList.Items[i].GetName(), namespace); err != nil {
+			return nil, errkit.Wrap(err, "Failed to delete the VolumeSnapshot of the group snapshot", "volumeGroupSnapshot", name, "volumeSnapshot", usList.Items[i].GetName())
+		}
+	}
+	// If the group snapshot does not exist, that's an acceptable error and we ignore it
+	return snap, nil
+}
+
+// ListGroupMembers returns the VolumeSnapshots of the PVCs of a ready
+// VolumeGroupSnapshot, sorted by PVC name.
+func (sna *Snapshot) ListGroupMembers(ctx context.Context, name, namespace string) ([]GroupMember, error) {
+	vgs, err := sna.GetGroup(ctx, name, namespace)
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to get group snapshot", "volumeGroupSnapshot", name)
+	}
+	if vgs.Status == nil || vgs.Status.ReadyToUse == nil || !*vgs.Status.ReadyToUse {
+		return nil, errkit.New("Group snapshot is not ready", "volumeGroupSnapshot", name, "namespace", namespace)
+	}
+
+	usList, err := sna.dynCli.Resource(VolSnapGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to list VolumeSnapshots", "namespace", namespace)
+	}
+	var snaps []*v1.VolumeSnapshot
+	for i := range usList.Items {
+		if !isGroupMember(&usList.Items[i], vgs) {
+			continue
+		}
+		vs := &v1.VolumeSnapshot{}
+		if err := TransformUnstructured(&usList.Items[i], vs); err != nil {
+			return nil, err
+		}
+		snaps = append(snaps, vs)
+	}
+	if len(snaps) == 0 {
+		return nil, errkit.New("Failed to find the VolumeSnapshots of the group snapshot", "volumeGroupSnapshot", name, "namespace", namespace)
+	}
+
+	pvcBySnapshotHandle, err := sna.groupPVCsBySnapshotHandle(ctx, vgs)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]GroupMember, 0, len(snaps))
+	for _, vs := range snaps {
+		m := GroupMember{VolumeSnapshot: vs.Name}
+		if vs.Status != nil {
+			m.RestoreSize = vs.Status.RestoreSize
+		}
+		if vs.Spec.Source.PersistentVolumeClaimName != nil {
+			m.PVC = *vs.Spec.Source.PersistentVolumeClaimName
+		} else if vs.Status != nil && vs.Status.BoundVolumeSnapshotContentName != nil {
+			content, err := getSnapshotContent(ctx, sna.dynCli, VolSnapContentGVR, *vs.Status.BoundVolumeSnapshotContentName)
+			if err != nil {
+				return nil, errkit.Wrap(err, "Failed to get snapshot content", "volumeSnapshot", vs.Name, "volumeSnapshotContent", *vs.Status.BoundVolumeSnapshotContentName)
+			}
+			if content.Status != nil && content.Status.SnapshotHandle != nil {
+				m.PVC = pvcBySnapshotHandle[*content.Status.SnapshotHandle]
+			}
+		}
+		if m.PVC == "" {
+			return nil, errkit.New("Failed to find the PVC of the group snapshot member", "volumeGroupSnapshot", name, "volumeSnapshot", vs.Name)
+		}
+		members = append(members, m)
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].PVC < members[j].PVC })
+	return members, nil
+}
+
+// groupPVCsBySnapshotHandle maps the snapshot handles of the group snapshot
+// content to the names of the snapshotted PVCs, using the CSI volume handles
+// of their PVs.
+func (sna *Snapshot) groupPVCsBySnapshotHandle(ctx context.Context, vgs *VolumeGroupSnapshot) (map[string]string, error) {
+	if vgs.Status.BoundVolumeGroupSnapshotContentName == nil {
+		return nil, errkit.New("Group snapshot does not have content", "volumeGroupSnapshot", vgs.Name, "namespace", vgs.Namespace)
+	}
+	contentName := *vgs.Status.BoundVolumeGroupSnapshotContentName
+	us, err := sna.dynCli.Resource(VolGroupSnapContentGVR).Get(ctx, contentName, metav1.GetOptions{})
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to get group snapshot content", "volumeGroupSnapshotContent", contentName)
+	}
+	content := &VolumeGroupSnapshotContent{}
+	if err := TransformUnstructured(us, content); err != nil {
+		return nil, err
+	}
+	if content.Status == nil || len(content.Status.VolumeSnapshotHandlePairList) == 0 {
+		return map[string]string{}, nil
+	}
+
+	pvs, err := sna.kubeCli.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to list PVs")
+	}
+	pvcByVolumeHandle := map[string]string{}
+	for _, pv := range pvs.Items {
+		if pv.Spec.CSI == nil || pv.Spec.ClaimRef == nil || pv.Spec.ClaimRef.Namespace != vgs.Namespace {
+			continue
+		}
+		pvcByVolumeHandle[pv.Spec.CSI.VolumeHandle] = pv.Spec.ClaimRef.Name
+	}
+	pvcBySnapshotHandle := map[string]string{}
+	for _, p := range content.Status.VolumeSnapshotHandlePairList {
+		pvcBySnapshotHandle[p.SnapshotHandle] = pvcByVolumeHandle[p.VolumeHandle]
+	}
+	return pvcBySnapshotHandle, nil
+}
+
+// isGroupMember checks if the VolumeSnapshot was created for the group
+// snapshot, either by its owner reference or its status.
+func isGroupMember(us *unstructured.Unstructured, vgs *VolumeGroupSnapshot) bool {
+	for _, ref := range us.GetOwnerReferences() {
+		if ref.Kind == VolGroupSnapKind && ref.Name == vgs.Name {
+			return true
+		}
+	}
+	groupName, _, _ := unstructured.NestedString(us.Object, "status", "volumeGroupSnapshotName")
+	return groupName == vgs.Name
+}
+
+func isGroupReadyToUse(us *unstructured.Unstructured) (bool, error) {
+	vgs := VolumeGroupSnapshot{}
+	if err := TransformUnstructured(us, &vgs); err != nil {
+		return false, err
+	}
+	if vgs.Status == nil {
+		return false, nil
+	}
+	// Error can be set while waiting for creation
+	if vgs.Status.Error != nil && vgs.Status.Error.Message != nil {
+		return false, errkit.New(*vgs.Status.Error.Message)
+	}
+	return (vgs.Status.ReadyToUse != nil && *vgs.Status.ReadyToUse && vgs.Status.CreationTime != nil), nil
+}
+
+// UnstructuredVolumeGroupSnapshot returns Unstructured object for the VolumeGroupSnapshot resource.
+// The class is omitted if groupSnapClassName is nil, so the default class is used.
+func UnstructuredVolumeGroupSnapshot(gvr schema.GroupVersionResource, selector map[string]string, groupSnapClassName *string, snapshotMeta ObjectMeta) *unstructured.Unstructured {
+	snap := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": fmt.Sprintf("%s/%s", gvr.Group, gvr.Version),
+			"kind":       VolGroupSnapKind,
+			"metadata": map[string]interface{}{
+				"name":      snapshotMeta.Name,
+				"namespace": snapshotMeta.Namespace,
+			},
+			"spec": map[string]interface{}{
+				"source": map[string]interface{}{
+					"selector": map[string]interface{}{
+						"matchLabels": Mss2msi(selector),
+					},
+				},
+			},
+		},
+	}
+	if groupSnapClassName != nil {
+		snap.Object["spec"].(map[string]interface{})["volumeGroupSnapshotClassName"] = *groupSnapClassName
+	}
+	if snapshotMeta.Labels != nil {
+		snap.SetLabels(snapshotMeta.Labels)
+	}
+	if snapshotMeta.Annotations != nil {
+		snap.SetAnnotations(snapshotMeta.Annotations)
+	}
+	return snap
+}
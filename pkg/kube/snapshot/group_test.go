@@ -0,0 +1,238 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot_test
+
+import (
+	"context"
+	"time"
+
+	"gopkg.in/check.v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kanisterio/kanister/pkg/kube/snapshot"
+)
+
+type GroupSnapshotSuite struct{}
+
+var _ = check.Suite(&GroupSnapshotSuite{})
+
+func newFakeGroupSnapshotDynClient(objects ...runtime.Object) *dynfake.FakeDynamicClient {
+	return dynfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		snapshot.VolSnapGVR:             "VolumeSnapshotList",
+		snapshot.VolSnapContentGVR:      "VolumeSnapshotContentList",
+		snapshot.VolGroupSnapGVR:        "VolumeGroupSnapshotList",
+		snapshot.VolGroupSnapContentGVR: "VolumeGroupSnapshotContentList",
+	}, objects...)
+}
+
+// setGroupSnapshotReady sets the status the snapshot controller sets when
+// the group snapshot is cut.
+func setGroupSnapshotReady(c *check.C, dynCli *dynfake.FakeDynamicClient, name, namespace, content string) {
+	ctx := context.Background()
+	us, err := dynCli.Resource(snapshot.VolGroupSnapGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	c.Assert(err, check.IsNil)
+	us.Object["status"] = map[string]interface{}{
+		"boundVolumeGroupSnapshotContentName": content,
+		"creationTime":                        time.Now().UTC().Format(time.RFC3339),
+		"readyToUse":                          true,
+	}
+	_, err = dynCli.Resource(snapshot.VolGroupSnapGVR).Namespace(namespace).Update(ctx, us, metav1.UpdateOptions{})
+	c.Assert(err, check.IsNil)
+}
+
+// groupMemberSnapshot returns a VolumeSnapshot created by the snapshot
+// controller for a member of the group snapshot.
+func groupMemberSnapshot(name, namespace, group, content, restoreSize string) *unstructured.Unstructured {
+	us := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "snapshot.storage.k8s.io/v1",
+		"kind":       snapshot.VolSnapKind,
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"spec": map[string]interface{}{
+			"source": map[string]interface{}{"volumeSnapshotContentName": content},
+		},
+		"status": map[string]interface{}{
+			"boundVolumeSnapshotContentName": content,
+			"readyToUse":                     true,
+			"restoreSize":                    restoreSize,
+		},
+	}}
+	us.SetOwnerReferences([]metav1.OwnerReference{{
+		APIVersion: snapshot.GroupSnapshotGroupName + "/" + snapshot.GroupSnapshotVersion,
+		Kind:       snapshot.VolGroupSnapKind,
+		Name:       group,
+	}})
+	return us
+}
+
+func csiPV(name, handle, namespace, pvc string) *corev1.PersistentVolume {
+	return &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1.PersistentVolumeSpec{
+			PersistentVolumeSource: corev1.PersistentVolumeSource{CSI: &corev1.CSIPersistentVolumeSource{Driver: "driver", VolumeHandle: handle}},
+			ClaimRef:               &corev1.ObjectReference{Namespace: namespace, Name: pvc},
+		},
+	}
+}
+
+func (s *GroupSnapshotSuite) TestCreateGroup(c *check.C) {
+	ctx := context.Background()
+	ns := "ns"
+	class := "group-class"
+	kubeCli := fake.NewSimpleClientset(
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: ns, Labels: map[string]string{"app": "db"}}},
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: ns}},
+	)
+	dynCli := newFakeGroupSnapshotDynClient()
+	snapshotter := snapshot.NewSnapshotter(kubeCli, dynCli)
+
+	meta := snapshot.ObjectMeta{Name: "group", Namespace: ns, Labels: map[string]string{"kanister": "true"}}
+	err := snapshotter.CreateGroup(ctx, map[string]string{"app": "db"}, &class, false, meta)
+	c.Assert(err, check.IsNil)
+	vgs, err := snapshotter.GetGroup(ctx, "group", ns)
+	c.Assert(err, check.IsNil)
+	c.Assert(vgs.Spec.Source.Selector.MatchLabels, check.DeepEquals, map[string]string{"app": "db"})
+	c.Assert(*vgs.Spec.VolumeGroupSnapshotClassName, check.Equals, class)
+	c.Assert(vgs.Labels, check.DeepEquals, map[string]string{"kanister": "true"})
+
+	// The default class is used if the class isn't set
+	c.Assert(snapshotter.CreateGroup(ctx, map[string]string{"app": "db"}, nil, false, snapshot.ObjectMeta{Name: "default-class", Namespace: ns}), check.IsNil)
+	vgs, err = snapshotter.GetGroup(ctx, "default-class", ns)
+	c.Assert(err, check.IsNil)
+	c.Assert(vgs.Spec.VolumeGroupSnapshotClassName, check.IsNil)
+
+	err = snapshotter.CreateGroup(ctx, map[string]string{"app": "web"}, &class, false, snapshot.ObjectMeta{Name: "group2", Namespace: ns})
+	c.Assert(err, check.ErrorMatches, "Failed to find PVCs matching the selector.*")
+	err = snapshotter.CreateGroup(ctx, nil, &class, false, snapshot.ObjectMeta{Name: "group2", Namespace: ns})
+	c.Assert(err, check.ErrorMatches, "Label selector of the group snapshot must not be empty")
+}
+
+func (s *GroupSnapshotSuite) TestWaitOnGroupReadyToUse(c *check.C) {
+	ctx := context.Background()
+	ns := "ns"
+	class := "group-class"
+	kubeCli := fake.NewSimpleClientset(&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: ns, Labels: map[string]string{"app": "db"}}})
+	dynCli := newFakeGroupSnapshotDynClient()
+	snapshotter := snapshot.NewSnapshotter(kubeCli, dynCli)
+	c.Assert(snapshotter.CreateGroup(ctx, map[string]string{"app": "db"}, &class, false, snapshot.ObjectMeta{Name: "group", Namespace: ns}), check.IsNil)
+
+	setGroupSnapshotReady(c, dynCli, "group", ns, "content")
+	c.Assert(snapshotter.WaitOnGroupReadyToUse(ctx, "group", ns), check.IsNil)
+
+	us, err := dynCli.Resource(snapshot.VolGroupSnapGVR).Namespace(ns).Get(ctx, "group", metav1.GetOptions{})
+	c.Assert(err, check.IsNil)
+	us.Object["status"] = map[string]interface{}{
+		"readyToUse": false,
+		"error":      map[string]interface{}{"message": "driver failed"},
+	}
+	_, err = dynCli.Resource(snapshot.VolGroupSnapGVR).Namespace(ns).Update(ctx, us, metav1.UpdateOptions{})
+	c.Assert(err, check.IsNil)
+	c.Assert(snapshotter.WaitOnGroupReadyToUse(ctx, "group", ns), check.ErrorMatches, ".*driver failed.*")
+}
+
+func (s *GroupSnapshotSuite) TestListGroupMembers(c *check.C) {
+	ctx := context.Background()
+	ns := "ns"
+	class := "group-class"
+	kubeCli := fake.NewSimpleClientset(
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: ns, Labels: map[string]string{"app": "db"}}},
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "wal", Namespace: ns, Labels: map[string]string{"app": "db"}}},
+		csiPV("pv-data", "vol-data", ns, "data"),
+		csiPV("pv-wal", "vol-wal", ns, "wal"),
+		csiPV("pv-other", "vol-other", "other-ns", "data"),
+	)
+	groupContent := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": snapshot.GroupSnapshotGroupName + "/" + snapshot.GroupSnapshotVersion,
+		"kind":       snapshot.VolGroupSnapContentKind,
+		"metadata":   map[string]interface{}{"name": "group-content"},
+		"status": map[string]interface{}{
+			"volumeSnapshotHandlePairList": []interface{}{
+				map[string]interface{}{"volumeHandle": "vol-wal", "snapshotHandle": "snap-wal"},
+				map[string]interface{}{"volumeHandle": "vol-data", "snapshotHandle": "snap-data"},
+			},
+		},
+	}}
+	content := func(name, handle string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "snapshot.storage.k8s.io/v1",
+			"kind":       snapshot.VolSnapContentKind,
+			"metadata":   map[string]interface{}{"name": name},
+			"spec":       map[string]interface{}{"driver": "driver"},
+			"status":     map[string]interface{}{"snapshotHandle": handle},
+		}}
+	}
+	dynCli := newFakeGroupSnapshotDynClient(
+		groupContent,
+		content("content-wal", "snap-wal"),
+		content("content-data", "snap-data"),
+		groupMemberSnapshot("snapshot-wal", ns, "group", "content-wal", "2Gi"),
+		groupMemberSnapshot("snapshot-data", ns, "group", "content-data", "1Gi"),
+		groupMemberSnapshot("snapshot-other", ns, "other-group", "content-data", "1Gi"),
+	)
+	snapshotter := snapshot.NewSnapshotter(kubeCli, dynCli)
+	c.Assert(snapshotter.CreateGroup(ctx, map[string]string{"app": "db"}, &class, false, snapshot.ObjectMeta{Name: "group", Namespace: ns}), check.IsNil)
+
+	_, err := snapshotter.ListGroupMembers(ctx, "group", ns)
+	c.Assert(err, check.ErrorMatches, "Group snapshot is not ready.*")
+
+	setGroupSnapshotReady(c, dynCli, "group", ns, "group-content")
+	members, err := snapshotter.ListGroupMembers(ctx, "group", ns)
+	c.Assert(err, check.IsNil)
+	c.Assert(members, check.HasLen, 2)
+	c.Assert(members[0].PVC, check.Equals, "data")
+	c.Assert(members[0].VolumeSnapshot, check.Equals, "snapshot-data")
+	c.Assert(members[0].RestoreSize.String(), check.Equals, "1Gi")
+	c.Assert(members[1].PVC, check.Equals, "wal")
+	c.Assert(members[1].VolumeSnapshot, check.Equals, "snapshot-wal")
+	c.Assert(members[1].RestoreSize.String(), check.Equals, "2Gi")
+}
+
+func (s *GroupSnapshotSuite) TestDeleteGroup(c *check.C) {
+	ctx := context.Background()
+	ns := "ns"
+	class := "group-class"
+	kubeCli := fake.NewSimpleClientset(&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: ns, Labels: map[string]string{"app": "db"}}})
+	dynCli := newFakeGroupSnapshotDynClient(
+		groupMemberSnapshot("snapshot-data", ns, "group", "content-data", "1Gi"),
+		groupMemberSnapshot("snapshot-other", ns, "other-group", "content-other", "1Gi"),
+	)
+	snapshotter := snapshot.NewSnapshotter(kubeCli, dynCli)
+	c.Assert(snapshotter.CreateGroup(ctx, map[string]string{"app": "db"}, &class, false, snapshot.ObjectMeta{Name: "group", Namespace: ns}), check.IsNil)
+
+	vgs, err := snapshotter.DeleteGroup(ctx, "group", ns)
+	c.Assert(err, check.IsNil)
+	c.Assert(vgs.Name, check.Equals, "group")
+	_, err = snapshotter.GetGroup(ctx, "group", ns)
+	c.Assert(apierrors.IsNotFound(err), check.Equals, true)
+	// The VolumeSnapshots of the members are deleted too
+	_, err = dynCli.Resource(snapshot.VolSnapGVR).Namespace(ns).Get(ctx, "snapshot-data", metav1.GetOptions{})
+	c.Assert(apierrors.IsNotFound(err), check.Equals, true)
+	_, err = dynCli.Resource(snapshot.VolSnapGVR).Namespace(ns).Get(ctx, "snapshot-other", metav1.GetOptions{})
+	c.Assert(err, check.IsNil)
+
+	// Deleting a missing group snapshot isn't an error
+	vgs, err = snapshotter.DeleteGroup(ctx, "group", ns)
+	c.Assert(err, check.IsNil)
+	c.Assert(vgs, check.IsNil)
+}
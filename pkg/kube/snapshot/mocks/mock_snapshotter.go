@@ -108,6 +108,20 @@ func (mr *MockSnapshotterMockRecorder) CreateFromSource(ctx, source, waitForRead
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFromSource", reflect.TypeOf((*MockSnapshotter)(nil).CreateFromSource), ctx, source, waitForReady, snapshotMeta, snapshotContentMeta)
 }
 
+// CreateGroup mocks base method.
+func (m *MockSnapshotter) CreateGroup(ctx context.Context, selector map[string]string, groupSnapshotClass *string, waitForReady bool, snapshotMeta snapshot.ObjectMeta) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroup", ctx, selector, groupSnapshotClass, waitForReady, snapshotMeta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateGroup indicates an expected call of CreateGroup.
+func (mr *MockSnapshotterMockRecorder) CreateGroup(ctx, selector, groupSnapshotClass, waitForReady, snapshotMeta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockSnapshotter)(nil).CreateGroup), ctx, selector, groupSnapshotClass, waitForReady, snapshotMeta)
+}
+
 // Delete mocks base method.
 func (m *MockSnapshotter) Delete(ctx context.Context, name, namespace string) (*v1.VolumeSnapshot, error) {
 	m.ctrl.T.Helper()
@@ -137,6 +151,21 @@ func (mr *MockSnapshotterMockRecorder) DeleteContent(ctx, name interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContent", reflect.TypeOf((*MockSnapshotter)(nil).DeleteContent), ctx, name)
 }
 
+// DeleteGroup mocks base method.
+func (m *MockSnapshotter) DeleteGroup(ctx context.Context, name, namespace string) (*snapshot.VolumeGroupSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGroup", ctx, name, namespace)
+	ret0, _ := ret[0].(*snapshot.VolumeGroupSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteGroup indicates an expected call of DeleteGroup.
+func (mr *MockSnapshotterMockRecorder) DeleteGroup(ctx, name, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockSnapshotter)(nil).DeleteGroup), ctx, name, namespace)
+}
+
 // Get mocks base method.
 func (m *MockSnapshotter) Get(ctx context.Context, name, namespace string) (*v1.VolumeSnapshot, error) {
 	m.ctrl.T.Helper()
@@ -152,6 +181,21 @@ func (mr *MockSnapshotterMockRecorder) Get(ctx, name, namespace interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSnapshotter)(nil).Get), ctx, name, namespace)
 }
 
+// GetGroup mocks base method.
+func (m *MockSnapshotter) GetGroup(ctx context.Context, name, namespace string) (*snapshot.VolumeGroupSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroup", ctx, name, namespace)
+	ret0, _ := ret[0].(*snapshot.VolumeGroupSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroup indicates an expected call of GetGroup.
+func (mr *MockSnapshotterMockRecorder) GetGroup(ctx, name, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroup", reflect.TypeOf((*MockSnapshotter)(nil).GetGroup), ctx, name, namespace)
+}
+
 // GetSource mocks base method.
 func (m *MockSnapshotter) GetSource(ctx context.Context, snapshotName, namespace string) (*snapshot.Source, error) {
 	m.ctrl.T.Helper()
@@ -211,6 +255,35 @@ func (mr *MockSnapshotterMockRecorder) List(ctx, namespace, labels interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSnapshotter)(nil).List), ctx, namespace, labels)
 }
 
+// ListGroupMembers mocks base method.
+func (m *MockSnapshotter) ListGroupMembers(ctx context.Context, name, namespace string) ([]snapshot.GroupMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroupMembers", ctx, name, namespace)
+	ret0, _ := ret[0].([]snapshot.GroupMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroupMembers indicates an expected call of ListGroupMembers.
+func (mr *MockSnapshotterMockRecorder) ListGroupMembers(ctx, name, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupMembers", reflect.TypeOf((*MockSnapshotter)(nil).ListGroupMembers), ctx, name, namespace)
+}
+
+// WaitOnGroupReadyToUse mocks base method.
+func (m *MockSnapshotter) WaitOnGroupReadyToUse(ctx context.Context, snapshotName, namespace string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitOnGroupReadyToUse", ctx, snapshotName, namespace)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitOnGroupReadyToUse indicates an expected call of WaitOnGroupReadyToUse.
+func (mr *MockSnapshotterMockRecorder) WaitOnGroupReadyToUse(ctx, snapshotName, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitOnGroupReadyToUse", reflect.TypeOf((*MockSnapshotter)(nil).WaitOnGroupReadyToUse), ctx, snapshotName, namespace)
+}
+
 // WaitOnReadyToUse mocks base method.
 func (m *MockSnapshotter) WaitOnReadyToUse(ctx context.Context, snapshotName, namespace string) error {
 	m.ctrl.T.Helper()
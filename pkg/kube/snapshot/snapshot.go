@@ -111,6 +111,23 @@ type Snapshotter interface {
 	List(ctx context.Context, namespace string, labels map[string]string) (*v1.VolumeSnapshotList, error)
 	// GroupVersion returns the group and version according to snapshotter version
 	GroupVersion(ctx context.Context) schema.GroupVersion
+	// CreateGroup creates a VolumeGroupSnapshot of the PVCs that match 'selector' and returns any error happened meanwhile.
+	//
+	// 'selector' has the labels of the PVCs that are snapshotted at the same point in time.
+	// The PVCs must be in the namespace of the VolumeGroupSnapshot.
+	// 'waitForReady' will block the caller until the group snapshot status is 'ReadyToUse'.
+	// 'snapshotMeta' has metadata of the VolumeGroupSnapshot resource that is going to get created.
+	CreateGroup(ctx context.Context, selector map[string]string, groupSnapshotClass *string, waitForReady bool, snapshotMeta ObjectMeta) error
+	// GetGroup will return the VolumeGroupSnapshot in the namespace 'namespace' with given 'name'.
+	GetGroup(ctx context.Context, name, namespace string) (*VolumeGroupSnapshot, error)
+	// WaitOnGroupReadyToUse will block until the VolumeGroupSnapshot in namespace 'namespace' with name 'snapshotName'
+	// has status 'ReadyToUse' or 'ctx.Done()' is signalled.
+	WaitOnGroupReadyToUse(ctx context.Context, snapshotName, namespace string) error
+	// ListGroupMembers returns the VolumeSnapshots of the PVCs of a ready VolumeGroupSnapshot.
+	ListGroupMembers(ctx context.Context, name, namespace string) ([]GroupMember, error)
+	// DeleteGroup will delete the VolumeGroupSnapshot together with the VolumeSnapshots of its members.
+	// Returns the `VolumeGroupSnapshot` deleted and any error as a result.
+	DeleteGroup(ctx context.Context, name, namespace string) (*VolumeGroupSnapshot, error)
 }
 
 // Source represents the CSI source of the Volumesnapshot.
@@ -0,0 +1,98 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kanisterio/errkit"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kanisterio/kanister/pkg/jsonpath"
+)
+
+const (
+	waitConditionPrefix = "condition="
+	waitJSONPathPrefix  = "jsonpath="
+)
+
+// WaitCondition is a condition on a k8s resource in the format of
+// `kubectl wait --for`, i.e. `condition=<type>[=<status>]` or
+// `jsonpath=<jsonpath>=<value>`.
+type WaitCondition struct {
+	// ConditionType is the type of the status condition that has to have
+	// ConditionStatus.
+	ConditionType   string
+	ConditionStatus string
+	// JSONPath is evaluated on the resource and has to be Value.
+	JSONPath string
+	Value    string
+}
+
+// ParseWaitCondition parses a condition in the format of `kubectl wait --for`.
+func ParseWaitCondition(s string) (WaitCondition, error) {
+	switch {
+	case strings.HasPrefix(s, waitConditionPrefix):
+		t, status, found := strings.Cut(strings.TrimPrefix(s, waitConditionPrefix), "=")
+		if !found {
+			status = "True"
+		}
+		if t == "" || status == "" {
+			return WaitCondition{}, errkit.New("Invalid wait condition", "condition", s)
+		}
+		return WaitCondition{ConditionType: t, ConditionStatus: status}, nil
+	case strings.HasPrefix(s, waitJSONPathPrefix):
+		path := strings.TrimPrefix(s, waitJSONPathPrefix)
+		// The value follows the last `=` after the closing brace of the
+		// jsonpath, which can contain `=` in filters
+		i := strings.LastIndex(path, "}")
+		if i < 0 || !strings.HasPrefix(path, "{") || !strings.HasPrefix(path[i+1:], "=") {
+			return WaitCondition{}, errkit.New("Invalid wait condition, expected jsonpath={<jsonpath>}=<value>", "condition", s)
+		}
+		return WaitCondition{JSONPath: path[:i+1], Value: path[i+2:]}, nil
+	}
+	return WaitCondition{}, errkit.New("Invalid wait condition, expected condition=<type>[=<status>] or jsonpath={<jsonpath>}=<value>", "condition", s)
+}
+
+// Met returns true if obj meets the condition.
+func (w WaitCondition) Met(obj *unstructured.Unstructured) (bool, error) {
+	if w.JSONPath != "" {
+		v, err := jsonpath.ResolveJsonpathToString(obj, w.JSONPath)
+		if err != nil {
+			return false, errkit.Wrap(err, "Failed to resolve jsonpath", "jsonpath", w.JSONPath)
+		}
+		return v == w.Value, nil
+	}
+	conditions, _, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if err != nil {
+		return false, err
+	}
+	for _, c := range conditions {
+		cm, ok := c.(map[string]interface{})
+		if !ok || !strings.EqualFold(fmt.Sprint(cm["type"]), w.ConditionType) {
+			continue
+		}
+		return strings.EqualFold(fmt.Sprint(cm["status"]), w.ConditionStatus), nil
+	}
+	return false, nil
+}
+
+func (w WaitCondition) String() string {
+	if w.JSONPath != "" {
+		return waitJSONPathPrefix + w.JSONPath + "=" + w.Value
+	}
+	return waitConditionPrefix + w.ConditionType + "=" + w.ConditionStatus
+}
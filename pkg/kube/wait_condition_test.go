@@ -0,0 +1,76 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"gopkg.in/check.v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type WaitConditionSuite struct{}
+
+var _ = check.Suite(&WaitConditionSuite{})
+
+func (s *WaitConditionSuite) TestParseWaitCondition(c *check.C) {
+	for _, tc := range []struct {
+		cond     string
+		expected WaitCondition
+		errChk   check.Checker
+	}{
+		{cond: "condition=Available", expected: WaitCondition{ConditionType: "Available", ConditionStatus: "True"}, errChk: check.IsNil},
+		{cond: "condition=Ready=False", expected: WaitCondition{ConditionType: "Ready", ConditionStatus: "False"}, errChk: check.IsNil},
+		{cond: "jsonpath={.status.phase}=Running", expected: WaitCondition{JSONPath: "{.status.phase}", Value: "Running"}, errChk: check.IsNil},
+		{cond: `jsonpath={.status.conditions[?(@.type=="Ready")].status}=True`, expected: WaitCondition{JSONPath: `{.status.conditions[?(@.type=="Ready")].status}`, Value: "True"}, errChk: check.IsNil},
+		{cond: "jsonpath={.status.phase}", errChk: check.NotNil},
+		{cond: "condition=", errChk: check.NotNil},
+		{cond: "delete", errChk: check.NotNil},
+	} {
+		w, err := ParseWaitCondition(tc.cond)
+		c.Assert(err, tc.errChk, check.Commentf(tc.cond))
+		c.Assert(w, check.DeepEquals, tc.expected)
+	}
+}
+
+func (s *WaitConditionSuite) TestWaitConditionMet(c *check.C) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"status": map[string]interface{}{
+			"phase": "Running",
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True"},
+				map[string]interface{}{"type": "Initialized", "status": "False"},
+			},
+		},
+	}}
+	for _, tc := range []struct {
+		cond string
+		met  bool
+	}{
+		{cond: "condition=Ready", met: true},
+		{cond: "condition=ready=true", met: true},
+		{cond: "condition=Initialized", met: false},
+		{cond: "condition=Initialized=False", met: true},
+		{cond: "condition=Missing", met: false},
+		{cond: "jsonpath={.status.phase}=Running", met: true},
+		{cond: "jsonpath={.status.phase}=Pending", met: false},
+	} {
+		w, err := ParseWaitCondition(tc.cond)
+		c.Assert(err, check.IsNil)
+		met, err := w.Met(obj)
+		c.Assert(err, check.IsNil)
+		c.Assert(met, check.Equals, tc.met, check.Commentf(tc.cond))
+	}
+}
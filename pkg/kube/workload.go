@@ -24,6 +24,7 @@ import (
 	osAppsv1 "github.com/openshift/api/apps/v1"
 	osversioned "github.com/openshift/client-go/apps/clientset/versioned"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -41,6 +42,10 @@ const (
 
 	// ReplicationControllerRevisionAnnotation is annotation of deploymentconfig's repliationcontroller
 	ReplicationControllerRevisionAnnotation = "openshift.io/deployment-config.latest-version"
+
+	// DaemonSetPausedNodeSelector is the node selector that is added to the pod template of a
+	// paused daemonset. No node has the label, so the pods of the daemonset aren't scheduled.
+	DaemonSetPausedNodeSelector = "kanister.io/paused"
 )
 
 // CreateConfigMap creates a configmap set from a yaml spec.
@@ -285,6 +290,136 @@ func WaitOnDeploymentConfigReady(ctx context.Context, osCli osversioned.Interfac
 	})
 }
 
+// DaemonSetReady checks if a daemonset has the desired number of scheduled,
+// updated and available pods.
+func DaemonSetReady(ctx context.Context, kubeCli kubernetes.Interface, namespace string, name string) (bool, string, error) {
+	ds, err := kubeCli.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return false, "", errkit.Wrap(err, "could not get DaemonSet", "namespace", namespace, "name", name)
+	}
+	var status string
+	switch {
+	case ds.Status.ObservedGeneration < ds.Generation:
+		status = fmt.Sprintf(
+			"Need generation of at least %d and observed %d", ds.Generation, ds.Status.ObservedGeneration,
+		)
+	case ds.Status.UpdatedNumberScheduled != ds.Status.DesiredNumberScheduled:
+		status = fmt.Sprintf(
+			"Desired %d scheduled pods and only have %d updated pods", ds.Status.DesiredNumberScheduled, ds.Status.UpdatedNumberScheduled,
+		)
+	case ds.Status.NumberAvailable != ds.Status.DesiredNumberScheduled:
+		status = fmt.Sprintf(
+			"Desired %d scheduled pods and only have %d available pods", ds.Status.DesiredNumberScheduled, ds.Status.NumberAvailable,
+		)
+	}
+	if status != "" {
+		return false, status, nil
+	}
+	runningPods, _, err := FetchPods(kubeCli, namespace, ds.GetUID())
+	if err != nil {
+		return false, "", err
+	}
+	if len(runningPods) != int(ds.Status.DesiredNumberScheduled) {
+		status = fmt.Sprintf(
+			"Desired %d scheduled pods and only %d are running", ds.Status.DesiredNumberScheduled, len(runningPods),
+		)
+		return false, status, nil
+	}
+	return true, "", nil
+}
+
+// WaitOnDaemonSetReady waits for the daemonset to be ready
+func WaitOnDaemonSetReady(ctx context.Context, kubeCli kubernetes.Interface, namespace string, name string) error {
+	var status string
+	err := poll.Wait(ctx, func(ctx context.Context) (bool, error) {
+		ok, s, err := DaemonSetReady(ctx, kubeCli, namespace, name)
+		if s != "" {
+			status = s
+		}
+		if apierrors.IsNotFound(errkit.Unwrap(err)) {
+			return false, nil
+		}
+		return ok, err
+	})
+	if err != nil && status != "" {
+		return errkit.Wrap(err, status)
+	}
+	return err
+}
+
+// ReplicaSetReady checks if a replicaset has the desired number of available
+// replicas.
+func ReplicaSetReady(ctx context.Context, kubeCli kubernetes.Interface, namespace string, name string) (bool, string, error) {
+	rs, err := kubeCli.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return false, "", errkit.Wrap(err, "could not get ReplicaSet", "namespace", namespace, "name", name)
+	}
+	var status string
+	switch {
+	case rs.Status.ObservedGeneration < rs.Generation:
+		status = fmt.Sprintf(
+			"Need generation of at least %d and observed %d", rs.Generation, rs.Status.ObservedGeneration,
+		)
+	case rs.Status.Replicas != *rs.Spec.Replicas:
+		status = fmt.Sprintf(
+			"Specified %d replicas and only have %d", *rs.Spec.Replicas, rs.Status.Replicas,
+		)
+	case rs.Status.AvailableReplicas != *rs.Spec.Replicas:
+		status = fmt.Sprintf(
+			"Specified %d replicas and only have %d available replicas", *rs.Spec.Replicas, rs.Status.AvailableReplicas,
+		)
+	}
+	if status != "" {
+		return false, status, nil
+	}
+	runningPods, notRunningPods, err := FetchPods(kubeCli, namespace, rs.GetUID())
+	if err != nil {
+		return false, "", err
+	}
+	if len(runningPods) != int(rs.Status.AvailableReplicas) || len(notRunningPods) != 0 {
+		status = fmt.Sprintf(
+			"%d out of %d pods are running", len(runningPods), len(runningPods)+len(notRunningPods),
+		)
+		return false, status, nil
+	}
+	return true, "", nil
+}
+
+// WaitOnReplicaSetReady waits for the replicaset to be ready
+func WaitOnReplicaSetReady(ctx context.Context, kubeCli kubernetes.Interface, namespace string, name string) error {
+	var status string
+	err := poll.Wait(ctx, func(ctx context.Context) (bool, error) {
+		ok, s, err := ReplicaSetReady(ctx, kubeCli, namespace, name)
+		if s != "" {
+			status = s
+		}
+		if apierrors.IsNotFound(errkit.Unwrap(err)) {
+			return false, nil
+		}
+		return ok, err
+	})
+	if err != nil && status != "" {
+		return errkit.Wrap(err, status)
+	}
+	return err
+}
+
+// FetchJobs fetches the jobs matching the specified owner UID
+func FetchJobs(cli kubernetes.Interface, namespace string, uid types.UID) ([]batchv1.Job, error) {
+	jobs, err := cli.BatchV1().Jobs(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, errkit.Wrap(err, "Could not list Jobs")
+	}
+	var owned []batchv1.Job
+	for _, j := range jobs.Items {
+		if !uidInOwnerRefs(j.OwnerReferences, uid) {
+			continue
+		}
+		owned = append(owned, j)
+	}
+	return owned, nil
+}
+
 // FetchReplicationController fetches the replication controller that has owner with UID provided uid
 func FetchReplicationController(cli kubernetes.Interface, namespace string, uid types.UID, revision string) (*corev1.ReplicationController, error) {
 	repCtrls, err := cli.CoreV1().ReplicationControllers(namespace).List(context.TODO(), metav1.ListOptions{})
@@ -394,6 +529,68 @@ func ScaleDeploymentConfig(ctx context.Context, kubeCli kubernetes.Interface, os
 	return WaitOnDeploymentConfigReady(ctx, osCli, kubeCli, namespace, name)
 }
 
+func ScaleReplicaSet(ctx context.Context, kubeCli kubernetes.Interface, namespace string, name string, replicas int32, waitForReady bool) error {
+	rs, err := kubeCli.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return errkit.Wrap(err, "Could not get ReplicaSet", "namespace", namespace, "name", name)
+	}
+	rs.Spec.Replicas = &replicas
+	_, err = kubeCli.AppsV1().ReplicaSets(namespace).Update(ctx, rs, metav1.UpdateOptions{})
+	if err != nil {
+		return errkit.Wrap(err, "Could not update ReplicaSet", "namespace", namespace, "name", name)
+	}
+	if !waitForReady {
+		return nil
+	}
+	return WaitOnReplicaSetReady(ctx, kubeCli, namespace, name)
+}
+
+// ScaleDaemonSet pauses the daemonset if replicas is 0 and resumes it otherwise.
+// A daemonset runs a pod on every eligible node, so the number of replicas
+// can't be set. It's paused by adding a node selector that matches no node.
+func ScaleDaemonSet(ctx context.Context, kubeCli kubernetes.Interface, namespace string, name string, replicas int32, waitForReady bool) error {
+	ds, err := kubeCli.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return errkit.Wrap(err, "Could not get DaemonSet", "namespace", namespace, "name", name)
+	}
+	_, paused := ds.Spec.Template.Spec.NodeSelector[DaemonSetPausedNodeSelector]
+	switch {
+	case replicas == 0 && !paused:
+		if ds.Spec.Template.Spec.NodeSelector == nil {
+			ds.Spec.Template.Spec.NodeSelector = map[string]string{}
+		}
+		ds.Spec.Template.Spec.NodeSelector[DaemonSetPausedNodeSelector] = "true"
+	case replicas != 0 && paused:
+		delete(ds.Spec.Template.Spec.NodeSelector, DaemonSetPausedNodeSelector)
+	default:
+		return nil
+	}
+	_, err = kubeCli.AppsV1().DaemonSets(namespace).Update(ctx, ds, metav1.UpdateOptions{})
+	if err != nil {
+		return errkit.Wrap(err, "Could not update DaemonSet", "namespace", namespace, "name", name)
+	}
+	if !waitForReady {
+		return nil
+	}
+	return WaitOnDaemonSetReady(ctx, kubeCli, namespace, name)
+}
+
+// ScaleCronJob suspends the cronjob if replicas is 0 and resumes it otherwise.
+// Jobs that are already running aren't stopped.
+func ScaleCronJob(ctx context.Context, kubeCli kubernetes.Interface, namespace string, name string, replicas int32) error {
+	cj, err := kubeCli.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return errkit.Wrap(err, "Could not get CronJob", "namespace", namespace, "name", name)
+	}
+	suspend := replicas == 0
+	cj.Spec.Suspend = &suspend
+	_, err = kubeCli.BatchV1().CronJobs(namespace).Update(ctx, cj, metav1.UpdateOptions{})
+	if err != nil {
+		return errkit.Wrap(err, "Could not update CronJob", "namespace", namespace, "name", name)
+	}
+	return nil
+}
+
 // DeploymentVolumes returns the PVCs referenced by this deployment as a [pods spec volume name]->[PVC name] map
 func DeploymentVolumes(cli kubernetes.Interface, d *appsv1.Deployment) (volNameToPvc map[string]string) {
 	volNameToPvc = make(map[string]string)
@@ -407,6 +604,20 @@ func DeploymentVolumes(cli kubernetes.Interface, d *appsv1.Deployment) (volNameT
 	return volNameToPvc
 }
 
+// PodTemplateVolumes returns the PVCs referenced by the pod template as a [pod spec volume name]->[PVC name] map.
+// It can be used for any workload that creates its pods from a template without claim templates,
+// e.g. DaemonSets, ReplicaSets, Jobs and CronJobs.
+func PodTemplateVolumes(tmpl corev1.PodTemplateSpec) (volNameToPvc map[string]string) {
+	volNameToPvc = make(map[string]string)
+	for _, v := range tmpl.Spec.Volumes {
+		if v.PersistentVolumeClaim == nil {
+			continue
+		}
+		volNameToPvc[v.Name] = v.PersistentVolumeClaim.ClaimName
+	}
+	return volNameToPvc
+}
+
 // PodContainers returns list of containers specified by the pod
 func PodContainers(ctx context.Context, kubeCli kubernetes.Interface, namespace string, name string) ([]corev1.Container, error) {
 	p, err := kubeCli.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
@@ -527,3 +738,38 @@ func DeploymentConfigReplicas(ctx context.Context, osCli osversioned.Interface,
 	}
 	return dc.Spec.Replicas, nil
 }
+
+func ReplicaSetReplicas(ctx context.Context, kubeCli kubernetes.Interface, namespace, name string) (int32, error) {
+	rs, err := kubeCli.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return 0, errkit.Wrap(err, "Could not get ReplicaSet, to figure out replicas", "Namespace", namespace, "ReplicaSet", name)
+	}
+	return *rs.Spec.Replicas, nil
+}
+
+// DaemonSetReplicas returns the number of nodes the pods of the daemonset
+// should run on, or 0 if the daemonset is paused. A daemonset that isn't
+// paused has at least 1 replica, even if it should run on no node, so that
+// pausing it is recorded and it can be resumed.
+func DaemonSetReplicas(ctx context.Context, kubeCli kubernetes.Interface, namespace, name string) (int32, error) {
+	ds, err := kubeCli.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return 0, errkit.Wrap(err, "Could not get DaemonSet, to figure out replicas", "Namespace", namespace, "DaemonSet", name)
+	}
+	if _, ok := ds.Spec.Template.Spec.NodeSelector[DaemonSetPausedNodeSelector]; ok {
+		return 0, nil
+	}
+	return max(ds.Status.DesiredNumberScheduled, 1), nil
+}
+
+// CronJobReplicas returns 0 if the cronjob is suspended and 1 otherwise.
+func CronJobReplicas(ctx context.Context, kubeCli kubernetes.Interface, namespace, name string) (int32, error) {
+	cj, err := kubeCli.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return 0, errkit.Wrap(err, "Could not get CronJob, to figure out replicas", "Namespace", namespace, "CronJob", name)
+	}
+	if cj.Spec.Suspend != nil && *cj.Spec.Suspend {
+		return 0, nil
+	}
+	return 1, nil
+}
@@ -186,3 +186,73 @@ func getCli(cp cliParams) kubernetes.Interface {
 
 	return fake.NewSimpleClientset(kubeObjects...)
 }
+
+func (s *WorkloadReadySuite) TestDaemonSetReady(c *check.C) {
+	const dsUID types.UID = "1234"
+	for _, tc := range []struct {
+		status    appsv1.DaemonSetStatus
+		podStatus corev1.PodPhase
+		ready     bool
+		want      string
+	}{
+		{
+			status:    appsv1.DaemonSetStatus{DesiredNumberScheduled: 1, UpdatedNumberScheduled: 1, NumberAvailable: 1},
+			podStatus: corev1.PodRunning,
+			ready:     true,
+		},
+		{
+			status:    appsv1.DaemonSetStatus{DesiredNumberScheduled: 2, UpdatedNumberScheduled: 1, NumberAvailable: 1},
+			podStatus: corev1.PodRunning,
+			want:      "Desired 2 scheduled pods and only have 1 updated pods",
+		},
+		{
+			status:    appsv1.DaemonSetStatus{DesiredNumberScheduled: 2, UpdatedNumberScheduled: 2, NumberAvailable: 1},
+			podStatus: corev1.PodRunning,
+			want:      "Desired 2 scheduled pods and only have 1 available pods",
+		},
+		{
+			status:    appsv1.DaemonSetStatus{DesiredNumberScheduled: 1, UpdatedNumberScheduled: 1, NumberAvailable: 1},
+			podStatus: corev1.PodPending,
+			want:      "Desired 1 scheduled pods and only 0 are running",
+		},
+	} {
+		cli := fake.NewSimpleClientset(
+			&appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "ds", Namespace: "default", UID: dsUID},
+				Status:     tc.status,
+			},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "pod",
+					Namespace:       "default",
+					OwnerReferences: []metav1.OwnerReference{{UID: dsUID}},
+				},
+				Status: corev1.PodStatus{Phase: tc.podStatus},
+			},
+		)
+		ready, status, err := DaemonSetReady(context.Background(), cli, "default", "ds")
+		c.Assert(err, check.IsNil)
+		c.Assert(ready, check.Equals, tc.ready)
+		c.Assert(status, check.Equals, tc.want)
+	}
+}
+
+func (s *WorkloadReadySuite) TestReplicaSetReady(c *check.C) {
+	// getCli creates a ReplicaSet named "repset" whose pod is owned by it.
+	cp := cliParams{"dep", "default", false, 1, 1, 1, 1, 1, 1, "Running"}
+	cli := getCli(cp)
+	rs, err := cli.AppsV1().ReplicaSets(cp.namespace).Get(context.Background(), "repset", metav1.GetOptions{})
+	c.Assert(err, check.IsNil)
+	ready, status, err := ReplicaSetReady(context.Background(), cli, cp.namespace, rs.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(ready, check.Equals, false)
+	c.Assert(status, check.Equals, "Specified 1 replicas and only have 0")
+
+	rs.Status = appsv1.ReplicaSetStatus{Replicas: 1, AvailableReplicas: 1}
+	_, err = cli.AppsV1().ReplicaSets(cp.namespace).UpdateStatus(context.Background(), rs, metav1.UpdateOptions{})
+	c.Assert(err, check.IsNil)
+	ready, status, err = ReplicaSetReady(context.Background(), cli, cp.namespace, rs.Name)
+	c.Assert(err, check.IsNil)
+	c.Assert(ready, check.Equals, true)
+	c.Assert(status, check.Equals, "")
+}
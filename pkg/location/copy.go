@@ -0,0 +1,193 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package location
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/kanisterio/errkit"
+
+	"github.com/kanisterio/kanister/pkg/field"
+	"github.com/kanisterio/kanister/pkg/log"
+	"github.com/kanisterio/kanister/pkg/objectstore"
+	"github.com/kanisterio/kanister/pkg/param"
+)
+
+// CopyResult lists the objects copied by Copy, relative to the copied prefix
+type CopyResult struct {
+	// Copied are the objects that were copied
+	Copied []string
+	// Skipped are the objects that already had a copy with the same
+	// checksum in the destination, e.g. from an interrupted copy
+	Skipped []string
+}
+
+// Copy copies the objects under `srcSuffix` in the location specified by
+// `src` to `dstSuffix` in the location specified by `dst`. The objects are
+// streamed from the source to the destination without staging them, and the
+// SHA-256 checksum of each copy is verified by reading it back. Objects that
+// already have a copy with the same checksum in the destination are skipped,
+// so an interrupted copy can be resumed by running it again.
+func Copy(ctx context.Context, src, dst param.Profile, srcSuffix, dstSuffix string) (*CopyResult, error) {
+	srcType, err := getProviderType(src.Location.Type)
+	if err != nil {
+		return nil, err
+	}
+	dstType, err := getProviderType(dst.Location.Type)
+	if err != nil {
+		return nil, err
+	}
+	srcBucket, err := getBucket(ctx, srcType, src)
+	if err != nil {
+		return nil, err
+	}
+	dstBucket, err := getBucket(ctx, dstType, dst)
+	if err != nil {
+		return nil, err
+	}
+	srcPath := filepath.Join(src.Location.Prefix, srcSuffix)
+	dstPath := filepath.Join(dst.Location.Prefix, dstSuffix)
+	return copyObjects(ctx, srcBucket, dstBucket, dstType, srcPath, dstPath)
+}
+
+func copyObjects(ctx context.Context, src, dst objectstore.Directory, dstType objectstore.ProviderType, srcPath, dstPath string) (*CopyResult, error) {
+	srcDir, err := src.GetDirectory(ctx, srcPath)
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to find objects to copy", "path", srcPath)
+	}
+	objects, err := listObjects(ctx, srcDir, "")
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to list objects to copy", "path", srcPath)
+	}
+	// The destination doesn't exist if nothing was copied to it yet
+	existing := map[string]bool{}
+	if dstDir, err := dst.GetDirectory(ctx, dstPath); err == nil {
+		dstObjects, err := listObjects(ctx, dstDir, "")
+		if err != nil {
+			return nil, errkit.Wrap(err, "Failed to list copied objects", "path", dstPath)
+		}
+		for _, name := range dstObjects {
+			existing[name] = true
+		}
+	}
+
+	res := &CopyResult{}
+	for _, name := range objects {
+		srcName := path.Join(srcPath, name)
+		dstName := path.Join(dstPath, name)
+		if existing[name] {
+			copied, err := sameChecksum(ctx, src, dst, srcName, dstName)
+			if err != nil {
+				return nil, err
+			}
+			if copied {
+				log.Debug().Print("Skipping copied object", field.M{"object": dstName})
+				res.Skipped = append(res.Skipped, name)
+				continue
+			}
+		}
+		if err := copyObject(ctx, src, dst, dstType, srcName, dstName); err != nil {
+			return nil, err
+		}
+		res.Copied = append(res.Copied, name)
+	}
+	return res, nil
+}
+
+// listObjects lists the objects in the directory and its sub directories
+// with their path relative to the directory.
+func listObjects(ctx context.Context, dir objectstore.Directory, rel string) ([]string, error) {
+	names, err := dir.ListObjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]string, 0, len(names))
+	for _, name := range names {
+		objects = append(objects, path.Join(rel, name))
+	}
+	dirs, err := dir.ListDirectories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for name, d := range dirs {
+		sub, err := listObjects(ctx, d, path.Join(rel, name))
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, sub...)
+	}
+	sort.Strings(objects)
+	return objects, nil
+}
+
+// copyObject streams the object to the destination and verifies the
+// checksum of the copy. A copy that doesn't match is deleted.
+func copyObject(ctx context.Context, src, dst objectstore.Directory, dstType objectstore.ProviderType, srcName, dstName string) error {
+	r, tags, err := src.Get(ctx, srcName)
+	if err != nil {
+		return errkit.Wrap(err, "Failed to read object", "object", srcName)
+	}
+	defer r.Close() //nolint:errcheck
+	h := sha256.New()
+	if err := putData(ctx, dstType, dst, io.TeeReader(r, h), dstName, tags); err != nil {
+		return errkit.Wrap(err, "Failed to write object", "object", dstName)
+	}
+	srcSum := hex.EncodeToString(h.Sum(nil))
+	dstSum, err := objectChecksum(ctx, dst, dstName)
+	if err != nil {
+		return err
+	}
+	if srcSum != dstSum {
+		if err := dst.Delete(ctx, dstName); err != nil {
+			log.Error().WithError(err).Print("Failed to delete corrupted copy", field.M{"object": dstName})
+		}
+		return errkit.New(fmt.Sprintf("Checksum of the copy of %s doesn't match", srcName), "sourceChecksum", srcSum, "destinationChecksum", dstSum)
+	}
+	return nil
+}
+
+// sameChecksum checks if the objects have the same SHA-256 checksum
+func sameChecksum(ctx context.Context, src, dst objectstore.Directory, srcName, dstName string) (bool, error) {
+	srcSum, err := objectChecksum(ctx, src, srcName)
+	if err != nil {
+		return false, err
+	}
+	dstSum, err := objectChecksum(ctx, dst, dstName)
+	if err != nil {
+		return false, err
+	}
+	return srcSum == dstSum, nil
+}
+
+// objectChecksum returns the hex encoded SHA-256 checksum of the object
+func objectChecksum(ctx context.Context, dir objectstore.Directory, name string) (string, error) {
+	r, _, err := dir.Get(ctx, name)
+	if err != nil {
+		return "", errkit.Wrap(err, "Failed to read object", "object", name)
+	}
+	defer r.Close() //nolint:errcheck
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", errkit.Wrap(err, "Failed to read object", "object", name)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
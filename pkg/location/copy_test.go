@@ -0,0 +1,195 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package location
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/kanisterio/errkit"
+	"gopkg.in/check.v1"
+
+	"github.com/kanisterio/kanister/pkg/objectstore"
+)
+
+// memDirectory is an in-memory objectstore.Directory. Directories share the
+// objects of their bucket, which are indexed by their path without the
+// leading '/'.
+type memDirectory struct {
+	objects map[string][]byte
+	path    string
+	// corrupt flips the data of the objects that are Put
+	corrupt bool
+	puts    int
+}
+
+var _ objectstore.Directory = (*memDirectory)(nil)
+
+func newMemBucket() *memDirectory {
+	return &memDirectory{objects: map[string][]byte{}}
+}
+
+func (d *memDirectory) abs(name string) string {
+	return strings.TrimPrefix(path.Join(d.path, name), "/")
+}
+
+func (d *memDirectory) prefix() string {
+	if d.path == "" {
+		return ""
+	}
+	return strings.TrimPrefix(d.path, "/") + "/"
+}
+
+func (d *memDirectory) CreateDirectory(ctx context.Context, dir string) (objectstore.Directory, error) {
+	return nil, errkit.New("Not implemented")
+}
+
+func (d *memDirectory) GetDirectory(ctx context.Context, dir string) (objectstore.Directory, error) {
+	sub := *d
+	sub.path = d.abs(dir)
+	for name := range d.objects {
+		if strings.HasPrefix(name, sub.prefix()) {
+			return &sub, nil
+		}
+	}
+	return nil, errkit.New("Directory not found")
+}
+
+func (d *memDirectory) DeleteDirectory(ctx context.Context) error {
+	return errkit.New("Not implemented")
+}
+
+func (d *memDirectory) DeleteAllWithPrefix(ctx context.Context, prefix string) error {
+	return errkit.New("Not implemented")
+}
+
+func (d *memDirectory) ListDirectories(ctx context.Context) (map[string]objectstore.Directory, error) {
+	dirs := map[string]objectstore.Directory{}
+	for name := range d.objects {
+		rel, ok := strings.CutPrefix(name, d.prefix())
+		if !ok || !strings.Contains(rel, "/") {
+			continue
+		}
+		dir := strings.SplitN(rel, "/", 2)[0]
+		sub := *d
+		sub.path = d.abs(dir)
+		dirs[dir] = &sub
+	}
+	return dirs, nil
+}
+
+func (d *memDirectory) ListObjects(ctx context.Context) ([]string, error) {
+	var objects []string
+	for name := range d.objects {
+		if rel, ok := strings.CutPrefix(name, d.prefix()); ok && !strings.Contains(rel, "/") {
+			objects = append(objects, rel)
+		}
+	}
+	return objects, nil
+}
+
+func (d *memDirectory) Get(ctx context.Context, name string) (io.ReadCloser, map[string]string, error) {
+	data, ok := d.objects[d.abs(name)]
+	if !ok {
+		return nil, nil, errkit.New("Object not found")
+	}
+	return io.NopCloser(bytes.NewReader(data)), map[string]string{"kind": "test"}, nil
+}
+
+func (d *memDirectory) GetBytes(ctx context.Context, name string) ([]byte, map[string]string, error) {
+	return nil, nil, errkit.New("Not implemented")
+}
+
+func (d *memDirectory) Put(ctx context.Context, name string, r io.Reader, size int64, tags map[string]string) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if d.corrupt {
+		data = append(data, '!')
+	}
+	d.puts++
+	d.objects[d.abs(name)] = data
+	return nil
+}
+
+func (d *memDirectory) PutBytes(ctx context.Context, name string, data []byte, tags map[string]string) error {
+	return d.Put(ctx, name, bytes.NewReader(data), int64(len(data)), tags)
+}
+
+func (d *memDirectory) Delete(ctx context.Context, name string) error {
+	delete(d.objects, d.abs(name))
+	return nil
+}
+
+func (d *memDirectory) String() string {
+	return d.path
+}
+
+type CopySuite struct{}
+
+var _ = check.Suite(&CopySuite{})
+
+func (s *CopySuite) TestCopyObjects(c *check.C) {
+	ctx := context.Background()
+	src := newMemBucket()
+	src.objects["backups/mysql/dump.sql.gz"] = []byte("dump")
+	src.objects["backups/mysql/logs/binlog.000001"] = []byte("binlog 1")
+	src.objects["backups/mysql/logs/binlog.000002"] = []byte("binlog 2")
+	src.objects["backups/postgres/dump.sql.gz"] = []byte("other dump")
+	dst := newMemBucket()
+	// A copy from an interrupted run and a partial one
+	dst.objects["dr/mysql/dump.sql.gz"] = []byte("dump")
+	dst.objects["dr/mysql/logs/binlog.000001"] = []byte("bin")
+
+	res, err := copyObjects(ctx, src, dst, objectstore.ProviderTypeS3, "backups/mysql", "dr/mysql")
+	c.Assert(err, check.IsNil)
+	c.Assert(res.Copied, check.DeepEquals, []string{"logs/binlog.000001", "logs/binlog.000002"})
+	c.Assert(res.Skipped, check.DeepEquals, []string{"dump.sql.gz"})
+	c.Assert(dst.objects, check.DeepEquals, map[string][]byte{
+		"dr/mysql/dump.sql.gz":        []byte("dump"),
+		"dr/mysql/logs/binlog.000001": []byte("binlog 1"),
+		"dr/mysql/logs/binlog.000002": []byte("binlog 2"),
+	})
+	c.Assert(dst.puts, check.Equals, 2)
+
+	// Copying again only verifies the copies
+	res, err = copyObjects(ctx, src, dst, objectstore.ProviderTypeS3, "backups/mysql", "dr/mysql")
+	c.Assert(err, check.IsNil)
+	c.Assert(res.Copied, check.IsNil)
+	c.Assert(res.Skipped, check.HasLen, 3)
+	c.Assert(dst.puts, check.Equals, 2)
+}
+
+func (s *CopySuite) TestCopyObjectsChecksumMismatch(c *check.C) {
+	ctx := context.Background()
+	src := newMemBucket()
+	src.objects["backups/dump.sql.gz"] = []byte("dump")
+	dst := newMemBucket()
+	dst.corrupt = true
+
+	_, err := copyObjects(ctx, src, dst, objectstore.ProviderTypeS3, "backups", "dr")
+	c.Assert(err, check.ErrorMatches, ".*Checksum of the copy of backups/dump.sql.gz doesn't match.*")
+	// The corrupted copy is deleted
+	c.Assert(dst.objects, check.HasLen, 0)
+}
+
+func (s *CopySuite) TestCopyObjectsNotFound(c *check.C) {
+	_, err := copyObjects(context.Background(), newMemBucket(), newMemBucket(), objectstore.ProviderTypeS3, "backups", "dr")
+	c.Assert(err, check.ErrorMatches, ".*Failed to find objects to copy.*")
+}
@@ -111,34 +111,32 @@ func readData(ctx context.Context, pType objectstore.ProviderType, profile param
 }
 
 func writeData(ctx context.Context, pType objectstore.ProviderType, profile param.Profile, in io.Reader, path string) error {
-	var input io.Reader
-	var size int64
 	bucket, err := getBucket(ctx, pType, profile)
 	if err != nil {
 		return err
 	}
 
-	var r io.Reader
-	var n int64
+	if err := putData(ctx, pType, bucket, in, path, nil); err != nil {
+		return errkit.Wrap(err, fmt.Sprintf("failed to write contents to bucket '%s'", profile.Location.Bucket))
+	}
+
+	return nil
+}
+
+// putData writes the data from `in` to the object at path. Azure needs the
+// size of the data to switch to multipart upload.
+func putData(ctx context.Context, pType objectstore.ProviderType, dir objectstore.Directory, in io.Reader, path string, tags map[string]string) error {
+	var size int64
 	if pType == objectstore.ProviderTypeAzure {
 		// Switch to multipart upload based on data size
-		r, n, err = readerSize(in, buffSize)
+		r, n, err := readerSize(in, buffSize)
 		if err != nil {
 			return err
 		}
-
-		input = r
-		size = int64(n)
-	} else {
-		input = in
-		size = 0
-	}
-
-	if err := bucket.Put(ctx, path, input, size, nil); err != nil {
-		return errkit.Wrap(err, fmt.Sprintf("failed to write contents to bucket '%s'", profile.Location.Bucket))
+		in = r
+		size = n
 	}
-
-	return nil
+	return dir.Put(ctx, path, in, size, tags)
 }
 
 // readerSize checks if data size is greater than buffSize i.e the max size of an object that can be Put to Azure container in a single request
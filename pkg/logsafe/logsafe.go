@@ -18,6 +18,7 @@
 package logsafe
 
 import (
+	"sort"
 	"strings"
 )
 
@@ -127,6 +128,21 @@ func (c Cmd) Argv() []string {
 	return argv
 }
 
+// Redact replaces every occurrence of the secrets in s with the redaction
+// marker. Empty secrets are ignored.
+func Redact(s string, secrets ...string) string {
+	// Replace longer secrets first, so that a secret that contains another
+	// one is redacted as a whole
+	secrets = append([]string(nil), secrets...)
+	sort.SliceStable(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, argRedacted)
+		}
+	}
+	return s
+}
+
 // arg holds the value of a given token, and whether or not it is
 // considered safe to log.
 type arg struct {
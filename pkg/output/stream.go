@@ -190,8 +190,26 @@ func checkSplitSeparator(line []byte) (splitSeparator int, separatorSuffix []byt
 	return -1, nil
 }
 
+// redactKey is the context key of the function that redacts the logged lines.
+type redactKey struct{}
+
+// WithRedaction returns a context with which the lines logged from pod output
+// are passed through redact, e.g. to remove sensitive values.
+func WithRedaction(ctx context.Context, redact func(string) string) context.Context {
+	return context.WithValue(ctx, redactKey{}, redact)
+}
+
+// RedactWithCtx passes s through the redaction function of the context, if
+// there is one.
+func RedactWithCtx(ctx context.Context, s string) string {
+	if redact, ok := ctx.Value(redactKey{}).(func(string) string); ok {
+		return redact(s)
+	}
+	return s
+}
+
 func logOutput(ctx context.Context, out []byte) {
-	log.WithContext(ctx).Print("", field.M{"Pod_Out": string(out)})
+	log.WithContext(ctx).Print("", field.M{"Pod_Out": RedactWithCtx(ctx, string(out))})
 }
 
 // State machine
@@ -203,11 +221,21 @@ func logOutput(ctx context.Context, out []byte) {
 // output state -> init state : on reaching \n: parse output json from output buffer and clean the buffer
 
 func LogAndParse(ctx context.Context, r io.ReadCloser) (map[string]interface{}, error) {
+	return LogAndParseRedacted(ctx, r, nil)
+}
+
+// LogAndParseRedacted is like LogAndParse, but the logged lines are passed
+// through redact, e.g. to remove sensitive values. The outputs aren't
+// redacted.
+func LogAndParseRedacted(ctx context.Context, r io.ReadCloser, redact func(string) string) (map[string]interface{}, error) {
+	if redact != nil {
+		ctx = WithRedaction(ctx, redact)
+	}
 	out := make(map[string]interface{})
 	err := splitLines(ctx, r, func(ctx context.Context, outputContent []byte) error {
 		outputContent = bytes.TrimSpace(outputContent)
 		if len(outputContent) != 0 {
-			log.WithContext(ctx).Print("", field.M{"Pod_Out": string(outputContent)})
+			logOutput(ctx, outputContent)
 			op, err := UnmarshalOutput(outputContent)
 			if err != nil {
 				return err
@@ -19,6 +19,7 @@ import (
 	"io"
 	"log"
 	"math/rand"
+	"strings"
 	"time"
 
 	"gopkg.in/check.v1"
@@ -264,3 +265,17 @@ func (s *OutputTestSuite) TestHugeStreamsWithHugePhaseOutputWithoutNewlineDelimi
 	c.Check(len(m), check.Equals, 1)
 	c.Check(m[cases[0].key], check.Equals, string(cases[0].value))
 }
+
+func (s *OutputTestSuite) TestLogAndParseRedacted(c *check.C) {
+	in := "logging in with s3cr3t\n" + output.PhaseOpString + ` {"key":"token","value":"s3cr3t"}` + "\n"
+	var logged []string
+	redact := func(line string) string {
+		logged = append(logged, line)
+		return strings.ReplaceAll(line, "s3cr3t", "<****>")
+	}
+	m, err := output.LogAndParseRedacted(context.TODO(), io.NopCloser(strings.NewReader(in)), redact)
+	c.Assert(err, check.IsNil)
+	// The logged lines are redacted, but the outputs aren't.
+	c.Assert(logged, check.HasLen, 2)
+	c.Assert(m, check.DeepEquals, map[string]interface{}{"token": "s3cr3t"})
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package param
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/kanisterio/errkit"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kanisterio/kanister/pkg/jsonpath"
+	"github.com/kanisterio/kanister/pkg/kube"
+)
+
+const (
+	// LookupAllowListEnvName is the env var used to override the resources
+	// that can be read by the `lookup` template functions. It's a comma
+	// separated list of `resource[.group][@namespace]` entries, e.g.
+	// `services,storageclasses.storage.k8s.io,configmaps@app-ns`.
+	LookupAllowListEnvName = "KANISTER_TEMPLATE_LOOKUP_ALLOWLIST"
+
+	lookupFuncName         = "lookup"
+	lookupJsonpathFuncName = "lookupJsonpath"
+)
+
+// LookupRule allows reading resources of the given group and resource using
+// the `lookup` template functions. If Namespaces is empty, objects from any
+// namespace can be read.
+type LookupRule struct {
+	Group      string
+	Resource   string
+	Namespaces []string
+}
+
+// LookupAllowList is the set of rules the `lookup` template functions are
+// scoped by. A request is allowed if any of the rules match it.
+type LookupAllowList []LookupRule
+
+// DefaultLookupAllowList is used when LookupAllowListEnvName is not set.
+// Secrets are intentionally not part of it.
+var DefaultLookupAllowList = LookupAllowList{
+	{Group: "", Resource: "services"},
+	{Group: "", Resource: "configmaps"},
+	{Group: "", Resource: "persistentvolumeclaims"},
+	{Group: "", Resource: "persistentvolumes"},
+	{Group: "storage.k8s.io", Resource: "storageclasses"},
+	{Group: "apps", Resource: "deployments"},
+	{Group: "apps", Resource: "statefulsets"},
+	{Group: "apps", Resource: "daemonsets"},
+}
+
+// Allows returns true if any of the rules allows reading the resource from
+// the namespace.
+func (l LookupAllowList) Allows(gvr schema.GroupVersionResource, namespace string) bool {
+	for _, r := range l {
+		if r.Group != gvr.Group || r.Resource != gvr.Resource {
+			continue
+		}
+		if len(r.Namespaces) == 0 {
+			return true
+		}
+		for _, ns := range r.Namespaces {
+			if ns == namespace {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ParseLookupAllowList parses a comma separated list of
+// `resource[.group][@namespace]` entries.
+func ParseLookupAllowList(s string) (LookupAllowList, error) {
+	var l LookupAllowList
+	for _, e := range strings.Split(s, ",") {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		var namespaces []string
+		if i := strings.Index(e, "@"); i != -1 {
+			if e[i+1:] == "" {
+				return nil, errkit.New(fmt.Sprintf("Invalid lookup allowlist entry %q, namespace cannot be empty", e))
+			}
+			namespaces = []string{e[i+1:]}
+			e = e[:i]
+		}
+		resource, group, _ := strings.Cut(e, ".")
+		if resource == "" {
+			return nil, errkit.New(fmt.Sprintf("Invalid lookup allowlist entry %q, resource cannot be empty", e))
+		}
+		l = append(l, LookupRule{Group: group, Resource: resource, Namespaces: namespaces})
+	}
+	return l, nil
+}
+
+// lookupAllowListFromEnv returns the allowlist configured through
+// LookupAllowListEnvName or the DefaultLookupAllowList.
+func lookupAllowListFromEnv() (LookupAllowList, error) {
+	s, ok := os.LookupEnv(LookupAllowListEnvName)
+	if !ok {
+		return DefaultLookupAllowList, nil
+	}
+	return ParseLookupAllowList(s)
+}
+
+// lookup provides read-only access to Kubernetes objects for templates.
+type lookup struct {
+	ctx    context.Context
+	dynCli dynamic.Interface
+	allow  LookupAllowList
+}
+
+// EnableLookup makes the `lookup` and `lookupJsonpath` template functions
+// read objects through dynCli, scoped by the allowlist.
+func (tp *TemplateParams) EnableLookup(ctx context.Context, dynCli dynamic.Interface, allow LookupAllowList) {
+	tp.lookup = &lookup{ctx: ctx, dynCli: dynCli, allow: allow}
+}
+
+// lookupFuncMap returns the template functions backed by l. The functions are
+// always defined so that templates parse, but fail if lookup isn't enabled.
+func lookupFuncMap(l *lookup) template.FuncMap {
+	return template.FuncMap{
+		lookupFuncName:         l.object,
+		lookupJsonpathFuncName: l.jsonpath,
+	}
+}
+
+// object returns the unstructured content of the object. The resource is
+// specified as `group/version/resource`, or `version/resource` for the core
+// group.
+func (l *lookup) object(resource, namespace, name string) (map[string]interface{}, error) {
+	u, err := l.fetch(lookupFuncName, resource, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return u.UnstructuredContent(), nil
+}
+
+// jsonpath evaluates the jsonpath expression, e.g. `{.spec.clusterIP}`, on
+// the object.
+func (l *lookup) jsonpath(resource, namespace, name, path string) (string, error) {
+	u, err := l.fetch(lookupJsonpathFuncName, resource, namespace, name)
+	if err != nil {
+		return "", err
+	}
+	v, err := jsonpath.ResolveJsonpathToString(u, path)
+	if err != nil {
+		return "", errkit.Wrap(err, "Failed to resolve jsonpath", "jsonpath", path)
+	}
+	return v, nil
+}
+
+func (l *lookup) fetch(funcName, resource, namespace, name string) (runtime.Unstructured, error) {
+	if l == nil {
+		return nil, errkit.New(fmt.Sprintf("Template function %s is not enabled", funcName))
+	}
+	gvr, err := parseLookupResource(resource)
+	if err != nil {
+		return nil, err
+	}
+	if !l.allow.Allows(gvr, namespace) {
+		return nil, errkit.New(fmt.Sprintf("Lookup of %s in namespace %q is not allowed", gvr.GroupResource(), namespace))
+	}
+	u, err := kube.FetchUnstructuredObjectWithCli(l.ctx, l.dynCli, gvr, namespace, name)
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to lookup object", "resource", resource, "namespace", namespace, "name", name)
+	}
+	return u, nil
+}
+
+func parseLookupResource(s string) (schema.GroupVersionResource, error) {
+	parts := strings.Split(s, "/")
+	switch len(parts) {
+	case 2:
+		return schema.GroupVersionResource{Version: parts[0], Resource: parts[1]}, nil
+	case 3:
+		return schema.GroupVersionResource{Group: parts[0], Version: parts[1], Resource: parts[2]}, nil
+	}
+	return schema.GroupVersionResource{}, errkit.New(fmt.Sprintf("Expected group/version/resource or version/resource for the core group. Got %s", s))
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package param
+
+import (
+	"context"
+
+	"gopkg.in/check.v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakedyncli "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+type LookupSuite struct{}
+
+var _ = check.Suite(&LookupSuite{})
+
+func (s *LookupSuite) TestLookup(c *check.C) {
+	dynCli := fakedyncli.NewSimpleDynamicClient(scheme.Scheme,
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "app"},
+			Spec:       corev1.ServiceSpec{ClusterIP: "10.0.0.1"},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "app"},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "conf", Namespace: "other"},
+			Data:       map[string]string{"key": "value"},
+		},
+	)
+	allow := LookupAllowList{
+		{Resource: "services"},
+		{Resource: "configmaps", Namespaces: []string{"app"}},
+	}
+	tp := TemplateParams{}
+	tp.EnableLookup(context.Background(), dynCli, allow)
+
+	for _, tc := range []struct {
+		arg     string
+		out     string
+		checker check.Checker
+	}{
+		{
+			arg:     `{{ lookupJsonpath "v1/services" "app" "db" "{.spec.clusterIP}" }}`,
+			out:     "10.0.0.1",
+			checker: check.IsNil,
+		},
+		{
+			arg:     `{{ (lookup "v1/services" "app" "db").spec.clusterIP }}`,
+			out:     "10.0.0.1",
+			checker: check.IsNil,
+		},
+		{
+			// Secrets aren't allowed
+			arg:     `{{ lookupJsonpath "v1/secrets" "app" "creds" "{.data}" }}`,
+			checker: check.NotNil,
+		},
+		{
+			// ConfigMaps are only allowed in the app namespace
+			arg:     `{{ lookupJsonpath "v1/configmaps" "other" "conf" "{.data.key}" }}`,
+			checker: check.NotNil,
+		},
+		{
+			arg:     `{{ lookup "services" "app" "db" }}`,
+			checker: check.NotNil,
+		},
+		{
+			arg:     `{{ lookup "v1/services" "app" "missing" }}`,
+			checker: check.NotNil,
+		},
+	} {
+		out, err := renderStringArg(tc.arg, tp)
+		c.Assert(err, tc.checker, check.Commentf("%s", tc.arg))
+		if err == nil {
+			c.Assert(out, check.Equals, tc.out)
+		}
+	}
+}
+
+func (s *LookupSuite) TestLookupNotEnabled(c *check.C) {
+	_, err := renderStringArg(`{{ lookup "v1/services" "app" "db" }}`, TemplateParams{})
+	c.Assert(err, check.ErrorMatches, ".*Template function lookup is not enabled.*")
+}
+
+func (s *LookupSuite) TestParseLookupAllowList(c *check.C) {
+	l, err := ParseLookupAllowList("services, storageclasses.storage.k8s.io,configmaps@app-ns")
+	c.Assert(err, check.IsNil)
+	c.Assert(l, check.DeepEquals, LookupAllowList{
+		{Resource: "services"},
+		{Group: "storage.k8s.io", Resource: "storageclasses"},
+		{Resource: "configmaps", Namespaces: []string{"app-ns"}},
+	})
+
+	_, err = ParseLookupAllowList("configmaps@")
+	c.Assert(err, check.NotNil)
+	_, err = ParseLookupAllowList(".apps")
+	c.Assert(err, check.NotNil)
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package param
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/kanisterio/errkit"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/logsafe"
+)
+
+// resolveOptions merges the literal options with the options set from the
+// keys of ConfigMaps and Secrets. It also returns the values that were set from
+// Secrets.
+func resolveOptions(ctx context.Context, cli kubernetes.Interface, namespace string, literal map[string]string, from map[string]crv1alpha1.OptionValueSource) (map[string]string, []string, error) {
+	if len(from) == 0 {
+		return literal, nil, nil
+	}
+	options := make(map[string]string, len(literal)+len(from))
+	var sensitive []string
+	for name, src := range from {
+		if _, ok := literal[name]; ok {
+			continue
+		}
+		v, found, err := optionValue(ctx, cli, namespace, src)
+		if err != nil {
+			return nil, nil, errkit.Wrap(err, "Failed to resolve option", "option", name)
+		}
+		if !found {
+			continue
+		}
+		options[name] = v
+		if src.SecretKeyRef != nil && v != "" {
+			sensitive = append(sensitive, v)
+		}
+	}
+	for name, v := range literal {
+		options[name] = v
+	}
+	return options, sensitive, nil
+}
+
+// optionValue returns the value of the key selected by src. found is false
+// if an optional key doesn't exist.
+func optionValue(ctx context.Context, cli kubernetes.Interface, namespace string, src crv1alpha1.OptionValueSource) (value string, found bool, err error) {
+	if (src.ConfigMapKeyRef == nil) == (src.SecretKeyRef == nil) {
+		return "", false, errkit.New("Exactly one of configMapKeyRef and secretKeyRef must be set")
+	}
+	sel := src.ConfigMapKeyRef
+	if sel == nil {
+		sel = src.SecretKeyRef
+	}
+	if sel.Namespace != "" {
+		namespace = sel.Namespace
+	}
+	var data map[string]string
+	switch {
+	case src.ConfigMapKeyRef != nil:
+		cm, err := cli.CoreV1().ConfigMaps(namespace).Get(ctx, sel.Name, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) && sel.Optional {
+				return "", false, nil
+			}
+			return "", false, errkit.Wrap(err, "Failed to get ConfigMap", "namespace", namespace, "name", sel.Name)
+		}
+		data = cm.Data
+	default:
+		s, err := cli.CoreV1().Secrets(namespace).Get(ctx, sel.Name, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) && sel.Optional {
+				return "", false, nil
+			}
+			return "", false, errkit.Wrap(err, "Failed to get Secret", "namespace", namespace, "name", sel.Name)
+		}
+		data = make(map[string]string, len(s.Data)+len(s.StringData))
+		for k, v := range s.Data {
+			data[k] = string(v)
+		}
+		for k, v := range s.StringData {
+			data[k] = v
+		}
+	}
+	v, ok := data[sel.Key]
+	if !ok && !sel.Optional {
+		return "", false, errkit.New("Key not found", "namespace", namespace, "name", sel.Name, "key", sel.Key)
+	}
+	return v, ok, nil
+}
+
+// Redact replaces the values of the options that were set from Secrets in s,
+// so that s can be logged and recorded in events.
+func (tp TemplateParams) Redact(s string) string {
+	return logsafe.Redact(s, tp.sensitive...)
+}
+
+// RedactOutput returns a copy of the phase output in which the values of the
+// options that were set from Secrets are redacted, so that it can be recorded
+// in the ActionSet status.
+func (tp TemplateParams) RedactOutput(output map[string]interface{}) map[string]interface{} {
+	if output == nil || len(tp.sensitive) == 0 {
+		return output
+	}
+	redacted := make(map[string]interface{}, len(output))
+	for k, v := range output {
+		redacted[k] = tp.redactValue(v)
+	}
+	return redacted
+}
+
+func (tp TemplateParams) redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case nil, bool:
+		return v
+	case string:
+		return tp.Redact(v)
+	case map[string]interface{}:
+		return tp.RedactOutput(v)
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, e := range v {
+			redacted[i] = tp.redactValue(e)
+		}
+		return redacted
+	}
+	// Other values are redacted in their JSON representation, which is how
+	// they are stored in the status.
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	s := tp.Redact(string(data))
+	if s == string(data) {
+		return v
+	}
+	var redacted interface{}
+	if err := json.Unmarshal([]byte(s), &redacted); err != nil {
+		return s
+	}
+	return redacted
+}
@@ -47,6 +47,10 @@ type TemplateParams struct {
 	StatefulSet      *StatefulSetParams
 	DeploymentConfig *DeploymentConfigParams
 	Deployment       *DeploymentParams
+	DaemonSet        *DaemonSetParams
+	ReplicaSet       *ReplicaSetParams
+	Job              *JobParams
+	CronJob          *CronJobParams
 	PVC              *PVCParams
 	Namespace        *NamespaceParams
 	ArtifactsIn      map[string]crv1alpha1.Artifact
@@ -62,6 +66,18 @@ type TemplateParams struct {
 	PodOverride      crv1alpha1.JSONMap
 	PodAnnotations   map[string]string
 	PodLabels        map[string]string
+	// Action has the details of the running action and whether it failed.
+	Action *ActionParams
+	// Item and Index are the item and its index in the list of a phase that
+	// runs for each item of a list.
+	Item  interface{}
+	Index int
+
+	// lookup backs the `lookup` template functions. It's unexported so
+	// that it isn't accessible from templates.
+	lookup *lookup
+	// sensitive are the values of the options that were set from Secrets.
+	sensitive []string
 }
 
 // DeploymentConfigParams are params for deploymentconfig, will be used if working on open shift cluster
@@ -92,6 +108,43 @@ type DeploymentParams struct {
 	PersistentVolumeClaims map[string]map[string]string
 }
 
+// DaemonSetParams are params for daemon sets
+type DaemonSetParams struct {
+	Name                   string
+	Namespace              string
+	Pods                   []string
+	Containers             [][]string
+	PersistentVolumeClaims map[string]map[string]string
+}
+
+// ReplicaSetParams are params for replica sets
+type ReplicaSetParams struct {
+	Name                   string
+	Namespace              string
+	Pods                   []string
+	Containers             [][]string
+	PersistentVolumeClaims map[string]map[string]string
+}
+
+// JobParams are params for jobs
+type JobParams struct {
+	Name                   string
+	Namespace              string
+	Pods                   []string
+	Containers             [][]string
+	PersistentVolumeClaims map[string]map[string]string
+}
+
+// CronJobParams are params for cron jobs. Pods are the pods of the jobs
+// that were spawned by the cron job and still exist.
+type CronJobParams struct {
+	Name                   string
+	Namespace              string
+	Pods                   []string
+	Containers             [][]string
+	PersistentVolumeClaims map[string]map[string]string
+}
+
 // PVCParams are params for persistent volume claims
 type PVCParams struct {
 	Name      string
@@ -142,6 +195,19 @@ type KopiaServerCreds struct {
 	ConnectOptions map[string]int
 }
 
+// ActionParams are the details of the action that is being run.
+// Failed, FailedPhase and Error are set before the DeferPhase is run if a
+// phase of the action failed.
+type ActionParams struct {
+	Name        string
+	ActionSet   string
+	Namespace   string
+	Blueprint   string
+	Failed      bool
+	FailedPhase string
+	Error       string
+}
+
 // Phase represents a Blueprint phase and contains the phase output
 type Phase struct {
 	Secrets    map[string]corev1.Secret
@@ -153,6 +219,10 @@ const (
 	DeploymentKind       = "deployment"
 	StatefulSetKind      = "statefulset"
 	DeploymentConfigKind = "deploymentconfig"
+	DaemonSetKind        = "daemonset"
+	ReplicaSetKind       = "replicaset"
+	JobKind              = "job"
+	CronJobKind          = "cronjob"
 	PVCKind              = "pvc"
 	NamespaceKind        = "namespace"
 	SecretKind           = "secret"
@@ -174,6 +244,10 @@ func New(ctx context.Context, cli kubernetes.Interface, dynCli dynamic.Interface
 	if err != nil {
 		return nil, err
 	}
+	options, sensitive, err := resolveOptions(ctx, cli, as.Object.Namespace, as.Options, as.OptionsFrom)
+	if err != nil {
+		return nil, err
+	}
 	now := time.Now().UTC()
 	tp := TemplateParams{
 		ArtifactsIn:    as.Artifacts,
@@ -181,13 +255,19 @@ func New(ctx context.Context, cli kubernetes.Interface, dynCli dynamic.Interface
 		Secrets:        secrets,
 		Profile:        prof,
 		Time:           now.Format(timeFormat),
-		Options:        as.Options,
+		Options:        options,
 		PodOverride:    as.PodOverride,
 		PodAnnotations: as.PodAnnotations,
 		PodLabels:      as.PodLabels,
 		DeferPhase:     &Phase{},
 		Phases:         make(map[string]*Phase),
+		sensitive:      sensitive,
+	}
+	allow, err := lookupAllowListFromEnv()
+	if err != nil {
+		return nil, err
 	}
+	tp.EnableLookup(ctx, dynCli, allow)
 	var gvr schema.GroupVersionResource
 	namespace := as.Object.Namespace
 	switch strings.ToLower(as.Object.Kind) {
@@ -212,6 +292,34 @@ func New(ctx context.Context, cli kubernetes.Interface, dynCli dynamic.Interface
 		}
 		tp.Deployment = dp
 		gvr = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	case DaemonSetKind:
+		dsp, err := fetchDaemonSetParams(ctx, cli, as.Object.Namespace, as.Object.Name)
+		if err != nil {
+			return nil, err
+		}
+		tp.DaemonSet = dsp
+		gvr = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "daemonsets"}
+	case ReplicaSetKind:
+		rsp, err := fetchReplicaSetParams(ctx, cli, as.Object.Namespace, as.Object.Name)
+		if err != nil {
+			return nil, err
+		}
+		tp.ReplicaSet = rsp
+		gvr = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "replicasets"}
+	case JobKind:
+		jp, err := fetchJobParams(ctx, cli, as.Object.Namespace, as.Object.Name)
+		if err != nil {
+			return nil, err
+		}
+		tp.Job = jp
+		gvr = schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}
+	case CronJobKind:
+		cjp, err := fetchCronJobParams(ctx, cli, as.Object.Namespace, as.Object.Name)
+		if err != nil {
+			return nil, err
+		}
+		tp.CronJob = cjp
+		gvr = schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "cronjobs"}
 	case PVCKind:
 		pp, err := fetchPVCParams(ctx, cli, as.Object.Namespace, as.Object.Name)
 		if err != nil {
@@ -240,6 +348,12 @@ func New(ctx context.Context, cli kubernetes.Interface, dynCli dynamic.Interface
 	return &tp, nil
 }
 
+// FetchProfile fetches the Profile with its credentials, e.g. to use another
+// Profile than the one of the ActionSet.
+func FetchProfile(ctx context.Context, cli kubernetes.Interface, crCli versioned.Interface, ref crv1alpha1.ObjectReference) (*Profile, error) {
+	return fetchProfile(ctx, cli, crCli, &ref)
+}
+
 func fetchProfile(ctx context.Context, cli kubernetes.Interface, crCli versioned.Interface, ref *crv1alpha1.ObjectReference) (*Profile, error) {
 	if ref == nil {
 		log.Debug().Print("Executing the action without a profile")
@@ -448,6 +562,106 @@ func fetchDeploymentParams(ctx context.Context, cli kubernetes.Interface, namesp
 	return dp, nil
 }
 
+func fetchDaemonSetParams(ctx context.Context, cli kubernetes.Interface, namespace, name string) (*DaemonSetParams, error) {
+	ds, err := cli.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errkit.WithStack(err)
+	}
+	pods, _, err := kube.FetchPods(cli, namespace, ds.UID)
+	if err != nil {
+		return nil, err
+	}
+	podNames, containers, pvcs := podParams(pods, kube.PodTemplateVolumes(ds.Spec.Template))
+	return &DaemonSetParams{
+		Name:                   name,
+		Namespace:              namespace,
+		Pods:                   podNames,
+		Containers:             containers,
+		PersistentVolumeClaims: pvcs,
+	}, nil
+}
+
+func fetchReplicaSetParams(ctx context.Context, cli kubernetes.Interface, namespace, name string) (*ReplicaSetParams, error) {
+	rs, err := cli.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errkit.WithStack(err)
+	}
+	pods, _, err := kube.FetchPods(cli, namespace, rs.UID)
+	if err != nil {
+		return nil, err
+	}
+	podNames, containers, pvcs := podParams(pods, kube.PodTemplateVolumes(rs.Spec.Template))
+	return &ReplicaSetParams{
+		Name:                   name,
+		Namespace:              namespace,
+		Pods:                   podNames,
+		Containers:             containers,
+		PersistentVolumeClaims: pvcs,
+	}, nil
+}
+
+func fetchJobParams(ctx context.Context, cli kubernetes.Interface, namespace, name string) (*JobParams, error) {
+	j, err := cli.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errkit.WithStack(err)
+	}
+	pods, _, err := kube.FetchPods(cli, namespace, j.UID)
+	if err != nil {
+		return nil, err
+	}
+	podNames, containers, pvcs := podParams(pods, kube.PodTemplateVolumes(j.Spec.Template))
+	return &JobParams{
+		Name:                   name,
+		Namespace:              namespace,
+		Pods:                   podNames,
+		Containers:             containers,
+		PersistentVolumeClaims: pvcs,
+	}, nil
+}
+
+func fetchCronJobParams(ctx context.Context, cli kubernetes.Interface, namespace, name string) (*CronJobParams, error) {
+	cj, err := cli.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errkit.WithStack(err)
+	}
+	jobs, err := kube.FetchJobs(cli, namespace, cj.UID)
+	if err != nil {
+		return nil, err
+	}
+	var pods []corev1.Pod
+	for _, j := range jobs {
+		jobPods, _, err := kube.FetchPods(cli, namespace, j.UID)
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, jobPods...)
+	}
+	podNames, containers, pvcs := podParams(pods, kube.PodTemplateVolumes(cj.Spec.JobTemplate.Spec.Template))
+	return &CronJobParams{
+		Name:                   name,
+		Namespace:              namespace,
+		Pods:                   podNames,
+		Containers:             containers,
+		PersistentVolumeClaims: pvcs,
+	}, nil
+}
+
+// podParams returns the pod names, container names and the PVC to mount path
+// mapping for the given pods in the shape used by the workload params.
+func podParams(pods []corev1.Pod, volToPvc map[string]string) ([]string, [][]string, map[string]map[string]string) {
+	podNames := []string{}
+	containers := [][]string{}
+	pvcs := make(map[string]map[string]string)
+	for _, p := range pods {
+		podNames = append(podNames, p.Name)
+		containers = append(containers, containerNames(p))
+		if pvcToMountPath := volumes(p, volToPvc); len(pvcToMountPath) > 0 {
+			pvcs[p.Name] = pvcToMountPath
+		}
+	}
+	return podNames, containers, pvcs
+}
+
 func containerNames(pod corev1.Pod) []string {
 	cs := make([]string, 0, len(pod.Status.ContainerStatuses))
 	for _, c := range pod.Status.ContainerStatuses {
@@ -560,6 +774,17 @@ func GetPhaseParams(ctx context.Context, cli kubernetes.Interface, objects map[s
 	}, nil
 }
 
+// UpdateActionFailureParams records in the TemplateParams that the action
+// failed while running the phase phaseName.
+func UpdateActionFailureParams(ctx context.Context, tp *TemplateParams, phaseName string, err error) {
+	if tp.Action == nil {
+		tp.Action = &ActionParams{}
+	}
+	tp.Action.Failed = true
+	tp.Action.FailedPhase = phaseName
+	tp.Action.Error = tp.Redact(err.Error())
+}
+
 func InitDeferPhaseParams(ctx context.Context, cli kubernetes.Interface, tp *TemplateParams, objects map[string]crv1alpha1.ObjectReference) error {
 	phase, err := GetPhaseParams(ctx, cli, objects)
 	if err != nil {
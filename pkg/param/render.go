@@ -119,7 +119,7 @@ func RenderArtifacts(arts map[string]crv1alpha1.Artifact, tp TemplateParams) (ma
 }
 
 func renderStringArg(arg string, tp TemplateParams) (string, error) {
-	t, err := template.New("config").Option("missingkey=error").Funcs(ksprig.TxtFuncMap()).Parse(arg)
+	t, err := template.New("config").Option("missingkey=error").Funcs(ksprig.TxtFuncMap()).Funcs(lookupFuncMap(tp.lookup)).Parse(arg)
 	if err != nil {
 		return "", errkit.WithStack(err)
 	}
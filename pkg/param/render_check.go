@@ -0,0 +1,388 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package param
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/kanisterio/kanister/pkg/ksprig"
+)
+
+// UndefinedReference is a template reference that can't be resolved
+// against the TemplateParams.
+type UndefinedReference struct {
+	// Action and Phase are set when the reference is found by walking the
+	// phases of a blueprint action.
+	Action string
+	Phase  string
+	// Arg is the path of the argument within the phase args, e.g.
+	// `command[2]` or `podOverride.containers[0].image`.
+	Arg string
+	// Position is the line:column of the reference in the template.
+	Position string
+	// Reference is the referenced field chain, e.g. `.Phases.backup.Output.id`.
+	Reference string
+	// Reason describes why the reference can't be resolved.
+	Reason string
+}
+
+func (r UndefinedReference) String() string {
+	var loc []string
+	if r.Action != "" {
+		loc = append(loc, "action "+r.Action)
+	}
+	if r.Phase != "" {
+		loc = append(loc, "phase "+r.Phase)
+	}
+	loc = append(loc, fmt.Sprintf("arg %s (%s)", r.Arg, r.Position))
+	return fmt.Sprintf("%s: %s %s", strings.Join(loc, ", "), r.Reference, r.Reason)
+}
+
+// UndefinedReferencesError reports all the undefined references that were
+// found while rendering templates.
+type UndefinedReferencesError struct {
+	References []UndefinedReference
+}
+
+func (e *UndefinedReferencesError) Error() string {
+	msgs := make([]string, 0, len(e.References))
+	for _, r := range e.References {
+		msgs = append(msgs, r.String())
+	}
+	return fmt.Sprintf("Failed to render templates, found %d undefined references: %s", len(e.References), strings.Join(msgs, "; "))
+}
+
+// CheckOptions configures how CheckArgs resolves references.
+type CheckOptions struct {
+	// Offline checks references against the shape of TemplateParams. Values
+	// that are only known at execution time, like the keys of Options or a
+	// nil StatefulSet, are not reported.
+	Offline bool
+	// Keys limits the keys of the map at a path, e.g. `ArtifactsIn` to the
+	// input artifact names of an action. Paths use `.` separated field
+	// names without the leading `.`.
+	Keys map[string][]string
+	// Pending lists the paths whose values are populated later during
+	// execution, e.g. `Phases.backup.Output`. References under them are not
+	// reported.
+	Pending []string
+}
+
+// RenderArgsStrict renders the arguments like RenderArgs. If any of the
+// templates reference undefined values, it returns an
+// UndefinedReferencesError with all of them instead of the first one.
+func RenderArgsStrict(args map[string]interface{}, tp TemplateParams) (map[string]interface{}, error) {
+	if refs := CheckArgs(args, tp, CheckOptions{}); len(refs) > 0 {
+		return nil, &UndefinedReferencesError{References: refs}
+	}
+	return RenderArgs(args, tp)
+}
+
+// CheckArgs walks all the templates in the arguments and returns the
+// references that can't be resolved against the TemplateParams. Templates
+// that fail to parse are reported as well.
+func CheckArgs(args map[string]interface{}, tp TemplateParams, opts CheckOptions) []UndefinedReference {
+	var refs []UndefinedReference
+	for _, n := range sortedKeys(args) {
+		refs = append(refs, checkArg(n, args[n], tp, opts)...)
+	}
+	return refs
+}
+
+func checkArg(path string, arg interface{}, tp TemplateParams, opts CheckOptions) []UndefinedReference {
+	val := reflect.ValueOf(arg)
+	if !val.IsValid() {
+		return nil
+	}
+	var refs []UndefinedReference
+	switch val.Kind() {
+	case reflect.String:
+		refs = checkTemplate(path, val.String(), tp, opts)
+	case reflect.Slice:
+		for i := 0; i < val.Len(); i++ {
+			refs = append(refs, checkArg(fmt.Sprintf("%s[%d]", path, i), val.Index(i).Interface(), tp, opts)...)
+		}
+	case reflect.Map:
+		keys := val.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		for _, k := range keys {
+			refs = append(refs, checkArg(fmt.Sprintf("%s.%v", path, k), val.MapIndex(k).Interface(), tp, opts)...)
+		}
+	}
+	return refs
+}
+
+func checkTemplate(arg, text string, tp TemplateParams, opts CheckOptions) []UndefinedReference {
+	t, err := template.New(arg).Funcs(ksprig.TxtFuncMap()).Funcs(lookupFuncMap(tp.lookup)).Parse(text)
+	if err != nil {
+		return []UndefinedReference{{Arg: arg, Position: "-", Reference: text, Reason: err.Error()}}
+	}
+	if t.Tree == nil {
+		return nil
+	}
+	c := &checker{tree: t.Tree, arg: arg, tp: reflect.ValueOf(tp), opts: opts}
+	c.walk(t.Tree.Root, true)
+	return c.refs
+}
+
+// checker walks a template parse tree and resolves the field chains that
+// are rooted at the TemplateParams.
+type checker struct {
+	tree *parse.Tree
+	arg  string
+	tp   reflect.Value
+	opts CheckOptions
+	refs []UndefinedReference
+	// guarded is set within the branches of `if`, `with` and `range`
+	// blocks, which may not be executed.
+	guarded bool
+}
+
+// walk visits the node. rootDot is false within `range` and `with` blocks,
+// where `.` no longer refers to the TemplateParams.
+func (c *checker) walk(node parse.Node, rootDot bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, ln := range n.Nodes {
+			c.walk(ln, rootDot)
+		}
+	case *parse.ActionNode:
+		c.walk(n.Pipe, rootDot)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			c.walk(cmd, rootDot)
+		}
+	case *parse.CommandNode:
+		for _, a := range n.Args {
+			c.walk(a, rootDot)
+		}
+	case *parse.ChainNode:
+		c.walk(n.Node, rootDot)
+	case *parse.FieldNode:
+		if rootDot {
+			c.resolve(n, n.Ident)
+		}
+	case *parse.VariableNode:
+		// Only `$` is known to refer to the TemplateParams
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			c.resolve(n, n.Ident[1:])
+		}
+	case *parse.IfNode:
+		c.walk(n.Pipe, rootDot)
+		c.walkGuarded(n.List, rootDot)
+		c.walkGuarded(n.ElseList, rootDot)
+	case *parse.RangeNode:
+		c.walk(n.Pipe, rootDot)
+		c.walkGuarded(n.List, false)
+		c.walkGuarded(n.ElseList, rootDot)
+	case *parse.WithNode:
+		c.walk(n.Pipe, rootDot)
+		c.walkGuarded(n.List, false)
+		c.walkGuarded(n.ElseList, rootDot)
+	}
+}
+
+// walkGuarded visits a branch of a block. References in the branch are only
+// checked against the types of the TemplateParams, since the guard of the
+// block may keep them from being evaluated, e.g.
+// `{{ if .Deployment }}{{ .Deployment.Name }}{{ end }}`.
+func (c *checker) walkGuarded(node *parse.ListNode, rootDot bool) {
+	guarded := c.guarded
+	c.guarded = true
+	c.walk(node, rootDot)
+	c.guarded = guarded
+}
+
+func (c *checker) resolve(node parse.Node, path []string) {
+	tp, opts := c.tp, c.opts
+	if c.guarded {
+		tp = reflect.Zero(c.tp.Type())
+		opts.Offline = true
+	}
+	reason := resolvePath(tp, path, opts)
+	if reason == "" {
+		return
+	}
+	location, _ := c.tree.ErrorContext(node)
+	// location is formatted as name:line:col, the name is the arg path
+	position := strings.TrimPrefix(location, c.arg+":")
+	c.refs = append(c.refs, UndefinedReference{
+		Arg:       c.arg,
+		Position:  position,
+		Reference: "." + strings.Join(path, "."),
+		Reason:    reason,
+	})
+}
+
+// resolvePath follows the path from v and returns why it can't be resolved,
+// or an empty string if it can be. If v becomes invalid, the path is checked
+// against the types only.
+func resolvePath(v reflect.Value, path []string, opts CheckOptions) string {
+	t := v.Type()
+	for i, seg := range path {
+		prefix := strings.Join(path[:i], ".")
+		if keys, ok := opts.Keys[prefix]; ok && !contains(keys, seg) {
+			if len(keys) == 0 {
+				return fmt.Sprintf("is not defined, .%s has no keys", prefix)
+			}
+			return fmt.Sprintf("is not defined, %q is not one of [%s]", seg, strings.Join(keys, ", "))
+		}
+		if isPending(strings.Join(path[:i+1], "."), opts.Pending) {
+			return ""
+		}
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+			if !v.IsValid() {
+				continue
+			}
+			if v.IsNil() {
+				if !opts.Offline {
+					return fmt.Sprintf("is not defined, .%s is nil", prefix)
+				}
+				v = reflect.Value{}
+				continue
+			}
+			v = v.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Struct:
+			if hasMethod(t, seg) {
+				// The result of a method call isn't resolved
+				return ""
+			}
+			f, ok := t.FieldByName(seg)
+			if !ok || !f.IsExported() {
+				return fmt.Sprintf("is not defined, %s has no field %s", t.Name(), seg)
+			}
+			t = f.Type
+			if v.IsValid() {
+				fv, err := v.FieldByIndexErr(f.Index)
+				if err != nil {
+					return fmt.Sprintf("is not defined, %s", err)
+				}
+				v = fv
+			}
+		case reflect.Map:
+			if t.Key().Kind() != reflect.String {
+				return ""
+			}
+			_, keyed := opts.Keys[prefix]
+			t = t.Elem()
+			if !v.IsValid() {
+				if !keyed && opts.Offline {
+					return ""
+				}
+				continue
+			}
+			mv := v.MapIndex(reflect.ValueOf(seg).Convert(v.Type().Key()))
+			if !mv.IsValid() {
+				if opts.Offline {
+					if !keyed {
+						return ""
+					}
+					v = reflect.Value{}
+					continue
+				}
+				return fmt.Sprintf("is not defined, map has no entry for key %q", seg)
+			}
+			v = mv
+		case reflect.Interface:
+			if !v.IsValid() {
+				return ""
+			}
+			if v.IsNil() {
+				if opts.Offline {
+					return ""
+				}
+				return fmt.Sprintf("is not defined, .%s is nil", prefix)
+			}
+			// Resolve the dynamic value and process the segment again
+			return resolvePath(v.Elem(), path[i:], subOptions(opts, prefix))
+		default:
+			return fmt.Sprintf("is not defined, can't evaluate field %s in type %s", seg, t)
+		}
+	}
+	return ""
+}
+
+// subOptions rebases the paths in opts that are below prefix, so that they
+// can be used to resolve the rest of a path.
+func subOptions(opts CheckOptions, prefix string) CheckOptions {
+	sub := CheckOptions{Offline: opts.Offline, Keys: map[string][]string{}}
+	for p, keys := range opts.Keys {
+		if rel, ok := relativePath(p, prefix); ok {
+			sub.Keys[rel] = keys
+		}
+	}
+	for _, p := range opts.Pending {
+		if rel, ok := relativePath(p, prefix); ok {
+			sub.Pending = append(sub.Pending, rel)
+		}
+	}
+	return sub
+}
+
+func relativePath(path, prefix string) (string, bool) {
+	if path == prefix {
+		return "", true
+	}
+	return strings.CutPrefix(path, prefix+".")
+}
+
+func hasMethod(t reflect.Type, name string) bool {
+	if _, ok := t.MethodByName(name); ok {
+		return true
+	}
+	_, ok := reflect.PointerTo(t).MethodByName(name)
+	return ok
+}
+
+func isPending(prefix string, pending []string) bool {
+	for _, p := range pending {
+		if prefix == p || strings.HasPrefix(prefix, p+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
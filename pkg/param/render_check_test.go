@@ -0,0 +1,118 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package param
+
+import (
+	"gopkg.in/check.v1"
+	corev1 "k8s.io/api/core/v1"
+
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+)
+
+type RenderCheckSuite struct{}
+
+var _ = check.Suite(&RenderCheckSuite{})
+
+func (s *RenderCheckSuite) TestCheckArgs(c *check.C) {
+	tp := TemplateParams{
+		StatefulSet: &StatefulSetParams{Name: "ss", Pods: []string{"ss-0"}},
+		Options:     map[string]string{"db": "test"},
+		ConfigMaps: map[string]corev1.ConfigMap{
+			"location": {Data: map[string]string{"path": "/backup"}},
+		},
+		Phases: map[string]*Phase{
+			"backup": {Output: map[string]interface{}{"snapshotID": "abc", "nested": map[string]interface{}{"a": "b"}}},
+		},
+		Object: map[string]interface{}{"metadata": map[string]interface{}{"name": "obj"}},
+	}
+	args := map[string]interface{}{
+		"valid": []interface{}{
+			"{{ .StatefulSet.Name }} {{ index .StatefulSet.Pods 0 }} {{ .Options.db }}",
+			"{{ .ConfigMaps.location.Data.path }} {{ .ConfigMaps.location.GetName }}",
+			"{{ .Phases.backup.Output.snapshotID }} {{ .Phases.backup.Output.nested.a }}",
+			"{{ .Object.metadata.name }} {{ range .StatefulSet.Pods }}{{ .Anything }}{{ end }}",
+			"{{ $.Options.db }} {{ toDate \"2006-01-02\" .Time | date \"2006\" }}",
+		},
+		"command": []interface{}{
+			"echo",
+			"{{ .Deployment.Name }}",
+			"{{ .Options.db }} {{ .Options.missing }}",
+		},
+		"podOverride": map[string]interface{}{
+			"image": "{{ .Phases.backup.Output.snapshotId }}",
+			"name":  "{{ .StatefulSet.Nmae }}",
+		},
+		"phase":   "{{ .Phases.restore.Output.id }}",
+		"invalid": "{{ .Options.db ",
+	}
+	refs := CheckArgs(args, tp, CheckOptions{})
+	c.Assert(refs, check.HasLen, 6)
+	c.Assert(refs[0], check.DeepEquals, UndefinedReference{
+		Arg:       "command[1]",
+		Position:  "1:14",
+		Reference: ".Deployment.Name",
+		Reason:    "is not defined, .Deployment is nil",
+	})
+	c.Assert(refs[1].Arg, check.Equals, "command[2]")
+	c.Assert(refs[1].Position, check.Equals, "1:29")
+	c.Assert(refs[1].Reference, check.Equals, ".Options.missing")
+	c.Assert(refs[2].Arg, check.Equals, "invalid")
+	c.Assert(refs[3].Reference, check.Equals, ".Phases.restore.Output.id")
+	c.Assert(refs[4].Arg, check.Equals, "podOverride.image")
+	c.Assert(refs[4].Reason, check.Equals, `is not defined, map has no entry for key "snapshotId"`)
+	c.Assert(refs[5].Arg, check.Equals, "podOverride.name")
+	c.Assert(refs[5].Reason, check.Equals, "is not defined, StatefulSetParams has no field Nmae")
+
+	_, err := RenderArgsStrict(args, tp)
+	c.Assert(err, check.FitsTypeOf, &UndefinedReferencesError{})
+	c.Assert(err, check.ErrorMatches, "Failed to render templates, found 6 undefined references: .*")
+}
+
+func (s *RenderCheckSuite) TestCheckArgsOptions(c *check.C) {
+	args := map[string]interface{}{
+		"a": "{{ .ArtifactsIn.backupInfo.KeyValue.path }} {{ .ArtifactsIn.other.KeyValue.path }}",
+		"b": "{{ .Phases.backup.Output.id }} {{ .Options.anything }} {{ .StatefulSet.Name }}",
+		"c": "{{ .Profile.Location.Bucket }} {{ .Profile.Location.Buckett }}",
+	}
+	refs := CheckArgs(args, TemplateParams{}, CheckOptions{
+		Offline: true,
+		Keys:    map[string][]string{"ArtifactsIn": {"backupInfo"}},
+		Pending: []string{"Phases.backup.Output"},
+	})
+	c.Assert(refs, check.HasLen, 2)
+	c.Assert(refs[0].Reference, check.Equals, ".ArtifactsIn.other.KeyValue.path")
+	c.Assert(refs[0].Reason, check.Equals, `is not defined, "other" is not one of [backupInfo]`)
+	c.Assert(refs[1].Reference, check.Equals, ".Profile.Location.Buckett")
+
+	tp := TemplateParams{ArtifactsIn: map[string]crv1alpha1.Artifact{"backupInfo": {KeyValue: map[string]string{"path": "p"}}}}
+	refs = CheckArgs(map[string]interface{}{"a": "{{ .ArtifactsIn.backupInfo.KeyValue.path }}"}, tp, CheckOptions{})
+	c.Assert(refs, check.HasLen, 0)
+}
+
+func (s *RenderCheckSuite) TestCheckArgsGuarded(c *check.C) {
+	args := map[string]interface{}{
+		"a": "{{ if .Deployment }}{{ .Deployment.Name }}{{ else }}{{ .Options.missing }}{{ end }}",
+		"b": "{{ with .StatefulSet }}{{ .Name }}{{ else }}{{ $.Deployment.Name }}{{ end }}",
+		"c": "{{ if .Deployment }}{{ .Deployment.Nmae }}{{ end }}",
+		"d": "{{ .Deployment.Name }}",
+	}
+	refs := CheckArgs(args, TemplateParams{}, CheckOptions{})
+	c.Assert(refs, check.HasLen, 2)
+	// Field typos are reported within the branches
+	c.Assert(refs[0].Arg, check.Equals, "c")
+	c.Assert(refs[0].Reason, check.Equals, "is not defined, DeploymentParams has no field Nmae")
+	c.Assert(refs[1].Arg, check.Equals, "d")
+	c.Assert(refs[1].Reason, check.Equals, "is not defined, .Deployment is nil")
+}
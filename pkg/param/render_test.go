@@ -15,6 +15,8 @@
 package param
 
 import (
+	"context"
+	"errors"
 	"time"
 
 	"gopkg.in/check.v1"
@@ -136,6 +138,22 @@ func (s *RenderSuite) TestRender(c *check.C) {
 	}
 }
 
+func (s *RenderSuite) TestRenderActionFailure(c *check.C) {
+	tp := TemplateParams{
+		Action:    &ActionParams{Name: "backup", ActionSet: "backup-x7k2p"},
+		sensitive: []string{"s3cr3t"},
+	}
+	arg := "{{ .Action.Name }} {{ if .Action.Failed }}failed in {{ .Action.FailedPhase }}: {{ .Action.Error }}{{ else }}succeeded{{ end }}"
+	out, err := RenderArgs(map[string]interface{}{"arg": arg}, tp)
+	c.Assert(err, check.IsNil)
+	c.Assert(out["arg"], check.Equals, "backup succeeded")
+
+	UpdateActionFailureParams(context.Background(), &tp, "dump", errors.New("failed to log in with s3cr3t"))
+	out, err = RenderArgs(map[string]interface{}{"arg": arg}, tp)
+	c.Assert(err, check.IsNil)
+	c.Assert(out["arg"], check.Equals, "backup failed in dump: failed to log in with <****>")
+}
+
 func (s *RenderSuite) TestRenderObjects(c *check.C) {
 	tp := TemplateParams{
 		Time: time.Now().String(),
@@ -17,7 +17,9 @@ package kanister
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"sync/atomic"
 
 	"github.com/Masterminds/semver"
 	"github.com/kanisterio/errkit"
@@ -36,10 +38,17 @@ var skipRenderFuncs = map[string]bool{
 
 // Phase is an atomic unit of execution.
 type Phase struct {
-	name    string
-	args    map[string]interface{}
-	objects map[string]crv1alpha1.ObjectReference
-	f       Func
+	name        string
+	args        map[string]interface{}
+	objects     map[string]crv1alpha1.ObjectReference
+	f           Func
+	version     semver.Version
+	deprecation string
+	forEach     *crv1alpha1.ForEach
+	// itemsTotal and itemsDone track the progress of a phase that runs for
+	// each item of a list.
+	itemsTotal atomic.Int64
+	itemsDone  atomic.Int64
 }
 
 // Name returns the name of this phase.
@@ -47,8 +56,23 @@ func (p *Phase) Name() string {
 	return p.name
 }
 
+// Version returns the version of the function that was chosen for the
+// preferred version of the action.
+func (p *Phase) Version() string {
+	return p.version.Original()
+}
+
+// Deprecation returns the deprecation notice of the chosen version of the
+// function, or an empty string if that version is not deprecated.
+func (p *Phase) Deprecation() string {
+	return p.deprecation
+}
+
 // Progress return execution progress of the phase.
 func (p *Phase) Progress() (crv1alpha1.PhaseProgress, error) {
+	if p.forEach != nil {
+		return p.forEachProgress(), nil
+	}
 	return p.f.ExecutionProgress()
 }
 
@@ -57,42 +81,64 @@ func (p *Phase) Objects() map[string]crv1alpha1.ObjectReference {
 	return p.objects
 }
 
+// ForEach returns true if the phase runs once for each item of a list.
+func (p *Phase) ForEach() bool {
+	return p.forEach != nil
+}
+
 // Exec renders the argument templates in this Phase's Func and executes with
-// those arguments.
+// those arguments. A phase that runs for each item of a list returns the
+// list of the outputs for each item under ForEachOutputKey.
 func (p *Phase) Exec(ctx context.Context, bp crv1alpha1.Blueprint, action string, tp param.TemplateParams) (map[string]interface{}, error) {
+	if p.forEach != nil && p.args == nil {
+		return p.execForEach(ctx, bp, action, tp)
+	}
 	if p.args == nil {
-		// Get the action from Blueprint
-		a, ok := bp.Actions[action]
-		if !ok {
-			return nil, errkit.New(fmt.Sprintf("Action {%s} not found in action map", action))
-		}
 		// Render the argument templates for the Phase's function
-		phases := []crv1alpha1.BlueprintPhase{}
-		phases = append(phases, a.Phases...)
-		if a.DeferPhase != nil {
-			phases = append(phases, *a.DeferPhase)
-		}
-
-		err := p.setPhaseArgs(phases, tp)
-		if err != nil {
+		if err := p.setPhaseArgs(bp, action, tp); err != nil {
 			return nil, err
 		}
 	}
+	// Check the shapes of the rendered arguments before executing
+	if err := DescribeFunc(p.f).ValidateArgs(p.args); err != nil {
+		return nil, err
+	}
 	// To simplify usage of the phase secrets in phase functions
 	tp.CurrentPhase = tp.Phases[p.name]
 	// Execute the function
 	return p.f.Exec(ctx, tp, p.args)
 }
 
-func (p *Phase) setPhaseArgs(phases []crv1alpha1.BlueprintPhase, tp param.TemplateParams) error {
-	for _, ap := range phases {
+func (p *Phase) setPhaseArgs(bp crv1alpha1.Blueprint, action string, tp param.TemplateParams) error {
+	// Get the action from Blueprint
+	a, ok := bp.Actions[action]
+	if !ok {
+		return errkit.New(fmt.Sprintf("Action {%s} not found in action map", action))
+	}
+	phases := []crv1alpha1.BlueprintPhase{}
+	phases = append(phases, a.Phases...)
+	if a.DeferPhase != nil {
+		phases = append(phases, *a.DeferPhase)
+	}
+	for i, ap := range phases {
 		if ap.Name != p.name {
 			continue
 		}
 
 		args, err := renderFuncArgs(ap.Func, ap.Args, tp)
 		if err != nil {
-			return err
+			// Report all the undefined references in the phase along with
+			// the error of the first template that failed rendering.
+			earlier := phases[:i]
+			if i == len(a.Phases) {
+				// The defer phase can reference all the phases
+				earlier = a.Phases
+			}
+			if refs := checkPhaseTemplates(a, action, ap, earlier, tp, false); len(refs) > 0 {
+				refErr := &param.UndefinedReferencesError{References: refs}
+				return errkit.Wrap(err, "Failed to render phase args", "phase", ap.Name, "undefinedReferences", refErr.Error())
+			}
+			return errkit.Wrap(err, "Failed to render phase args", "phase", ap.Name)
 		}
 
 		if err = utils.CheckRequiredArgs(p.f.RequiredArgs(), args); err != nil {
@@ -120,6 +166,108 @@ func renderFuncArgs(
 	return param.RenderArgs(args, tp)
 }
 
+// CheckTemplates walks the arguments of every phase of the action, including
+// the defer phase, and returns a *param.UndefinedReferencesError with all the
+// template references that can't be resolved.
+//
+// If offline is true, the references are checked against the shape of the
+// TemplateParams and the names declared in the blueprint, since no values are
+// available yet. Otherwise they are resolved against tp, ignoring the outputs
+// of phases that haven't run yet.
+func CheckTemplates(bp crv1alpha1.Blueprint, action string, tp param.TemplateParams, offline bool) error {
+	a, ok := bp.Actions[action]
+	if !ok {
+		return errkit.New(fmt.Sprintf("Action {%s} not found in action map", action))
+	}
+	var refs []param.UndefinedReference
+	for i, phase := range a.Phases {
+		refs = append(refs, checkPhaseTemplates(a, action, phase, a.Phases[:i], tp, offline)...)
+	}
+	if a.DeferPhase != nil {
+		refs = append(refs, checkPhaseTemplates(a, action, *a.DeferPhase, a.Phases, tp, offline)...)
+	}
+	if len(refs) == 0 {
+		return nil
+	}
+	return &param.UndefinedReferencesError{References: refs}
+}
+
+// checkPhaseTemplates returns the undefined references in the args of the
+// phase, which can reference the earlier phases of the action.
+func checkPhaseTemplates(a *crv1alpha1.BlueprintAction, action string, phase crv1alpha1.BlueprintPhase, earlier []crv1alpha1.BlueprintPhase, tp param.TemplateParams, offline bool) []param.UndefinedReference {
+	// wait functions handle their own go template and jsonpath arguments
+	if skipRenderFuncs[strings.ToLower(phase.Func)] {
+		return nil
+	}
+	opts := checkOptions(a, phase, earlier, tp, offline)
+	args := phase.Args
+	if phase.ForEach != nil {
+		args = make(map[string]interface{}, len(phase.Args)+1)
+		for k, v := range phase.Args {
+			args[k] = v
+		}
+		args["forEach.items"] = forEachItemsTemplate(phase.ForEach.Items)
+	}
+	refs := param.CheckArgs(args, tp, opts)
+	for i := range refs {
+		refs[i].Action = action
+		refs[i].Phase = phase.Name
+	}
+	return refs
+}
+
+// checkOptions returns the options to check the args of the phase. Phases
+// can reference themselves, to access their objects, and the earlier phases.
+func checkOptions(a *crv1alpha1.BlueprintAction, phase crv1alpha1.BlueprintPhase, earlier []crv1alpha1.BlueprintPhase, tp param.TemplateParams, offline bool) param.CheckOptions {
+	opts := param.CheckOptions{
+		Offline: offline,
+		Keys:    map[string][]string{},
+	}
+	visible := append([]crv1alpha1.BlueprintPhase{}, earlier...)
+	if a.DeferPhase == nil || phase.Name != a.DeferPhase.Name {
+		visible = append(visible, phase)
+	}
+	for _, p := range visible {
+		opts.Keys["Phases"] = append(opts.Keys["Phases"], p.Name)
+		if !offline {
+			if _, ok := tp.Phases[p.Name]; !ok {
+				// The phase hasn't run yet
+				opts.Pending = append(opts.Pending, "Phases."+p.Name)
+			}
+			continue
+		}
+		opts.Keys["Phases."+p.Name+".Secrets"] = objectRefNames(p.ObjectRefs, param.SecretKind)
+		opts.Keys["Phases."+p.Name+".ConfigMaps"] = objectRefNames(p.ObjectRefs, param.ConfigMapKind)
+		if p.Name == phase.Name {
+			// A phase can't reference its own output
+			opts.Keys["Phases."+p.Name+".Output"] = []string{}
+		}
+	}
+	if offline {
+		if len(a.InputArtifactNames) > 0 {
+			opts.Keys["ArtifactsIn"] = a.InputArtifactNames
+		}
+		if len(a.ConfigMapNames) > 0 {
+			opts.Keys["ConfigMaps"] = a.ConfigMapNames
+		}
+		if len(a.SecretNames) > 0 {
+			opts.Keys["Secrets"] = a.SecretNames
+		}
+	}
+	return opts
+}
+
+func objectRefNames(refs map[string]crv1alpha1.ObjectReference, kind string) []string {
+	names := []string{}
+	for name, ref := range refs {
+		if strings.EqualFold(ref.Kind, kind) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 func GetDeferPhase(bp crv1alpha1.Blueprint, action, version string, tp param.TemplateParams) (*Phase, error) {
 	a, ok := bp.Actions[action]
 	if !ok {
@@ -140,17 +288,44 @@ func GetDeferPhase(bp crv1alpha1.Blueprint, action, version string, tp param.Tem
 		return nil, err
 	}
 
+	return newPhase(*a.DeferPhase, regVersion, objs)
+}
+
+func newPhase(bp crv1alpha1.BlueprintPhase, version semver.Version, objs map[string]crv1alpha1.ObjectReference) (*Phase, error) {
+	if bp.ForEach != nil {
+		if strings.TrimSpace(bp.ForEach.Items) == "" {
+			return nil, errkit.New(fmt.Sprintf("Items of forEach of phase {%s} must be set", bp.Name))
+		}
+		if bp.ForEach.Parallelism < 0 {
+			return nil, errkit.New(fmt.Sprintf("Parallelism of forEach of phase {%s} must not be negative", bp.Name))
+		}
+	}
+	funcMu.RLock()
+	defer funcMu.RUnlock()
 	return &Phase{
-		name:    a.DeferPhase.Name,
-		objects: objs,
-		f:       funcs[a.DeferPhase.Func][regVersion],
+		name:        bp.Name,
+		objects:     objs,
+		f:           funcs[bp.Func][version],
+		version:     version,
+		deprecation: funcOpts[bp.Func][version].deprecation,
+		forEach:     bp.ForEach,
 	}, nil
 }
 
+// regFuncVersion returns the registered version of the function that is used
+// for the preferred version. The preferred version is either a version or a
+// constraint, e.g. `>=0.1.0 <1.0`. For a version, the function registered
+// with exactly that version is used, then the highest version that declares
+// support for it and then the default version. For a constraint, the highest
+// registered version satisfying it is used.
 func regFuncVersion(f, version string) (semver.Version, error) {
 	funcMu.RLock()
 	defer funcMu.RUnlock()
 
+	if isVersionConstraint(version) {
+		return constrainedFuncVersion(f, version)
+	}
+
 	defaultVersion, funcVersion, err := getFunctionVersion(version)
 	if err != nil {
 		return semver.Version{}, errkit.Wrap(err, "Failed to get function version")
@@ -164,6 +339,9 @@ func regFuncVersion(f, version string) (semver.Version, error) {
 		if funcVersion.Equal(defaultVersion) {
 			return semver.Version{}, errkit.New(fmt.Sprintf("Requested function {%s} has not been registered with version {%s}", f, version))
 		}
+		if v, ok := supportingFuncVersion(f, funcVersion); ok {
+			return v, nil
+		}
 		if _, ok := funcs[f][*defaultVersion]; !ok {
 			return semver.Version{}, errkit.New(fmt.Sprintf("Requested function {%s} has not been registered with versions {%s} or {%s}", f, version, DefaultVersion))
 		}
@@ -174,6 +352,46 @@ func regFuncVersion(f, version string) (semver.Version, error) {
 	return *funcVersion, nil
 }
 
+// constrainedFuncVersion returns the highest registered version of the
+// function that satisfies the constraint. funcMu must be held by the caller.
+func constrainedFuncVersion(f, constraint string) (semver.Version, error) {
+	c, err := parseVersionConstraint(constraint)
+	if err != nil {
+		return semver.Version{}, errkit.Wrap(err, "Failed to get function version")
+	}
+	if _, ok := funcs[f]; !ok {
+		return semver.Version{}, errkit.New(fmt.Sprintf("Requested function {%s} has not been registered", f))
+	}
+	var chosen *semver.Version
+	for v := range funcs[f] {
+		v := v
+		if c.Check(&v) && (chosen == nil || v.GreaterThan(chosen)) {
+			chosen = &v
+		}
+	}
+	if chosen == nil {
+		return semver.Version{}, errkit.New(fmt.Sprintf("Requested function {%s} has not been registered with a version satisfying {%s}", f, constraint))
+	}
+	return *chosen, nil
+}
+
+// supportingFuncVersion returns the highest registered version of the
+// function that declares support for the given version. funcMu must be held
+// by the caller.
+func supportingFuncVersion(f string, version *semver.Version) (semver.Version, bool) {
+	var chosen *semver.Version
+	for v, o := range funcOpts[f] {
+		v := v
+		if o.supports != nil && o.supports.Check(version) && (chosen == nil || v.GreaterThan(chosen)) {
+			chosen = &v
+		}
+	}
+	if chosen == nil {
+		return semver.Version{}, false
+	}
+	return *chosen, true
+}
+
 // GetPhases renders the returns a list of Phases with pre-rendered arguments.
 func GetPhases(bp crv1alpha1.Blueprint, action, version string, tp param.TemplateParams) ([]*Phase, error) {
 	a, ok := bp.Actions[action]
@@ -193,18 +411,22 @@ func GetPhases(bp crv1alpha1.Blueprint, action, version string, tp param.Templat
 		if err != nil {
 			return nil, err
 		}
-		phases = append(phases, &Phase{
-			name:    p.Name,
-			objects: objs,
-			f:       funcs[p.Func][regVersion],
-		})
+		phase, err := newPhase(p, regVersion, objs)
+		if err != nil {
+			return nil, err
+		}
+		phases = append(phases, phase)
 	}
 	return phases, nil
 }
 
 // Validate gets the provided arguments from a blueprint and calls Validate method of function to valdiate a function.
+// It also checks the arguments against the types in the schema of the function.
 func (p *Phase) Validate(args map[string]any) error {
-	return p.f.Validate(args)
+	if err := p.f.Validate(args); err != nil {
+		return err
+	}
+	return DescribeFunc(p.f).ValidateArgs(args)
 }
 
 func getFunctionVersion(version string) (*semver.Version, *semver.Version, error) {
@@ -223,3 +445,44 @@ func getFunctionVersion(version string) (*semver.Version, *semver.Version, error
 		return dv, fv, nil
 	}
 }
+
+// isVersionConstraint returns true if the preferred version is not a plain
+// version and should be treated as a constraint.
+func isVersionConstraint(version string) bool {
+	if version == "" {
+		return false
+	}
+	_, err := semver.NewVersion(version)
+	return err != nil
+}
+
+// parseVersionConstraint parses a semver constraint. Besides the comma
+// separated form, ranges can be written with spaces, e.g. `>=0.1.0 <1.0`.
+func parseVersionConstraint(constraint string) (*semver.Constraints, error) {
+	c, err := semver.NewConstraint(constraint)
+	if err == nil {
+		return c, nil
+	}
+	ors := strings.Split(constraint, "||")
+	for i, or := range ors {
+		var ands []string
+		op := ""
+		for _, tok := range strings.Fields(or) {
+			if strings.Trim(tok, "=<>!~^") == "" {
+				// operator separated from its version by a space, e.g. `>= 0.1.0`
+				op += tok
+				continue
+			}
+			if tok = strings.TrimSuffix(tok, ","); tok != "" {
+				ands = append(ands, op+tok)
+				op = ""
+			}
+		}
+		ors[i] = strings.Join(ands, ", ")
+	}
+	c, nErr := semver.NewConstraint(strings.Join(ors, " || "))
+	if nErr != nil {
+		return nil, errkit.Wrap(err, fmt.Sprintf("Failed to parse function version {%s}", constraint))
+	}
+	return c, nil
+}
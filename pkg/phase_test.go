@@ -16,7 +16,10 @@ package kanister
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 
+	"github.com/kanisterio/errkit"
 	"gopkg.in/check.v1"
 
 	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
@@ -219,3 +222,246 @@ func (s *PhaseSuite) TestRegFuncVersion(c *check.C) {
 		c.Assert(semVer.Original(), check.Equals, tc.expectedVersion)
 	}
 }
+
+func (s *PhaseSuite) TestCheckTemplates(c *check.C) {
+	bp := crv1alpha1.Blueprint{
+		Actions: map[string]*crv1alpha1.BlueprintAction{
+			"backup": {
+				InputArtifactNames: []string{"backupInfo"},
+				Phases: []crv1alpha1.BlueprintPhase{
+					{
+						Name: "first",
+						Func: "mock",
+						Args: map[string]interface{}{
+							"testKey": "{{ .ArtifactsIn.backupInfo.KeyValue.path }} {{ .Phases.first.Output.id }}",
+						},
+					},
+					{
+						Name: "second",
+						Func: "mock",
+						ObjectRefs: map[string]crv1alpha1.ObjectReference{
+							"creds": {Kind: param.SecretKind, Name: "creds"},
+						},
+						Args: map[string]interface{}{
+							"testKey": "{{ .Phases.first.Output.id }} {{ .Phases.second.Secrets.creds.Data.key }} {{ .Phases.third.Output.id }}",
+						},
+					},
+				},
+				DeferPhase: &crv1alpha1.BlueprintPhase{
+					Name: "cleanup",
+					Func: "mock",
+					Args: map[string]interface{}{
+						"testKey": "{{ .Phases.second.Output.id }} {{ .ArtifactsIn.other.KeyValue.path }}",
+					},
+				},
+			},
+		},
+	}
+
+	err := CheckTemplates(bp, "backup", param.TemplateParams{}, true)
+	c.Assert(err, check.NotNil)
+	refErr, ok := err.(*param.UndefinedReferencesError)
+	c.Assert(ok, check.Equals, true)
+	c.Assert(refErr.References, check.HasLen, 3)
+	c.Assert(refErr.References[0].Phase, check.Equals, "first")
+	c.Assert(refErr.References[0].Reference, check.Equals, ".Phases.first.Output.id")
+	c.Assert(refErr.References[1].Phase, check.Equals, "second")
+	c.Assert(refErr.References[1].Reference, check.Equals, ".Phases.third.Output.id")
+	c.Assert(refErr.References[2].Phase, check.Equals, "cleanup")
+	c.Assert(refErr.References[2].Reference, check.Equals, ".ArtifactsIn.other.KeyValue.path")
+
+	// At execution time, only the phases that ran are checked
+	tp := param.TemplateParams{
+		ArtifactsIn: map[string]crv1alpha1.Artifact{"backupInfo": {KeyValue: map[string]string{"path": "p"}}},
+		Phases: map[string]*param.Phase{
+			"first": {Output: map[string]interface{}{}},
+		},
+	}
+	err = CheckTemplates(bp, "backup", tp, false)
+	c.Assert(err, check.NotNil)
+	refErr, ok = err.(*param.UndefinedReferencesError)
+	c.Assert(ok, check.Equals, true)
+	c.Assert(refErr.References, check.HasLen, 4)
+	for i, ref := range []string{
+		".Phases.first.Output.id",
+		".Phases.first.Output.id",
+		".Phases.third.Output.id",
+		".ArtifactsIn.other.KeyValue.path",
+	} {
+		c.Assert(refErr.References[i].Action, check.Equals, "backup")
+		c.Assert(refErr.References[i].Reference, check.Equals, ref)
+	}
+
+	// Exec reports the undefined references of the phase when rendering fails
+	var output string
+	p := Phase{name: "second", f: &testFunc{output: &output}}
+	_, err = p.Exec(context.Background(), bp, "backup", tp)
+	c.Assert(err, check.ErrorMatches, `Failed to render phase args: .*"id" not found`)
+	var ewd interface{ Details() errkit.ErrorDetails }
+	c.Assert(errors.As(err, &ewd), check.Equals, true)
+	details := ewd.Details()
+	c.Assert(details["phase"], check.Equals, "second")
+	c.Assert(details["undefinedReferences"], check.Matches, "Failed to render templates, found 2 undefined references: .*")
+
+	// The references of the other phases and the guarded references aren't
+	// reported with the error of the phase
+	bp.Actions["backup"].Phases[1].Args = map[string]interface{}{
+		"testKey": `{{ if .Deployment }}{{ .Deployment.Name }}{{ end }} {{ fail "boom" }}`,
+	}
+	_, err = p.Exec(context.Background(), bp, "backup", tp)
+	c.Assert(err, check.ErrorMatches, "Failed to render phase args: .*boom")
+	c.Assert(errors.As(err, &ewd), check.Equals, true)
+	details = ewd.Details()
+	c.Assert(details["phase"], check.Equals, "second")
+	_, ok = details["undefinedReferences"]
+	c.Assert(ok, check.Equals, false)
+}
+
+type schemaFunc struct {
+	testFunc
+}
+
+func (*schemaFunc) Name() string {
+	return "schemaTestFunc"
+}
+
+func (*schemaFunc) Arguments() []string {
+	return []string{"count", "mode", "testKey"}
+}
+
+func (sf *schemaFunc) Validate(args map[string]any) error {
+	return utils.CheckSupportedArgs(sf.Arguments(), args)
+}
+
+func (*schemaFunc) Schema() FuncSchema {
+	return FuncSchema{
+		Description: "Function used to test schemas",
+		Args: []ArgSchema{
+			{Name: "count", Type: ArgTypeInteger},
+			{Name: "mode", Type: ArgTypeString, Enum: []string{"fast", "slow"}},
+			{Name: "testKey", Type: ArgTypeString},
+		},
+	}
+}
+
+func (s *PhaseSuite) TestDescribeFunc(c *check.C) {
+	schema := DescribeFunc(&anotherFunc{})
+	c.Assert(schema, check.DeepEquals, FuncSchema{Name: "anotherTestFunc"})
+
+	schema = DescribeFunc(&schemaFunc{})
+	c.Assert(schema.Name, check.Equals, "schemaTestFunc")
+	c.Assert(schema.Args, check.HasLen, 3)
+
+	var output string
+	p := Phase{name: "test", f: &schemaFunc{testFunc: testFunc{output: &output}}}
+	for _, tc := range []struct {
+		args   map[string]interface{}
+		expErr string
+	}{
+		{
+			args: map[string]interface{}{"count": 3, "mode": "Fast", "testKey": "value"},
+		},
+		{
+			args: map[string]interface{}{"count": "{{ .Options.count }}", "mode": "{{ .Options.mode }}"},
+		},
+		{
+			args:   map[string]interface{}{"count": []interface{}{1}},
+			expErr: "Invalid value for arg count of function schemaTestFunc.*Expected integer.*",
+		},
+		{
+			args:   map[string]interface{}{"mode": "medium"},
+			expErr: "Invalid value for arg mode of function schemaTestFunc.*\"medium\" is not one of \\[fast, slow\\].*",
+		},
+		{
+			args:   map[string]interface{}{"unknown": "value"},
+			expErr: "argument unknown is not supported",
+		},
+	} {
+		err := p.Validate(tc.args)
+		if tc.expErr == "" {
+			c.Assert(err, check.IsNil)
+			continue
+		}
+		c.Assert(err, check.ErrorMatches, tc.expErr)
+	}
+
+	// Rendered args are checked before executing the function
+	p.args = map[string]interface{}{"count": "three", "testKey": "value"}
+	_, err := p.Exec(context.Background(), crv1alpha1.Blueprint{}, "", param.TemplateParams{})
+	c.Assert(err, check.ErrorMatches, "Invalid value for arg count.*")
+	c.Assert(output, check.Equals, "")
+
+	err = RegisterVersion(&schemaFunc{}, "v1.0.0")
+	c.Assert(err, check.IsNil)
+	out, err := ExportFuncSchemas("schemaTestFunc")
+	c.Assert(err, check.IsNil)
+	var schemas []FuncSchema
+	c.Assert(json.Unmarshal(out, &schemas), check.IsNil)
+	c.Assert(schemas, check.HasLen, 1)
+	c.Assert(schemas[0].Version, check.Equals, "v1.0.0")
+	c.Assert(schemas[0].Args[1].Enum, check.DeepEquals, []string{"fast", "slow"})
+
+	_, err = ExportFuncSchemas("notRegistered")
+	c.Assert(err, check.ErrorMatches, ".*notRegistered.* has not been registered")
+}
+
+type versionedFunc struct {
+	testFunc
+}
+
+func (*versionedFunc) Name() string {
+	return "versionedTestFunc"
+}
+
+func (s *PhaseSuite) TestVersionNegotiation(c *check.C) {
+	err := RegisterVersion(&versionedFunc{}, "v0.1.0", WithDeprecation("use v0.2.0 instead"))
+	c.Assert(err, check.IsNil)
+	err = RegisterVersion(&versionedFunc{}, "v0.2.0", WithSupportedVersions(">=0.2.0 <0.3"))
+	c.Assert(err, check.IsNil)
+	err = RegisterVersion(&versionedFunc{}, "v1.0.0")
+	c.Assert(err, check.IsNil)
+	err = RegisterVersion(&versionedFunc{}, "v2.0.0", WithSupportedVersions("not a constraint"))
+	c.Assert(err, check.NotNil)
+
+	for _, tc := range []struct {
+		queryVersion    string
+		expectedVersion string
+		deprecation     string
+		expErr          string
+	}{
+		{queryVersion: "v0.1.0", expectedVersion: "v0.1.0", deprecation: "use v0.2.0 instead"},
+		{queryVersion: "v0.2.5", expectedVersion: "v0.2.0"},
+		{queryVersion: ">=0.1.0 <1.0", expectedVersion: "v0.2.0"},
+		{queryVersion: ">= 0.1.0, < 0.2", expectedVersion: "v0.1.0", deprecation: "use v0.2.0 instead"},
+		{queryVersion: "^1.0", expectedVersion: "v1.0.0"},
+		{queryVersion: ">=3.0", expErr: ".*has not been registered with a version satisfying {>=3.0}"},
+		// no version supports v0.5.0 and there is no default version
+		{queryVersion: "v0.5.0", expErr: ".*has not been registered with versions {v0.5.0} or {v0.0.0}"},
+		{queryVersion: "<<1", expErr: "Failed to get function version.*"},
+	} {
+		bp := crv1alpha1.Blueprint{
+			Actions: map[string]*crv1alpha1.BlueprintAction{
+				"backup": {
+					Phases: []crv1alpha1.BlueprintPhase{{Name: "first", Func: "versionedTestFunc"}},
+				},
+			},
+		}
+		phases, err := GetPhases(bp, "backup", tc.queryVersion, param.TemplateParams{})
+		if tc.expErr != "" {
+			c.Assert(err, check.ErrorMatches, tc.expErr, check.Commentf("%s", tc.queryVersion))
+			continue
+		}
+		c.Assert(err, check.IsNil, check.Commentf("%s", tc.queryVersion))
+		c.Assert(phases, check.HasLen, 1)
+		c.Assert(phases[0].Version(), check.Equals, tc.expectedVersion, check.Commentf("%s", tc.queryVersion))
+		c.Assert(phases[0].Deprecation(), check.Equals, tc.deprecation)
+	}
+
+	out, err := ExportFuncSchemas("versionedTestFunc")
+	c.Assert(err, check.IsNil)
+	var schemas []FuncSchema
+	c.Assert(json.Unmarshal(out, &schemas), check.IsNil)
+	c.Assert(schemas, check.HasLen, 3)
+	c.Assert(schemas[0].Deprecated, check.Equals, "use v0.2.0 instead")
+	c.Assert(schemas[1].Deprecated, check.Equals, "")
+}
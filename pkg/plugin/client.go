@@ -0,0 +1,144 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package plugin allows Kanister functions to be served by processes outside
+// of the controller over gRPC. The controller discovers the plugins at
+// startup and registers their functions like built-in functions.
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/kanisterio/errkit"
+	"google.golang.org/grpc/status"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/param"
+)
+
+const rpcTimeout = 30 * time.Second
+
+var _ kanister.FuncDescriber = (*function)(nil)
+
+// function is a Kanister function that is executed by a plugin.
+type function struct {
+	client FunctionServiceClient
+	info   *Function
+	schema *kanister.FuncSchema
+}
+
+func newFunction(client FunctionServiceClient, info *Function) (*function, error) {
+	f := &function{client: client, info: info}
+	if len(info.GetSchema()) != 0 {
+		f.schema = &kanister.FuncSchema{}
+		if err := json.Unmarshal(info.GetSchema(), f.schema); err != nil {
+			return nil, errkit.Wrap(err, "Failed to decode schema of plugin function", "function", info.GetName())
+		}
+	}
+	return f, nil
+}
+
+func (f *function) ref() *FunctionRef {
+	return &FunctionRef{Name: f.info.GetName(), Version: f.info.GetVersion()}
+}
+
+func (f *function) Name() string {
+	return f.info.GetName()
+}
+
+func (f *function) RequiredArgs() []string {
+	return f.info.GetRequiredArgs()
+}
+
+func (f *function) Arguments() []string {
+	return f.info.GetArguments()
+}
+
+func (f *function) Schema() kanister.FuncSchema {
+	if f.schema != nil {
+		return *f.schema
+	}
+	s := kanister.FuncSchema{Name: f.Name()}
+	required := make(map[string]bool)
+	for _, a := range f.RequiredArgs() {
+		required[a] = true
+	}
+	for _, a := range f.Arguments() {
+		s.Args = append(s.Args, kanister.ArgSchema{Name: a, Type: kanister.ArgTypeAny, Required: required[a]})
+	}
+	return s
+}
+
+func (f *function) Validate(args map[string]any) error {
+	b, err := json.Marshal(args)
+	if err != nil {
+		return errkit.Wrap(err, "Failed to encode args of plugin function", "function", f.Name())
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+	_, err = f.client.Validate(ctx, &ValidateRequest{Function: f.ref(), Args: b})
+	return fromStatus(err)
+}
+
+func (f *function) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	tpb, err := json.Marshal(tp)
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to encode template params for plugin function", "function", f.Name())
+	}
+	argsb, err := json.Marshal(args)
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to encode args of plugin function", "function", f.Name())
+	}
+	resp, err := f.client.Exec(ctx, &ExecRequest{Function: f.ref(), TemplateParams: tpb, Args: argsb})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(resp.GetOutput(), &out); err != nil {
+		return nil, errkit.Wrap(err, "Failed to decode output of plugin function", "function", f.Name())
+	}
+	return out, nil
+}
+
+func (f *function) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+	p, err := f.client.ExecutionProgress(ctx, f.ref())
+	if err != nil {
+		return crv1alpha1.PhaseProgress{}, fromStatus(err)
+	}
+	return crv1alpha1.PhaseProgress{
+		ProgressPercent:        p.GetProgressPercent(),
+		SizeDownloadedB:        p.GetSizeDownloadedB(),
+		SizeUploadedB:          p.GetSizeUploadedB(),
+		EstimatedDownloadSizeB: p.GetEstimatedDownloadSizeB(),
+		EstimatedUploadSizeB:   p.GetEstimatedUploadSizeB(),
+		EstimatedTimeSeconds:   p.GetEstimatedTimeSeconds(),
+	}, nil
+}
+
+// fromStatus returns the error reported by the plugin without the gRPC
+// status prefix.
+func fromStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if s, ok := status.FromError(err); ok {
+		return errkit.New(s.Message(), "code", s.Code().String())
+	}
+	return err
+}
@@ -0,0 +1,213 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"github.com/kanisterio/errkit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	"github.com/kanisterio/kanister/pkg/field"
+	"github.com/kanisterio/kanister/pkg/log"
+	"github.com/kanisterio/kanister/pkg/poll"
+	"github.com/kanisterio/kanister/pkg/utils"
+)
+
+const (
+	// AddressesEnv is a comma separated list of addresses of running plugins,
+	// e.g. sidecars of the controller, like `unix:///plugins/my.sock` or
+	// `localhost:9000`.
+	AddressesEnv = "KANISTER_FUNCTION_PLUGINS"
+	// DirEnv is a directory with plugin binaries that are started by the
+	// controller.
+	DirEnv = "KANISTER_FUNCTION_PLUGIN_DIR"
+	// StartTimeoutEnv is the number of seconds to wait for a plugin to serve
+	// its functions.
+	StartTimeoutEnv = "KANISTER_FUNCTION_PLUGIN_START_TIMEOUT"
+	// AddressEnv is set for the plugin binaries started by the controller to
+	// the unix socket they have to serve on.
+	AddressEnv = "KANISTER_FUNCTION_PLUGIN_ADDRESS"
+
+	defaultStartTimeout = 60 * time.Second
+)
+
+// Config configures where plugins are discovered.
+type Config struct {
+	// Addresses of running plugins.
+	Addresses []string
+	// Dir with plugin binaries. Every executable file in the directory is
+	// started and has to serve on the unix socket passed in AddressEnv.
+	Dir string
+	// StartTimeout is how long to wait for a plugin to serve its functions.
+	StartTimeout time.Duration
+}
+
+// ConfigFromEnv returns the Config set in the environment of the controller.
+func ConfigFromEnv() Config {
+	c := Config{
+		Dir:          os.Getenv(DirEnv),
+		StartTimeout: time.Duration(utils.GetEnvAsIntOrDefault(StartTimeoutEnv, int(defaultStartTimeout.Seconds()))) * time.Second,
+	}
+	for _, a := range strings.Split(os.Getenv(AddressesEnv), ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			c.Addresses = append(c.Addresses, a)
+		}
+	}
+	return c
+}
+
+// Plugins are the plugins that were discovered.
+type Plugins struct {
+	conns  []*grpc.ClientConn
+	cmds   []*exec.Cmd
+	tmpDir string
+}
+
+// Discover connects to the configured plugins, starting the plugin binaries,
+// and registers the functions they serve. Discovery fails if a function is
+// already registered with the same version. Close stops the started plugins.
+func Discover(ctx context.Context, c Config) (*Plugins, error) {
+	p := &Plugins{}
+	addrs := c.Addresses
+	if c.Dir != "" {
+		started, err := p.start(c.Dir)
+		if err != nil {
+			p.Close() //nolint:errcheck
+			return nil, err
+		}
+		addrs = append(addrs, started...)
+	}
+	for _, addr := range addrs {
+		if err := p.register(ctx, addr, c.StartTimeout); err != nil {
+			p.Close() //nolint:errcheck
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// start starts the plugin binaries in dir and returns the addresses they
+// serve on.
+func (p *Plugins) start(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to read plugin directory", "dir", dir)
+	}
+	p.tmpDir, err = os.MkdirTemp("", "kanister-plugins-")
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to create directory for plugin sockets")
+	}
+	var addrs []string
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0o111 == 0 {
+			continue
+		}
+		sock := filepath.Join(p.tmpDir, fmt.Sprintf("%d.sock", len(addrs)))
+		cmd := exec.Command(filepath.Join(dir, e.Name()))
+		cmd.Env = append(os.Environ(), AddressEnv+"="+sock)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Start(); err != nil {
+			return nil, errkit.Wrap(err, "Failed to start function plugin", "plugin", e.Name())
+		}
+		log.Print("Started function plugin", field.M{"plugin": e.Name(), "address": sock})
+		p.cmds = append(p.cmds, cmd)
+		addrs = append(addrs, "unix://"+sock)
+	}
+	return addrs, nil
+}
+
+func (p *Plugins) register(ctx context.Context, addr string, timeout time.Duration) error {
+	if strings.HasPrefix(addr, "/") {
+		addr = "unix://" + addr
+	}
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return errkit.Wrap(err, "Failed to connect to function plugin", "address", addr)
+	}
+	p.conns = append(p.conns, conn)
+	client := NewFunctionServiceClient(conn)
+
+	if timeout == 0 {
+		timeout = defaultStartTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	var resp *ListFunctionsResponse
+	var listErr error
+	err = poll.Wait(ctx, func(ctx context.Context) (bool, error) {
+		// The plugin may still be starting, so keep trying until the timeout
+		resp, listErr = client.ListFunctions(ctx, &ListFunctionsRequest{})
+		return listErr == nil, nil
+	})
+	if err != nil {
+		if listErr != nil {
+			err = listErr
+		}
+		return errkit.Wrap(err, "Failed to list functions of plugin", "address", addr)
+	}
+
+	for _, info := range resp.GetFunctions() {
+		if _, err := semver.NewVersion(info.GetVersion()); err != nil {
+			return errkit.Wrap(err, "Invalid version of plugin function", "function", info.GetName(), "version", info.GetVersion())
+		}
+		if kanister.KanisterFuncForName(info.GetName(), info.GetVersion()) != nil {
+			return errkit.New("Plugin function is already registered", "function", info.GetName(), "version", info.GetVersion(), "address", addr)
+		}
+		f, err := newFunction(client, info)
+		if err != nil {
+			return err
+		}
+		if err := kanister.RegisterVersion(f, info.GetVersion()); err != nil {
+			return err
+		}
+		log.Print("Registered plugin function", field.M{"function": info.GetName(), "version": info.GetVersion(), "address": addr})
+	}
+	return nil
+}
+
+// Close closes the connections to the plugins and stops the started plugin
+// binaries. The registered functions remain registered.
+func (p *Plugins) Close() error {
+	var err error
+	for _, conn := range p.conns {
+		if cErr := conn.Close(); cErr != nil {
+			err = errkit.Append(err, cErr)
+		}
+	}
+	for _, cmd := range p.cmds {
+		if kErr := cmd.Process.Kill(); kErr != nil {
+			err = errkit.Append(err, kErr)
+		}
+		_ = cmd.Wait()
+	}
+	if p.tmpDir != "" {
+		if rErr := os.RemoveAll(p.tmpDir); rErr != nil {
+			err = errkit.Append(err, rErr)
+		}
+	}
+	return err
+}
@@ -0,0 +1,613 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        v3.21.12
+// source: pkg/plugin/plugin.proto
+
+package plugin
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListFunctionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFunctionsRequest) Reset() {
+	*x = ListFunctionsRequest{}
+	mi := &file_pkg_plugin_plugin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFunctionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFunctionsRequest) ProtoMessage() {}
+
+func (x *ListFunctionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_plugin_plugin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFunctionsRequest.ProtoReflect.Descriptor instead.
+func (*ListFunctionsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_plugin_plugin_proto_rawDescGZIP(), []int{0}
+}
+
+type ListFunctionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Functions     []*Function            `protobuf:"bytes,1,rep,name=functions,proto3" json:"functions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFunctionsResponse) Reset() {
+	*x = ListFunctionsResponse{}
+	mi := &file_pkg_plugin_plugin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFunctionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFunctionsResponse) ProtoMessage() {}
+
+func (x *ListFunctionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_plugin_plugin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFunctionsResponse.ProtoReflect.Descriptor instead.
+func (*ListFunctionsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_plugin_plugin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListFunctionsResponse) GetFunctions() []*Function {
+	if x != nil {
+		return x.Functions
+	}
+	return nil
+}
+
+type Function struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Arguments     []string               `protobuf:"bytes,3,rep,name=arguments,proto3" json:"arguments,omitempty"`
+	RequiredArgs  []string               `protobuf:"bytes,4,rep,name=requiredArgs,proto3" json:"requiredArgs,omitempty"`
+	Schema        []byte                 `protobuf:"bytes,5,opt,name=schema,proto3" json:"schema,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Function) Reset() {
+	*x = Function{}
+	mi := &file_pkg_plugin_plugin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Function) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Function) ProtoMessage() {}
+
+func (x *Function) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_plugin_plugin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Function.ProtoReflect.Descriptor instead.
+func (*Function) Descriptor() ([]byte, []int) {
+	return file_pkg_plugin_plugin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Function) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Function) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Function) GetArguments() []string {
+	if x != nil {
+		return x.Arguments
+	}
+	return nil
+}
+
+func (x *Function) GetRequiredArgs() []string {
+	if x != nil {
+		return x.RequiredArgs
+	}
+	return nil
+}
+
+func (x *Function) GetSchema() []byte {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+type FunctionRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FunctionRef) Reset() {
+	*x = FunctionRef{}
+	mi := &file_pkg_plugin_plugin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FunctionRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FunctionRef) ProtoMessage() {}
+
+func (x *FunctionRef) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_plugin_plugin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FunctionRef.ProtoReflect.Descriptor instead.
+func (*FunctionRef) Descriptor() ([]byte, []int) {
+	return file_pkg_plugin_plugin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FunctionRef) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FunctionRef) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type ValidateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Function      *FunctionRef           `protobuf:"bytes,1,opt,name=function,proto3" json:"function,omitempty"`
+	Args          []byte                 `protobuf:"bytes,2,opt,name=args,proto3" json:"args,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateRequest) Reset() {
+	*x = ValidateRequest{}
+	mi := &file_pkg_plugin_plugin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateRequest) ProtoMessage() {}
+
+func (x *ValidateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_plugin_plugin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateRequest.ProtoReflect.Descriptor instead.
+func (*ValidateRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_plugin_plugin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ValidateRequest) GetFunction() *FunctionRef {
+	if x != nil {
+		return x.Function
+	}
+	return nil
+}
+
+func (x *ValidateRequest) GetArgs() []byte {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+type ValidateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateResponse) Reset() {
+	*x = ValidateResponse{}
+	mi := &file_pkg_plugin_plugin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateResponse) ProtoMessage() {}
+
+func (x *ValidateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_plugin_plugin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateResponse.ProtoReflect.Descriptor instead.
+func (*ValidateResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_plugin_plugin_proto_rawDescGZIP(), []int{5}
+}
+
+type ExecRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Function       *FunctionRef           `protobuf:"bytes,1,opt,name=function,proto3" json:"function,omitempty"`
+	TemplateParams []byte                 `protobuf:"bytes,2,opt,name=templateParams,proto3" json:"templateParams,omitempty"`
+	Args           []byte                 `protobuf:"bytes,3,opt,name=args,proto3" json:"args,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ExecRequest) Reset() {
+	*x = ExecRequest{}
+	mi := &file_pkg_plugin_plugin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecRequest) ProtoMessage() {}
+
+func (x *ExecRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_plugin_plugin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecRequest.ProtoReflect.Descriptor instead.
+func (*ExecRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_plugin_plugin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ExecRequest) GetFunction() *FunctionRef {
+	if x != nil {
+		return x.Function
+	}
+	return nil
+}
+
+func (x *ExecRequest) GetTemplateParams() []byte {
+	if x != nil {
+		return x.TemplateParams
+	}
+	return nil
+}
+
+func (x *ExecRequest) GetArgs() []byte {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+type ExecResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Output        []byte                 `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecResponse) Reset() {
+	*x = ExecResponse{}
+	mi := &file_pkg_plugin_plugin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecResponse) ProtoMessage() {}
+
+func (x *ExecResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_plugin_plugin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecResponse.ProtoReflect.Descriptor instead.
+func (*ExecResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_plugin_plugin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ExecResponse) GetOutput() []byte {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
+type Progress struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	ProgressPercent        string                 `protobuf:"bytes,1,opt,name=progressPercent,proto3" json:"progressPercent,omitempty"`
+	SizeDownloadedB        int64                  `protobuf:"varint,2,opt,name=sizeDownloadedB,proto3" json:"sizeDownloadedB,omitempty"`
+	SizeUploadedB          int64                  `protobuf:"varint,3,opt,name=sizeUploadedB,proto3" json:"sizeUploadedB,omitempty"`
+	EstimatedDownloadSizeB int64                  `protobuf:"varint,4,opt,name=estimatedDownloadSizeB,proto3" json:"estimatedDownloadSizeB,omitempty"`
+	EstimatedUploadSizeB   int64                  `protobuf:"varint,5,opt,name=estimatedUploadSizeB,proto3" json:"estimatedUploadSizeB,omitempty"`
+	EstimatedTimeSeconds   int64                  `protobuf:"varint,6,opt,name=estimatedTimeSeconds,proto3" json:"estimatedTimeSeconds,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Progress) Reset() {
+	*x = Progress{}
+	mi := &file_pkg_plugin_plugin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Progress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Progress) ProtoMessage() {}
+
+func (x *Progress) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_plugin_plugin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Progress.ProtoReflect.Descriptor instead.
+func (*Progress) Descriptor() ([]byte, []int) {
+	return file_pkg_plugin_plugin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Progress) GetProgressPercent() string {
+	if x != nil {
+		return x.ProgressPercent
+	}
+	return ""
+}
+
+func (x *Progress) GetSizeDownloadedB() int64 {
+	if x != nil {
+		return x.SizeDownloadedB
+	}
+	return 0
+}
+
+func (x *Progress) GetSizeUploadedB() int64 {
+	if x != nil {
+		return x.SizeUploadedB
+	}
+	return 0
+}
+
+func (x *Progress) GetEstimatedDownloadSizeB() int64 {
+	if x != nil {
+		return x.EstimatedDownloadSizeB
+	}
+	return 0
+}
+
+func (x *Progress) GetEstimatedUploadSizeB() int64 {
+	if x != nil {
+		return x.EstimatedUploadSizeB
+	}
+	return 0
+}
+
+func (x *Progress) GetEstimatedTimeSeconds() int64 {
+	if x != nil {
+		return x.EstimatedTimeSeconds
+	}
+	return 0
+}
+
+var File_pkg_plugin_plugin_proto protoreflect.FileDescriptor
+
+const file_pkg_plugin_plugin_proto_rawDesc = "" +
+	"\n" +
+	"\x17pkg/plugin/plugin.proto\x12\x06plugin\"\x16\n" +
+	"\x14ListFunctionsRequest\"G\n" +
+	"\x15ListFunctionsResponse\x12.\n" +
+	"\tfunctions\x18\x01 \x03(\v2\x10.plugin.FunctionR\tfunctions\"\x92\x01\n" +
+	"\bFunction\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x1c\n" +
+	"\targuments\x18\x03 \x03(\tR\targuments\x12\"\n" +
+	"\frequiredArgs\x18\x04 \x03(\tR\frequiredArgs\x12\x16\n" +
+	"\x06schema\x18\x05 \x01(\fR\x06schema\";\n" +
+	"\vFunctionRef\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\"V\n" +
+	"\x0fValidateRequest\x12/\n" +
+	"\bfunction\x18\x01 \x01(\v2\x13.plugin.FunctionRefR\bfunction\x12\x12\n" +
+	"\x04args\x18\x02 \x01(\fR\x04args\"\x12\n" +
+	"\x10ValidateResponse\"z\n" +
+	"\vExecRequest\x12/\n" +
+	"\bfunction\x18\x01 \x01(\v2\x13.plugin.FunctionRefR\bfunction\x12&\n" +
+	"\x0etemplateParams\x18\x02 \x01(\fR\x0etemplateParams\x12\x12\n" +
+	"\x04args\x18\x03 \x01(\fR\x04args\"&\n" +
+	"\fExecResponse\x12\x16\n" +
+	"\x06output\x18\x01 \x01(\fR\x06output\"\xa4\x02\n" +
+	"\bProgress\x12(\n" +
+	"\x0fprogressPercent\x18\x01 \x01(\tR\x0fprogressPercent\x12(\n" +
+	"\x0fsizeDownloadedB\x18\x02 \x01(\x03R\x0fsizeDownloadedB\x12$\n" +
+	"\rsizeUploadedB\x18\x03 \x01(\x03R\rsizeUploadedB\x126\n" +
+	"\x16estimatedDownloadSizeB\x18\x04 \x01(\x03R\x16estimatedDownloadSizeB\x122\n" +
+	"\x14estimatedUploadSizeB\x18\x05 \x01(\x03R\x14estimatedUploadSizeB\x122\n" +
+	"\x14estimatedTimeSeconds\x18\x06 \x01(\x03R\x14estimatedTimeSeconds2\x95\x02\n" +
+	"\x0fFunctionService\x12N\n" +
+	"\rListFunctions\x12\x1c.plugin.ListFunctionsRequest\x1a\x1d.plugin.ListFunctionsResponse\"\x00\x12?\n" +
+	"\bValidate\x12\x17.plugin.ValidateRequest\x1a\x18.plugin.ValidateResponse\"\x00\x123\n" +
+	"\x04Exec\x12\x13.plugin.ExecRequest\x1a\x14.plugin.ExecResponse\"\x00\x12<\n" +
+	"\x11ExecutionProgress\x12\x13.plugin.FunctionRef\x1a\x10.plugin.Progress\"\x00B+Z)github.com/kanisterio/kanister/pkg/pluginb\x06proto3"
+
+var (
+	file_pkg_plugin_plugin_proto_rawDescOnce sync.Once
+	file_pkg_plugin_plugin_proto_rawDescData []byte
+)
+
+func file_pkg_plugin_plugin_proto_rawDescGZIP() []byte {
+	file_pkg_plugin_plugin_proto_rawDescOnce.Do(func() {
+		file_pkg_plugin_plugin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_plugin_plugin_proto_rawDesc), len(file_pkg_plugin_plugin_proto_rawDesc)))
+	})
+	return file_pkg_plugin_plugin_proto_rawDescData
+}
+
+var file_pkg_plugin_plugin_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_pkg_plugin_plugin_proto_goTypes = []any{
+	(*ListFunctionsRequest)(nil),  // 0: plugin.ListFunctionsRequest
+	(*ListFunctionsResponse)(nil), // 1: plugin.ListFunctionsResponse
+	(*Function)(nil),              // 2: plugin.Function
+	(*FunctionRef)(nil),           // 3: plugin.FunctionRef
+	(*ValidateRequest)(nil),       // 4: plugin.ValidateRequest
+	(*ValidateResponse)(nil),      // 5: plugin.ValidateResponse
+	(*ExecRequest)(nil),           // 6: plugin.ExecRequest
+	(*ExecResponse)(nil),          // 7: plugin.ExecResponse
+	(*Progress)(nil),              // 8: plugin.Progress
+}
+var file_pkg_plugin_plugin_proto_depIdxs = []int32{
+	2, // 0: plugin.ListFunctionsResponse.functions:type_name -> plugin.Function
+	3, // 1: plugin.ValidateRequest.function:type_name -> plugin.FunctionRef
+	3, // 2: plugin.ExecRequest.function:type_name -> plugin.FunctionRef
+	0, // 3: plugin.FunctionService.ListFunctions:input_type -> plugin.ListFunctionsRequest
+	4, // 4: plugin.FunctionService.Validate:input_type -> plugin.ValidateRequest
+	6, // 5: plugin.FunctionService.Exec:input_type -> plugin.ExecRequest
+	3, // 6: plugin.FunctionService.ExecutionProgress:input_type -> plugin.FunctionRef
+	1, // 7: plugin.FunctionService.ListFunctions:output_type -> plugin.ListFunctionsResponse
+	5, // 8: plugin.FunctionService.Validate:output_type -> plugin.ValidateResponse
+	7, // 9: plugin.FunctionService.Exec:output_type -> plugin.ExecResponse
+	8, // 10: plugin.FunctionService.ExecutionProgress:output_type -> plugin.Progress
+	7, // [7:11] is the sub-list for method output_type
+	3, // [3:7] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_pkg_plugin_plugin_proto_init() }
+func file_pkg_plugin_plugin_proto_init() {
+	if File_pkg_plugin_plugin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_plugin_plugin_proto_rawDesc), len(file_pkg_plugin_plugin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_plugin_plugin_proto_goTypes,
+		DependencyIndexes: file_pkg_plugin_plugin_proto_depIdxs,
+		MessageInfos:      file_pkg_plugin_plugin_proto_msgTypes,
+	}.Build()
+	File_pkg_plugin_plugin_proto = out.File
+	file_pkg_plugin_plugin_proto_goTypes = nil
+	file_pkg_plugin_plugin_proto_depIdxs = nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kanisterio/errkit"
+	"gopkg.in/check.v1"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	crv1alpha1 "github.com/kanisterio/kanister/pkg/apis/cr/v1alpha1"
+	"github.com/kanisterio/kanister/pkg/param"
+	"github.com/kanisterio/kanister/pkg/utils"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type PluginSuite struct{}
+
+var _ = check.Suite(&PluginSuite{})
+
+type echoFunc struct {
+	progress string
+}
+
+func (*echoFunc) Name() string {
+	return "pluginEcho"
+}
+
+func (*echoFunc) RequiredArgs() []string {
+	return []string{"message"}
+}
+
+func (*echoFunc) Arguments() []string {
+	return []string{"message", "fail"}
+}
+
+func (f *echoFunc) Validate(args map[string]any) error {
+	return utils.CheckSupportedArgs(f.Arguments(), args)
+}
+
+func (*echoFunc) Schema() kanister.FuncSchema {
+	return kanister.FuncSchema{
+		Args: []kanister.ArgSchema{
+			{Name: "message", Type: kanister.ArgTypeString, Required: true},
+			{Name: "fail", Type: kanister.ArgTypeBoolean},
+		},
+	}
+}
+
+func (f *echoFunc) Exec(ctx context.Context, tp param.TemplateParams, args map[string]interface{}) (map[string]interface{}, error) {
+	if fail, _ := args["fail"].(bool); fail {
+		return nil, errkit.New("Echo failed")
+	}
+	f.progress = "100"
+	return map[string]interface{}{
+		"message":   args["message"],
+		"namespace": tp.Namespace.Name,
+	}, nil
+}
+
+func (f *echoFunc) ExecutionProgress() (crv1alpha1.PhaseProgress, error) {
+	return crv1alpha1.PhaseProgress{ProgressPercent: f.progress}, nil
+}
+
+func (s *PluginSuite) TestDiscover(c *check.C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	srv, err := NewServer(&echoFunc{}, WithVersion(&echoFunc{}, "v1.0.0"))
+	c.Assert(err, check.IsNil)
+	addr := filepath.Join(c.MkDir(), "plugin.sock")
+	go srv.Serve(ctx, addr) //nolint:errcheck
+
+	plugins, err := Discover(ctx, Config{Addresses: []string{addr}, StartTimeout: 10 * time.Second})
+	c.Assert(err, check.IsNil)
+	defer plugins.Close() //nolint:errcheck
+
+	f := kanister.KanisterFuncForName("pluginEcho", "v1.0.0")
+	c.Assert(f, check.NotNil)
+	c.Assert(f.Arguments(), check.DeepEquals, []string{"message", "fail"})
+	c.Assert(f.RequiredArgs(), check.DeepEquals, []string{"message"})
+	schema := kanister.DescribeFunc(f)
+	c.Assert(schema.Args[1].Type, check.Equals, kanister.ArgTypeBoolean)
+
+	c.Assert(f.Validate(map[string]any{"message": "hello"}), check.IsNil)
+	err = f.Validate(map[string]any{"unknown": "hello"})
+	c.Assert(err, check.ErrorMatches, "argument unknown is not supported")
+
+	tp := param.TemplateParams{Namespace: &param.NamespaceParams{Name: "ns"}}
+	out, err := f.Exec(ctx, tp, map[string]interface{}{"message": "hello"})
+	c.Assert(err, check.IsNil)
+	c.Assert(out, check.DeepEquals, map[string]interface{}{"message": "hello", "namespace": "ns"})
+	p, err := f.ExecutionProgress()
+	c.Assert(err, check.IsNil)
+	c.Assert(p.ProgressPercent, check.Equals, "100")
+
+	_, err = f.Exec(ctx, tp, map[string]interface{}{"message": "hello", "fail": true})
+	c.Assert(err, check.ErrorMatches, "Echo failed")
+
+	// The functions of a plugin can't be registered twice
+	_, err = Discover(ctx, Config{Addresses: []string{"unix://" + addr}, StartTimeout: 10 * time.Second})
+	c.Assert(err, check.ErrorMatches, "Plugin function is already registered")
+}
+
+func (s *PluginSuite) TestDiscoverTimeout(c *check.C) {
+	addr := filepath.Join(c.MkDir(), "missing.sock")
+	_, err := Discover(context.Background(), Config{Addresses: []string{addr}, StartTimeout: time.Second})
+	c.Assert(err, check.ErrorMatches, "Failed to list functions of plugin.*")
+}
+
+func (s *PluginSuite) TestNewServer(c *check.C) {
+	_, err := NewServer(&echoFunc{}, &echoFunc{})
+	c.Assert(err, check.ErrorMatches, "Function is served twice")
+	_, err = NewServer(WithVersion(&echoFunc{}, "latest"))
+	c.Assert(err, check.ErrorMatches, "Invalid function version.*")
+}
@@ -0,0 +1,212 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/Masterminds/semver"
+	"github.com/kanisterio/errkit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	kanister "github.com/kanisterio/kanister/pkg"
+	"github.com/kanisterio/kanister/pkg/field"
+	"github.com/kanisterio/kanister/pkg/log"
+	"github.com/kanisterio/kanister/pkg/param"
+)
+
+// VersionedFunc is a Func that is served with a version other than the
+// default version.
+type VersionedFunc struct {
+	kanister.Func
+	Version string
+}
+
+// WithVersion returns the Func to be served with the given version.
+func WithVersion(f kanister.Func, version string) kanister.Func {
+	return &VersionedFunc{Func: f, Version: version}
+}
+
+// Schema returns the schema of the wrapped Func.
+func (vf *VersionedFunc) Schema() kanister.FuncSchema {
+	return kanister.DescribeFunc(vf.Func)
+}
+
+type functionServiceServer struct {
+	UnimplementedFunctionServiceServer
+	funcs map[string]map[string]kanister.Func
+}
+
+func newFunctionServiceServer(funcs ...kanister.Func) (*functionServiceServer, error) {
+	s := &functionServiceServer{funcs: make(map[string]map[string]kanister.Func)}
+	for _, f := range funcs {
+		version := kanister.DefaultVersion
+		if vf, ok := f.(*VersionedFunc); ok {
+			version = vf.Version
+		}
+		if _, err := semver.NewVersion(version); err != nil {
+			return nil, errkit.Wrap(err, "Invalid function version", "function", f.Name(), "version", version)
+		}
+		if _, ok := s.funcs[f.Name()]; !ok {
+			s.funcs[f.Name()] = make(map[string]kanister.Func)
+		}
+		if _, ok := s.funcs[f.Name()][version]; ok {
+			return nil, errkit.New("Function is served twice", "function", f.Name(), "version", version)
+		}
+		s.funcs[f.Name()][version] = f
+	}
+	return s, nil
+}
+
+func (s *functionServiceServer) function(ref *FunctionRef) (kanister.Func, error) {
+	f, ok := s.funcs[ref.GetName()][ref.GetVersion()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "function %s with version %s is not served by this plugin", ref.GetName(), ref.GetVersion())
+	}
+	return f, nil
+}
+
+func (s *functionServiceServer) ListFunctions(context.Context, *ListFunctionsRequest) (*ListFunctionsResponse, error) {
+	resp := &ListFunctionsResponse{}
+	for name, versions := range s.funcs {
+		for version, f := range versions {
+			schema, err := json.Marshal(kanister.DescribeFunc(f))
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			resp.Functions = append(resp.Functions, &Function{
+				Name:         name,
+				Version:      version,
+				Arguments:    f.Arguments(),
+				RequiredArgs: f.RequiredArgs(),
+				Schema:       schema,
+			})
+		}
+	}
+	return resp, nil
+}
+
+func (s *functionServiceServer) Validate(_ context.Context, req *ValidateRequest) (*ValidateResponse, error) {
+	f, err := s.function(req.GetFunction())
+	if err != nil {
+		return nil, err
+	}
+	var args map[string]any
+	if err := json.Unmarshal(req.GetArgs(), &args); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := f.Validate(args); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &ValidateResponse{}, nil
+}
+
+func (s *functionServiceServer) Exec(ctx context.Context, req *ExecRequest) (*ExecResponse, error) {
+	f, err := s.function(req.GetFunction())
+	if err != nil {
+		return nil, err
+	}
+	var tp param.TemplateParams
+	if err := json.Unmarshal(req.GetTemplateParams(), &tp); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var args map[string]interface{}
+	if err := json.Unmarshal(req.GetArgs(), &args); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	out, err := f.Exec(ctx, tp, args)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, err.Error())
+	}
+	output, err := json.Marshal(out)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &ExecResponse{Output: output}, nil
+}
+
+func (s *functionServiceServer) ExecutionProgress(_ context.Context, ref *FunctionRef) (*Progress, error) {
+	f, err := s.function(ref)
+	if err != nil {
+		return nil, err
+	}
+	p, err := f.ExecutionProgress()
+	if err != nil {
+		return nil, status.Error(codes.Unknown, err.Error())
+	}
+	return &Progress{
+		ProgressPercent:        p.ProgressPercent,
+		SizeDownloadedB:        p.SizeDownloadedB,
+		SizeUploadedB:          p.SizeUploadedB,
+		EstimatedDownloadSizeB: p.EstimatedDownloadSizeB,
+		EstimatedUploadSizeB:   p.EstimatedUploadSizeB,
+		EstimatedTimeSeconds:   p.EstimatedTimeSeconds,
+	}, nil
+}
+
+// Server serves Kanister functions to the controller. It is used to build
+// function plugins.
+type Server struct {
+	grpcs *grpc.Server
+	fss   *functionServiceServer
+}
+
+// NewServer returns a Server for the given functions. Functions are served
+// with the default version unless they are wrapped with WithVersion.
+func NewServer(funcs ...kanister.Func) (*Server, error) {
+	fss, err := newFunctionServiceServer(funcs...)
+	if err != nil {
+		return nil, err
+	}
+	return &Server{
+		grpcs: grpc.NewServer(),
+		fss:   fss,
+	}, nil
+}
+
+// Serve serves the functions on the unix socket at addr until the context
+// is canceled or the process is terminated. If addr is empty, the address
+// passed by the controller in the AddressEnv environment variable is used.
+func (s *Server) Serve(ctx context.Context, addr string) error {
+	if addr == "" {
+		addr = os.Getenv(AddressEnv)
+	}
+	if addr == "" {
+		return errkit.New("No address to serve function plugin on", "env", AddressEnv)
+	}
+	// os.Interrupt is a platform specific interrupt
+	ctx, can := signal.NotifyContext(ctx, syscall.SIGTERM, os.Interrupt)
+	defer can()
+	go func() {
+		<-ctx.Done()
+		log.Info().Print("Gracefully stopping function plugin")
+		s.grpcs.GracefulStop()
+	}()
+	RegisterFunctionServiceServer(s.grpcs, s.fss)
+	lis, err := net.Listen("unix", addr)
+	if err != nil {
+		return err
+	}
+	log.Info().Print("Serving functions on socket", field.M{"address": lis.Addr()})
+	defer os.Remove(addr) //nolint:errcheck
+	return s.grpcs.Serve(lis)
+}
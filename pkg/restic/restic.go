@@ -34,6 +34,7 @@ import (
 	"github.com/kanisterio/kanister/pkg/kube"
 	"github.com/kanisterio/kanister/pkg/location"
 	"github.com/kanisterio/kanister/pkg/log"
+	pkgout "github.com/kanisterio/kanister/pkg/output"
 	"github.com/kanisterio/kanister/pkg/param"
 	"github.com/kanisterio/kanister/pkg/secrets"
 )
@@ -178,6 +179,85 @@ func PruneCommand(profile *param.Profile, repository, encryptionKey string, inse
 	return shCommand(command), nil
 }
 
+// CheckCommand returns restic check command. With readData, the data of all
+// the snapshots is read and verified too, not just the repository structure.
+func CheckCommand(profile *param.Profile, repository, encryptionKey string, readData, insecureTLS bool) ([]string, error) {
+	cmd, err := resticArgs(profile, repository, encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	cmd = append(cmd, "check")
+	if readData {
+		cmd = append(cmd, "--read-data")
+	}
+	if insecureTLS {
+		cmd = append(cmd, "--insecure-tls")
+	}
+	command := strings.Join(cmd, " ")
+	return shCommand(command), nil
+}
+
+// RetentionPolicy specifies how many of the latest snapshots and of the
+// latest snapshots per period are kept by ForgetCommandByPolicy. Zero values
+// aren't set.
+type RetentionPolicy struct {
+	KeepLast    int
+	KeepHourly  int
+	KeepDaily   int
+	KeepWeekly  int
+	KeepMonthly int
+	KeepYearly  int
+}
+
+// IsZero returns true if the policy doesn't keep any snapshots.
+func (rp RetentionPolicy) IsZero() bool {
+	return rp == RetentionPolicy{}
+}
+
+var tagPattern = regexp.MustCompile(`^[a-zA-Z0-9_.:=-]+$`)
+
+// ForgetCommandByPolicy returns restic forget command that removes the
+// snapshots not kept by the policy. Only the snapshots with all of the given
+// tags are considered.
+func ForgetCommandByPolicy(profile *param.Profile, repository string, policy RetentionPolicy, tags []string, encryptionKey string, insecureTLS bool) ([]string, error) {
+	if policy.IsZero() {
+		return nil, errkit.New("Retention policy must keep at least one snapshot")
+	}
+	for _, t := range tags {
+		if !tagPattern.MatchString(t) {
+			return nil, errkit.New("Invalid tag", "tag", t)
+		}
+	}
+	cmd, err := resticArgs(profile, repository, encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	cmd = append(cmd, "forget", "--json")
+	for _, k := range []struct {
+		flag  string
+		value int
+	}{
+		{"--keep-last", policy.KeepLast},
+		{"--keep-hourly", policy.KeepHourly},
+		{"--keep-daily", policy.KeepDaily},
+		{"--keep-weekly", policy.KeepWeekly},
+		{"--keep-monthly", policy.KeepMonthly},
+		{"--keep-yearly", policy.KeepYearly},
+	} {
+		if k.value > 0 {
+			cmd = append(cmd, k.flag, strconv.Itoa(k.value))
+		}
+	}
+	if len(tags) > 0 {
+		cmd = append(cmd, "--tag", strings.Join(tags, ","))
+	}
+	if insecureTLS {
+		cmd = append(cmd, "--insecure-tls")
+	}
+	command := strings.Join(cmd, " ")
+	return shCommand(command), nil
+}
+
 // StatsCommandByID returns restic stats command
 func StatsCommandByID(profile *param.Profile, repository, id, mode, encryptionKey string) ([]string, error) {
 	cmd, err := resticArgs(profile, repository, encryptionKey)
@@ -313,8 +393,8 @@ func GetOrCreateRepository(
 		return errkit.Wrap(err, "Failed to create init command")
 	}
 	stdout, stderr, err := kube.Exec(ctx, cli, namespace, pod, container, cmd, nil)
-	format.Log(pod, container, stdout)
-	format.Log(pod, container, stderr)
+	format.Log(pod, container, pkgout.RedactWithCtx(ctx, stdout))
+	format.Log(pod, container, pkgout.RedactWithCtx(ctx, stderr))
 	return errkit.Wrap(err, "Failed to create object store backup location")
 }
 
@@ -361,8 +441,8 @@ func getLatestSnapshots(
 		return "", "", errkit.Wrap(err, "Failed to create snapshot command")
 	}
 	stdout, stderr, err := kube.Exec(ctx, cli, namespace, pod, container, cmd, nil)
-	format.Log(pod, container, stdout)
-	format.Log(pod, container, stderr)
+	format.Log(pod, container, pkgout.RedactWithCtx(ctx, stdout))
+	format.Log(pod, container, pkgout.RedactWithCtx(ctx, stderr))
 	return stdout, stderr, err
 }
 
@@ -380,6 +460,32 @@ func SnapshotIDFromSnapshotLog(output string) (string, error) {
 	return snapID.(string), nil
 }
 
+// SnapshotIDsFromForgetLog gets the IDs of the snapshots removed by the
+// forget command with the --json flag
+func SnapshotIDsFromForgetLog(output string) ([]string, error) {
+	var groups []struct {
+		Remove []struct {
+			ShortID string `json:"short_id"`
+		} `json:"remove"`
+	}
+	for _, l := range strings.Split(output, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(l), "[") {
+			continue
+		}
+		if err := json.Unmarshal([]byte(l), &groups); err != nil {
+			return nil, errkit.Wrap(err, "Failed to unmarshall output from forgetCommand")
+		}
+		ids := []string{}
+		for _, g := range groups {
+			for _, snap := range g.Remove {
+				ids = append(ids, snap.ShortID)
+			}
+		}
+		return ids, nil
+	}
+	return nil, errkit.New("Failed to find the removed snapshots in the output of forgetCommand")
+}
+
 // SnapshotIDFromBackupLog gets the SnapshotID from Backup Command log
 func SnapshotIDFromBackupLog(output string) string {
 	if output == "" {
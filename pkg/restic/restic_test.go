@@ -15,6 +15,7 @@
 package restic
 
 import (
+	"strings"
 	"testing"
 
 	"gopkg.in/check.v1"
@@ -504,3 +505,107 @@ func (s *ResticDataSuite) TestResticSizeStringParser(c *check.C) {
 		c.Check(parsedSize, check.Equals, tc.expectedSizeB)
 	}
 }
+
+func (s *ResticDataSuite) TestForgetCommandByPolicy(c *check.C) {
+	profile := &param.Profile{
+		Location: crv1alpha1.Location{
+			Type:     crv1alpha1.LocationTypeS3Compliant,
+			Endpoint: "endpoint",
+		},
+		Credential: param.Credential{
+			Type: param.CredentialTypeKeyPair,
+			KeyPair: &param.KeyPair{
+				ID:     "id",
+				Secret: "secret",
+			},
+		},
+	}
+	for _, tc := range []struct {
+		policy   RetentionPolicy
+		tags     []string
+		expected string
+		errMsg   string
+	}{
+		{
+			policy:   RetentionPolicy{KeepLast: 3, KeepDaily: 7},
+			expected: "restic forget --json --keep-last 3 --keep-daily 7",
+		},
+		{
+			policy:   RetentionPolicy{KeepHourly: 1, KeepWeekly: 2, KeepMonthly: 3, KeepYearly: 4},
+			tags:     []string{"app=db", "daily"},
+			expected: "restic forget --json --keep-hourly 1 --keep-weekly 2 --keep-monthly 3 --keep-yearly 4 --tag app=db,daily",
+		},
+		{
+			policy: RetentionPolicy{},
+			errMsg: "Retention policy must keep at least one snapshot",
+		},
+		{
+			policy: RetentionPolicy{KeepLast: 1},
+			tags:   []string{"a; rm -rf /"},
+			errMsg: "Invalid tag.*",
+		},
+	} {
+		cmd, err := ForgetCommandByPolicy(profile, "repo", tc.policy, tc.tags, "my-secret", false)
+		if tc.errMsg != "" {
+			c.Assert(err, check.ErrorMatches, tc.errMsg)
+			continue
+		}
+		c.Assert(err, check.IsNil)
+		c.Assert(strings.HasSuffix(cmd[len(cmd)-1], tc.expected), check.Equals, true, check.Commentf("%s", cmd[len(cmd)-1]))
+	}
+}
+
+func (s *ResticDataSuite) TestCheckCommand(c *check.C) {
+	profile := &param.Profile{
+		Location: crv1alpha1.Location{
+			Type:     crv1alpha1.LocationTypeS3Compliant,
+			Endpoint: "endpoint",
+		},
+		Credential: param.Credential{
+			Type: param.CredentialTypeKeyPair,
+			KeyPair: &param.KeyPair{
+				ID:     "id",
+				Secret: "secret",
+			},
+		},
+	}
+	for _, tc := range []struct {
+		readData    bool
+		insecureTLS bool
+		expected    string
+	}{
+		{expected: "restic check"},
+		{readData: true, expected: "restic check --read-data"},
+		{readData: true, insecureTLS: true, expected: "restic check --read-data --insecure-tls"},
+	} {
+		cmd, err := CheckCommand(profile, "repo", "my-secret", tc.readData, tc.insecureTLS)
+		c.Assert(err, check.IsNil)
+		c.Assert(strings.HasSuffix(cmd[len(cmd)-1], tc.expected), check.Equals, true, check.Commentf("%s", cmd[len(cmd)-1]))
+	}
+}
+
+func (s *ResticDataSuite) TestSnapshotIDsFromForgetLog(c *check.C) {
+	for _, tc := range []struct {
+		log      string
+		expected []string
+		checker  check.Checker
+	}{
+		{
+			log: `[{"tags":null,"host":"h","paths":["/data"],"keep":[{"id":"aaaa1111","short_id":"aaaa"}],` +
+				`"remove":[{"id":"bbbb2222","short_id":"bbbb"},{"id":"cccc3333","short_id":"cccc"}],"reasons":[]},` +
+				`{"tags":null,"host":"h","paths":["/logs"],"keep":[{"id":"dddd4444","short_id":"dddd"}],"remove":null,"reasons":[]}]`,
+			expected: []string{"bbbb", "cccc"},
+			checker:  check.IsNil,
+		},
+		{
+			log:      "some log\n[]\n",
+			expected: []string{},
+			checker:  check.IsNil,
+		},
+		{log: "Fatal: unable to open repository", checker: check.NotNil},
+	} {
+		ids, err := SnapshotIDsFromForgetLog(tc.log)
+		c.Assert(err, tc.checker)
+		c.Assert(ids, check.DeepEquals, tc.expected)
+	}
+}
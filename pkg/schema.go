@@ -0,0 +1,277 @@
+// Copyright 2026 The Kanister Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kanister
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kanisterio/errkit"
+)
+
+// ArgType is the type of the value of a Func argument or output.
+type ArgType string
+
+const (
+	ArgTypeString  ArgType = "string"
+	ArgTypeInteger ArgType = "integer"
+	ArgTypeBoolean ArgType = "boolean"
+	// ArgTypeDuration is a string that can be parsed by time.ParseDuration, e.g. `10m`
+	ArgTypeDuration ArgType = "duration"
+	ArgTypeList     ArgType = "list"
+	ArgTypeMap      ArgType = "map"
+	// ArgTypeAny is used for values that can have more than one shape
+	ArgTypeAny ArgType = "any"
+)
+
+// ArgSchema describes an argument of a Func.
+type ArgSchema struct {
+	Name        string      `json:"name"`
+	Type        ArgType     `json:"type"`
+	Description string      `json:"description,omitempty"`
+	Required    bool        `json:"required,omitempty"`
+	Default     interface{} `json:"default,omitempty"`
+	// Enum lists the allowed values of a string argument. Values are
+	// compared case insensitively.
+	Enum []string `json:"enum,omitempty"`
+	// Deprecated is set for deprecated arguments and describes what to use
+	// instead.
+	Deprecated string `json:"deprecated,omitempty"`
+}
+
+// OutputSchema describes an output key of a Func.
+type OutputSchema struct {
+	Name        string  `json:"name"`
+	Type        ArgType `json:"type"`
+	Description string  `json:"description,omitempty"`
+}
+
+// FuncSchema describes the arguments and outputs of a Func.
+type FuncSchema struct {
+	Name string `json:"name"`
+	// Version is the version the Func is registered with. It is set by
+	// RegisteredFuncSchemas.
+	Version string `json:"version,omitempty"`
+	// Deprecated is the deprecation notice of the registered version. It is
+	// set by RegisteredFuncSchemas.
+	Deprecated  string         `json:"deprecated,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Args        []ArgSchema    `json:"args"`
+	Outputs     []OutputSchema `json:"outputs,omitempty"`
+}
+
+// FuncDescriber is implemented by Funcs that describe the types of their
+// arguments and their outputs. The args of the schema must match
+// Arguments and RequiredArgs.
+type FuncDescriber interface {
+	Schema() FuncSchema
+}
+
+// DescribeFunc returns the schema of the Func. If the Func doesn't implement
+// FuncDescriber, the schema is derived from Arguments and RequiredArgs and
+// the args can have any type.
+func DescribeFunc(f Func) FuncSchema {
+	if d, ok := f.(FuncDescriber); ok {
+		s := d.Schema()
+		if s.Name == "" {
+			s.Name = f.Name()
+		}
+		return s
+	}
+	s := FuncSchema{Name: f.Name()}
+	required := f.RequiredArgs()
+	for _, a := range f.Arguments() {
+		s.Args = append(s.Args, ArgSchema{
+			Name:     a,
+			Type:     ArgTypeAny,
+			Required: contains(required, a),
+		})
+	}
+	return s
+}
+
+// Arg returns the schema of the argument with the given name.
+func (s FuncSchema) Arg(name string) (ArgSchema, bool) {
+	for _, a := range s.Args {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return ArgSchema{}, false
+}
+
+// ValidateArgs checks that the values of the args match the types and enums
+// in the schema. Args that are not part of the schema are ignored, Validate
+// of the Func reports them. String values that are go templates are only
+// known after rendering and are accepted for all types.
+func (s FuncSchema) ValidateArgs(args map[string]interface{}) error {
+	names := make([]string, 0, len(args))
+	for n := range args {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		a, ok := s.Arg(n)
+		if !ok {
+			continue
+		}
+		if err := a.validate(args[n]); err != nil {
+			return errkit.Wrap(err, fmt.Sprintf("Invalid value for arg %s of function %s", n, s.Name))
+		}
+	}
+	return nil
+}
+
+func (a ArgSchema) validate(val interface{}) error {
+	if val == nil {
+		return nil
+	}
+	if str, ok := val.(string); ok && isTemplate(str) {
+		return nil
+	}
+	if err := checkArgType(a.Type, val); err != nil {
+		return err
+	}
+	if len(a.Enum) == 0 {
+		return nil
+	}
+	str := fmt.Sprint(val)
+	for _, e := range a.Enum {
+		if strings.EqualFold(e, str) {
+			return nil
+		}
+	}
+	return errkit.New(fmt.Sprintf("%q is not one of [%s]", str, strings.Join(a.Enum, ", ")))
+}
+
+// checkArgType follows the conversions done by the weakly typed decoding of
+// args, e.g. a string can be used for an integer if it can be parsed as one
+// and a single value can be used for a list.
+func checkArgType(t ArgType, val interface{}) error {
+	v := reflect.ValueOf(val)
+	var ok bool
+	switch t {
+	case ArgTypeString:
+		ok = isScalar(v)
+	case ArgTypeInteger:
+		switch {
+		case isNumber(v), v.Kind() == reflect.Bool:
+			ok = v.Kind() != reflect.Float32 && v.Kind() != reflect.Float64 || v.Float() == math.Trunc(v.Float())
+		case v.Kind() == reflect.String:
+			_, err := strconv.ParseInt(v.String(), 0, 64)
+			ok = err == nil || v.String() == ""
+		}
+	case ArgTypeBoolean:
+		switch {
+		case isNumber(v), v.Kind() == reflect.Bool:
+			ok = true
+		case v.Kind() == reflect.String:
+			_, err := strconv.ParseBool(v.String())
+			ok = err == nil || v.String() == ""
+		}
+	case ArgTypeDuration:
+		if v.Kind() == reflect.String {
+			_, err := time.ParseDuration(v.String())
+			ok = err == nil
+		}
+	case ArgTypeList:
+		ok = v.Kind() == reflect.Slice || v.Kind() == reflect.Array || isScalar(v)
+	case ArgTypeMap:
+		ok = v.Kind() == reflect.Map
+	default:
+		ok = true
+	}
+	if !ok {
+		return errkit.New(fmt.Sprintf("Expected %s, got %T %v", t, val, val))
+	}
+	return nil
+}
+
+func isNumber(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func isScalar(v reflect.Value) bool {
+	return isNumber(v) || v.Kind() == reflect.String || v.Kind() == reflect.Bool
+}
+
+func isTemplate(s string) bool {
+	return strings.Contains(s, "{{")
+}
+
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// RegisteredFuncSchemas returns the schemas of all the registered Funcs and
+// their versions, sorted by name and version.
+func RegisteredFuncSchemas() []FuncSchema {
+	funcMu.RLock()
+	defer funcMu.RUnlock()
+	var schemas []FuncSchema
+	for _, versions := range funcs {
+		for v, f := range versions {
+			s := DescribeFunc(f)
+			s.Version = "v" + v.String()
+			s.Deprecated = funcOpts[s.Name][v].deprecation
+			schemas = append(schemas, s)
+		}
+	}
+	sort.Slice(schemas, func(i, j int) bool {
+		if schemas[i].Name != schemas[j].Name {
+			return schemas[i].Name < schemas[j].Name
+		}
+		return schemas[i].Version < schemas[j].Version
+	})
+	return schemas
+}
+
+// ExportFuncSchemas returns the schemas of the registered Funcs as JSON. If
+// names are given, only the schemas of those Funcs are returned.
+func ExportFuncSchemas(names ...string) ([]byte, error) {
+	schemas := []FuncSchema{}
+	for _, s := range RegisteredFuncSchemas() {
+		if len(names) == 0 || contains(names, s.Name) {
+			schemas = append(schemas, s)
+		}
+	}
+	for _, n := range names {
+		if _, ok := RegisteredFunctions()[n]; !ok {
+			return nil, errkit.New(fmt.Sprintf("Requested function {%s} has not been registered", n))
+		}
+	}
+	b, err := json.MarshalIndent(schemas, "", "  ")
+	if err != nil {
+		return nil, errkit.Wrap(err, "Failed to marshal function schemas")
+	}
+	return b, nil
+}
@@ -70,6 +70,14 @@ func actionSpec(s crv1alpha1.ActionSpec) error {
 		fallthrough
 	case param.DeploymentConfigKind:
 		fallthrough
+	case param.DaemonSetKind:
+		fallthrough
+	case param.ReplicaSetKind:
+		fallthrough
+	case param.JobKind:
+		fallthrough
+	case param.CronJobKind:
+		fallthrough
 	case param.NamespaceKind:
 		// Known types
 	case param.UnstructuredKind: